@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	// Tekrarlanan etkinliklerin saat dilimleri, sistemde zoneinfo olmayan imajlarda da yüklenebilsin
+	_ "time/tzdata"
+
+	"event/backend/internal/app"
+	"event/backend/internal/config"
+	"event/backend/internal/migrations"
+	"event/backend/pkg/database"
+)
+
+func main() {
+	// Yapılandırmayı yükle
+	cfg, args, err := config.LoadConfig()
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Yapılandırma yüklenemedi: %v", err)
+	}
+
+	// Alt komutlar: migrate up|down|status
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			log.Fatalf("Bilinmeyen komut: %q\n%s", args[0], migrateUsage)
+		}
+		if err := runMigrate(cfg, args[1:]); err != nil {
+			log.Fatalf("Migration başarısız: %v", err)
+		}
+		return
+	}
+
+	// Veritabanı bağlantısını aç
+	db, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("Veritabanı başlatılamadı: %v", err)
+	}
+	defer func() {
+		if err := database.Close(db); err != nil {
+			log.Printf("Veritabanı bağlantısı kapatılamadı: %v", err)
+		}
+	}()
+
+	// Şemayı güncelle veya güncel olduğunu doğrula
+	if err := migrations.Prepare(context.Background(), db, cfg); err != nil {
+		log.Fatalf("Veritabanı şeması hazırlanamadı: %v", err)
+	}
+
+	// Tüm repository, servis ve handler'ları kur
+	application, err := app.New(cfg, db)
+	if err != nil {
+		log.Fatalf("Uygulama kurulamadı: %v", err)
+	}
+	go application.Hub.Run()
+
+	srv := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           application.Router,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+	}
+
+	go func() {
+		log.Printf("Sunucu %s portunda başlatılıyor (ortam: %s)", cfg.Port, cfg.Env)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Sunucu başlatılamadı: %v", err)
+		}
+	}()
+
+	// Kapatma sinyalini bekle ve açık istekleri tamamlamaya izin ver
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Sunucu kapatılıyor...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Sunucu düzgün kapatılamadı: %v", err)
+	}
+	application.Hub.Stop()
+}
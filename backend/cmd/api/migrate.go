@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"event/backend/internal/config"
+	"event/backend/internal/migrations"
+	"event/backend/pkg/database"
+)
+
+const migrateUsage = `kullanım: api [bayraklar] migrate <komut>
+
+komutlar:
+  up          bekleyen tüm migration'ları uygular
+  down [n]    son n migration'ı geri alır (varsayılan 1)
+  status      migration'ların durumunu listeler`
+
+// runMigrate "migrate" alt komutunu çalıştırır. Veritabanı bayrakları ve ortam değişkenleri sunucuyla aynıdır.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("komut eksik\n%s", migrateUsage)
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("veritabanı başlatılamadı: %w", err)
+	}
+	defer database.Close(db)
+
+	migrator, err := migrations.NewMigrator(db, cfg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Şema güncel, uygulanacak migration yok.")
+			return nil
+		}
+		fmt.Printf("%d migration uygulandı.\n", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("geçersiz adım sayısı: %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration geri alındı.\n", len(reverted))
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SÜRÜM\tAD\tDURUM")
+		for _, s := range statuses {
+			state := "bekliyor"
+			if s.AppliedAt != nil {
+				state = "uygulandı " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Unknown {
+				state += " (bu sürümde tanımlı değil)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, state)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("bilinmeyen komut %q\n%s", args[0], migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Package app uygulamanın tüm bağımlılıklarını tek bir yerde kurar. Repository'ler verilen *gorm.DB ile
+// oluşturulur; servisler veritabanına yalnızca repository'ler ve UnitOfWork üzerinden erişir.
+// Böylece aynı süreçte farklı veritabanlarıyla (örn. testlerde bellek içi SQLite) birden fazla App kurulabilir.
+package app
+
+import (
+	"fmt"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/repository"
+	"event/backend/internal/routes"
+	"event/backend/internal/services"
+	"event/backend/internal/websocket"
+	"event/backend/pkg/mailer"
+	"event/backend/pkg/oauth"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Services uygulamanın servisleri
+type Services struct {
+	Auth                 *services.AuthService
+	PasswordReset        *services.PasswordResetService
+	EmailVerification    *services.EmailVerificationService
+	TwoFactor            *services.TwoFactorService
+	OAuth                *services.OAuthService
+	PersonalAccessTokens *services.PersonalAccessTokenService
+	Calendar             *services.CalendarService
+	Sessions             *services.SessionService
+	Events               *services.EventService
+	Rooms                *services.RoomService
+	Chat                 *services.ChatService
+	Proposals            *services.ProposalService
+	Friendships          *services.FriendshipService
+	Notifications        *services.NotificationService
+	Suggestions          *services.SuggestionService
+	Users                *services.UserService
+	Interests            services.InterestService
+	Reports              *services.ReportService
+	Admin                *services.AdminService
+}
+
+// App kurulmuş uygulama bileşenlerini bir arada tutar
+type App struct {
+	Config       *config.Config
+	DB           *gorm.DB
+	Repositories repository.Repositories
+	Services     Services
+	Hub          *websocket.Hub
+	Router       *gin.Engine
+}
+
+// New tüm repository'leri, servisleri, WebSocket hub'ını ve router'ı verilen yapılandırma ve veritabanıyla kurar.
+// Hub'ın çalıştırılması (Hub.Run) çağırana bırakılır. Token iptal listesi ve hesap durumu denetleyicisi
+// örneğe özeldir; aynı süreçte birden fazla App birbirini etkilemeden kurulabilir.
+func New(cfg *config.Config, db *gorm.DB) (*App, error) {
+	// Çıkış yapılan erişim token'larının iptal listesi
+	var revocations auth.RevocationStore
+	if cfg.RevocationStore == "memory" {
+		revocations = auth.NewMemoryRevocationStore()
+	} else {
+		revocations = auth.NewDBRevocationStore(db)
+	}
+	// Askıya alınmış hesapların token'ları ve WebSocket bağlantıları reddedilir
+	accountStatus := auth.NewDBAccountStatusChecker(db)
+
+	// Repository'ler
+	repos := repository.NewRepositories(db)
+	uow := repository.NewUnitOfWork(db)
+
+	// Servisler
+	var svc Services
+	svc.Users = services.NewUserService(repos.Users)
+	svc.Notifications = services.NewNotificationService(repos.Notifications)
+	svc.Chat = services.NewChatService(services.ChatServiceInput{
+		Messages:    repos.Messages,
+		UserService: svc.Users,
+	})
+	svc.Rooms = services.NewRoomService(services.RoomServiceInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+		UserService:  svc.Users,
+	})
+	svc.Events = services.NewEventService(services.EventServiceInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+	})
+	svc.Calendar = services.NewCalendarService(services.CalendarServiceInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+		Config:       cfg,
+		EventService: svc.Events,
+	})
+	svc.Proposals = services.NewProposalService(repos, uow)
+	svc.Friendships = services.NewFriendshipService(repos.Friendships, repos.Users)
+	svc.Suggestions = services.NewSuggestionService(repos.Users, repos.Events, repos.Interests, repos.Rooms)
+	svc.Interests = services.NewInterestService(repos.Interests, repos.Users)
+	svc.Reports = services.NewReportService(repos.Reports)
+
+	// WebSocket hub'ı
+	hub := websocket.NewHub(websocket.HubInput{
+		Config:      cfg,
+		ChatService: svc.Chat,
+		UserService: svc.Users,
+	})
+
+	// Kimlik doğrulama servisleri
+	passwordHasher, err := auth.NewPasswordHasherFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("şifre hasher'ı oluşturulamadı: %w", err)
+	}
+	passwordPolicy, err := auth.NewPasswordPolicyFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("şifre politikası oluşturulamadı: %w", err)
+	}
+
+	mail := mailer.NewFromConfig(cfg)
+	// Oturum iptal edildiğinde hub o oturumun WebSocket bağlantılarını keser
+	svc.Sessions = services.NewSessionService(services.SessionServiceInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+		Config:       cfg,
+		Disconnector: hub,
+		Revocations:  revocations,
+	})
+	// Giriş ve iki adımlı doğrulama kod denemeleri aynı sayaçları paylaşır
+	loginGuard := services.NewLoginGuard(services.LoginGuardInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+		Config:       cfg,
+		Mailer:       mail,
+	})
+	svc.TwoFactor = services.NewTwoFactorService(services.TwoFactorServiceInput{
+		Repositories:   repos,
+		UnitOfWork:     uow,
+		Config:         cfg,
+		PasswordHasher: passwordHasher,
+		LoginGuard:     loginGuard,
+	})
+	svc.Auth = services.NewAuthService(services.AuthServiceInput{
+		Repositories:     repos,
+		UnitOfWork:       uow,
+		Config:           cfg,
+		LoginGuard:       loginGuard,
+		TwoFactorService: svc.TwoFactor,
+		SessionService:   svc.Sessions,
+		PasswordHasher:   passwordHasher,
+		PasswordPolicy:   passwordPolicy,
+		Revocations:      revocations,
+	})
+	svc.OAuth = services.NewOAuthService(services.OAuthServiceInput{
+		Repositories: repos,
+		UnitOfWork:   uow,
+		Config:       cfg,
+		AuthService:  svc.Auth,
+		Providers:    oauth.ProvidersFromConfig(cfg),
+	})
+	svc.PasswordReset = services.NewPasswordResetService(services.PasswordResetServiceInput{
+		Repositories:   repos,
+		UnitOfWork:     uow,
+		Config:         cfg,
+		Mailer:         mail,
+		PasswordHasher: passwordHasher,
+		PasswordPolicy: passwordPolicy,
+		SessionService: svc.Sessions,
+	})
+	svc.PersonalAccessTokens = services.NewPersonalAccessTokenService(services.PersonalAccessTokenServiceInput{
+		Tokens:     repos.PersonalAccessTokens,
+		UnitOfWork: uow,
+		Config:     cfg,
+	})
+	svc.EmailVerification = services.NewEmailVerificationService(services.EmailVerificationServiceInput{
+		Users:  repos.Users,
+		Config: cfg,
+		Mailer: mail,
+	})
+	svc.Admin = services.NewAdminService(services.AdminServiceInput{
+		Repositories:   repos,
+		UnitOfWork:     uow,
+		SessionService: svc.Sessions,
+	})
+
+	router := routes.SetupRouter(routes.RouterInput{
+		Config:                   cfg,
+		Revocations:              revocations,
+		AccountStatus:            accountStatus,
+		Hub:                      hub,
+		AuthService:              svc.Auth,
+		PasswordResetService:     svc.PasswordReset,
+		EmailVerificationService: svc.EmailVerification,
+		TwoFactorService:         svc.TwoFactor,
+		OAuthService:             svc.OAuth,
+		PersonalAccessTokens:     svc.PersonalAccessTokens,
+		CalendarService:          svc.Calendar,
+		SessionService:           svc.Sessions,
+		EventService:             svc.Events,
+		RoomService:              svc.Rooms,
+		ChatService:              svc.Chat,
+		ProposalService:          svc.Proposals,
+		FriendshipService:        svc.Friendships,
+		NotificationService:      svc.Notifications,
+		SuggestionService:        svc.Suggestions,
+		UserService:              svc.Users,
+		InterestService:          svc.Interests,
+		ReportService:            svc.Reports,
+		AdminService:             svc.Admin,
+	})
+
+	return &App{
+		Config:       cfg,
+		DB:           db,
+		Repositories: repos,
+		Services:     svc,
+		Hub:          hub,
+		Router:       router,
+	}, nil
+}
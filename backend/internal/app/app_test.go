@@ -0,0 +1,133 @@
+package app_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/testutil"
+	"event/backend/internal/utils"
+)
+
+// Aynı süreçteki uygulamalar token iptal listesini ve hesap durumu denetleyicisini paylaşmamalıdır
+func TestAppsDoNotShareAuthState(t *testing.T) {
+	first := testutil.New(t)
+	second := testutil.New(t)
+
+	// İki veritabanında da ID'si 1 olan kullanıcı; yalnızca ikincisinde askıya alınmış
+	user := first.User()
+	suspended := second.User()
+	if user.ID != suspended.ID {
+		t.Fatalf("kullanıcı ID'leri eşleşmeli: %d, %d", user.ID, suspended.ID)
+	}
+	second.DB.Model(&models.User{}).Where("id = ?", suspended.ID).Update("suspended_at", time.Now())
+
+	token, err := utils.GenerateSessionToken(user.ID, user.Email, "family", first.Config)
+	if err != nil {
+		t.Fatalf("token üretilemedi: %v", err)
+	}
+	me := func(env *testutil.Env) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		env.App.Router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := me(second); code != http.StatusForbidden {
+		t.Fatalf("askıya alınmış hesabın uygulamasında 403 beklenirdi, %d", code)
+	}
+	if code := me(first); code != http.StatusOK {
+		t.Fatalf("diğer uygulamanın askıya alması etkilememeli, %d", code)
+	}
+
+	claims, err := utils.ValidateToken(token, first.Config)
+	if err != nil {
+		t.Fatalf("token doğrulanamadı: %v", err)
+	}
+	if err := second.Services().Auth.Logout(user.ID, claims.ID, claims.ExpiresAt.Time, claims.SessionID, ""); err != nil {
+		t.Fatalf("çıkış yapılamadı: %v", err)
+	}
+	if code := me(first); code != http.StatusOK {
+		t.Fatalf("diğer uygulamadaki iptal etkilememeli, %d", code)
+	}
+}
+
+// Aynı anahtarla imzalanmış olsa bile e-posta doğrulama tokeni erişim tokeni olarak kabul edilmemelidir
+func TestOnlyAccessTokensAuthenticate(t *testing.T) {
+	env := testutil.New(t)
+	env.Config.EmailVerificationSecret = env.Config.JWTSecret
+	user := env.User()
+
+	verification, err := utils.GenerateEmailVerificationToken(user.ID, user.Email, env.Config)
+	if err != nil {
+		t.Fatalf("doğrulama tokeni üretilemedi: %v", err)
+	}
+	access, err := utils.GenerateToken(user.ID, user.Email, env.Config)
+	if err != nil {
+		t.Fatalf("erişim tokeni üretilemedi: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		token string
+		want  int
+	}{
+		"doğrulama tokeni": {verification, http.StatusUnauthorized},
+		"erişim tokeni":    {access, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		rec := httptest.NewRecorder()
+		env.App.Router.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Fatalf("%s için %d beklenirdi, %d", name, tc.want, rec.Code)
+		}
+	}
+}
+
+// Rol ve askıya alma bilgileri yalnızca yönetici endpoint'lerinde yayınlanmalıdır
+func TestModerationFieldsOnlyOnAdminEndpoints(t *testing.T) {
+	env := testutil.New(t)
+	admin := env.User(func(u *models.User) { u.Role = models.UserRoleAdmin })
+	viewer := env.User()
+	target := env.User()
+
+	request := func(method, path string, as *models.User) map[string]interface{} {
+		token, err := utils.GenerateToken(as.ID, as.Email, env.Config)
+		if err != nil {
+			t.Fatalf("token üretilemedi: %v", err)
+		}
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		env.App.Router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: 200 beklenirdi, %d: %s", method, path, rec.Code, rec.Body.String())
+		}
+		var body struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("yanıt çözümlenemedi: %v", err)
+		}
+		return body.Data
+	}
+
+	suspended := request(http.MethodPost, fmt.Sprintf("/api/admin/users/%d/suspend", target.ID), admin)
+	for _, key := range []string{"role", "suspended_at"} {
+		if _, ok := suspended[key]; !ok {
+			t.Fatalf("yönetici yanıtında %q olmalı: %v", key, suspended)
+		}
+	}
+
+	profile := request(http.MethodGet, fmt.Sprintf("/api/users/%d", admin.ID), viewer)
+	for _, key := range []string{"role", "suspended_at", "suspension_reason"} {
+		if _, ok := profile[key]; ok {
+			t.Fatalf("herkese açık profilde %q olmamalı: %v", key, profile)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"errors"
+
+	"event/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AccountStatusChecker kullanıcı hesabının askıya alınıp alınmadığını bildirir.
+// HTTP middleware'i ve WebSocket el sıkışması her istekte bu kontrolü yapar; denetleyici
+// uygulama örneği başına kurulup middleware kurucularına verilir.
+type AccountStatusChecker interface {
+	IsSuspended(userID uint64) (bool, error)
+}
+
+// DBAccountStatusChecker askıya alma durumunu users tablosundan okur
+type DBAccountStatusChecker struct {
+	db *gorm.DB
+}
+
+// NewDBAccountStatusChecker veritabanı tabanlı bir AccountStatusChecker oluşturur
+func NewDBAccountStatusChecker(db *gorm.DB) *DBAccountStatusChecker {
+	return &DBAccountStatusChecker{db: db}
+}
+
+// IsSuspended hesabın askıda olup olmadığını döndürür.
+// Silinmiş kullanıcıların token'ları da kabul edilmemesi için bulunamayan kullanıcı askıda sayılır.
+func (c *DBAccountStatusChecker) IsSuspended(userID uint64) (bool, error) {
+	var user models.User
+	err := c.db.Select("id", "suspended_at").Where("id = ?", userID).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.IsSuspended(), nil
+}
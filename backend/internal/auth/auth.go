@@ -2,11 +2,12 @@ package auth
 
 import (
 	"errors"
-	"event/backend/internal/config"
 	"fmt"
 
+	"event/backend/internal/config"
+	"event/backend/internal/utils"
+
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 // GetUserIDFromContext, Gin context'inden kullanıcı ID'sini alır.
@@ -31,36 +32,50 @@ func GetUserIDFromContext(c *gin.Context) (uint64, error) {
 }
 
 // ValidateTokenAndGetUserID, bir JWT'yi doğrular ve kullanıcı kimliğini döndürür.
-// Config'i artık parametre olarak almıyor, kendi içinde yüklüyor.
-func ValidateTokenAndGetUserID(tokenString string) (uint64, error) {
+func ValidateTokenAndGetUserID(tokenString string, cfg *config.Config, revocations RevocationStore, accounts AccountStatusChecker) (uint64, error) {
+	userID, _, err := ValidateTokenAndGetSession(tokenString, cfg, revocations, accounts)
+	return userID, err
+}
+
+// ValidateTokenAndGetSession, bir JWT'yi doğrular; kullanıcı kimliğini ve tokenin ait olduğu oturumu (sid) döndürür.
+// İptal edilmiş token'lar ve oturumlar revocations, askıya alınmış hesaplar accounts ile reddedilir.
+// Oturum kimliği taşımayan eski token'lar için sid boş döner.
+func ValidateTokenAndGetSession(tokenString string, cfg *config.Config, revocations RevocationStore, accounts AccountStatusChecker) (uint64, string, error) {
 	if tokenString == "" {
-		return 0, errors.New("token sağlanmadı")
+		return 0, "", errors.New("token sağlanmadı")
 	}
 
-	// Yapılandırmayı bu fonksiyon içinde yükle
-	cfg, err := config.LoadConfig()
+	// Erişim tokeni olmayan (ör. e-posta doğrulama) token'lar middleware'deki gibi reddedilir
+	claims, err := utils.ValidateToken(tokenString, cfg)
 	if err != nil {
-		return 0, fmt.Errorf("yapılandırma yüklenemedi: %w", err)
+		return 0, "", fmt.Errorf("token doğrulanamadı: %w", err)
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("beklenmeyen imzalama metodu: %v", token.Header["alg"])
-		}
-		return []byte(cfg.JWTSecret), nil
-	})
-
+	// Çıkış yapılmış (iptal edilmiş) token'larla WebSocket bağlantısı kurulamaz
+	revoked, err := IsTokenRevoked(revocations, claims.ID)
 	if err != nil {
-		return 0, fmt.Errorf("token ayrıştırılamadı: %w", err)
+		return 0, "", fmt.Errorf("token iptal durumu kontrol edilemedi: %w", err)
+	}
+	if revoked {
+		return 0, "", errors.New("token iptal edilmiş")
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userIDFloat, ok := claims["user_id"].(float64)
-		if !ok {
-			return 0, errors.New("user_id claim'i bulunamadı veya geçersiz formatta")
-		}
-		return uint64(userIDFloat), nil
+	// İptal edilmiş oturumların token'larıyla da bağlantı kurulamaz
+	revoked, err = IsSessionRevoked(revocations, claims.SessionID)
+	if err != nil {
+		return 0, "", fmt.Errorf("oturum iptal durumu kontrol edilemedi: %w", err)
+	}
+	if revoked {
+		return 0, "", errors.New("oturum sonlandırılmış")
 	}
 
-	return 0, errors.New("geçersiz token")
+	// Askıya alınmış hesaplar WebSocket bağlantısı kuramaz
+	suspended, err := accounts.IsSuspended(claims.UserID)
+	if err != nil {
+		return 0, "", fmt.Errorf("hesap durumu kontrol edilemedi: %w", err)
+	}
+	if suspended {
+		return 0, "", errors.New("hesap askıya alınmış")
+	}
+	return claims.UserID, claims.SessionID, nil
 }
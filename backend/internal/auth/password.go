@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"event/backend/internal/config"
+)
+
+// PasswordHasher şifreleri parametreleri hash'in içine kodlanmış biçimde saklar ve doğrular.
+// Kodlanmış hash kendi algoritmasını ve parametrelerini taşıdığından ayarlar değiştiğinde
+// eski hash'ler doğrulanmaya devam eder ve NeedsRehash ile yükseltilebilir.
+type PasswordHasher interface {
+	// Hash şifreyi güncel parametrelerle hashler
+	Hash(password string) (string, error)
+	// Verify şifrenin kodlanmış hash ile eşleşip eşleşmediğini döndürür.
+	// Hash bozuksa veya desteklenmiyorsa hata döner.
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash hash'in güncel algoritma veya parametrelerle üretilmediğini bildirir
+	NeedsRehash(encoded string) bool
+	// Supports kodlanmış hash'in bu hasher'ın biçiminde olup olmadığını döndürür
+	Supports(encoded string) bool
+}
+
+// ErrUnsupportedPasswordHash tanınmayan biçimdeki hash'ler için döner
+var ErrUnsupportedPasswordHash = errors.New("desteklenmeyen şifre hash biçimi")
+
+// MultiPasswordHasher yeni şifreleri tercih edilen hasher ile üretir, eski biçimleri de doğrular.
+// Tercih edilenden farklı bir biçimdeki her hash yeniden hashlenmelidir.
+type MultiPasswordHasher struct {
+	preferred PasswordHasher
+	legacy    []PasswordHasher
+}
+
+// NewMultiPasswordHasher tercih edilen ve yalnızca doğrulama için kullanılacak hasher'larla bir MultiPasswordHasher oluşturur
+func NewMultiPasswordHasher(preferred PasswordHasher, legacy ...PasswordHasher) *MultiPasswordHasher {
+	return &MultiPasswordHasher{preferred: preferred, legacy: legacy}
+}
+
+// NewPasswordHasherFromConfig yapılandırmadaki algoritma ve parametrelerle hasher oluşturur.
+// Hangi algoritma seçilirse seçilsin diğerinin hash'leri doğrulanır ve girişte yükseltilir.
+func NewPasswordHasherFromConfig(cfg *config.Config) (*MultiPasswordHasher, error) {
+	bcryptHasher := NewBcryptHasher(cfg.BcryptCost)
+	argon2Hasher := NewArgon2idHasher(Argon2idParams{
+		Memory:      uint32(cfg.Argon2MemoryKiB),
+		Iterations:  uint32(cfg.Argon2Iterations),
+		Parallelism: uint8(cfg.Argon2Parallelism),
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+
+	switch cfg.PasswordHashAlgorithm {
+	case "argon2id":
+		if err := argon2Hasher.params.validate(); err != nil {
+			return nil, err
+		}
+		return NewMultiPasswordHasher(argon2Hasher, bcryptHasher), nil
+	case "bcrypt":
+		if err := bcryptHasher.validate(); err != nil {
+			return nil, err
+		}
+		return NewMultiPasswordHasher(bcryptHasher, argon2Hasher), nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen şifre hash algoritması: %q (argon2id veya bcrypt olmalı)", cfg.PasswordHashAlgorithm)
+	}
+}
+
+// Hash şifreyi tercih edilen hasher ile hashler
+func (m *MultiPasswordHasher) Hash(password string) (string, error) {
+	return m.preferred.Hash(password)
+}
+
+// Verify hash'i biçimini tanıyan hasher ile doğrular
+func (m *MultiPasswordHasher) Verify(password, encoded string) (bool, error) {
+	h := m.hasherFor(encoded)
+	if h == nil {
+		return false, ErrUnsupportedPasswordHash
+	}
+	return h.Verify(password, encoded)
+}
+
+// NeedsRehash hash tercih edilen biçimde değilse veya parametreleri eskiyse true döner
+func (m *MultiPasswordHasher) NeedsRehash(encoded string) bool {
+	if !m.preferred.Supports(encoded) {
+		return true
+	}
+	return m.preferred.NeedsRehash(encoded)
+}
+
+// Supports hash'i tanıyan bir hasher varsa true döner
+func (m *MultiPasswordHasher) Supports(encoded string) bool {
+	return m.hasherFor(encoded) != nil
+}
+
+func (m *MultiPasswordHasher) hasherFor(encoded string) PasswordHasher {
+	if m.preferred.Supports(encoded) {
+		return m.preferred
+	}
+	for _, h := range m.legacy {
+		if h.Supports(encoded) {
+			return h
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptMaxPasswordBytes bcrypt'in işleyebildiği en uzun şifre
+const bcryptMaxPasswordBytes = 72
+
+// BcryptHasher şifreleri bcrypt ile hashler. Maliyet hash'in içinde saklanır ($2a$12$...).
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher verilen maliyetle bir BcryptHasher oluşturur
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) validate() error {
+	if h.cost < bcrypt.MinCost || h.cost > bcrypt.MaxCost {
+		return fmt.Errorf("bcrypt maliyeti %d ile %d arasında olmalı", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return nil
+}
+
+// Hash şifreyi bcrypt ile hashler. bcrypt 72 bayttan uzun şifreleri kabul etmez.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify şifreyi bcrypt hash'i ile karşılaştırır. bcrypt karşılaştırmada yalnızca ilk 72 baytı kullandığından
+// daha uzun şifreler reddedilir; aksi halde ilk 72 baytı tutan her şifre kabul edilir ve girişteki yeniden
+// hashleme bu farklı şifreyi kalıcı hale getirirdi.
+func (h *BcryptHasher) Verify(password, encoded string) (bool, error) {
+	if len(password) > bcryptMaxPasswordBytes {
+		return false, nil
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return false, err
+}
+
+// NeedsRehash hash'in maliyeti yapılandırılandan farklıysa true döner
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.cost
+}
+
+// Supports hash'in bcrypt önekiyle başlayıp başlamadığını döndürür
+func (h *BcryptHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"event/backend/internal/config"
+)
+
+//go:embed breached_passwords.txt
+var defaultBreachedPasswords string
+
+// PasswordPolicy yeni belirlenen şifrelerin uyması gereken kurallar.
+// Kayıt, şifre sıfırlama ve şifre değiştirme aynı politikayı kullanır.
+type PasswordPolicy struct {
+	// MinLength karakter (rune), MaxLength bayt cinsindendir
+	MinLength int
+	MaxLength int
+	// MinCharClasses büyük harf, küçük harf, rakam ve özel karakterden kaç türün gerektiği
+	MinCharClasses int
+	breached       *BreachedPasswordList
+}
+
+// PasswordPolicyError şifrenin ihlal ettiği kuralları içerir
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return strings.Join(e.Violations, "; ")
+}
+
+// NewPasswordPolicyFromConfig yapılandırmadaki kurallarla ve yerleşik sızdırılmış şifre listesiyle politika oluşturur.
+// PasswordBreachedListFile verilmişse dosyadaki girdiler de listeye eklenir.
+func NewPasswordPolicyFromConfig(cfg *config.Config) (*PasswordPolicy, error) {
+	breached := NewBreachedPasswordList()
+	if err := breached.Load(strings.NewReader(defaultBreachedPasswords)); err != nil {
+		return nil, err
+	}
+	if cfg.PasswordBreachedListFile != "" {
+		f, err := os.Open(cfg.PasswordBreachedListFile)
+		if err != nil {
+			return nil, fmt.Errorf("sızdırılmış şifre listesi açılamadı: %w", err)
+		}
+		defer f.Close()
+		if err := breached.Load(f); err != nil {
+			return nil, fmt.Errorf("sızdırılmış şifre listesi okunamadı: %w", err)
+		}
+	}
+
+	maxLength := cfg.PasswordMaxLength
+	// bcrypt 72 bayttan uzun şifreleri hashleyemez
+	if cfg.PasswordHashAlgorithm == "bcrypt" && (maxLength <= 0 || maxLength > bcryptMaxPasswordBytes) {
+		maxLength = bcryptMaxPasswordBytes
+	}
+
+	return &PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MaxLength:      maxLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+		breached:       breached,
+	}, nil
+}
+
+// Validate şifreyi politikaya göre denetler; ihlal varsa *PasswordPolicyError döner.
+// userInputs (kullanıcı adı, e-posta gibi) verilirse şifrenin bunları içermesine izin verilmez.
+func (p *PasswordPolicy) Validate(password string, userInputs ...string) error {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("şifre en az %d karakter olmalı", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("şifre en fazla %d bayt olabilir", p.MaxLength))
+	}
+	if countCharClasses(password) < p.MinCharClasses {
+		violations = append(violations, fmt.Sprintf("şifre büyük harf, küçük harf, rakam ve özel karakterden en az %d türünü içermeli", p.MinCharClasses))
+	}
+	if containsUserInput(password, userInputs) {
+		violations = append(violations, "şifre kullanıcı adınızı veya e-posta adresinizi içermemeli")
+	}
+	if p.breached != nil && p.breached.Contains(password) {
+		violations = append(violations, "bu şifre sızdırılmış şifre listelerinde yer alıyor, lütfen başka bir şifre seçin")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// countCharClasses şifredeki karakter türü sayısını döndürür
+func countCharClasses(password string) int {
+	var hasUpper, hasLower, hasNumber, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasNumber = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	classes := 0
+	for _, ok := range []bool{hasUpper, hasLower, hasNumber, hasSpecial} {
+		if ok {
+			classes++
+		}
+	}
+	return classes
+}
+
+// containsUserInput şifrenin kullanıcı adını veya e-postanın yerel kısmını içerip içermediğini döndürür.
+// Çok kısa girdiler yanlış pozitif üretmemesi için yok sayılır.
+func containsUserInput(password string, userInputs []string) bool {
+	lower := strings.ToLower(password)
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if at := strings.IndexByte(input, '@'); at >= 0 {
+			input = input[:at]
+		}
+		if utf8.RuneCountInString(input) >= 4 && strings.Contains(lower, input) {
+			return true
+		}
+	}
+	return false
+}
+
+// BreachedPasswordList sızdırılmış şifreleri bellekte tutar.
+// Düz metin girdiler büyük/küçük harf duyarsız, SHA-1 girdiler birebir karşılaştırılır.
+type BreachedPasswordList struct {
+	plain map[string]struct{}
+	sha1  map[string]struct{}
+}
+
+// NewBreachedPasswordList boş bir liste oluşturur
+func NewBreachedPasswordList() *BreachedPasswordList {
+	return &BreachedPasswordList{
+		plain: make(map[string]struct{}),
+		sha1:  make(map[string]struct{}),
+	}
+}
+
+// Load satır başına bir girdi okur. Boş satırlar ve # ile başlayan satırlar atlanır.
+// 40 karakterlik onaltılık girdiler (isteğe bağlı ":adet" ekiyle) SHA-1 özeti olarak yorumlanır.
+func (l *BreachedPasswordList) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if hash, _, _ := strings.Cut(line, ":"); isSHA1Hex(hash) {
+			l.sha1[strings.ToUpper(hash)] = struct{}{}
+			continue
+		}
+		l.plain[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Contains şifrenin listede olup olmadığını döndürür
+func (l *BreachedPasswordList) Contains(password string) bool {
+	if _, ok := l.plain[strings.ToLower(password)]; ok {
+		return true
+	}
+	if len(l.sha1) == 0 {
+		return false
+	}
+	sum := sha1.Sum([]byte(password))
+	_, ok := l.sha1[strings.ToUpper(hex.EncodeToString(sum[:]))]
+	return ok
+}
+
+func isSHA1Hex(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
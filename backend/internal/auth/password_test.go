@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Testlerde hash'ler hızlı olsun diye düşük maliyetler kullanılır
+func testArgon2(memory uint32) *auth.Argon2idHasher {
+	return auth.NewArgon2idHasher(auth.Argon2idParams{
+		Memory:      memory,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+}
+
+func TestMultiPasswordHasherRoundTrip(t *testing.T) {
+	hashers := map[string]*auth.MultiPasswordHasher{
+		"argon2id": auth.NewMultiPasswordHasher(testArgon2(64), auth.NewBcryptHasher(bcrypt.MinCost)),
+		"bcrypt":   auth.NewMultiPasswordHasher(auth.NewBcryptHasher(bcrypt.MinCost), testArgon2(64)),
+	}
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := hasher.Hash("Doğru-Şifre-1")
+			if err != nil {
+				t.Fatalf("hash üretilemedi: %v", err)
+			}
+			if ok, err := hasher.Verify("Doğru-Şifre-1", encoded); !ok || err != nil {
+				t.Fatalf("doğru şifre kabul edilmeli: %v, %v", ok, err)
+			}
+			if ok, err := hasher.Verify("Yanlış-Şifre-1", encoded); ok || err != nil {
+				t.Fatalf("yanlış şifre hatasız reddedilmeli: %v, %v", ok, err)
+			}
+			if hasher.NeedsRehash(encoded) {
+				t.Fatal("güncel ayarlarla üretilen hash yeniden hashlenmemeli")
+			}
+		})
+	}
+
+	if _, err := hashers["argon2id"].Verify("şifre", "$1$eski$md5"); err != auth.ErrUnsupportedPasswordHash {
+		t.Fatalf("tanınmayan hash için ErrUnsupportedPasswordHash beklenirdi: %v", err)
+	}
+}
+
+func TestMultiPasswordHasherLegacyHashes(t *testing.T) {
+	// Argon2id'ye geçmeden önce saklanmış bcrypt hash'i
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Eski-Şifre-1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt hash'i üretilemedi: %v", err)
+	}
+
+	hasher := auth.NewMultiPasswordHasher(testArgon2(64), auth.NewBcryptHasher(bcrypt.MinCost))
+	if ok, err := hasher.Verify("Eski-Şifre-1", string(legacy)); !ok || err != nil {
+		t.Fatalf("eski bcrypt hash'i doğrulanmalı: %v, %v", ok, err)
+	}
+	if !hasher.NeedsRehash(string(legacy)) {
+		t.Fatal("tercih edilmeyen algoritmanın hash'i yeniden hashlenmeli")
+	}
+
+	// Aynı algoritmada parametreler değiştiğinde de yeniden hashlenir
+	oldArgon2, err := testArgon2(32).Hash("Eski-Şifre-1")
+	if err != nil {
+		t.Fatalf("argon2id hash'i üretilemedi: %v", err)
+	}
+	if !hasher.NeedsRehash(oldArgon2) {
+		t.Fatal("eski parametreli argon2id hash'i yeniden hashlenmeli")
+	}
+	oldCost, err := bcrypt.GenerateFromPassword([]byte("Eski-Şifre-1"), bcrypt.MinCost+1)
+	if err != nil {
+		t.Fatalf("bcrypt hash'i üretilemedi: %v", err)
+	}
+	if !auth.NewMultiPasswordHasher(auth.NewBcryptHasher(bcrypt.MinCost)).NeedsRehash(string(oldCost)) {
+		t.Fatal("farklı maliyetli bcrypt hash'i yeniden hashlenmeli")
+	}
+}
+
+func TestPasswordsLongerThanBcryptLimit(t *testing.T) {
+	long := strings.Repeat("a", 72) + "-uzun-şifre-1"
+	truncated := long[:72]
+
+	// bcrypt tercih edilirse uzun şifre hashlenemez; politika da bu yüzden 72 baytla sınırlar
+	bcryptHasher := auth.NewMultiPasswordHasher(auth.NewBcryptHasher(bcrypt.MinCost), testArgon2(64))
+	if _, err := bcryptHasher.Hash(long); err == nil {
+		t.Fatal("bcrypt 72 bayttan uzun şifreyi hashlememeli")
+	}
+	cfg := config.Default()
+	cfg.PasswordHashAlgorithm = "bcrypt"
+	policy, err := auth.NewPasswordPolicyFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("politika oluşturulamadı: %v", err)
+	}
+	if policy.MaxLength != 72 {
+		t.Fatalf("bcrypt ile en uzun şifre 72 bayt olmalı, %d", policy.MaxLength)
+	}
+
+	// argon2id uzun şifreleri tam olarak kullanır
+	argon2Hasher := auth.NewMultiPasswordHasher(testArgon2(64), auth.NewBcryptHasher(bcrypt.MinCost))
+	encoded, err := argon2Hasher.Hash(long)
+	if err != nil {
+		t.Fatalf("argon2id uzun şifreyi hashlemeli: %v", err)
+	}
+	if ok, _ := argon2Hasher.Verify(long, encoded); !ok {
+		t.Fatal("uzun şifre doğrulanmalı")
+	}
+	if ok, _ := argon2Hasher.Verify(truncated, encoded); ok {
+		t.Fatal("şifrenin yalnızca ilk 72 baytı kabul edilmemeli")
+	}
+
+	// Eski bcrypt hash'i ilk 72 baytı aynı olan daha uzun bir şifreyle doğrulanmamalı
+	legacy, err := bcrypt.GenerateFromPassword([]byte(truncated), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt hash'i üretilemedi: %v", err)
+	}
+	if ok, err := argon2Hasher.Verify(long, string(legacy)); ok || err != nil {
+		t.Fatalf("72 bayttan uzun şifre eski bcrypt hash'iyle eşleşmemeli: %v, %v", ok, err)
+	}
+	if ok, _ := argon2Hasher.Verify(truncated, string(legacy)); !ok {
+		t.Fatal("eski bcrypt hash'i kendi şifresiyle doğrulanmalı")
+	}
+}
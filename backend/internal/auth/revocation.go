@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"event/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevocationStore iptal edilmiş erişim token'larının (jti) tutulduğu depo.
+// Kayıtlar yalnızca token'ın son kullanma tarihine kadar saklanır; sonrasında token zaten geçersizdir.
+// Uygulama örneği başına bir depo kurulur ve HTTP middleware'ine, WebSocket el sıkışmasına ve
+// token iptal eden servislere verilir.
+type RevocationStore interface {
+	Revoke(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+}
+
+// RevokeToken verilen jti'yi son kullanma tarihine kadar iptal edilmiş olarak işaretler
+func RevokeToken(store RevocationStore, jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return errors.New("token kimliği (jti) bulunamadı")
+	}
+	return store.Revoke(jti, expiresAt)
+}
+
+// IsTokenRevoked jti'nin iptal edilip edilmediğini kontrol eder.
+// jti içermeyen eski token'lar iptal edilemez ve geçerli sayılır.
+func IsTokenRevoked(store RevocationStore, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	return store.IsRevoked(jti)
+}
+
+// sessionRevocationKey oturum iptallerini aynı depoda jti'lerden ayırmak için kullanılan anahtar
+func sessionRevocationKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+// RevokeSession oturuma (sid) ait tüm erişim token'larını verilen zamana kadar iptal edilmiş sayar.
+// until, oturumda üretilmiş en son erişim tokeninin son kullanma zamanından sonra olmalıdır.
+func RevokeSession(store RevocationStore, sessionID string, until time.Time) error {
+	if sessionID == "" {
+		return errors.New("oturum kimliği bulunamadı")
+	}
+	return store.Revoke(sessionRevocationKey(sessionID), until)
+}
+
+// IsSessionRevoked oturumun iptal edilip edilmediğini kontrol eder.
+// Oturum kimliği taşımayan eski token'lar için false döner.
+func IsSessionRevoked(store RevocationStore, sessionID string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+	return store.IsRevoked(sessionRevocationKey(sessionID))
+}
+
+// MemoryRevocationStore tek sunuculu kurulumlar ve testler için bellek içi depo.
+// Sunucu yeniden başlatıldığında iptal listesi kaybolur.
+type MemoryRevocationStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+}
+
+// NewMemoryRevocationStore yeni bir MemoryRevocationStore oluşturur
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{entries: make(map[string]time.Time)}
+}
+
+// Revoke jti'yi iptal listesine ekler ve süresi dolmuş kayıtları temizler
+func (m *MemoryRevocationStore) Revoke(jti string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for k, exp := range m.entries {
+		if !now.Before(exp) {
+			delete(m.entries, k)
+		}
+	}
+	m.entries[jti] = expiresAt
+	return nil
+}
+
+// IsRevoked jti'nin iptal listesinde olup olmadığını kontrol eder
+func (m *MemoryRevocationStore) IsRevoked(jti string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exp, ok := m.entries[jti]
+	if !ok {
+		return false, nil
+	}
+	if !time.Now().Before(exp) {
+		delete(m.entries, jti)
+		return false, nil
+	}
+	return true, nil
+}
+
+// DBRevocationStore iptal listesini veritabanında tutar; birden fazla sunucu örneği arasında paylaşılır
+type DBRevocationStore struct {
+	db *gorm.DB
+}
+
+// NewDBRevocationStore yeni bir DBRevocationStore oluşturur
+func NewDBRevocationStore(db *gorm.DB) *DBRevocationStore {
+	return &DBRevocationStore{db: db}
+}
+
+// Revoke jti'yi revoked_tokens tablosuna ekler ve süresi dolmuş kayıtları siler
+func (d *DBRevocationStore) Revoke(jti string, expiresAt time.Time) error {
+	now := time.Now()
+	if err := d.db.Where("expires_at < ?", now).Delete(&models.RevokedToken{}).Error; err != nil {
+		return err
+	}
+	record := models.RevokedToken{JTI: jti, ExpiresAt: expiresAt, CreatedAt: now}
+	return d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error
+}
+
+// IsRevoked jti'nin süresi dolmamış bir iptal kaydı olup olmadığını kontrol eder
+func (d *DBRevocationStore) IsRevoked(jti string) (bool, error) {
+	var count int64
+	if err := d.db.Model(&models.RevokedToken{}).
+		Where("jti = ? AND expires_at > ?", jti, time.Now()).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
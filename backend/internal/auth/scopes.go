@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PersonalAccessTokenPrefix kişisel erişim token'larını JWT'lerden ayırt etmeye yarayan önek
+const PersonalAccessTokenPrefix = "evtpat_"
+
+// Kişisel erişim token'larının yetkilendirilebildiği kaynaklar.
+// Her kaynak için "<kaynak>:read" ve "<kaynak>:write" kapsamları vardır; write, read'i de kapsar.
+const (
+	ResourceEvents        = "events"
+	ResourceRooms         = "rooms"
+	ResourceFriends       = "friends"
+	ResourceNotifications = "notifications"
+	ResourceProposals     = "proposals"
+	ResourceUsers         = "users"
+)
+
+var resources = []string{
+	ResourceEvents,
+	ResourceRooms,
+	ResourceFriends,
+	ResourceNotifications,
+	ResourceProposals,
+	ResourceUsers,
+}
+
+// IsPersonalAccessToken token'ın kişisel erişim tokeni biçiminde olup olmadığını döndürür
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// ReadScope kaynağın okuma kapsamını döndürür (örn. "rooms:read")
+func ReadScope(resource string) string {
+	return resource + ":read"
+}
+
+// WriteScope kaynağın yazma kapsamını döndürür (örn. "events:write")
+func WriteScope(resource string) string {
+	return resource + ":write"
+}
+
+// AllScopes tanımlı tüm kapsamları sıralı olarak döndürür
+func AllScopes() []string {
+	scopes := make([]string, 0, len(resources)*2)
+	for _, r := range resources {
+		scopes = append(scopes, ReadScope(r), WriteScope(r))
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// NormalizeScopes kapsamları doğrular, tekrarları atar ve sıralı döndürür
+func NormalizeScopes(scopes []string) ([]string, error) {
+	valid := make(map[string]bool)
+	for _, s := range AllScopes() {
+		valid[s] = true
+	}
+
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !valid[s] {
+			return nil, fmt.Errorf("geçersiz kapsam: %q", s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			normalized = append(normalized, s)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("en az bir kapsam seçilmelidir")
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// HasScope verilen kapsamların istenen kapsamı karşılayıp karşılamadığını döndürür.
+// "<kaynak>:write" aynı kaynağın okuma kapsamını da karşılar.
+func HasScope(granted []string, required string) bool {
+	resource, action, _ := strings.Cut(required, ":")
+	for _, s := range granted {
+		if s == required || (action == "read" && s == WriteScope(resource)) {
+			return true
+		}
+	}
+	return false
+}
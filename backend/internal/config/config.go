@@ -2,109 +2,295 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
-	"strconv"
+	"net/url"
+	"strings"
 	"time"
+)
 
-	"github.com/joho/godotenv"
+// Geliştirme ortamı için varsayılan gizli anahtarlar. Üretimde (ENV=production) bu değerlerle
+// veya minSecretLength'ten kısa anahtarlarla uygulama başlatılmaz.
+const (
+	defaultJWTSecret               = "your-secret-key"
+	defaultRefreshSecret           = "your-refresh-secret-key"
+	defaultEmailVerificationSecret = "your-email-verification-secret"
 )
 
-// Config uygulama yapılandırmasını temsil eder
+// Config uygulama yapılandırmasını temsil eder.
+// config etiketi alanın yapılandırma dosyasındaki anahtarıdır; ortam değişkeni adı anahtarın
+// büyük harfli hali (jwt_secret -> JWT_SECRET), komut satırı bayrağı ise tireli halidir (-jwt-secret).
+// "zero" seçeneği taşıyan sayısal alanlar ve süreler 0 olabilir, diğerleri pozitif olmalıdır.
 type Config struct {
 	// Veritabanı ayarları
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
+	// DBDriver kullanılacak veritabanı: mysql, postgres veya sqlite.
+	// sqlite için DBName veritabanı dosyasının yoludur (":memory:" bellek içi veritabanı); host ve kullanıcı ayarları kullanılmaz.
+	DBDriver string `config:"db_driver"`
+	DBHost   string `config:"db_host"`
+	// DBPort boşsa sürücünün varsayılan portu kullanılır (mysql 3306, postgres 5432)
+	DBPort     string `config:"db_port"`
+	DBUser     string `config:"db_user"`
+	DBPassword string `config:"db_password"`
+	DBName     string `config:"db_name"`
+	// DBSSLMode postgres bağlantısının sslmode değeri (disable, require, verify-full, ...)
+	DBSSLMode string `config:"db_sslmode"`
+	// Bağlantı havuzu. DBConnMaxLifetime 0 ise bağlantılar süresiz yeniden kullanılır.
+	DBMaxOpenConns    int           `config:"db_max_open_conns"`
+	DBMaxIdleConns    int           `config:"db_max_idle_conns,zero"`
+	DBConnMaxLifetime time.Duration `config:"db_conn_max_lifetime,zero"`
+	// DBLogLevel GORM sorgu günlüğü seviyesi: silent, error, warn veya info
+	DBLogLevel string `config:"db_log_level"`
+	// DBAutoMigrate açıksa sunucu açılırken bekleyen migration'ları uygular; kapalıysa bekleyen migration varken açılmaz.
+	// Birden fazla örnek aynı anda açılabilir; migration'lar veritabanı kilidiyle tek örnekte çalışır.
+	DBAutoMigrate bool `config:"db_auto_migrate"`
+	// DBMigrationLockTimeout başka bir örneğin tuttuğu migration kilidinin en fazla ne kadar bekleneceği
+	DBMigrationLockTimeout time.Duration `config:"db_migration_lock_timeout"`
 
 	// JWT ayarları
-	JWTSecret         string
-	JWTExpiration     time.Duration
-	RefreshSecret     string
-	RefreshExpiration time.Duration
+	JWTSecret         string        `config:"jwt_secret"`
+	JWTExpiration     time.Duration `config:"jwt_expiration"`
+	RefreshSecret     string        `config:"refresh_secret"`
+	RefreshExpiration time.Duration `config:"refresh_expiration"`
+	// RevocationStore iptal edilmiş erişim token'larının tutulacağı yer: "database" veya "memory"
+	RevocationStore string `config:"revocation_store"`
+	// MFAPendingExpiration şifresi doğru girilen kullanıcının iki adımlı doğrulama kodunu girmesi için verilen süre
+	MFAPendingExpiration time.Duration `config:"mfa_pending_expiration"`
+	// TOTPIssuer authenticator uygulamasında görünen uygulama adı
+	TOTPIssuer string `config:"totp_issuer"`
 
 	// Sunucu ayarları
-	Port string
-	Env  string
+	Port string `config:"port"`
+	// Env çalışma ortamı: development, test veya production
+	Env string `config:"env"`
+	// HTTP sunucusu zaman aşımları. ShutdownTimeout kapatılırken açık isteklerin tamamlanması için beklenen süredir.
+	HTTPReadHeaderTimeout time.Duration `config:"http_read_header_timeout"`
+	HTTPReadTimeout       time.Duration `config:"http_read_timeout"`
+	HTTPWriteTimeout      time.Duration `config:"http_write_timeout"`
+	HTTPIdleTimeout       time.Duration `config:"http_idle_timeout"`
+	ShutdownTimeout       time.Duration `config:"shutdown_timeout"`
+	// MaxRequestBodyBytes istek gövdesinin en fazla boyutu
+	MaxRequestBodyBytes int `config:"max_request_body_bytes"`
+	// TrustedProxies X-Forwarded-For başlığına güvenilen vekil sunucuların IP veya CIDR adresleri.
+	// Boşsa başlık yok sayılır ve istemci IP'si bağlantının adresidir; giriş denemesi sınırları bu IP'yle tutulur.
+	TrustedProxies []string `config:"trusted_proxies"`
 
-	// CSRF
-	CSRFAuthKey string
-}
+	// CORS. Aynı liste WebSocket el sıkışmasında Origin kontrolü için de kullanılır.
+	CORSAllowedOrigins []string      `config:"cors_allowed_origins"`
+	CORSMaxAge         time.Duration `config:"cors_max_age"`
 
-// LoadConfig .env dosyasından veya ortam değişkenlerinden yapılandırmayı yükler
-func LoadConfig() (*Config, error) {
-	// Proje kök dizinini bulmak için daha sağlam bir yöntem
-	_, b, _, _ := runtime.Caller(0)
-	basepath := filepath.Dir(b)
-	projectRoot := filepath.Join(basepath, "..", "..") // internal/config -> backend/
+	// WebSocket bağlantı limitleri
+	// WSMaxMessageSize istemciden kabul edilen en büyük mesaj (bayt)
+	WSMaxMessageSize int `config:"ws_max_message_size"`
+	// WSSendBufferSize istemciye gönderilmeyi bekleyen en fazla mesaj sayısı; dolarsa bağlantı kesilir
+	WSSendBufferSize int           `config:"ws_send_buffer_size"`
+	WSWriteWait      time.Duration `config:"ws_write_wait"`
+	// WSPongWait istemciden pong beklenecek süre; ping'ler bu sürenin %90'ında bir gönderilir
+	WSPongWait time.Duration `config:"ws_pong_wait"`
 
-	// .env dosyasının tam yolunu belirt
-	envPath := filepath.Join(projectRoot, ".env")
+	// E-posta ayarları. SMTPHost boşsa e-postalar MailOutboxDir'e yazılır.
+	SMTPHost      string `config:"smtp_host"`
+	SMTPPort      string `config:"smtp_port"`
+	SMTPUser      string `config:"smtp_user"`
+	SMTPPassword  string `config:"smtp_password"`
+	MailFrom      string `config:"mail_from"`
+	MailOutboxDir string `config:"mail_outbox_dir"`
 
-	if err := godotenv.Load(envPath); err != nil {
-		fmt.Printf("Warning: .env file not loaded from '%s', falling back to environment variables and defaults\n", envPath)
-	}
+	// Şifre sıfırlama bağlantılarının yönlendirileceği frontend adresi
+	FrontendURL      string        `config:"frontend_url"`
+	PasswordResetTTL time.Duration `config:"password_reset_ttl"`
 
-	// JWT sürelerini parse et
-	jwtExp, err := time.ParseDuration(getEnv("JWT_EXPIRATION", "24h"))
-	if err != nil {
-		return nil, err
-	}
+	// E-posta doğrulama ayarları
+	EmailVerificationSecret string        `config:"email_verification_secret"`
+	EmailVerificationTTL    time.Duration `config:"email_verification_ttl"`
+	// VerificationResendInterval doğrulama e-postasının tekrar gönderilebilmesi için beklenecek süre
+	VerificationResendInterval time.Duration `config:"verification_resend_interval"`
+	// RequireVerifiedEmail açıksa e-postası doğrulanmamış kullanıcılar etkinlik/oda oluşturamaz ve davet gönderemez
+	RequireVerifiedEmail bool `config:"require_verified_email"`
 
-	refreshExp, err := time.ParseDuration(getEnv("REFRESH_EXPIRATION", "720h")) // 30 gün
-	if err != nil {
-		return nil, err
-	}
+	// Sosyal giriş (OAuth2 / OpenID Connect). İstemci kimliği boş olan sağlayıcılar devre dışıdır.
+	// OAuthRedirectBaseURL sağlayıcının kodu döndüreceği frontend sayfasının temel adresi
+	OAuthRedirectBaseURL string        `config:"oauth_redirect_base_url"`
+	OAuthStateTTL        time.Duration `config:"oauth_state_ttl"`
+	GoogleClientID       string        `config:"google_client_id"`
+	GoogleClientSecret   string        `config:"google_client_secret"`
+	GitHubClientID       string        `config:"github_client_id"`
+	GitHubClientSecret   string        `config:"github_client_secret"`
+	OIDCProviderName     string        `config:"oidc_provider_name"`
+	OIDCIssuer           string        `config:"oidc_issuer"`
+	OIDCClientID         string        `config:"oidc_client_id"`
+	OIDCClientSecret     string        `config:"oidc_client_secret"`
+
+	// Kaba kuvvet (brute-force) koruması
+	// LoginFailureWindow bu süre boyunca yeni hata olmazsa hata sayacı sıfırlanır
+	LoginFailureWindow time.Duration `config:"login_failure_window"`
+	// Hesap başına ve IP başına kaç hatalı denemeden sonra üstel bekleme başlayacağı
+	AccountBackoffThreshold int `config:"account_backoff_threshold"`
+	IPBackoffThreshold      int `config:"ip_backoff_threshold"`
+	// Üstel beklemenin başlangıç ve en fazla süresi
+	LoginBackoffBase time.Duration `config:"login_backoff_base"`
+	LoginBackoffMax  time.Duration `config:"login_backoff_max"`
+	// AccountLockoutThreshold hatalı denemeden sonra hesap AccountLockoutDuration boyunca kilitlenir
+	AccountLockoutThreshold int           `config:"account_lockout_threshold"`
+	AccountLockoutDuration  time.Duration `config:"account_lockout_duration"`
 
+	// Şifre saklama ve şifre politikası
+	// PasswordHashAlgorithm yeni şifrelerin hashleneceği algoritma: "argon2id" veya "bcrypt".
+	// Diğer algoritmayla veya eski parametrelerle saklanmış hash'ler girişte yeniden hashlenir.
+	PasswordHashAlgorithm string `config:"password_hash_algorithm"`
+	BcryptCost            int    `config:"bcrypt_cost"`
+	// Argon2id parametreleri; bellek KiB cinsindendir
+	Argon2MemoryKiB   int `config:"argon2_memory_kib"`
+	Argon2Iterations  int `config:"argon2_iterations"`
+	Argon2Parallelism int `config:"argon2_parallelism"`
+	PasswordMinLength int `config:"password_min_length"`
+	PasswordMaxLength int `config:"password_max_length"`
+	// PasswordMinCharClasses büyük harf, küçük harf, rakam ve özel karakterden kaç türün gerektiği
+	PasswordMinCharClasses int `config:"password_min_char_classes"`
+	// PasswordBreachedListFile yerleşik listeye eklenecek sızdırılmış şifre dosyası (isteğe bağlı)
+	PasswordBreachedListFile string `config:"password_breached_list_file"`
+
+	// Kişisel erişim token'ları
+	// PersonalAccessTokenDefaultTTL süre belirtilmeden oluşturulan token'ların geçerlilik süresi
+	PersonalAccessTokenDefaultTTL time.Duration `config:"pat_default_ttl"`
+	// PersonalAccessTokenMaxTTL bir token'a verilebilecek en uzun geçerlilik süresi
+	PersonalAccessTokenMaxTTL time.Duration `config:"pat_max_ttl"`
+	// MaxPersonalAccessTokens bir kullanıcının aynı anda sahip olabileceği aktif token sayısı
+	MaxPersonalAccessTokens int `config:"max_personal_access_tokens"`
+}
+
+// Default yerel geliştirme için varsayılan yapılandırmayı döndürür.
+// Yapılandırma dosyası, ortam değişkenleri ve bayraklar bu değerlerin üzerine yazılır.
+func Default() *Config {
 	return &Config{
 		// Veritabanı ayarları
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "3306"),
-		DBUser:     getEnv("DB_USER", "root"),
-		DBPassword: getEnv("DB_PASSWORD", ""),
-		DBName:     getEnv("DB_NAME", "event_db"),
+		DBDriver:               "mysql",
+		DBHost:                 "localhost",
+		DBUser:                 "root",
+		DBName:                 "event_db",
+		DBSSLMode:              "disable",
+		DBMaxOpenConns:         100,
+		DBMaxIdleConns:         10,
+		DBConnMaxLifetime:      time.Hour,
+		DBLogLevel:             "info",
+		DBAutoMigrate:          true,
+		DBMigrationLockTimeout: 5 * time.Minute,
 
 		// JWT ayarları
-		JWTSecret:         getEnv("JWT_SECRET", "your-secret-key"),
-		JWTExpiration:     jwtExp,
-		RefreshSecret:     getEnv("REFRESH_SECRET", "your-refresh-secret-key"),
-		RefreshExpiration: refreshExp,
+		JWTSecret:            defaultJWTSecret,
+		JWTExpiration:        24 * time.Hour,
+		RefreshSecret:        defaultRefreshSecret,
+		RefreshExpiration:    30 * 24 * time.Hour,
+		RevocationStore:      "database",
+		MFAPendingExpiration: 5 * time.Minute,
+		TOTPIssuer:           "Event",
 
 		// Sunucu ayarları
-		Port: getEnv("PORT", "8082"),
-		Env:  getEnv("ENV", "development"),
+		Port:                  "8082",
+		Env:                   "development",
+		HTTPReadHeaderTimeout: 10 * time.Second,
+		HTTPReadTimeout:       30 * time.Second,
+		HTTPWriteTimeout:      30 * time.Second,
+		HTTPIdleTimeout:       2 * time.Minute,
+		ShutdownTimeout:       10 * time.Second,
+		MaxRequestBodyBytes:   1 << 20,
+
+		CORSAllowedOrigins: []string{"http://localhost:5173", "http://127.0.0.1:5173"},
+		CORSMaxAge:         12 * time.Hour,
+
+		WSMaxMessageSize: 512,
+		WSSendBufferSize: 256,
+		WSWriteWait:      10 * time.Second,
+		WSPongWait:       60 * time.Second,
 
-		// CSRF
-		CSRFAuthKey: getEnv("CSRF_AUTH_KEY", "a-32-byte-long-auth-key-for-csrf"),
-	}, nil
+		// E-posta ayarları
+		SMTPPort:      "587",
+		MailFrom:      "no-reply@event.local",
+		MailOutboxDir: "outbox",
+
+		FrontendURL:      "http://localhost:5173",
+		PasswordResetTTL: time.Hour,
+
+		// E-posta doğrulama ayarları
+		EmailVerificationSecret:    defaultEmailVerificationSecret,
+		EmailVerificationTTL:       48 * time.Hour,
+		VerificationResendInterval: 2 * time.Minute,
+
+		// Sosyal giriş
+		OAuthRedirectBaseURL: "http://localhost:5173/oauth/callback",
+		OAuthStateTTL:        10 * time.Minute,
+		OIDCProviderName:     "oidc",
+
+		// Kaba kuvvet koruması
+		LoginFailureWindow:      15 * time.Minute,
+		AccountBackoffThreshold: 3,
+		IPBackoffThreshold:      10,
+		LoginBackoffBase:        time.Second,
+		LoginBackoffMax:         15 * time.Minute,
+		AccountLockoutThreshold: 10,
+		AccountLockoutDuration:  30 * time.Minute,
+
+		// Şifre saklama ve şifre politikası
+		PasswordHashAlgorithm:  "argon2id",
+		BcryptCost:             12,
+		Argon2MemoryKiB:        64 * 1024,
+		Argon2Iterations:       3,
+		Argon2Parallelism:      2,
+		PasswordMinLength:      8,
+		PasswordMaxLength:      128,
+		PasswordMinCharClasses: 3,
+
+		// Kişisel erişim token'ları
+		PersonalAccessTokenDefaultTTL: 30 * 24 * time.Hour,
+		PersonalAccessTokenMaxTTL:     365 * 24 * time.Hour,
+		MaxPersonalAccessTokens:       50,
+	}
 }
 
-// GetDSN veritabanı bağlantı dizesini döndürür
-func (c *Config) GetDSN() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName)
+// IsProduction üretim ortamında çalışılıp çalışılmadığını döndürür
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
 }
 
-// getEnv ortam değişkenini alır, yoksa varsayılan değeri döndürür
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// GetDSN seçili sürücü için veritabanı bağlantı dizesini döndürür
+func (c *Config) GetDSN() string {
+	switch c.DBDriver {
+	case "postgres":
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.DBUser, c.DBPassword),
+			Host:     c.DBHost + ":" + c.dbPort(),
+			Path:     "/" + c.DBName,
+			RawQuery: url.Values{"sslmode": {c.DBSSLMode}}.Encode(),
+		}
+		return dsn.String()
+	case "sqlite":
+		// Bellek içi veritabanı havuzdaki tüm bağlantılarca paylaşılmalı; aksi halde her bağlantı boş bir veritabanı görür
+		if c.IsInMemorySQLite() {
+			return "file::memory:?cache=shared&_pragma=foreign_keys(1)"
+		}
+		// _txlock=immediate: transaction'lar yazma kilidini baştan alır; eşzamanlı yazmalarda busy_timeout beklenir
+		// ve okuyup sonra yazan transaction'lar SQLITE_BUSY ile düşmez
+		separator := "?"
+		if strings.Contains(c.DBName, "?") {
+			separator = "&"
+		}
+		return c.DBName + separator + "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate"
+	default:
+		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			c.DBUser, c.DBPassword, c.DBHost, c.dbPort(), c.DBName)
 	}
-	return value
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	value := getEnv(key, "")
-	if value == "" {
-		return defaultValue
+// IsInMemorySQLite bellek içi SQLite veritabanı kullanılıp kullanılmadığını döndürür
+func (c *Config) IsInMemorySQLite() bool {
+	return c.DBDriver == "sqlite" && c.DBName == ":memory:"
+}
+
+// dbPort yapılandırılmış portu, yoksa sürücünün varsayılan portunu döndürür
+func (c *Config) dbPort() string {
+	if c.DBPort != "" {
+		return c.DBPort
 	}
-	intValue, err := strconv.Atoi(value)
-	if err != nil {
-		return defaultValue
+	if c.DBDriver == "postgres" {
+		return "5432"
 	}
-	return intValue
+	return "3306"
 }
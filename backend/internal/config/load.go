@@ -0,0 +1,239 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field Config'teki config etiketli bir alanı temsil eder
+type field struct {
+	key       string
+	allowZero bool
+	value     reflect.Value
+}
+
+// envName alanın ortam değişkeni adını döndürür (jwt_secret -> JWT_SECRET)
+func (f field) envName() string {
+	return strings.ToUpper(f.key)
+}
+
+// flagName alanın komut satırı bayrağı adını döndürür (jwt_secret -> jwt-secret)
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.key, "_", "-")
+}
+
+// LoadConfig yapılandırmayı komut satırı bayraklarıyla birlikte yükler. Uygulama başlarken bir kez çağrılır;
+// oluşan *Config bağımlılık olarak aktarılır. Bayraklardan sonra kalan argümanlar (ör. "migrate up") ayrıca döner.
+func LoadConfig() (*Config, []string, error) {
+	return load(os.Args[1:])
+}
+
+// Load yapılandırmayı şu sırayla katmanlar; her katman öncekinin üzerine yazar:
+// varsayılanlar, yapılandırma dosyası, ortam değişkenleri, komut satırı bayrakları.
+// Yapılandırma dosyası -config bayrağı veya CONFIG_FILE ortam değişkeniyle verilir ve uzantısına göre
+// YAML (.yaml, .yml) ya da TOML (.toml) olarak okunur. Çalışma dizinindeki .env dosyası varsa
+// ortam değişkenlerine eklenir; zaten tanımlı değişkenlerin üzerine yazmaz.
+// Sonuç Validate ile doğrulanır.
+func Load(args []string) (*Config, error) {
+	cfg, _, err := load(args)
+	return cfg, err
+}
+
+func load(args []string) (*Config, []string, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf(".env dosyası okunamadı: %w", err)
+	}
+
+	cfg := Default()
+	fields := cfg.fields()
+
+	flagValues, configFile, rest, err := parseFlags(args, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+	if configFile != "" {
+		if err := loadFile(configFile, fields); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := loadEnv(fields); err != nil {
+		return nil, nil, err
+	}
+
+	for _, f := range fields {
+		if raw, ok := flagValues[f.key]; ok {
+			if err := setField(f, raw); err != nil {
+				return nil, nil, fmt.Errorf("-%s bayrağı: %w", f.flagName(), err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, rest, nil
+}
+
+// fields config etiketli alanları tanımlandıkları sırayla döndürür
+func (c *Config) fields() []field {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("config")
+		if !ok {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		fields = append(fields, field{key: key, allowZero: opts == "zero", value: v.Field(i)})
+	}
+	return fields
+}
+
+// parseFlags her yapılandırma anahtarı için bir bayrak tanımlar ve yalnızca verilen bayrakların değerlerini döndürür.
+// Değerler diğer katmanlar yüklendikten sonra uygulanır. Bayrak olmayan ilk argümandan sonrası ayrıca döner.
+func parseFlags(args []string, fields []field) (map[string]string, string, []string, error) {
+	flags := flag.NewFlagSet("event", flag.ContinueOnError)
+	configFile := flags.String("config", "", "YAML veya TOML yapılandırma dosyası (CONFIG_FILE)")
+
+	values := make(map[string]string)
+	for _, f := range fields {
+		key := f.key
+		usage := fmt.Sprintf("%s (varsayılan: %v)", f.envName(), f.value.Interface())
+		set := func(s string) error {
+			values[key] = s
+			return nil
+		}
+		if f.value.Kind() == reflect.Bool {
+			flags.BoolFunc(f.flagName(), usage, set)
+		} else {
+			flags.Func(f.flagName(), usage, set)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, "", nil, err
+	}
+	return values, *configFile, flags.Args(), nil
+}
+
+// loadFile yapılandırma dosyasını okur. Tanınmayan anahtarlar yazım hatalarının fark edilmesi için hata verir.
+func loadFile(path string, fields []field) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("yapılandırma dosyası okunamadı: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return fmt.Errorf("desteklenmeyen yapılandırma dosyası uzantısı: %s (.yaml, .yml veya .toml olmalı)", path)
+	}
+	if err != nil {
+		return fmt.Errorf("yapılandırma dosyası ayrıştırılamadı (%s): %w", path, err)
+	}
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	for key, raw := range values {
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("yapılandırma dosyası (%s): bilinmeyen anahtar %q", path, key)
+		}
+		if list, isList := raw.([]interface{}); isList && f.value.Kind() == reflect.Slice {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+			f.value.Set(reflect.ValueOf(items))
+			continue
+		}
+		if raw == nil {
+			raw = ""
+		}
+		if err := setField(f, fmt.Sprint(raw)); err != nil {
+			return fmt.Errorf("yapılandırma dosyası (%s): %w", path, err)
+		}
+	}
+	return nil
+}
+
+// loadEnv ortam değişkenlerini uygular. Boş değişkenler tanımsız sayılır.
+func loadEnv(fields []field) error {
+	for _, f := range fields {
+		raw := os.Getenv(f.envName())
+		if raw == "" {
+			continue
+		}
+		if err := setField(f, raw); err != nil {
+			return fmt.Errorf("%s ortam değişkeni: %w", f.envName(), err)
+		}
+	}
+	return nil
+}
+
+// setField metin değeri alanın türüne çevirip atar. Listeler virgülle ayrılır.
+func setField(f field, raw string) error {
+	v := f.value
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: geçersiz süre %q (örn. 30s, 15m, 24h)", f.key, raw)
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s: geçersiz tamsayı %q", f.key, raw)
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: geçersiz mantıksal değer %q (true veya false olmalı)", f.key, raw)
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s: desteklenmeyen alan türü %s", f.key, v.Type())
+	}
+	return nil
+}
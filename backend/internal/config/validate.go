@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// minSecretLength üretimde imzalama anahtarlarının en az uzunluğu (bayt)
+const minSecretLength = 32
+
+// placeholderSecrets varsayılanlar ve README'deki örnek .env'de geçen, üretimde kullanılmaması gereken değerler
+var placeholderSecrets = map[string]bool{
+	defaultJWTSecret:                                      true,
+	defaultRefreshSecret:                                  true,
+	defaultEmailVerificationSecret:                        true,
+	"your_jwt_secret_key_change_in_production":            true,
+	"your_refresh_secret_key_change_in_production":        true,
+	"your_email_verification_secret_change_in_production": true,
+}
+
+// ValidationError yapılandırmadaki tüm hataları birlikte taşır
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "geçersiz yapılandırma:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate yapılandırma değerlerini doğrular. ENV=production iken varsayılan veya kısa gizli anahtarlarla
+// çalışılmasına izin vermez. Hatalar tek seferde görülebilsin diye hepsi birlikte döner.
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	// Sayılar ve süreler pozitif olmalı; "zero" seçeneği olanlar 0 da olabilir
+	for _, f := range c.fields() {
+		switch f.value.Kind() {
+		case reflect.Int, reflect.Int64:
+			n := f.value.Int()
+			if n < 0 || (n == 0 && !f.allowZero) {
+				add("%s pozitif olmalı", f.key)
+			}
+		}
+	}
+
+	checkOneOf := func(key, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		add("%s %q olamaz (%s olmalı)", key, value, strings.Join(allowed, ", "))
+	}
+	checkOneOf("env", c.Env, "development", "test", "production")
+	checkOneOf("revocation_store", c.RevocationStore, "database", "memory")
+	checkOneOf("password_hash_algorithm", c.PasswordHashAlgorithm, "argon2id", "bcrypt")
+	checkOneOf("db_log_level", c.DBLogLevel, "silent", "error", "warn", "info")
+	checkOneOf("db_driver", c.DBDriver, "mysql", "postgres", "sqlite")
+
+	checkPort := func(key, value string) {
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+			add("%s geçerli bir port olmalı: %q", key, value)
+		}
+	}
+	checkPort("port", c.Port)
+	if c.DBPort != "" && c.DBDriver != "sqlite" {
+		checkPort("db_port", c.DBPort)
+	}
+	if c.SMTPHost != "" {
+		checkPort("smtp_port", c.SMTPPort)
+	}
+
+	if c.DBMaxIdleConns > c.DBMaxOpenConns {
+		add("db_max_idle_conns db_max_open_conns değerinden büyük olamaz")
+	}
+	if c.PasswordMinLength > c.PasswordMaxLength {
+		add("password_min_length password_max_length değerinden büyük olamaz")
+	}
+	if c.PasswordMinCharClasses > 4 {
+		add("password_min_char_classes en fazla 4 olabilir")
+	}
+	if c.LoginBackoffBase > c.LoginBackoffMax {
+		add("login_backoff_base login_backoff_max değerinden büyük olamaz")
+	}
+	if c.PersonalAccessTokenDefaultTTL > c.PersonalAccessTokenMaxTTL {
+		add("pat_default_ttl pat_max_ttl değerinden büyük olamaz")
+	}
+
+	if len(c.CORSAllowedOrigins) == 0 {
+		add("cors_allowed_origins en az bir origin içermeli")
+	}
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == "*" {
+			add("cors_allowed_origins '*' içeremez; kimlik bilgili istekler için origin'ler tek tek belirtilmeli")
+		}
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				add("trusted_proxies geçersiz IP veya CIDR içeriyor: %q", proxy)
+			}
+		}
+	}
+
+	if c.IsProduction() {
+		secrets := []struct {
+			key   string
+			value string
+		}{
+			{"jwt_secret", c.JWTSecret},
+			{"refresh_secret", c.RefreshSecret},
+			{"email_verification_secret", c.EmailVerificationSecret},
+		}
+		for _, s := range secrets {
+			switch {
+			case placeholderSecrets[s.value]:
+				add("%s üretimde varsayılan değerde bırakılamaz", s.key)
+			case len(s.value) < minSecretLength:
+				add("%s üretimde en az %d bayt olmalı", s.key, minSecretLength)
+			}
+		}
+		// Token türleri aynı claim'leri (user_id, email) taşıdığından anahtarlar aynıysa bir türün tokeni
+		// diğerinin yerine kabul edilebilirdi
+		for i := range secrets {
+			for j := i + 1; j < len(secrets); j++ {
+				if secrets[i].value == secrets[j].value {
+					add("%s ve %s farklı olmalı", secrets[i].key, secrets[j].key)
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
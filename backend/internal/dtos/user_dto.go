@@ -0,0 +1,38 @@
+package dtos
+
+import (
+	"time"
+
+	"event/backend/internal/models"
+)
+
+// AdminUserDTO, yönetici endpoint'lerinde dönen kullanıcıyı temsil eder.
+// Herkese açık profilde gizlenen rol ve askıya alma alanlarını da içerir.
+type AdminUserDTO struct {
+	*models.User
+	Role             models.UserRole `json:"role"`
+	SuspendedAt      *time.Time      `json:"suspended_at,omitempty"`
+	SuspensionReason string          `json:"suspension_reason,omitempty"`
+}
+
+// NewAdminUserDTO, kullanıcı modelinden bir AdminUserDTO oluşturur.
+func NewAdminUserDTO(user *models.User) *AdminUserDTO {
+	return &AdminUserDTO{
+		User:             user,
+		Role:             user.Role,
+		SuspendedAt:      user.SuspendedAt,
+		SuspensionReason: user.SuspensionReason,
+	}
+}
+
+// CurrentUserDTO, giriş yapan kullanıcının kendi bilgilerini temsil eder.
+// Başka kullanıcılara gösterilmeyen iki adımlı doğrulama durumunu da içerir.
+type CurrentUserDTO struct {
+	*models.User
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+}
+
+// NewCurrentUserDTO, kullanıcı modelinden bir CurrentUserDTO oluşturur.
+func NewCurrentUserDTO(user *models.User) *CurrentUserDTO {
+	return &CurrentUserDTO{User: user, TwoFactorEnabled: user.IsTwoFactorEnabled()}
+}
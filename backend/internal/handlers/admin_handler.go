@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"event/backend/internal/dtos"
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler platform yöneticilerinin moderasyon endpoint'lerini içerir
+type AdminHandler struct {
+	adminService *services.AdminService
+}
+
+// NewAdminHandler yeni bir AdminHandler oluşturur
+func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// setRoleRequest rol değiştirme isteğinin gövdesi
+type setRoleRequest struct {
+	Role models.UserRole `json:"role" binding:"required"`
+}
+
+// suspendUserRequest hesap askıya alma isteğinin gövdesi
+type suspendUserRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// moderationRequest içerik silme isteğinin isteğe bağlı gövdesi
+type moderationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// resolveReportRequest şikayet sonuçlandırma isteğinin gövdesi
+type resolveReportRequest struct {
+	Status models.ReportStatus `json:"status" binding:"required"`
+	Note   string              `json:"note" binding:"max=500"`
+}
+
+// SetUserRole kullanıcının platform rolünü değiştirir
+// PUT /api/admin/users/:id/role
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	adminID, userID, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+	var req setRoleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.adminService.SetRole(adminID, userID, req.Role)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Kullanıcı rolü güncellendi", dtos.NewAdminUserDTO(user))
+}
+
+// SuspendUser kullanıcının hesabını askıya alır ve tüm oturumlarını sonlandırır
+// POST /api/admin/users/:id/suspend
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	adminID, userID, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+	var req suspendUserRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	user, err := h.adminService.SuspendUser(adminID, userID, req.Reason)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Hesap askıya alındı", dtos.NewAdminUserDTO(user))
+}
+
+// UnsuspendUser askıya alınmış hesabı yeniden etkinleştirir
+// POST /api/admin/users/:id/unsuspend
+func (h *AdminHandler) UnsuspendUser(c *gin.Context) {
+	adminID, userID, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.UnsuspendUser(adminID, userID)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Hesap yeniden etkinleştirildi", dtos.NewAdminUserDTO(user))
+}
+
+// GetUserNotifications kullanıcının bildirimlerini salt okunur olarak listeler (destek amaçlı)
+// GET /api/admin/users/:id/notifications
+func (h *AdminHandler) GetUserNotifications(c *gin.Context) {
+	adminID, userID, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+
+	notifications, err := h.adminService.GetUserNotifications(adminID, userID)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", notifications)
+}
+
+// DeleteEvent etkinliği sahibinden bağımsız olarak siler
+// DELETE /api/admin/events/:id
+func (h *AdminHandler) DeleteEvent(c *gin.Context) {
+	h.forceDelete(c, h.adminService.ForceDeleteEvent, "Etkinlik silindi")
+}
+
+// DeleteRoom odayı kurucusundan bağımsız olarak siler
+// DELETE /api/admin/rooms/:id
+func (h *AdminHandler) DeleteRoom(c *gin.Context) {
+	h.forceDelete(c, h.adminService.ForceDeleteRoom, "Oda silindi")
+}
+
+// DeleteMessage mesajı göndereninden bağımsız olarak siler
+// DELETE /api/admin/messages/:id
+func (h *AdminHandler) DeleteMessage(c *gin.Context) {
+	h.forceDelete(c, h.adminService.ForceDeleteMessage, "Mesaj silindi")
+}
+
+// GetReports şikayetleri sayfalı olarak listeler
+// GET /api/admin/reports?status=open&page=1&limit=20
+func (h *AdminHandler) GetReports(c *gin.Context) {
+	page := queryInt(c, "page", 1)
+	limit := queryInt(c, "limit", 20)
+
+	reports, total, err := h.adminService.ListReports(models.ReportStatus(c.Query("status")), page, limit)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Şikayetler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{
+		"reports": reports,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// ResolveReport açık bir şikayeti sonuçlandırır
+// POST /api/admin/reports/:id/resolve
+func (h *AdminHandler) ResolveReport(c *gin.Context) {
+	adminID, reportID, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+	var req resolveReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.adminService.ResolveReport(adminID, reportID, req.Status, req.Note)
+	if err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Şikayet sonuçlandırıldı", report)
+}
+
+// GetAuditLogs yönetici işlem kayıtlarını sayfalı olarak listeler
+// GET /api/admin/audit-logs?page=1&limit=50
+func (h *AdminHandler) GetAuditLogs(c *gin.Context) {
+	page := queryInt(c, "page", 1)
+	limit := queryInt(c, "limit", 50)
+
+	logs, total, err := h.adminService.ListAuditLogs(page, limit)
+	if err != nil {
+		utils.ServerErrorResponse(c, "İşlem kayıtları alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{
+		"logs":  logs,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// forceDelete içerik silme endpoint'lerinin ortak akışı
+func (h *AdminHandler) forceDelete(c *gin.Context, del func(adminID, id uint64, reason string) error, message string) {
+	adminID, id, ok := h.adminAndTarget(c)
+	if !ok {
+		return
+	}
+	var req moderationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	if err := del(adminID, id, req.Reason); err != nil {
+		adminErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, message, nil)
+}
+
+// adminAndTarget isteği yapan yöneticinin ve :id parametresinin değerini döndürür
+func (h *AdminHandler) adminAndTarget(c *gin.Context) (uint64, uint64, bool) {
+	adminID, ok := requireUserID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+	return adminID, id, true
+}
+
+// bindOptionalJSON gövde boşsa hata vermez; gövde varsa bindJSON gibi doğrular
+func bindOptionalJSON(c *gin.Context, obj interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	return bindJSON(c, obj)
+}
+
+// adminErrorResponse moderasyon hatalarını uygun HTTP durum kodlarına çevirir
+func adminErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrReportNotFound), errors.Is(err, services.ErrModerationTargetNotFound):
+		utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, services.ErrAdminSelfAction):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrReportAlreadyClosed):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,465 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event/backend/internal/dtos"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+	"event/backend/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler kayıt, giriş ve token yenileme endpoint'lerini yönetir
+type AuthHandler struct {
+	authService              *services.AuthService
+	passwordResetService     *services.PasswordResetService
+	emailVerificationService *services.EmailVerificationService
+	twoFactorService         *services.TwoFactorService
+	validator                *validator.CustomValidator
+}
+
+// AuthHandlerInput, AuthHandler için bağımlılıkları içerir.
+type AuthHandlerInput struct {
+	AuthService              *services.AuthService
+	PasswordResetService     *services.PasswordResetService
+	EmailVerificationService *services.EmailVerificationService
+	TwoFactorService         *services.TwoFactorService
+}
+
+// NewAuthHandler yeni bir AuthHandler oluşturur
+func NewAuthHandler(input AuthHandlerInput) *AuthHandler {
+	return &AuthHandler{
+		authService:              input.AuthService,
+		passwordResetService:     input.PasswordResetService,
+		emailVerificationService: input.EmailVerificationService,
+		twoFactorService:         input.TwoFactorService,
+		validator:                validator.NewValidator(),
+	}
+}
+
+// registerRequest kayıt isteğinin gövdesi
+type registerRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	// Şifre kuralları servis katmanında auth.PasswordPolicy ile denetlenir
+	Password  string `json:"password" validate:"required"`
+	FirstName string `json:"first_name" validate:"max=100"`
+	LastName  string `json:"last_name" validate:"max=100"`
+	// DeviceLabel oturumun hangi cihazdan açıldığını gösterir (örn. "iPhone"); boşsa User-Agent kullanılır
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+}
+
+// loginRequest giriş isteğinin gövdesi
+type loginRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	Password    string `json:"password" validate:"required"`
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+}
+
+// refreshRequest token yenileme isteğinin gövdesi
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// forgotPasswordRequest şifremi unuttum isteğinin gövdesi
+type forgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// resetPasswordRequest şifre sıfırlama isteğinin gövdesi
+type resetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// verifyEmailRequest e-posta doğrulama isteğinin gövdesi
+type verifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// unlockAccountRequest hesap kilidi açma isteğinin gövdesi
+type unlockAccountRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// twoFactorCodeRequest iki adımlı doğrulama kurulumunu onaylama gövdesi
+type twoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// twoFactorVerifyRequest girişte mfa_pending tokeni ile kod doğrulama gövdesi
+type twoFactorVerifyRequest struct {
+	MFAToken    string `json:"mfa_token" validate:"required"`
+	Code        string `json:"code" validate:"required"`
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+}
+
+// twoFactorDisableRequest iki adımlı doğrulamayı kapatma gövdesi
+type twoFactorDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// logoutRequest çıkış isteğinin gövdesi. Yenileme tokeni gönderilirse o da iptal edilir.
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// changePasswordRequest şifre değiştirme isteğinin gövdesi
+type changePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required"`
+}
+
+// bindAndValidate istek gövdesini bağlar ve pkg/validator kurallarıyla doğrular
+func (h *AuthHandler) bindAndValidate(c *gin.Context, obj interface{}) bool {
+	if !bindJSON(c, obj) {
+		return false
+	}
+	if err := h.validator.Validate(obj); err != nil {
+		utils.ValidationErrorResponse(c, strings.Join(h.validator.FormatValidationErrors(err), "; "))
+		return false
+	}
+	return true
+}
+
+// clientInfo istekten token üretilecek istemcinin bilgilerini çıkarır.
+// İstek oturum açmış bir kullanıcıdan geliyorsa erişim tokeninin oturumu da eklenir.
+func clientInfo(c *gin.Context, deviceLabel string) services.ClientInfo {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	label := strings.TrimSpace(deviceLabel)
+	if label == "" {
+		label = userAgent
+	}
+	if len(label) > 100 {
+		label = label[:100]
+	}
+	return services.ClientInfo{
+		DeviceLabel: label,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   userAgent,
+		SessionID:   c.GetString("session_id"),
+	}
+}
+
+// loginErrorResponse giriş ve iki adımlı doğrulama hatalarını uygun HTTP durum kodlarına çevirir
+func loginErrorResponse(c *gin.Context, err error) {
+	var blocked *services.LoginBlockedError
+	switch {
+	case errors.As(err, &blocked):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+		utils.ErrorResponse(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, services.ErrAccountSuspended):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidTwoFactorCode):
+		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+	}
+}
+
+// twoFactorErrorResponse kurulum onayı ve kapatma hatalarını yanıtlar; çok fazla hatalı deneme girişteki gibi 429 döner
+func twoFactorErrorResponse(c *gin.Context, err error) {
+	var blocked *services.LoginBlockedError
+	if errors.As(err, &blocked) {
+		loginErrorResponse(c, err)
+		return
+	}
+	utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+}
+
+// Register yeni bir kullanıcı kaydı oluşturur
+// POST /api/auth/register
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req registerRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.Register(
+		strings.TrimSpace(req.Username),
+		strings.ToLower(strings.TrimSpace(req.Email)),
+		req.Password,
+		strings.TrimSpace(req.FirstName),
+		strings.TrimSpace(req.LastName),
+		clientInfo(c, req.DeviceLabel),
+	)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Doğrulama e-postası gönderilemese bile kayıt tamamlanır; kullanıcı tekrar gönderme isteyebilir
+	if err := h.emailVerificationService.SendVerificationEmail(resp.User.ID); err != nil {
+		log.Printf("[AuthHandler.Register] Doğrulama e-postası gönderilemedi (UserID: %d): %v", resp.User.ID, err)
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Kayıt başarılı, lütfen e-posta adresinizi doğrulayın", resp)
+}
+
+// Login e-posta ve şifre ile giriş yapar
+// POST /api/auth/login
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req loginRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.Login(strings.ToLower(strings.TrimSpace(req.Email)), req.Password, clientInfo(c, req.DeviceLabel))
+	if err != nil {
+		loginErrorResponse(c, err)
+		return
+	}
+	if resp.MFARequired {
+		utils.SuccessResponse(c, http.StatusOK, "İki adımlı doğrulama kodu gerekli", resp)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Giriş başarılı", resp)
+}
+
+// Refresh yenileme tokeni ile yeni bir token çifti üretir
+// POST /api/auth/refresh
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req refreshRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.RefreshToken(req.RefreshToken, clientInfo(c, ""))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Token yenilendi", resp)
+}
+
+// Me giriş yapan kullanıcının bilgilerini döndürür
+// GET /api/auth/me
+func (h *AuthHandler) Me(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.authService.GetUserByID(userID)
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", dtos.NewCurrentUserDTO(user))
+}
+
+// ForgotPassword e-posta adresine şifre sıfırlama bağlantısı gönderir.
+// Adresin kayıtlı olup olmadığından bağımsız olarak aynı yanıt döner.
+// POST /api/auth/forgot-password
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req forgotPasswordRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.passwordResetService.RequestPasswordReset(strings.ToLower(strings.TrimSpace(req.Email))); err != nil {
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Bu e-posta adresi kayıtlıysa şifre sıfırlama bağlantısı gönderildi", nil)
+}
+
+// ResetPassword e-postadaki token ile yeni şifre belirler
+// POST /api/auth/reset-password
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req resetPasswordRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(req.Token, req.Password); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Şifreniz güncellendi, yeni şifrenizle giriş yapabilirsiniz", nil)
+}
+
+// ChangePassword giriş yapan kullanıcının şifresini değiştirir.
+// Diğer oturumların yenileme token'ları geçersiz olur; bu oturum için yeni token çifti döner.
+// POST /api/auth/change-password
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req changePasswordRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.ChangePassword(userID, req.CurrentPassword, req.NewPassword, clientInfo(c, ""))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Şifreniz değiştirildi", resp)
+}
+
+// Logout mevcut erişim tokenini ve varsa gönderilen yenileme tokenini iptal eder
+// POST /api/auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	// Gövde isteğe bağlıdır; yalnızca erişim tokeni ile de çıkış yapılabilir
+	var req logoutRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	jti := c.GetString("jti")
+	expiresAt := c.GetTime("token_expires_at")
+	if err := h.authService.Logout(userID, jti, expiresAt, c.GetString("session_id"), req.RefreshToken); err != nil {
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Çıkış yapıldı", nil)
+}
+
+// VerifyEmail e-postadaki imzalı bağlantı ile kullanıcının e-posta adresini doğrular
+// POST /api/auth/verify-email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	user, err := h.emailVerificationService.VerifyEmail(req.Token)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "E-posta adresiniz doğrulandı", user)
+}
+
+// ResendVerification doğrulama e-postasını tekrar gönderir. Çok sık istenirse 429 döner.
+// POST /api/auth/resend-verification
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.emailVerificationService.SendVerificationEmail(userID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrVerificationThrottled):
+			utils.ErrorResponse(c, http.StatusTooManyRequests, err.Error())
+		case errors.Is(err, services.ErrEmailAlreadyVerified):
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.ServerErrorResponse(c, err.Error())
+		}
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Doğrulama e-postası gönderildi", nil)
+}
+
+// UnlockAccount kilitlenme e-postasındaki bağlantı ile hesabın kilidini açar
+// POST /api/auth/unlock
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	var req unlockAccountRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.authService.UnlockAccount(req.Token, c.ClientIP()); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Hesabınızın kilidi açıldı", nil)
+}
+
+// SetupTwoFactor iki adımlı doğrulama kurulumunu başlatır ve QR kod için provisioning URI döndürür
+// POST /api/auth/2fa/setup
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	setup, err := h.twoFactorService.BeginSetup(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Authenticator uygulamanızla QR kodu okutun ve üretilen kodu onaylayın", setup)
+}
+
+// ConfirmTwoFactor authenticator kodunu doğrulayarak iki adımlı doğrulamayı etkinleştirir.
+// Kurtarma kodları yalnızca bu yanıtta gösterilir.
+// POST /api/auth/2fa/confirm
+func (h *AuthHandler) ConfirmTwoFactor(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req twoFactorCodeRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	codes, err := h.twoFactorService.ConfirmSetup(userID, req.Code, c.ClientIP())
+	if err != nil {
+		twoFactorErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "İki adımlı doğrulama etkinleştirildi. Kurtarma kodlarınızı güvenli bir yerde saklayın", gin.H{
+		"recovery_codes": codes,
+	})
+}
+
+// VerifyTwoFactor girişte alınan mfa_token ve doğrulama kodu ile token çiftini döndürür
+// POST /api/auth/2fa/verify
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req twoFactorVerifyRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.VerifyMFALogin(req.MFAToken, req.Code, clientInfo(c, req.DeviceLabel))
+	if err != nil {
+		loginErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Giriş başarılı", resp)
+}
+
+// DisableTwoFactor şifre ve geçerli bir kod ile iki adımlı doğrulamayı kapatır
+// POST /api/auth/2fa/disable
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req twoFactorDisableRequest
+	if !h.bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.twoFactorService.Disable(userID, req.Password, req.Code, c.ClientIP()); err != nil {
+		twoFactorErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "İki adımlı doğrulama kapatıldı", nil)
+}
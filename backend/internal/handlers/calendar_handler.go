@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+	"event/backend/pkg/ical"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler iCalendar dışa aktarımı ve takvim aboneliği endpoint'lerini içerir
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+// NewCalendarHandler yeni bir CalendarHandler oluşturur
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// GetFeedToken kullanıcının takvim tokeninin bilgilerini döndürür (token'ın kendisi döndürülmez)
+// GET /api/calendar/token
+func (h *CalendarHandler) GetFeedToken(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.calendarService.GetFeedToken(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrCalendarTokenNotFound) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.ServerErrorResponse(c, "Takvim tokeni alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", token)
+}
+
+// RotateFeedToken yeni bir takvim tokeni oluşturur ve abonelik adreslerini döndürür.
+// Eski token ve adresleri geçersiz olur; token yalnızca bu yanıtta döner.
+// POST /api/calendar/token
+func (h *CalendarHandler) RotateFeedToken(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.calendarService.RotateFeedToken(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	feedBase := requestBaseURL(c) + "/api/calendar/feeds/" + token.Token
+	utils.SuccessResponse(c, http.StatusCreated, "Takvim tokeni oluşturuldu, token yalnızca bir kez gösterilir", gin.H{
+		"token":         token,
+		"feed_url":      feedBase + "/events.ics",
+		"room_feed_url": feedBase + "/rooms/{roomId}/events.ics",
+	})
+}
+
+// RevokeFeedToken takvim tokenini siler
+// DELETE /api/calendar/token
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarService.RevokeFeedToken(userID); err != nil {
+		if errors.Is(err, services.ErrCalendarTokenNotFound) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Takvim tokeni silindi", nil)
+}
+
+// ExportEvent etkinliği .ics dosyası olarak indirir
+// GET /api/events/:id/calendar.ics
+func (h *CalendarHandler) ExportEvent(c *gin.Context) {
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	cal, err := h.calendarService.EventCalendar(eventID, optionalUserID(c))
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+	writeCalendar(c, cal, fmt.Sprintf("event-%d.ics", eventID))
+}
+
+// GetUserFeed token sahibinin oluşturduğu ve katıldığı etkinliklerin abonelik akışını döndürür
+// GET /api/calendar/feeds/:token/events.ics
+func (h *CalendarHandler) GetUserFeed(c *gin.Context) {
+	cal, err := h.calendarService.UserFeed(c.Param("token"))
+	if err != nil {
+		feedError(c, err)
+		return
+	}
+	writeCalendar(c, cal, "")
+}
+
+// GetRoomFeed odadaki etkinliklerin abonelik akışını döndürür
+// GET /api/calendar/feeds/:token/rooms/:roomId/events.ics
+func (h *CalendarHandler) GetRoomFeed(c *gin.Context) {
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	cal, err := h.calendarService.RoomFeed(c.Param("token"), roomID)
+	if err != nil {
+		feedError(c, err)
+		return
+	}
+	writeCalendar(c, cal, "")
+}
+
+// ImportEvents .ics dosyasındaki etkinlikleri içe aktarır. Dosya istek gövdesinde ham olarak veya multipart
+// formun "file" alanında gönderilir. dry_run=true ile hiçbir şey kaydedilmeden önizleme döner; room_id
+// etkinliklerin ekleneceği odayı, time_zone saat dilimi belirtilmemiş zamanların yorumlanacağı dilimi belirler.
+// POST /api/events/import?room_id=&time_zone=&dry_run=
+func (h *CalendarHandler) ImportEvents(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	dto := services.ImportCalendarDTO{TimeZone: c.Query("time_zone")}
+	if value := c.Query("room_id"); value != "" {
+		roomID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || roomID == 0 {
+			utils.ValidationErrorResponse(c, "Geçersiz room_id parametresi")
+			return
+		}
+		dto.RoomID = &roomID
+	}
+	if value := c.Query("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.ValidationErrorResponse(c, "Geçersiz dry_run parametresi")
+			return
+		}
+		dto.DryRun = dryRun
+	}
+
+	var data io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			utils.ValidationErrorResponse(c, "file alanında .ics dosyası bekleniyor")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			utils.ServerErrorResponse(c, "Dosya okunamadı")
+			return
+		}
+		defer file.Close()
+		data = file
+	}
+
+	result, err := h.calendarService.ImportCalendar(userID, data, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dto.DryRun {
+		utils.SuccessResponse(c, http.StatusOK, "İçe aktarım önizlemesi", result)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlikler içe aktarıldı", result)
+}
+
+// feedError abonelik akışı hatasını yanıta yazar
+func feedError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidCalendarToken) {
+		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	log.Printf("[CalendarHandler] Takvim akışı oluşturulamadı: %v", err)
+	utils.NotFoundResponse(c, err.Error())
+}
+
+// writeCalendar takvimi text/calendar olarak yazar. filename verilirse dosya indirme olarak sunulur.
+func writeCalendar(c *gin.Context, cal *ical.Component, filename string) {
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	if filename != "" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	c.Status(http.StatusOK)
+	if err := cal.Encode(c.Writer); err != nil {
+		log.Printf("[CalendarHandler] Takvim yazılamadı: %v", err)
+	}
+}
+
+// requestBaseURL isteğin geldiği şema ve adresi döndürür; ters vekil arkasında X-Forwarded-Proto dikkate alınır
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardHandler ana sayfa verilerini tek bir endpoint'te toplar
+type DashboardHandler struct {
+	eventService      *services.EventService
+	friendshipService *services.FriendshipService
+	userService       *services.UserService
+}
+
+// DashboardHandlerInput, DashboardHandler için bağımlılıkları içerir.
+type DashboardHandlerInput struct {
+	EventService      *services.EventService
+	FriendshipService *services.FriendshipService
+	UserService       *services.UserService
+}
+
+// NewDashboardHandler yeni bir DashboardHandler oluşturur
+func NewDashboardHandler(input DashboardHandlerInput) *DashboardHandler {
+	return &DashboardHandler{
+		eventService:      input.EventService,
+		friendshipService: input.FriendshipService,
+		userService:       input.UserService,
+	}
+}
+
+// GetDashboard kullanıcının akışını, arkadaş önerilerini ve AI önerilerini döndürür.
+// Bölümlerden biri alınamazsa boş döner; sayfanın geri kalanı yine de gösterilir.
+// GET /api/dashboard
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.eventService.GetEventsForFeed(userID)
+	if err != nil {
+		log.Printf("[Dashboard] Etkinlik akışı alınamadı (UserID: %d): %v", userID, err)
+	}
+
+	friendSuggestions, err := h.friendshipService.GetFriendSuggestions(userID)
+	if err != nil {
+		log.Printf("[Dashboard] Arkadaş önerileri alınamadı (UserID: %d): %v", userID, err)
+	}
+
+	aiSuggestions, err := h.userService.GetUserAISuggestions(userID)
+	if err != nil {
+		log.Printf("[Dashboard] AI önerileri alınamadı (UserID: %d): %v", userID, err)
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{
+		"userFeed":          gin.H{"events": events},
+		"suggestions":       gin.H{"items": friendSuggestions},
+		"aiRecommendations": gin.H{"items": aiSuggestions},
+	})
+}
@@ -0,0 +1,686 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/recurrence"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventHandler etkinlik endpoint'lerini yönetir
+type EventHandler struct {
+	eventService *services.EventService
+}
+
+// NewEventHandler yeni bir EventHandler oluşturur
+func NewEventHandler(eventService *services.EventService) *EventHandler {
+	return &EventHandler{eventService: eventService}
+}
+
+// eventDetailResponse etkinlik detayını katılımcı sayılarıyla birlikte döndürür
+type eventDetailResponse struct {
+	models.Event
+	AttendeesCount   int64                     `json:"attendees_count"` // Katılacaklar ve getirdikleri misafirler
+	AttendanceCounts services.AttendanceCounts `json:"attendance_counts"`
+}
+
+// inviteToEventRequest etkinliğe davet isteğinin gövdesi
+type inviteToEventRequest struct {
+	InviteeID uint64 `json:"invitee_id" binding:"required"`
+}
+
+// reorderWaitlistRequest bekleme listesini yeniden sıralama isteğinin gövdesi
+type reorderWaitlistRequest struct {
+	UserIDs []uint64 `json:"user_ids" binding:"required"`
+}
+
+// finalizeEventRequest etkinliği sonlandırma isteğinin gövdesi
+type finalizeEventRequest struct {
+	OptionID *uint64 `json:"option_id"`
+}
+
+// GetEvents herkese açık etkinlikleri sayfalı olarak listeler
+// GET /api/events?page=1&limit=10
+func (h *EventHandler) GetEvents(c *gin.Context) {
+	page := queryInt(c, "page", 1)
+	limit := queryInt(c, "limit", 10)
+
+	events, total, err := h.eventService.GetAllPublicEvents(page, limit)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Etkinlikler alınamadı")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{
+		"events": events,
+		"total":  total,
+		"page":   page,
+		"limit":  limit,
+	})
+}
+
+// GetFeed ana sayfa akışı için etkinlikleri getirir. Anonim kullanıcılar da çağırabilir.
+// GET /api/events/feed
+func (h *EventHandler) GetFeed(c *gin.Context) {
+	events, err := h.eventService.GetEventsForFeed(optionalUserID(c))
+	if err != nil {
+		utils.ServerErrorResponse(c, "Etkinlik akışı alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", events)
+}
+
+// CreateEvent yeni bir etkinlik oluşturur
+// POST /api/events
+func (h *EventHandler) CreateEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var dto services.CreateEventDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	event, err := h.eventService.CreateEvent(userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Etkinlik oluşturuldu", event)
+}
+
+// GetEvent bir etkinliğin detaylarını getirir
+// GET /api/events/:id
+func (h *EventHandler) GetEvent(c *gin.Context) {
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	event, counts, err := h.eventService.GetEventByID(eventID, optionalUserID(c))
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", eventDetailResponse{
+		Event:            *event,
+		AttendeesCount:   counts.Seats(),
+		AttendanceCounts: counts,
+	})
+}
+
+// UpdateEvent bir etkinliği günceller
+// PUT /api/events/:id
+func (h *EventHandler) UpdateEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateEventDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	event, err := h.eventService.UpdateEvent(eventID, userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlik güncellendi", event)
+}
+
+// DeleteEvent bir etkinliği siler
+// DELETE /api/events/:id
+func (h *EventHandler) DeleteEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeleteEvent(eventID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlik silindi", nil)
+}
+
+// AttendEvent etkinliğe yanıt verir veya özel etkinlik için katılım isteği oluşturur.
+// Gövde isteğe bağlıdır: {"status": "going|maybe|not_going", "guests": 0, "note": "..."}; boşsa going.
+// POST /api/events/:id/attend
+func (h *EventHandler) AttendEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req services.RSVPDTO
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	attendance, err := h.eventService.RSVP(eventID, userID, req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	switch {
+	case attendance == nil:
+		utils.SuccessResponse(c, http.StatusOK, "Katılım isteğiniz alındı", nil)
+	case attendance.Status == models.AttendanceWaitlisted:
+		utils.SuccessResponse(c, http.StatusOK, "Etkinlik dolu, bekleme listesine alındınız", attendance)
+	case attendance.Status == models.AttendanceGoing:
+		utils.SuccessResponse(c, http.StatusOK, "Etkinliğe katıldınız", attendance)
+	default:
+		utils.SuccessResponse(c, http.StatusOK, "Yanıtınız kaydedildi", attendance)
+	}
+}
+
+// CancelAttendance etkinliğe katılımı iptal eder
+// DELETE /api/events/:id/attend
+func (h *EventHandler) CancelAttendance(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.CancelAttendance(eventID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Katılımınız iptal edildi", nil)
+}
+
+// GetWaitlist etkinliğin bekleme listesini sırasıyla döndürür
+// GET /api/events/:id/waitlist
+func (h *EventHandler) GetWaitlist(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	waitlist, err := h.eventService.GetWaitlist(eventID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", waitlist)
+}
+
+// ReorderWaitlist bekleme listesini verilen kullanıcı sırasına göre düzenler
+// PUT /api/events/:id/waitlist
+func (h *EventHandler) ReorderWaitlist(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req reorderWaitlistRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	waitlist, err := h.eventService.ReorderWaitlist(eventID, userID, req.UserIDs)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Bekleme listesi güncellendi", waitlist)
+}
+
+// PromoteFromWaitlist bekleme listesindeki kullanıcıyı sırasını beklemeden katılımcı yapar
+// POST /api/events/:id/waitlist/:userId/promote
+func (h *EventHandler) PromoteFromWaitlist(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	attendeeID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.PromoteFromWaitlist(eventID, userID, attendeeID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Kullanıcı katılımcılar arasına alındı", nil)
+}
+
+// GetAttendees etkinliğin katılımcılarını ve davetlilerini listeler
+// GET /api/events/:id/attendees
+func (h *EventHandler) GetAttendees(c *gin.Context) {
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// occurrence verilirse tekrarlanan etkinliğin o tekrarındaki katılımcılar döner
+	if value := c.Query("occurrence"); value != "" {
+		occurrence, err := recurrence.ParseKey(value)
+		if err != nil {
+			utils.ValidationErrorResponse(c, err.Error())
+			return
+		}
+		attendees, err := h.eventService.GetOccurrenceAttendees(eventID, occurrence, optionalUserID(c))
+		if err != nil {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.SuccessResponse(c, http.StatusOK, "", attendees)
+		return
+	}
+
+	attendees, err := h.eventService.GetEventAttendees(eventID, optionalUserID(c))
+	if err != nil {
+		utils.NotFoundResponse(c, "Etkinlik bulunamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", attendees)
+}
+
+// GetTimeOptions etkinliğin zaman seçeneklerini oy durumlarıyla listeler
+// GET /api/events/:id/time-options
+func (h *EventHandler) GetTimeOptions(c *gin.Context) {
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	options, err := h.eventService.GetEventTimeOptions(eventID, optionalUserID(c))
+	if err != nil {
+		utils.ServerErrorResponse(c, "Zaman seçenekleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", options)
+}
+
+// VoteForTimeOption bir zaman seçeneğine oy verir
+// POST /api/events/:id/time-options/:optionId/vote
+func (h *EventHandler) VoteForTimeOption(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	optionID, ok := parseIDParam(c, "optionId")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.VoteForTimeOption(eventID, optionID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oyunuz kaydedildi", nil)
+}
+
+// FinalizeEvent etkinliğin nihai zamanını belirler
+// POST /api/events/:id/finalize
+func (h *EventHandler) FinalizeEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req finalizeEventRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.eventService.FinalizeEvent(eventID, userID, req.OptionID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlik zamanı belirlendi", nil)
+}
+
+// InviteUser bir kullanıcıyı etkinliğe davet eder
+// POST /api/events/:id/invite
+func (h *EventHandler) InviteUser(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req inviteToEventRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	invitation, err := h.eventService.InviteUserToEvent(eventID, userID, req.InviteeID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Davet gönderildi", invitation)
+}
+
+// GetUserEvents bir kullanıcının oluşturduğu etkinlikleri listeler
+// GET /api/users/:id/events
+func (h *EventHandler) GetUserEvents(c *gin.Context) {
+	viewerID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	profileID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	events, err := h.eventService.GetEventsCreatedByUser(profileID, viewerID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Kullanıcının etkinlikleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", events)
+}
+
+// GetMyEvents giriş yapan kullanıcının görebileceği etkinlikleri listeler
+// GET /api/events/me
+func (h *EventHandler) GetMyEvents(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	// from ve to verilirse aralıkta başlayan etkinlikler, tekrarlananlar tekrarlarına açılarak döner
+	from, to, ranged, ok := queryTimeRange(c)
+	if !ok {
+		return
+	}
+	if ranged {
+		events, err := h.eventService.GetUserEventsInRange(userID, from, to)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.SuccessResponse(c, http.StatusOK, "", events)
+		return
+	}
+
+	events, err := h.eventService.GetUserEvents(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Etkinlikler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", events)
+}
+
+// ApproveRequest özel etkinliğe katılım isteğini onaylar
+// POST /api/requests/:id/approve
+func (h *EventHandler) ApproveRequest(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.ApproveParticipationRequest(requestID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Katılım isteği onaylandı", nil)
+}
+
+// DeclineRequest özel etkinliğe katılım isteğini reddeder
+// POST /api/requests/:id/decline
+func (h *EventHandler) DeclineRequest(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeclineParticipationRequest(requestID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Katılım isteği reddedildi", nil)
+}
+
+// GetInvitations kullanıcının bekleyen etkinlik davetlerini listeler
+// GET /api/event-invitations
+func (h *EventHandler) GetInvitations(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.eventService.GetUserEventInvitations(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Davetler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", invitations)
+}
+
+// AcceptInvitation etkinlik davetini kabul eder
+// POST /api/event-invitations/:id/accept
+func (h *EventHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.AcceptEventInvitation(invitationID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Davet kabul edildi", nil)
+}
+
+// DeclineInvitation etkinlik davetini reddeder
+// POST /api/event-invitations/:id/decline
+func (h *EventHandler) DeclineInvitation(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeclineEventInvitation(invitationID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Davet reddedildi", nil)
+}
+
+// parseOccurrenceParam, URL'deki tekrar zamanını (RFC 3339) okur. Geçersizse 400 yanıtı yazar ve false döndürür.
+func parseOccurrenceParam(c *gin.Context) (time.Time, bool) {
+	occurrence, err := recurrence.ParseKey(c.Param("occurrence"))
+	if err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return time.Time{}, false
+	}
+	return occurrence, true
+}
+
+// GetOccurrences etkinliğin from-to aralığındaki tekrarlarını getirir
+func (h *EventHandler) GetOccurrences(c *gin.Context) {
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	from, to, ranged, ok := queryTimeRange(c)
+	if !ok {
+		return
+	}
+	if !ranged {
+		utils.ValidationErrorResponse(c, "from ve to parametreleri zorunludur")
+		return
+	}
+
+	occurrences, err := h.eventService.GetEventOccurrences(eventID, optionalUserID(c), from, to)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", occurrences)
+}
+
+// UpdateOccurrence tekrarlanan etkinliğin bir tekrarını (scope=this) veya o tekrarı ve sonrakileri (scope=following) günceller
+func (h *EventHandler) UpdateOccurrence(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	occurrence, ok := parseOccurrenceParam(c)
+	if !ok {
+		return
+	}
+	scope, err := services.ParseOccurrenceScope(c.Query("scope"))
+	if err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	var dto services.UpdateOccurrenceDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	event, err := h.eventService.UpdateOccurrence(eventID, userID, occurrence, scope, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlik güncellendi", event)
+}
+
+// DeleteOccurrence tekrarlanan etkinliğin bir tekrarını (scope=this) veya o tekrarı ve sonrakileri (scope=following) siler
+func (h *EventHandler) DeleteOccurrence(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	occurrence, ok := parseOccurrenceParam(c)
+	if !ok {
+		return
+	}
+	scope, err := services.ParseOccurrenceScope(c.Query("scope"))
+	if err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if err := h.eventService.DeleteOccurrence(eventID, userID, occurrence, scope); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Etkinlik silindi", nil)
+}
+
+// AttendOccurrence kullanıcının tekrarlanan etkinliğin yalnızca bir tekrarına yanıtını kaydeder; gövde AttendEvent ile aynıdır
+func (h *EventHandler) AttendOccurrence(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	occurrence, ok := parseOccurrenceParam(c)
+	if !ok {
+		return
+	}
+
+	var req services.RSVPDTO
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	if err := h.eventService.RSVPOccurrence(eventID, userID, occurrence, req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Yanıtınız kaydedildi", nil)
+}
+
+// CancelOccurrenceAttendance kullanıcının tekrarlanan etkinliğin yalnızca bir tekrarına katılmayacağını kaydeder
+func (h *EventHandler) CancelOccurrenceAttendance(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	eventID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	occurrence, ok := parseOccurrenceParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.CancelOccurrenceAttendance(eventID, userID, occurrence); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Katılımınız iptal edildi", nil)
+}
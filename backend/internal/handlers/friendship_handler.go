@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FriendshipHandler arkadaşlık endpoint'lerini yönetir
+type FriendshipHandler struct {
+	friendshipService *services.FriendshipService
+}
+
+// NewFriendshipHandler yeni bir FriendshipHandler oluşturur
+func NewFriendshipHandler(friendshipService *services.FriendshipService) *FriendshipHandler {
+	return &FriendshipHandler{friendshipService: friendshipService}
+}
+
+// friendshipRequestBody arkadaşlık isteği gönderme gövdesi
+type friendshipRequestBody struct {
+	AddresseeID uint64 `json:"addressee_id" binding:"required"`
+}
+
+// GetFriends kullanıcının arkadaşlarını listeler
+// GET /api/friendships
+func (h *FriendshipHandler) GetFriends(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	friends, err := h.friendshipService.GetFriends(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Arkadaşlar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", friends)
+}
+
+// GetUserFriends başka bir kullanıcının arkadaşlarını listeler
+// GET /api/users/:id/friends
+func (h *FriendshipHandler) GetUserFriends(c *gin.Context) {
+	if _, ok := requireUserID(c); !ok {
+		return
+	}
+	profileID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	friends, err := h.friendshipService.GetFriends(profileID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Arkadaşlar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", friends)
+}
+
+// GetPendingRequests kullanıcıya gelen bekleyen arkadaşlık isteklerini listeler
+// GET /api/friendships/requests/pending
+func (h *FriendshipHandler) GetPendingRequests(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	requests, err := h.friendshipService.GetPendingRequests(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Arkadaşlık istekleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", requests)
+}
+
+// GetSuggestions kullanıcı için arkadaş önerilerini döndürür
+// GET /api/friendships/suggestions
+func (h *FriendshipHandler) GetSuggestions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.friendshipService.GetFriendSuggestions(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Arkadaş önerileri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", suggestions)
+}
+
+// GetStatus giriş yapan kullanıcı ile başka bir kullanıcı arasındaki arkadaşlık durumunu döndürür
+// GET /api/friendships/status/:id
+func (h *FriendshipHandler) GetStatus(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	otherUserID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	status, err := h.friendshipService.GetFriendshipStatus(userID, otherUserID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Arkadaşlık durumu alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", status)
+}
+
+// SendRequest gövdedeki kullanıcıya arkadaşlık isteği gönderir
+// POST /api/friendships/request
+func (h *FriendshipHandler) SendRequest(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req friendshipRequestBody
+	if !bindJSON(c, &req) {
+		return
+	}
+	h.sendRequest(c, userID, req.AddresseeID)
+}
+
+// SendRequestTo URL'deki kullanıcıya arkadaşlık isteği gönderir
+// POST /api/friendships/request/:id
+func (h *FriendshipHandler) SendRequestTo(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	addresseeID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	h.sendRequest(c, userID, addresseeID)
+}
+
+func (h *FriendshipHandler) sendRequest(c *gin.Context, requesterID, addresseeID uint64) {
+	if err := h.friendshipService.CreateFriendshipRequest(requesterID, addresseeID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Arkadaşlık isteği gönderildi", nil)
+}
+
+// AcceptRequest arkadaşlık isteğini kabul eder
+// POST /api/friendships/requests/:id/accept
+func (h *FriendshipHandler) AcceptRequest(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	friendshipID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.friendshipService.AcceptFriendshipRequest(friendshipID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Arkadaşlık isteği kabul edildi", nil)
+}
+
+// DeclineRequest arkadaşlık isteğini reddeder
+// POST /api/friendships/requests/:id/decline
+func (h *FriendshipHandler) DeclineRequest(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	friendshipID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.friendshipService.DeclineFriendshipRequest(friendshipID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Arkadaşlık isteği reddedildi", nil)
+}
+
+// DeleteFriendship arkadaşlığı sonlandırır
+// DELETE /api/friendships/:id
+func (h *FriendshipHandler) DeleteFriendship(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	friendshipID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.friendshipService.DeleteFriendship(friendshipID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Arkadaşlık sonlandırıldı", nil)
+}
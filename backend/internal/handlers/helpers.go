@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requireUserID, isteği yapan kullanıcının ID'sini context'ten alır.
+// Kullanıcı bulunamazsa 401 yanıtı yazar ve false döndürür.
+func requireUserID(c *gin.Context) (uint64, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Bu işlem için giriş yapmalısınız")
+		return 0, false
+	}
+	return userID, true
+}
+
+// optionalUserID, giriş yapmış kullanıcının ID'sini döndürür; anonim isteklerde 0 döner.
+func optionalUserID(c *gin.Context) uint64 {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return 0
+	}
+	return userID
+}
+
+// parseIDParam, URL'deki sayısal bir parametreyi uint64'e çevirir.
+// Parametre geçersizse 400 yanıtı yazar ve false döndürür.
+func parseIDParam(c *gin.Context, name string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		utils.ValidationErrorResponse(c, "Geçersiz "+name+" parametresi")
+		return 0, false
+	}
+	return id, true
+}
+
+// queryInt, sorgu parametresini pozitif bir tamsayı olarak okur; geçersizse varsayılanı döndürür.
+func queryInt(c *gin.Context, name string, defaultValue int) int {
+	value, err := strconv.Atoi(c.Query(name))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// queryTimeRange, from ve to sorgu parametrelerini RFC 3339 zaman aralığı olarak okur.
+// İkisi de yoksa present false döner. Biri eksik veya geçersizse 400 yanıtı yazar ve ok false döner.
+func queryTimeRange(c *gin.Context) (from, to time.Time, present, ok bool) {
+	fromStr, toStr := c.Query("from"), c.Query("to")
+	if fromStr == "" && toStr == "" {
+		return from, to, false, true
+	}
+	if fromStr == "" || toStr == "" {
+		utils.ValidationErrorResponse(c, "from ve to parametreleri birlikte verilmelidir")
+		return from, to, true, false
+	}
+	from, errFrom := time.Parse(time.RFC3339, fromStr)
+	to, errTo := time.Parse(time.RFC3339, toStr)
+	if errFrom != nil || errTo != nil {
+		utils.ValidationErrorResponse(c, "from ve to RFC 3339 biçiminde olmalıdır")
+		return from, to, true, false
+	}
+	return from, to, true, true
+}
+
+// bindJSON, istek gövdesini verilen yapıya bağlar. Hata olursa 400 yanıtı yazar.
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		utils.ValidationErrorResponse(c, "Geçersiz istek verisi: "+err.Error())
+		return false
+	}
+	return true
+}
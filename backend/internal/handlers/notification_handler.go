@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler bildirim endpoint'lerini yönetir
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler yeni bir NotificationHandler oluşturur
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// markNotificationsReadRequest bildirimleri okundu işaretleme gövdesi
+type markNotificationsReadRequest struct {
+	NotificationIDs []uint64 `json:"notification_ids" binding:"required,min=1"`
+}
+
+// GetNotifications kullanıcının bildirimlerini listeler
+// GET /api/notifications
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	notifications, err := h.notificationService.GetNotificationsForUser(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Bildirimler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", notifications)
+}
+
+// MarkAsRead verilen bildirimleri okundu olarak işaretler
+// POST /api/notifications/read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req markNotificationsReadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.notificationService.MarkNotificationsAsRead(userID, req.NotificationIDs); err != nil {
+		utils.ServerErrorResponse(c, "Bildirimler güncellenemedi")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Bildirimler okundu olarak işaretlendi", nil)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"event/backend/internal/config"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+	"event/backend/pkg/oauth"
+	"event/backend/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oauthStateCookie akışı başlatan tarayıcıya yazılan state çerezidir. Dönüşte gövdedeki state bu çerezle
+// eşleşmelidir; böylece başka bir tarayıcıda başlatılmış akış kurbana tamamlatılamaz (login CSRF).
+const (
+	oauthStateCookie     = "oauth_state"
+	oauthStateCookiePath = "/api/auth/oauth"
+)
+
+// OAuthHandler sosyal giriş (Google, GitHub, OIDC) endpoint'lerini yönetir
+type OAuthHandler struct {
+	oauthService *services.OAuthService
+	config       *config.Config
+	validator    *validator.CustomValidator
+}
+
+// OAuthHandlerInput, OAuthHandler için bağımlılıkları içerir.
+type OAuthHandlerInput struct {
+	OAuthService *services.OAuthService
+	Config       *config.Config
+}
+
+// NewOAuthHandler yeni bir OAuthHandler oluşturur
+func NewOAuthHandler(input OAuthHandlerInput) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: input.OAuthService,
+		config:       input.Config,
+		validator:    validator.NewValidator(),
+	}
+}
+
+// oauthCallbackRequest sağlayıcıdan frontend'e dönen kod ve state
+type oauthCallbackRequest struct {
+	Code        string `json:"code" validate:"required"`
+	State       string `json:"state" validate:"required"`
+	DeviceLabel string `json:"device_label" validate:"max=100"`
+}
+
+// GetProviders yapılandırılmış kimlik sağlayıcılarını listeler
+// GET /api/auth/oauth/providers
+func (h *OAuthHandler) GetProviders(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "", h.oauthService.ProviderNames())
+}
+
+// Start sosyal giriş akışını başlatır ve yönlendirilecek adresi döndürür
+// POST /api/auth/oauth/:provider/start
+func (h *OAuthHandler) Start(c *gin.Context) {
+	h.start(c, nil)
+}
+
+// Link giriş yapmış kullanıcının hesabına sağlayıcı bağlamak için akışı başlatır
+// POST /api/auth/oauth/:provider/link
+func (h *OAuthHandler) Link(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	h.start(c, &userID)
+}
+
+func (h *OAuthHandler) start(c *gin.Context, linkUserID *uint64) {
+	authURL, state, err := h.oauthService.StartAuthorization(c.Request.Context(), c.Param("provider"), linkUserID)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	h.setStateCookie(c, state, int(h.config.OAuthStateTTL.Seconds()))
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{"authorization_url": authURL})
+}
+
+// Callback sağlayıcıdan dönen kod ile girişi veya hesap bağlamayı tamamlar.
+// State, akışın başında yazılan çerezle eşleşmelidir; bağlama akışı ayrıca akışı başlatan kullanıcının oturumunu ister.
+// POST /api/auth/oauth/:provider/callback
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	var req oauthCallbackRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		utils.ValidationErrorResponse(c, strings.Join(h.validator.FormatValidationErrors(err), "; "))
+		return
+	}
+
+	cookieState, _ := c.Cookie(oauthStateCookie)
+	// State tek kullanımlık olduğu için çerez sonuçtan bağımsız olarak silinir
+	h.setStateCookie(c, "", -1)
+	if cookieState == "" || subtle.ConstantTimeCompare([]byte(cookieState), []byte(req.State)) != 1 {
+		h.errorResponse(c, services.ErrInvalidOAuthState)
+		return
+	}
+
+	resp, err := h.oauthService.HandleCallback(c.Request.Context(), c.Param("provider"), req.Code, req.State, optionalUserID(c), clientInfo(c, req.DeviceLabel))
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	switch {
+	case resp.Linked:
+		utils.SuccessResponse(c, http.StatusOK, "Hesap bağlandı", resp)
+	case resp.MFARequired:
+		utils.SuccessResponse(c, http.StatusOK, "İki adımlı doğrulama kodu gerekli", resp)
+	default:
+		utils.SuccessResponse(c, http.StatusOK, "Giriş başarılı", resp)
+	}
+}
+
+// GetIdentities giriş yapan kullanıcının bağlı sağlayıcı hesaplarını listeler
+// GET /api/auth/identities
+func (h *OAuthHandler) GetIdentities(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	identities, err := h.oauthService.GetIdentities(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Bağlı hesaplar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", identities)
+}
+
+// setStateCookie state çerezini yazar; maxAge negatifse çerezi siler
+func (h *OAuthHandler) setStateCookie(c *gin.Context, state string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, maxAge, oauthStateCookiePath, "", h.config.IsProduction() || c.Request.TLS != nil, true)
+}
+
+func (h *OAuthHandler) errorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, oauth.ErrProviderNotFound):
+		utils.NotFoundResponse(c, err.Error())
+	case errors.Is(err, services.ErrOAuthLinkForbidden):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrIdentityLinkedToOther):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokenHandler kişisel erişim token'larının yönetimi için endpoint'leri içerir
+type PersonalAccessTokenHandler struct {
+	tokenService *services.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenHandler yeni bir PersonalAccessTokenHandler oluşturur
+func NewPersonalAccessTokenHandler(tokenService *services.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{tokenService: tokenService}
+}
+
+// createPersonalAccessTokenRequest token oluşturma isteğinin gövdesi.
+// ExpiresAt verilmezse PAT_DEFAULT_TTL kadar geçerli olur.
+type createPersonalAccessTokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// GetScopes token'lara verilebilecek kapsamları listeler
+// GET /api/auth/tokens/scopes
+func (h *PersonalAccessTokenHandler) GetScopes(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "", auth.AllScopes())
+}
+
+// GetTokens kullanıcının erişim token'larını listeler
+// GET /api/auth/tokens
+func (h *PersonalAccessTokenHandler) GetTokens(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokenService.List(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Erişim tokenleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", tokens)
+}
+
+// CreateToken yeni bir erişim tokeni oluşturur. Token yalnızca bu yanıtta döner.
+// POST /api/auth/tokens
+func (h *PersonalAccessTokenHandler) CreateToken(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req createPersonalAccessTokenRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	token, err := h.tokenService.Create(userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Erişim tokeni oluşturuldu, token yalnızca bir kez gösterilir", token)
+}
+
+// RevokeToken erişim tokenini iptal eder
+// DELETE /api/auth/tokens/:id
+func (h *PersonalAccessTokenHandler) RevokeToken(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	tokenID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.tokenService.Revoke(userID, tokenID); err != nil {
+		if errors.Is(err, services.ErrPersonalAccessTokenNotFound) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Erişim tokeni iptal edildi", nil)
+}
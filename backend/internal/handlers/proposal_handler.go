@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProposalHandler etkinlik önerisi endpoint'lerini yönetir
+type ProposalHandler struct {
+	proposalService *services.ProposalService
+}
+
+// NewProposalHandler yeni bir ProposalHandler oluşturur
+func NewProposalHandler(proposalService *services.ProposalService) *ProposalHandler {
+	return &ProposalHandler{proposalService: proposalService}
+}
+
+// respondToProposalRequest öneriye yanıt gövdesi
+type respondToProposalRequest struct {
+	Response string `json:"response" binding:"required,oneof=accepted declined"`
+}
+
+// counterProposalRequest karşı öneri gövdesi
+type counterProposalRequest struct {
+	NewEventDetails json.RawMessage `json:"new_event_details" binding:"required"`
+}
+
+// CreateProposal yeni bir öneri oluşturur
+// POST /api/proposals
+func (h *ProposalHandler) CreateProposal(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var dto services.CreateProposalDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	proposal, err := h.proposalService.CreateProposal(userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Öneri gönderildi", proposal)
+}
+
+// GetIncoming kullanıcıya gelen önerileri listeler
+// GET /api/proposals/incoming
+func (h *ProposalHandler) GetIncoming(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	proposals, err := h.proposalService.GetIncomingProposals(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Öneriler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", proposals)
+}
+
+// GetOutgoing kullanıcının gönderdiği önerileri listeler
+// GET /api/proposals/outgoing
+func (h *ProposalHandler) GetOutgoing(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	proposals, err := h.proposalService.GetOutgoingProposals(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Öneriler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", proposals)
+}
+
+// Respond öneriye gövdedeki yanıtla cevap verir
+// POST /api/proposals/:id/respond
+func (h *ProposalHandler) Respond(c *gin.Context) {
+	var req respondToProposalRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	h.respond(c, req.Response)
+}
+
+// Accept öneriyi kabul eder
+// POST /api/suggestions/:id/accept
+func (h *ProposalHandler) Accept(c *gin.Context) {
+	h.respond(c, string(models.ProposalAccepted))
+}
+
+// Reject öneriyi reddeder
+// POST /api/suggestions/:id/reject
+func (h *ProposalHandler) Reject(c *gin.Context) {
+	h.respond(c, string(models.ProposalDeclined))
+}
+
+func (h *ProposalHandler) respond(c *gin.Context, response string) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	proposalID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.proposalService.RespondToProposal(proposalID, userID, response); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Öneri yanıtlandı", nil)
+}
+
+// CounterPropose öneriye karşı öneri oluşturur
+// POST /api/proposals/:id/counter
+func (h *ProposalHandler) CounterPropose(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	proposalID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req counterProposalRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.proposalService.CreateCounterProposal(proposalID, userID, req.NewEventDetails); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Karşı öneri gönderildi", nil)
+}
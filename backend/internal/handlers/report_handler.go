@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandler kullanıcıların içerik şikayeti endpoint'lerini içerir
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+// NewReportHandler yeni bir ReportHandler oluşturur
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// createReportRequest şikayet oluşturma isteğinin gövdesi
+type createReportRequest struct {
+	TargetType models.ReportTargetType `json:"target_type" binding:"required"`
+	TargetID   uint64                  `json:"target_id" binding:"required"`
+	Reason     string                  `json:"reason" binding:"required"`
+}
+
+// CreateReport bir kullanıcıyı, etkinliği, odayı veya mesajı yöneticilere şikayet eder
+// POST /api/reports
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	var req createReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.reportService.CreateReport(userID, req.TargetType, req.TargetID, req.Reason)
+	if err != nil {
+		if errors.Is(err, services.ErrReportTargetNotFound) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Şikayetiniz alındı", report)
+}
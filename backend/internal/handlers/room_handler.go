@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event/backend/internal/dtos"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoomHandler oda, sohbet ve oda daveti endpoint'lerini yönetir
+type RoomHandler struct {
+	roomService *services.RoomService
+	chatService *services.ChatService
+}
+
+// RoomHandlerInput, RoomHandler için bağımlılıkları içerir.
+type RoomHandlerInput struct {
+	RoomService *services.RoomService
+	ChatService *services.ChatService
+}
+
+// NewRoomHandler yeni bir RoomHandler oluşturur
+func NewRoomHandler(input RoomHandlerInput) *RoomHandler {
+	return &RoomHandler{
+		roomService: input.RoomService,
+		chatService: input.ChatService,
+	}
+}
+
+// addRoomMemberRequest odaya üye ekleme isteğinin gövdesi
+type addRoomMemberRequest struct {
+	UserID uint64 `json:"user_id" binding:"required"`
+}
+
+// inviteToRoomRequest odaya davet isteğinin gövdesi
+type inviteToRoomRequest struct {
+	InviteeID uint64 `json:"invitee_id" binding:"required"`
+	Message   string `json:"message"`
+}
+
+// GetRooms odaları filtreye göre listeler
+// GET /api/rooms?filter=all|public|private|mine|member
+func (h *RoomHandler) GetRooms(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	rooms, err := h.roomService.GetRoomsByFilter(c.DefaultQuery("filter", "all"), userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Odalar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", rooms)
+}
+
+// GetPublicRooms herkese açık odaları listeler
+// GET /api/rooms/public
+func (h *RoomHandler) GetPublicRooms(c *gin.Context) {
+	rooms, err := h.roomService.GetPublicRooms()
+	if err != nil {
+		utils.ServerErrorResponse(c, "Odalar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", rooms)
+}
+
+// CreateRoom yeni bir oda oluşturur
+// POST /api/rooms
+func (h *RoomHandler) CreateRoom(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var dto services.CreateRoomDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	room, err := h.roomService.CreateRoom(userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Oda oluşturuldu", room)
+}
+
+// GetConversations kullanıcının sohbet listesini getirir
+// GET /api/rooms/me
+func (h *RoomHandler) GetConversations(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	conversations, err := h.roomService.GetUserConversations(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Sohbetler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", conversations)
+}
+
+// GetUserRooms bir kullanıcının üye olduğu odaları listeler
+// GET /api/users/:id/rooms
+func (h *RoomHandler) GetUserRooms(c *gin.Context) {
+	if _, ok := requireUserID(c); !ok {
+		return
+	}
+	profileID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	rooms, err := h.roomService.GetUserRooms(profileID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Odalar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", rooms)
+}
+
+// GetOrCreateDMRoom iki kullanıcı arasındaki DM odasını getirir veya oluşturur
+// POST /api/rooms/dm
+func (h *RoomHandler) GetOrCreateDMRoom(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dtos.GetOrCreateDMRoomRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	room, err := h.roomService.GetOrCreateDMRoom(userID, req.OtherUserID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", room)
+}
+
+// CreateGroupChat yeni bir grup sohbeti oluşturur
+// POST /api/rooms/group-chat
+func (h *RoomHandler) CreateGroupChat(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var dto services.CreateGroupChatDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	room, err := h.roomService.CreateGroupChatRoom(userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Grup sohbeti oluşturuldu", room)
+}
+
+// GetUnreadCount kullanıcının tüm odalardaki okunmamış mesaj sayısını döndürür
+// GET /api/rooms/unread-count
+func (h *RoomHandler) GetUnreadCount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.roomService.GetUnreadMessagesCount(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Okunmamış mesaj sayısı alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", gin.H{"unread_count": count})
+}
+
+// GetRoom bir odanın detaylarını getirir
+// GET /api/rooms/:roomId
+func (h *RoomHandler) GetRoom(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	room, err := h.roomService.GetRoomByID(roomID, userID)
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", room)
+}
+
+// UpdateRoom oda bilgilerini günceller
+// PUT /api/rooms/:roomId
+func (h *RoomHandler) UpdateRoom(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var dto services.UpdateRoomDTO
+	if !bindJSON(c, &dto) {
+		return
+	}
+
+	room, err := h.roomService.UpdateRoom(roomID, userID, dto)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oda güncellendi", room)
+}
+
+// DeleteRoom odayı siler
+// DELETE /api/rooms/:roomId
+func (h *RoomHandler) DeleteRoom(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.DeleteRoom(roomID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oda silindi", nil)
+}
+
+// GetMembers odanın aktif üyelerini listeler
+// GET /api/rooms/:roomId/members
+func (h *RoomHandler) GetMembers(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	// Özel odaların üye listesi sadece erişimi olanlara gösterilir
+	if _, err := h.roomService.GetRoomByID(roomID, userID); err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+
+	members, err := h.roomService.GetRoomMembers(roomID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Oda üyeleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", members)
+}
+
+// AddMember odaya üye ekler veya kullanıcının kendisi odaya katılır
+// POST /api/rooms/:roomId/members
+func (h *RoomHandler) AddMember(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req addRoomMemberRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.roomService.AddRoomMember(roomID, req.UserID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Üye odaya eklendi", nil)
+}
+
+// RemoveMember odadan üye çıkarır
+// DELETE /api/rooms/:roomId/members/:userId
+func (h *RoomHandler) RemoveMember(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+	memberID, ok := parseIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.RemoveRoomMember(roomID, memberID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Üye odadan çıkarıldı", nil)
+}
+
+// GetMessages odanın mesaj geçmişini getirir
+// GET /api/rooms/:roomId/messages
+func (h *RoomHandler) GetMessages(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	isMember, err := h.roomService.IsUserMemberOfRoom(userID, roomID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Oda üyeliği kontrol edilemedi")
+		return
+	}
+	if !isMember {
+		utils.ErrorResponse(c, http.StatusForbidden, "Bu odanın mesajlarını görme yetkiniz yok")
+		return
+	}
+
+	messages, err := h.chatService.GetMessagesByRoomID(roomID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Mesajlar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", messages)
+}
+
+// MarkAsRead odadaki mesajları okundu olarak işaretler
+// POST /api/rooms/:roomId/read
+func (h *RoomHandler) MarkAsRead(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.MarkRoomAsRead(userID, roomID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oda okundu olarak işaretlendi", nil)
+}
+
+// InviteUser bir kullanıcıyı odaya davet eder
+// POST /api/rooms/:roomId/invite
+func (h *RoomHandler) InviteUser(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	roomID, ok := parseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	var req inviteToRoomRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	invitation, err := h.roomService.InviteUserToRoom(roomID, userID, req.InviteeID, req.Message)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Davet gönderildi", invitation)
+}
+
+// GetInvitations kullanıcıya gelen bekleyen oda davetlerini listeler
+// GET /api/rooms/invitations
+func (h *RoomHandler) GetInvitations(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.roomService.GetRoomInvitations(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Oda davetleri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", invitations)
+}
+
+// AcceptInvitation oda davetini kabul eder
+// POST /api/rooms/invitations/:invitationId/accept
+func (h *RoomHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "invitationId")
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.AcceptRoomInvitation(invitationID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oda daveti kabul edildi", nil)
+}
+
+// DeclineInvitation oda davetini reddeder
+// POST /api/rooms/invitations/:invitationId/decline
+func (h *RoomHandler) DeclineInvitation(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "invitationId")
+	if !ok {
+		return
+	}
+
+	if err := h.roomService.DeclineRoomInvitation(invitationID, userID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oda daveti reddedildi", nil)
+}
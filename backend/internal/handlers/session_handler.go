@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler kullanıcının aktif oturumlarını (cihazlarını) yönetme endpoint'lerini içerir
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+// NewSessionHandler yeni bir SessionHandler oluşturur
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// GetSessions kullanıcının aktif oturumlarını listeler; isteği yapan oturum current ile işaretlenir
+// GET /api/auth/sessions
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.List(userID, c.GetString("session_id"))
+	if err != nil {
+		utils.ServerErrorResponse(c, "Oturumlar alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", sessions)
+}
+
+// RevokeSession bir oturumu sonlandırır ve o oturumun canlı bağlantılarını keser
+// DELETE /api/auth/sessions/:id
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.Revoke(userID, sessionID); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.ServerErrorResponse(c, err.Error())
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Oturum sonlandırıldı", nil)
+}
+
+// RevokeOtherSessions isteği yapan oturum dışındaki tüm oturumları sonlandırır
+// POST /api/auth/sessions/revoke-others
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.sessionService.RevokeOthers(userID, c.GetString("session_id"))
+	if err != nil {
+		utils.ServerErrorResponse(c, "Oturumlar sonlandırılamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Diğer oturumlar sonlandırıldı", gin.H{"revoked": count})
+}
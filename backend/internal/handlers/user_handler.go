@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event/backend/internal/dtos"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler kullanıcı profili, ilgi alanları ve öneri endpoint'lerini yönetir
+type UserHandler struct {
+	userService       *services.UserService
+	interestService   services.InterestService
+	suggestionService *services.SuggestionService
+}
+
+// UserHandlerInput, UserHandler için bağımlılıkları içerir.
+type UserHandlerInput struct {
+	UserService       *services.UserService
+	InterestService   services.InterestService
+	SuggestionService *services.SuggestionService
+}
+
+// NewUserHandler yeni bir UserHandler oluşturur
+func NewUserHandler(input UserHandlerInput) *UserHandler {
+	return &UserHandler{
+		userService:       input.UserService,
+		interestService:   input.InterestService,
+		suggestionService: input.SuggestionService,
+	}
+}
+
+// updateProfileRequest profil güncelleme gövdesi. Boş bırakılan alanlar değiştirilmez.
+type updateProfileRequest struct {
+	FirstName         *string `json:"first_name" binding:"omitempty,max=100"`
+	LastName          *string `json:"last_name" binding:"omitempty,max=100"`
+	ProfilePictureURL *string `json:"profile_picture_url" binding:"omitempty,max=255"`
+	InterestIDs       []uint  `json:"interest_ids"`
+}
+
+// updateInterestsRequest ilgi alanı güncelleme gövdesi
+type updateInterestsRequest struct {
+	InterestIDs []uint `json:"interest_ids"`
+}
+
+// saveSuggestionsRequest AI önerilerini kaydetme gövdesi
+type saveSuggestionsRequest struct {
+	Suggestions []string `json:"suggestions" binding:"required"`
+}
+
+// GetMe giriş yapan kullanıcının profilini ilgi alanlarıyla getirir
+// GET /api/users/me
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUserWithInterests(userID)
+	if err != nil {
+		utils.NotFoundResponse(c, "Kullanıcı bulunamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", dtos.NewCurrentUserDTO(user))
+}
+
+// GetUser bir kullanıcının profilini getirir
+// GET /api/users/:id
+func (h *UserHandler) GetUser(c *gin.Context) {
+	if _, ok := requireUserID(c); !ok {
+		return
+	}
+	profileID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUserWithInterests(profileID)
+	if err != nil {
+		utils.NotFoundResponse(c, "Kullanıcı bulunamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", user)
+}
+
+// UpdateMe giriş yapan kullanıcının profilini günceller
+// PUT /api/users/me
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req updateProfileRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.userService.FindUserByID(userID)
+	if err != nil {
+		utils.NotFoundResponse(c, "Kullanıcı bulunamadı")
+		return
+	}
+	if req.FirstName != nil {
+		user.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		user.LastName = *req.LastName
+	}
+	if req.ProfilePictureURL != nil {
+		user.ProfilePictureURL = *req.ProfilePictureURL
+	}
+
+	if err := h.userService.UpdateUser(user, req.InterestIDs); err != nil {
+		utils.ServerErrorResponse(c, "Profil güncellenemedi")
+		return
+	}
+
+	updated, err := h.userService.GetUserWithInterests(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Profil güncellendi", updated)
+}
+
+// UpdateMyInterests giriş yapan kullanıcının ilgi alanlarını değiştirir
+// POST /api/users/me/interests
+func (h *UserHandler) UpdateMyInterests(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req updateInterestsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.interestService.UpdateUserInterests(uint(userID), req.InterestIDs)
+	if err != nil {
+		utils.ServerErrorResponse(c, "İlgi alanları güncellenemedi")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "İlgi alanları güncellendi", user)
+}
+
+// SearchUsers kullanıcıları ada veya kullanıcı adına göre arar
+// GET /api/users/search?q=
+func (h *UserHandler) SearchUsers(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	query := c.Query("q")
+	if len(query) < 2 {
+		utils.ValidationErrorResponse(c, "Arama sorgusu en az 2 karakter olmalıdır")
+		return
+	}
+
+	users, err := h.userService.SearchUsers(query, userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Kullanıcı araması yapılamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", users)
+}
+
+// GetMySuggestions kullanıcının kaydedilmiş AI önerilerini getirir
+// GET /api/users/me/suggestions
+func (h *UserHandler) GetMySuggestions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.userService.GetUserAISuggestions(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Öneriler alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", suggestions)
+}
+
+// SaveMySuggestions kullanıcının AI önerilerini kaydeder (eskileri silinir)
+// POST /api/users/me/suggestions
+func (h *UserHandler) SaveMySuggestions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req saveSuggestionsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.userService.SaveUserSuggestions(userID, req.Suggestions); err != nil {
+		utils.ServerErrorResponse(c, "Öneriler kaydedilemedi")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Öneriler kaydedildi", nil)
+}
+
+// DeleteMySuggestions kullanıcının kaydedilmiş AI önerilerini siler
+// DELETE /api/users/me/suggestions
+func (h *UserHandler) DeleteMySuggestions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteUserSuggestions(userID); err != nil {
+		utils.ServerErrorResponse(c, "Öneriler silinemedi")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Öneriler silindi", nil)
+}
+
+// GetInterests tüm ilgi alanlarını listeler
+// GET /api/interests
+func (h *UserHandler) GetInterests(c *gin.Context) {
+	interests, err := h.interestService.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, "İlgi alanları alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", interests)
+}
+
+// GetSuggestedEvents kullanıcı için önerilen etkinlikleri döndürür
+// GET /api/suggestions/events
+func (h *UserHandler) GetSuggestedEvents(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.suggestionService.GetSuggestedEvents(userID)
+	if err != nil {
+		utils.ServerErrorResponse(c, "Etkinlik önerileri alınamadı")
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "", events)
+}
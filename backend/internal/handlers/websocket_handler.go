@@ -6,26 +6,66 @@ import (
 	"strconv"
 
 	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/services"
 	appWS "event/backend/internal/websocket"
 
 	"github.com/gin-gonic/gin"
 	gorillaWS "github.com/gorilla/websocket"
 )
 
-var upgrader = gorillaWS.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Geliştirme ortamında tüm originlere izin ver
-		// Üretimde burayı kendi domain'inizle kısıtlamalısınız
-		// origin := r.Header.Get("Origin")
-		// return origin == "http://localhost:3000" || origin == "http://yourfrontenddomain.com"
-		return true
-	},
+// newUpgrader yalnızca CORS_ALLOWED_ORIGINS içindeki origin'lerden gelen el sıkışmalarını kabul eden bir upgrader döndürür.
+// Origin başlığı göndermeyen (tarayıcı dışı) istemciler token ile doğrulandığından kabul edilir.
+func newUpgrader(cfg *config.Config) gorillaWS.Upgrader {
+	allowed := make(map[string]bool, len(cfg.CORSAllowedOrigins))
+	for _, origin := range cfg.CORSAllowedOrigins {
+		allowed[origin] = true
+	}
+
+	return gorillaWS.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || allowed[origin]
+		},
+	}
+}
+
+// WebSocketHandler oda WebSocket bağlantılarını yönetir
+type WebSocketHandler struct {
+	hub           *appWS.Hub
+	config        *config.Config
+	roomService   *services.RoomService
+	revocations   auth.RevocationStore
+	accountStatus auth.AccountStatusChecker
+}
+
+// WebSocketHandlerInput, WebSocketHandler için bağımlılıkları içerir.
+// Revocations ve AccountStatus HTTP middleware'ine verilenlerle aynı olmalıdır.
+type WebSocketHandlerInput struct {
+	Hub           *appWS.Hub
+	Config        *config.Config
+	RoomService   *services.RoomService
+	Revocations   auth.RevocationStore
+	AccountStatus auth.AccountStatusChecker
+}
+
+// NewWebSocketHandler yeni bir WebSocketHandler oluşturur
+func NewWebSocketHandler(input WebSocketHandlerInput) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub:           input.Hub,
+		config:        input.Config,
+		roomService:   input.RoomService,
+		revocations:   input.Revocations,
+		accountStatus: input.AccountStatus,
+	}
 }
 
 // ServeWsRoom handles websocket requests for a specific room.
-func ServeWsRoom(hub *appWS.Hub, c *gin.Context) {
+// Bağlantı yalnızca odanın aktif üyeleri için yükseltilir; diğer kullanıcılar odanın mesajlarını dinleyemez.
+// GET /api/ws/room/:roomId
+func (h *WebSocketHandler) ServeWsRoom(c *gin.Context) {
 	roomIDStr := c.Param("roomId")
 
 	roomID, err := strconv.ParseUint(roomIDStr, 10, 64)
@@ -49,27 +89,34 @@ func ServeWsRoom(hub *appWS.Hub, c *gin.Context) {
 	}
 
 	// Token'ı doğrula ve kullanıcı ID'sini al
-	// Bu fonksiyonun auth paketinde olması beklenir: ValidateTokenAndGetUserID(tokenString string) (uint, error)
-	userID, err := auth.ValidateTokenAndGetUserID(tokenString) // auth.GetUserIDFromContext(c) yerine bu geldi
+	userID, sessionID, err := auth.ValidateTokenAndGetSession(tokenString, h.config, h.revocations, h.accountStatus)
 	if err != nil {
 		log.Printf("WebSocket Unauthorized: Invalid token (RoomID: %s, Error: %v)", roomIDStr, err)
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
 		return
 	}
 
+	// Mesaj geçmişiyle aynı kural: yalnızca odanın aktif üyeleri bağlanabilir
+	isMember, err := h.roomService.IsUserMemberOfRoom(userID, roomID)
+	if err != nil {
+		log.Printf("WebSocket membership check failed (RoomID: %s, UserID: %d): %v", roomIDStr, userID, err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Oda üyeliği kontrol edilemedi"})
+		return
+	}
+	if !isMember {
+		log.Printf("WebSocket Forbidden: user %d is not a member of room %s", userID, roomIDStr)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Bu odanın mesajlarını görme yetkiniz yok"})
+		return
+	}
+
+	upgrader := newUpgrader(h.config)
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("Failed to set websocket upgrade (RoomID: %s): %+v", roomIDStr, err)
 		return
 	}
 
-	client := &appWS.Client{
-		Hub:    hub,
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
-		RoomID: roomID,
-		UserID: userID,
-	}
+	client := h.hub.NewClient(conn, roomID, userID, sessionID)
 	client.Hub.Register <- client
 
 	// Allow collection of memory referenced by the caller by doing all work in
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin platform yöneticisi olmayan kullanıcıların isteğini 403 ile reddeder.
+// Rol her istekte veritabanından okunur; yetkisi kaldırılan yönetici token'ı geçerli olsa da erişemez.
+// AuthMiddleware'den sonra kullanılmalıdır.
+func RequireAdmin(users UserFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := auth.GetUserIDFromContext(c)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Bu işlem için giriş yapmalısınız")
+			c.Abort()
+			return
+		}
+
+		user, err := users.FindUserByID(userID)
+		if err != nil {
+			log.Printf("[RequireAdmin] Kullanıcı alınamadı (UserID: %d): %v", userID, err)
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Kullanıcı bulunamadı")
+			c.Abort()
+			return
+		}
+
+		if !user.IsAdmin() {
+			utils.ErrorResponse(c, http.StatusForbidden, "Bu işlem için yönetici yetkisi gerekir")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,195 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokenAuthenticator kişisel erişim tokenini doğrulayıp sahibiyle birlikte döndürür
+type PersonalAccessTokenAuthenticator interface {
+	Authenticate(rawToken string) (*models.PersonalAccessToken, error)
+}
+
+// AuthMiddlewareInput, kimlik doğrulama middleware'leri için bağımlılıkları içerir.
+// Revocations çıkış yapılmış token'ları ve sonlandırılmış oturumları, AccountStatus askıya alınmış hesapları reddeder.
+type AuthMiddlewareInput struct {
+	Config               *config.Config
+	Revocations          auth.RevocationStore
+	AccountStatus        auth.AccountStatusChecker
+	PersonalAccessTokens PersonalAccessTokenAuthenticator
+}
+
+// AuthMiddleware, Authorization header'ındaki Bearer token'ı doğrular ve
+// kullanıcı bilgilerini context'e ekler. Token yoksa veya geçersizse istek 401 ile sonlanır.
+// Yalnızca JWT kabul eder; kişisel erişim token'ları için TokenAuthMiddleware kullanılır.
+func AuthMiddleware(input AuthMiddlewareInput) gin.HandlerFunc {
+	return TokenAuthMiddleware(input, "")
+}
+
+// OptionalAuthMiddleware, token varsa doğrulayıp kullanıcı bilgilerini context'e ekler.
+// Token yoksa istek anonim olarak devam eder; geçersiz bir token ise 401 ile reddedilir.
+func OptionalAuthMiddleware(input AuthMiddlewareInput) gin.HandlerFunc {
+	return OptionalTokenAuthMiddleware(input, "")
+}
+
+// TokenAuthMiddleware JWT'lerin yanında resource kaynağı için yetkili kişisel erişim token'larını da kabul eder.
+// GET ve HEAD istekleri "<resource>:read", diğer istekler "<resource>:write" kapsamını gerektirir.
+// resource boşsa kişisel erişim token'ları reddedilir.
+func TokenAuthMiddleware(input AuthMiddlewareInput, resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := utils.ExtractTokenFromHeader(c.GetHeader("Authorization"))
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if !authenticate(c, input, resource, tokenString) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// OptionalTokenAuthMiddleware, OptionalAuthMiddleware gibi çalışır; ek olarak kişisel erişim token'larını
+// TokenAuthMiddleware ile aynı kapsam kurallarıyla kabul eder.
+func OptionalTokenAuthMiddleware(input AuthMiddlewareInput, resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		tokenString, err := utils.ExtractTokenFromHeader(authHeader)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if !authenticate(c, input, resource, tokenString) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// authenticate token'ı türüne göre doğrular ve kullanıcıyı context'e yazar.
+// Başarısız olursa yanıtı yazıp isteği durdurur ve false döner.
+func authenticate(c *gin.Context, input AuthMiddlewareInput, resource, tokenString string) bool {
+	if auth.IsPersonalAccessToken(tokenString) {
+		return authenticatePersonalAccessToken(c, input, resource, tokenString)
+	}
+
+	claims, err := utils.ValidateToken(tokenString, input.Config)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Geçersiz veya süresi dolmuş token")
+		c.Abort()
+		return false
+	}
+	if !checkNotRevoked(c, input.Revocations, claims) || !checkNotSuspended(c, input.AccountStatus, claims.UserID) {
+		return false
+	}
+
+	setUserContext(c, claims)
+	return true
+}
+
+// authenticatePersonalAccessToken kişisel erişim tokenini doğrular ve isteğin gerektirdiği kapsamı denetler
+func authenticatePersonalAccessToken(c *gin.Context, input AuthMiddlewareInput, resource, tokenString string) bool {
+	if input.PersonalAccessTokens == nil || resource == "" {
+		utils.ErrorResponse(c, http.StatusForbidden, "Bu endpoint kişisel erişim tokeni ile kullanılamaz")
+		c.Abort()
+		return false
+	}
+
+	token, err := input.PersonalAccessTokens.Authenticate(tokenString)
+	if err != nil {
+		log.Printf("[AuthMiddleware] Kişisel erişim tokeni reddedildi: %v", err)
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Geçersiz, süresi dolmuş veya iptal edilmiş erişim tokeni")
+		c.Abort()
+		return false
+	}
+
+	if !checkNotSuspended(c, input.AccountStatus, token.UserID) {
+		return false
+	}
+
+	required := auth.WriteScope(resource)
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		required = auth.ReadScope(resource)
+	}
+	scopes := token.ScopeList()
+	if !auth.HasScope(scopes, required) {
+		utils.ErrorResponse(c, http.StatusForbidden, fmt.Sprintf("Erişim tokeninin bu işlem için '%s' kapsamı yok", required))
+		c.Abort()
+		return false
+	}
+
+	c.Set("user_id", token.UserID)
+	c.Set("email", token.User.Email)
+	c.Set("personal_access_token_id", token.ID)
+	c.Set("token_scopes", scopes)
+	return true
+}
+
+// checkNotRevoked çıkış yapılarak iptal edilmiş token'ları ve sonlandırılmış oturumların token'larını reddeder.
+// İptal deposuna ulaşılamazsa istek güvenli tarafta kalınarak reddedilir.
+func checkNotRevoked(c *gin.Context, revocations auth.RevocationStore, claims *utils.JWTClaims) bool {
+	revoked, err := auth.IsTokenRevoked(revocations, claims.ID)
+	if err == nil && !revoked {
+		revoked, err = auth.IsSessionRevoked(revocations, claims.SessionID)
+	}
+	if err != nil {
+		log.Printf("[AuthMiddleware] Token iptal durumu kontrol edilemedi: %v", err)
+		utils.ServerErrorResponse(c, "Token doğrulanamadı")
+		c.Abort()
+		return false
+	}
+	if revoked {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Oturum sonlandırılmış, lütfen tekrar giriş yapın")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// checkNotSuspended askıya alınmış hesapların isteklerini 403 ile reddeder.
+// Hesap durumu okunamazsa istek güvenli tarafta kalınarak reddedilir.
+func checkNotSuspended(c *gin.Context, accounts auth.AccountStatusChecker, userID uint64) bool {
+	suspended, err := accounts.IsSuspended(userID)
+	if err != nil {
+		log.Printf("[AuthMiddleware] Hesap durumu kontrol edilemedi (UserID: %d): %v", userID, err)
+		utils.ServerErrorResponse(c, "Token doğrulanamadı")
+		c.Abort()
+		return false
+	}
+	if suspended {
+		utils.ErrorResponse(c, http.StatusForbidden, "Hesabınız yönetici tarafından askıya alındı")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// setUserContext doğrulanmış token bilgilerini auth.GetUserIDFromContext'in beklediği anahtarlarla yazar.
+// jti ve son kullanma zamanı çıkış işleminde token'ı iptal etmek, session_id ise
+// oturum listesinde geçerli oturumu işaretlemek için saklanır.
+func setUserContext(c *gin.Context, claims *utils.JWTClaims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("jti", claims.ID)
+	c.Set("session_id", claims.SessionID)
+	if claims.ExpiresAt != nil {
+		c.Set("token_expires_at", claims.ExpiresAt.Time)
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit istek gövdesini limit bayt ile sınırlar (MAX_REQUEST_BODY_BYTES).
+// Content-Length sınırı aşıyorsa istek 413 ile reddedilir; bildirilmeyen büyük gövdeler okunurken hata verir.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "İstek gövdesi çok büyük")
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
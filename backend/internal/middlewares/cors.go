@@ -1,20 +1,20 @@
 package middlewares
 
 import (
-	"time"
+	"event/backend/internal/config"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// CORSMiddleware middleware'ini döndürür
-func CORSMiddleware() gin.HandlerFunc {
+// CORSMiddleware yapılandırmadaki origin'lere (CORS_ALLOWED_ORIGINS) izin veren CORS middleware'ini döndürür
+func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:5173", "http://127.0.0.1:5173"},
+		AllowOrigins:     cfg.CORSAllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
 		ExposeHeaders:    []string{"Content-Length", "Authorization"},
 		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+		MaxAge:           cfg.CORSMaxAge,
 	})
 }
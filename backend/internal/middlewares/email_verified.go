@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserFinder kullanıcıyı ID ile getirebilen servisler (örn. services.UserService)
+type UserFinder interface {
+	FindUserByID(id uint64) (*models.User, error)
+}
+
+// RequireVerifiedEmail, cfg.RequireVerifiedEmail açıksa e-postası doğrulanmamış kullanıcıların
+// isteğini 403 ile reddeder. AuthMiddleware'den sonra kullanılmalıdır.
+func RequireVerifiedEmail(cfg *config.Config, users UserFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.RequireVerifiedEmail {
+			c.Next()
+			return
+		}
+
+		userID, err := auth.GetUserIDFromContext(c)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Bu işlem için giriş yapmalısınız")
+			c.Abort()
+			return
+		}
+
+		user, err := users.FindUserByID(userID)
+		if err != nil {
+			log.Printf("[RequireVerifiedEmail] Kullanıcı alınamadı (UserID: %d): %v", userID, err)
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Kullanıcı bulunamadı")
+			c.Abort()
+			return
+		}
+
+		if !user.IsEmailVerified() {
+			utils.ErrorResponse(c, http.StatusForbidden, "Bu işlem için e-posta adresinizi doğrulamalısınız")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
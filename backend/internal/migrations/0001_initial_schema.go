@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"event/backend/internal/migrations/initialschema"
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// initialSchema, migration'lardan önce açılışta AutoMigrate ile oluşturulan şemanın tamamıdır.
+// AutoMigrate yalnızca eksik tablo ve sütunları eklediği için bu şemayla zaten çalışan kurulumlarda güvenle uygulanır.
+// Tablolar internal/models yerine initialschema paketindeki dondurulmuş yapılardan oluşturulur; modellere sonradan
+// eklenen alanlar yalnızca kendi migration'larıyla gelir.
+var initialSchema = migrate.Migration{
+	Version: 1,
+	Name:    "initial_schema",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(initialschema.Tables()...)
+	},
+	Down: func(tx *gorm.DB) error {
+		tables := initialschema.Tables()
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := tx.Migrator().DropTable(tables[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
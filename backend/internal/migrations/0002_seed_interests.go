@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"event/backend/internal/migrations/initialschema"
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// defaultInterests kullanıcıların profilinde seçebileceği başlangıç ilgi alanları
+var defaultInterests = []initialschema.Interest{
+	{Name: "Yazılım Geliştirme", Category: "Teknoloji"},
+	{Name: "Teknoloji", Category: "Genel"},
+	{Name: "Yapay Zeka", Category: "Teknoloji"},
+	{Name: "Spor", Category: "Aktivite"},
+	{Name: "Müzik", Category: "Sanat"},
+	{Name: "Sanat", Category: "Sanat"},
+	{Name: "Sinema", Category: "Sanat"},
+	{Name: "Edebiyat", Category: "Sanat"},
+	{Name: "Gezi", Category: "Aktivite"},
+	{Name: "Yemek", Category: "Gurme"},
+	{Name: "Oyun", Category: "Hobi"},
+	{Name: "Doğa Yürüyüşü", Category: "Aktivite"},
+}
+
+// seedInterests başlangıç ilgi alanlarını ekler. Aynı isimde bir ilgi alanı varsa (silinmiş olsa bile) dokunulmaz.
+var seedInterests = migrate.Migration{
+	Version: 2,
+	Name:    "seed_interests",
+	Up: func(tx *gorm.DB) error {
+		for _, interest := range defaultInterests {
+			interest := interest
+			if err := tx.Unscoped().Where(initialschema.Interest{Name: interest.Name}).FirstOrCreate(&interest).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		names := make([]string, 0, len(defaultInterests))
+		for _, interest := range defaultInterests {
+			names = append(names, interest.Name)
+		}
+		// Kullanıcılara atanmış ilgi alanları silinmez
+		return tx.Unscoped().Where("name IN ? AND id NOT IN (?)", names, tx.Model(&initialschema.UserInterest{}).Select("interest_id")).
+			Delete(&initialschema.Interest{}).Error
+	},
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"time"
+
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// recurrenceEvent bu migration'ın etkinliklere eklediği sütunlar
+type recurrenceEvent struct {
+	TimeZone       string  `gorm:"size:64"`
+	RecurrenceRule string  `gorm:"size:255"`
+	ExDates        string  `gorm:"type:text"`
+	SeriesID       *uint64 `gorm:"index"`
+	RecurrenceID   *time.Time
+}
+
+func (recurrenceEvent) TableName() string { return "events" }
+
+// recurrenceAttendance katılımların tekrar bazındaki benzersiz indeksi
+type recurrenceAttendance struct {
+	EventID    uint64 `gorm:"uniqueIndex:idx_event_user_occurrence"`
+	UserID     uint64 `gorm:"uniqueIndex:idx_event_user_occurrence"`
+	Occurrence string `gorm:"size:32;not null;default:'';uniqueIndex:idx_event_user_occurrence"`
+}
+
+func (recurrenceAttendance) TableName() string { return "event_attendances" }
+
+// eventRecurrence etkinliklere tekrar kuralı, saat dilimi ve tekil tekrar değişiklikleri için sütunlar ekler;
+// katılım kayıtlarını tekrar bazında tutabilmek için (event_id, user_id) benzersiz indeksini
+// (event_id, user_id, occurrence) ile değiştirir. Mevcut kayıtların occurrence değeri boş kalır,
+// yani etkinliğin tamamına ait sayılır.
+var eventRecurrence = migrate.Migration{
+	Version: 3,
+	Name:    "event_recurrence",
+	Up: func(tx *gorm.DB) error {
+		err := addColumns(tx, &recurrenceEvent{}, "TimeZone", "RecurrenceRule", "ExDates", "SeriesID", "RecurrenceID")
+		if err != nil {
+			return err
+		}
+		if err := createIndexes(tx, &recurrenceEvent{}, "SeriesID"); err != nil {
+			return err
+		}
+		// Yeni indeks eskisi silinmeden oluşturulur; MySQL yabancı anahtar için event_id'li bir indeks ister
+		if err := addColumns(tx, &recurrenceAttendance{}, "Occurrence"); err != nil {
+			return err
+		}
+		if err := createIndexes(tx, &recurrenceAttendance{}, "idx_event_user_occurrence"); err != nil {
+			return err
+		}
+		return dropIndexes(tx, &recurrenceAttendance{}, "idx_event_user")
+	},
+	Down: func(tx *gorm.DB) error {
+		// Tekrar bazındaki katılımlar ve tekil tekrar kayıtları eski şemada karşılığı olmadığından silinir
+		if err := tx.Unscoped().Where("occurrence <> ?", "").Delete(&recurrenceAttendance{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("series_id IS NOT NULL").Delete(&recurrenceEvent{}).Error; err != nil {
+			return err
+		}
+
+		const createOldIndex = "CREATE UNIQUE INDEX idx_event_user ON event_attendances (event_id, user_id)"
+		if err := tx.Exec(createOldIndex).Error; err != nil {
+			return err
+		}
+		if err := dropIndexes(tx, &recurrenceAttendance{}, "idx_event_user_occurrence"); err != nil {
+			return err
+		}
+		if err := dropColumns(tx, &recurrenceAttendance{}, "Occurrence"); err != nil {
+			return err
+		}
+		if err := dropIndexes(tx, &recurrenceEvent{}, "SeriesID"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &recurrenceEvent{}, "TimeZone", "RecurrenceRule", "ExDates", "SeriesID", "RecurrenceID")
+	},
+}
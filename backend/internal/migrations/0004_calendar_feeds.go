@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"time"
+
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// calendarFeedEvent bu migration'ın etkinliklere eklediği sütun
+type calendarFeedEvent struct {
+	Sequence int `gorm:"not null;default:0"`
+}
+
+func (calendarFeedEvent) TableName() string { return "events" }
+
+// calendarFeedUser takvim token'larının yabancı anahtarla bağlandığı kullanıcılar tablosu
+type calendarFeedUser struct {
+	ID uint64 `gorm:"primaryKey"`
+}
+
+func (calendarFeedUser) TableName() string { return "users" }
+
+// calendarFeedToken takvim aboneliği token'ları tablosu
+type calendarFeedToken struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID     uint64 `gorm:"uniqueIndex;not null"`
+	TokenHash  string `gorm:"uniqueIndex;not null;size:64"`
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+
+	User calendarFeedUser `gorm:"foreignKey:UserID"`
+}
+
+func (calendarFeedToken) TableName() string { return "calendar_feed_tokens" }
+
+// calendarFeeds iCalendar dışa aktarımı için etkinliklere SEQUENCE sayacını ve kullanıcıların takvim
+// aboneliği token'larının tablosunu ekler. Mevcut etkinliklerin sayacı 0'dan başlar.
+var calendarFeeds = migrate.Migration{
+	Version: 4,
+	Name:    "calendar_feeds",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &calendarFeedEvent{}, "Sequence"); err != nil {
+			return err
+		}
+		if tx.Migrator().HasTable(&calendarFeedToken{}) {
+			return nil
+		}
+		return tx.Migrator().CreateTable(&calendarFeedToken{})
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropTable(&calendarFeedToken{}); err != nil {
+			return err
+		}
+		return dropColumns(tx, &calendarFeedEvent{}, "Sequence")
+	},
+}
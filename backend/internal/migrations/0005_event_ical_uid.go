@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// icalUIDEvent bu migration'ın etkinliklere eklediği sütun
+type icalUIDEvent struct {
+	ICalUID string `gorm:"column:ical_uid;size:255;index"`
+}
+
+func (icalUIDEvent) TableName() string { return "events" }
+
+// eventICalUID .ics içe aktarımının aynı dosyayı tekrar yüklediğinde etkinlikleri güncelleyebilmesi için
+// etkinliklere özgün iCalendar UID'sini ekler. Mevcut etkinliklerde alan boş kalır.
+var eventICalUID = migrate.Migration{
+	Version: 5,
+	Name:    "event_ical_uid",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &icalUIDEvent{}, "ICalUID"); err != nil {
+			return err
+		}
+		return createIndexes(tx, &icalUIDEvent{}, "ICalUID")
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropIndexes(tx, &icalUIDEvent{}, "ICalUID"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &icalUIDEvent{}, "ICalUID")
+	},
+}
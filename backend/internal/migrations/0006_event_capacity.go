@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// capacityEvent bu migration'ın etkinliklere eklediği sütun
+type capacityEvent struct {
+	Capacity *int
+}
+
+func (capacityEvent) TableName() string { return "events" }
+
+// capacityAttendance bu migration'ın katılımlara eklediği sütun
+type capacityAttendance struct {
+	WaitlistPosition int `gorm:"not null;default:0"`
+}
+
+func (capacityAttendance) TableName() string { return "event_attendances" }
+
+// eventCapacity etkinliklere isteğe bağlı kapasiteyi ve katılımlara bekleme listesi sırasını ekler.
+// Mevcut etkinliklerin kapasitesi boş (sınırsız) kalır.
+var eventCapacity = migrate.Migration{
+	Version: 6,
+	Name:    "event_capacity",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &capacityEvent{}, "Capacity"); err != nil {
+			return err
+		}
+		return addColumns(tx, &capacityAttendance{}, "WaitlistPosition")
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := dropColumns(tx, &capacityAttendance{}, "WaitlistPosition"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &capacityEvent{}, "Capacity")
+	},
+}
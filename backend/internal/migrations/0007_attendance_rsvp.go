@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// rsvpAttendance bu migration'ın katılımlara eklediği sütunlar ve durumun yeni varsayılanı
+type rsvpAttendance struct {
+	Status string `gorm:"type:varchar(20);default:'going'"`
+	Guests int    `gorm:"not null;default:0"`
+	Note   string `gorm:"size:500"`
+}
+
+func (rsvpAttendance) TableName() string { return "event_attendances" }
+
+// legacyAttendanceStatus durumun bu migration'dan önceki varsayılanı
+type legacyAttendanceStatus struct {
+	Status string `gorm:"type:varchar(20);default:'attending'"`
+}
+
+func (legacyAttendanceStatus) TableName() string { return "event_attendances" }
+
+// attendanceRSVP katılımlara ek misafir sayısı ve not ekler; eski attending / not_attending / cancelled
+// durumlarını going ve not_going olarak yeniden adlandırır.
+var attendanceRSVP = migrate.Migration{
+	Version: 7,
+	Name:    "attendance_rsvp",
+	Up: func(tx *gorm.DB) error {
+		if err := addColumns(tx, &rsvpAttendance{}, "Guests", "Note"); err != nil {
+			return err
+		}
+		if err := alterColumns(tx, &rsvpAttendance{}, "Status"); err != nil {
+			return err
+		}
+		return renameAttendanceStatuses(tx, map[string][]string{
+			"going":     {"attending"},
+			"not_going": {"not_attending", "cancelled"},
+		})
+	},
+	Down: func(tx *gorm.DB) error {
+		// Eski şemada "belki" karşılığı olmadığından bu yanıtlar katılmıyor sayılır; kapasite aşılmaz
+		if err := renameAttendanceStatuses(tx, map[string][]string{
+			"attending":     {"going"},
+			"not_attending": {"not_going", "maybe"},
+		}); err != nil {
+			return err
+		}
+		if err := alterColumns(tx, &legacyAttendanceStatus{}, "Status"); err != nil {
+			return err
+		}
+		return dropColumns(tx, &rsvpAttendance{}, "Guests", "Note")
+	},
+}
+
+// renameAttendanceStatuses her yeni durum için eski durumlardaki katılım kayıtlarını (silinmişler dahil) günceller
+func renameAttendanceStatuses(tx *gorm.DB, renames map[string][]string) error {
+	for status, old := range renames {
+		err := tx.Model(&rsvpAttendance{}).
+			Where("status IN ?", old).
+			Update("status", status).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
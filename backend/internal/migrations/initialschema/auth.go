@@ -0,0 +1,125 @@
+package initialschema
+
+import "time"
+
+// PasswordResetToken şifre sıfırlama token'ları tablosu
+type PasswordResetToken struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"not null;index"`
+	TokenHash string    `gorm:"uniqueIndex;not null;size:64"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// RefreshToken yenileme token'ları tablosu
+type RefreshToken struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64    `gorm:"not null;index"`
+	FamilyID    string    `gorm:"not null;size:64;index"`
+	JTI         string    `gorm:"column:jti;uniqueIndex;not null;size:64"`
+	DeviceLabel string    `gorm:"size:100"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	RotatedAt   *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// RevokedToken iptal edilmiş erişim token'ları tablosu
+type RevokedToken struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	JTI       string    `gorm:"column:jti;uniqueIndex;not null;size:64"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// LoginThrottle başarısız giriş sayaçları tablosu
+type LoginThrottle struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	Key           string `gorm:"column:throttle_key;uniqueIndex;not null;size:191"`
+	Failures      int    `gorm:"not null;default:0"`
+	LastFailureAt time.Time
+	BlockedUntil  *time.Time
+}
+
+// AuthAuditLog kimlik doğrulama denetim kaydı tablosu
+type AuthAuditLog struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    *uint64   `gorm:"index"`
+	Email     string    `gorm:"size:255"`
+	IPAddress string    `gorm:"size:45"`
+	Event     string    `gorm:"size:50;not null;index"`
+	Detail    string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// RecoveryCode iki adımlı doğrulama kurtarma kodları tablosu
+type RecoveryCode struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64 `gorm:"not null;index"`
+	CodeHash  string `gorm:"not null;size:64;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// UserIdentity harici kimlik sağlayıcı bağlantıları tablosu
+type UserIdentity struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64 `gorm:"not null;index"`
+	Provider    string `gorm:"not null;size:50;uniqueIndex:idx_identity_provider_subject"`
+	Subject     string `gorm:"not null;size:191;uniqueIndex:idx_identity_provider_subject"`
+	Email       string `gorm:"size:255"`
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// OAuthState yetkilendirme akışı durumları tablosu
+type OAuthState struct {
+	ID           uint64 `gorm:"primaryKey;autoIncrement"`
+	StateHash    string `gorm:"uniqueIndex;not null;size:64"`
+	Provider     string `gorm:"not null;size:50"`
+	CodeVerifier string `gorm:"not null;size:128"`
+	Nonce        string `gorm:"size:64"`
+	LinkUserID   *uint64
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+// PersonalAccessToken kişisel erişim token'ları tablosu
+type PersonalAccessToken struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64    `gorm:"not null;index"`
+	Name        string    `gorm:"not null;size:100"`
+	TokenPrefix string    `gorm:"not null;size:20"`
+	TokenHash   string    `gorm:"uniqueIndex;not null;size:64"`
+	Scopes      string    `gorm:"not null;size:500"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// Session oturumlar tablosu
+type Session struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64 `gorm:"not null;index"`
+	FamilyID    string `gorm:"uniqueIndex;not null;size:64"`
+	DeviceLabel string `gorm:"size:100"`
+	UserAgent   string `gorm:"size:255"`
+	IPAddress   string `gorm:"size:45"`
+	CreatedAt   time.Time
+	LastSeenAt  time.Time `gorm:"index"`
+	RevokedAt   *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,128 @@
+package initialschema
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Event etkinlikler tablosu
+type Event struct {
+	ID             uint64  `gorm:"primaryKey;autoIncrement"`
+	Title          string  `gorm:"not null;size:255"`
+	Description    string  `gorm:"type:text"`
+	Location       string  `gorm:"size:255"`
+	CreatorUserID  uint64  `gorm:"not null"`
+	RoomID         *uint64 `gorm:"index"`
+	IsPrivate      bool    `gorm:"default:false"`
+	ImageURL       string  `gorm:"size:255"`
+	FinalStartTime *time.Time
+	FinalEndTime   *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	Creator     User              `gorm:"foreignKey:CreatorUserID"`
+	Room        *Room             `gorm:"foreignKey:RoomID;references:ID"`
+	TimeOptions []EventTimeOption `gorm:"foreignKey:EventID"`
+	Proposals   []EventProposal   `gorm:"foreignKey:EventID"`
+}
+
+// EventTimeOption etkinlik zaman seçenekleri tablosu
+type EventTimeOption struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	EventID    uint64 `gorm:"not null"`
+	StartTime  time.Time
+	EndTime    time.Time
+	VotesCount int `gorm:"default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	Event Event       `gorm:"foreignKey:EventID"`
+	Votes []EventVote `gorm:"foreignKey:EventTimeOptionID"`
+}
+
+// EventVote zaman seçeneği oyları tablosu
+type EventVote struct {
+	UserID            uint64 `gorm:"primaryKey;not null"`
+	EventTimeOptionID uint64 `gorm:"primaryKey;not null"`
+	VotedAt           time.Time
+
+	User       User            `gorm:"foreignKey:UserID"`
+	TimeOption EventTimeOption `gorm:"foreignKey:EventTimeOptionID"`
+}
+
+// EventProposal etkinlik önerileri tablosu
+type EventProposal struct {
+	ID                       uint64 `gorm:"primaryKey;autoIncrement"`
+	EventID                  *uint64
+	ProposedEventDetailsJSON string `gorm:"type:json"`
+	SuggesterUserID          uint64 `gorm:"not null"`
+	RecipientUserID          uint64 `gorm:"not null"`
+	Status                   string `gorm:"not null;default:'pending';size:20"`
+	ProposedAt               time.Time
+	RespondedAt              *time.Time
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	DeletedAt                gorm.DeletedAt `gorm:"index"`
+
+	Event            *Event            `gorm:"foreignKey:EventID"`
+	Suggester        User              `gorm:"foreignKey:SuggesterUserID"`
+	Recipient        User              `gorm:"foreignKey:RecipientUserID"`
+	CounterProposals []CounterProposal `gorm:"foreignKey:OriginalProposalID"`
+}
+
+// CounterProposal karşı öneriler tablosu
+type CounterProposal struct {
+	ID                  uint64 `gorm:"primaryKey;autoIncrement"`
+	OriginalProposalID  uint64 `gorm:"not null"`
+	NewEventDetailsJSON string `gorm:"type:json"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
+
+	OriginalProposal EventProposal `gorm:"foreignKey:OriginalProposalID"`
+}
+
+// EventAttendance etkinlik katılımları tablosu
+type EventAttendance struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	EventID   uint64 `gorm:"uniqueIndex:idx_event_user"`
+	Event     Event  `gorm:"foreignKey:EventID"`
+	UserID    uint64 `gorm:"uniqueIndex:idx_event_user"`
+	User      User   `gorm:"foreignKey:UserID"`
+	Status    string `gorm:"type:varchar(20);default:'attending'"`
+	JoinedAt  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// EventParticipationRequest özel etkinliklere katılım istekleri tablosu
+type EventParticipationRequest struct {
+	ID        uint64 `gorm:"primaryKey"`
+	EventID   uint64 `gorm:"not null;uniqueIndex:idx_event_user_request"`
+	Event     Event  `gorm:"foreignKey:EventID"`
+	UserID    uint64 `gorm:"not null;uniqueIndex:idx_event_user_request"`
+	User      User   `gorm:"foreignKey:UserID"`
+	Status    string `gorm:"type:varchar(20);default:'pending'"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// EventInvitation etkinlik davetleri tablosu
+type EventInvitation struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	EventID   uint64 `gorm:"not null"`
+	Event     Event  `gorm:"foreignKey:EventID"`
+	InviterID uint64 `gorm:"not null"`
+	Inviter   User   `gorm:"foreignKey:InviterID"`
+	InviteeID uint64 `gorm:"not null"`
+	Invitee   User   `gorm:"foreignKey:InviteeID"`
+	Status    string `gorm:"type:varchar(20);default:'pending'"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
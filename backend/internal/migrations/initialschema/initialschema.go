@@ -0,0 +1,41 @@
+// Package initialschema ilk migration'ın oluşturduğu şemanın dondurulmuş kopyasıdır. Yapılar internal/models'taki
+// modellerin sürümlü migration'lara geçildiği andaki hâlidir; yalnızca şemayı belirleyen alanlar ve etiketler tutulur.
+// GORM birleşim tablosu sütunlarını ve kısıt adlarını yapı adlarından türettiği için adlar modellerle aynıdır.
+// Bu paket değiştirilmez; şemadaki yeni değişiklikler yeni bir migration olarak eklenir.
+package initialschema
+
+// Tables tabloları bağımlılık sırasıyla döndürür; geri alırken tersten silinir
+func Tables() []interface{} {
+	return []interface{}{
+		&User{},
+		&Interest{},
+		&UserInterest{},
+		&Room{},
+		&RoomMember{},
+		&Event{},
+		&EventTimeOption{},
+		&EventVote{},
+		&EventProposal{},
+		&CounterProposal{},
+		&Friendship{},
+		&EventAttendance{},
+		&EventParticipationRequest{},
+		&Message{},
+		&EventInvitation{},
+		&RoomInvitation{},
+		&Notification{},
+		&UserSuggestion{},
+		&PasswordResetToken{},
+		&RefreshToken{},
+		&RevokedToken{},
+		&LoginThrottle{},
+		&AuthAuditLog{},
+		&RecoveryCode{},
+		&UserIdentity{},
+		&OAuthState{},
+		&PersonalAccessToken{},
+		&Session{},
+		&Report{},
+		&AdminAuditLog{},
+	}
+}
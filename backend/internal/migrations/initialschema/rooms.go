@@ -0,0 +1,70 @@
+package initialschema
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Room odalar tablosu
+type Room struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	Name          string `gorm:"not null;size:100"`
+	Description   string `gorm:"type:text"`
+	CreatorUserID uint64 `gorm:"not null"`
+	IsPublic      bool   `gorm:"default:false"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+
+	Creator User         `gorm:"foreignKey:CreatorUserID"`
+	Members []RoomMember `gorm:"foreignKey:RoomID"`
+	Events  []Event      `gorm:"foreignKey:RoomID"`
+}
+
+// RoomMember oda üyeleri ara tablosu
+type RoomMember struct {
+	RoomID     uint64 `gorm:"primaryKey;not null"`
+	UserID     uint64 `gorm:"primaryKey;not null"`
+	Role       string `gorm:"not null;default:'member';size:50"`
+	JoinedAt   time.Time
+	IsActive   bool `gorm:"default:true"`
+	LastReadAt *time.Time
+
+	Room Room `gorm:"foreignKey:RoomID"`
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// RoomInvitation oda davetleri tablosu
+type RoomInvitation struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	RoomID    uint64 `gorm:"not null;index"`
+	InviterID uint64 `gorm:"not null;index"`
+	InviteeID uint64 `gorm:"not null;index"`
+	Message   string `gorm:"type:text"`
+	Status    string `gorm:"type:varchar(20);default:'pending'"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Room    Room `gorm:"foreignKey:RoomID"`
+	Inviter User `gorm:"foreignKey:InviterID"`
+	Invitee User `gorm:"foreignKey:InviteeID"`
+}
+
+// TableName veritabanı tablo adını belirtir
+func (RoomInvitation) TableName() string {
+	return "room_invitations"
+}
+
+// Message oda mesajları tablosu
+type Message struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement"`
+	RoomID    uint64         `gorm:"not null;index"`
+	UserID    uint64         `gorm:"not null;column:sender_id;index"`
+	Content   string         `gorm:"type:text;not null"`
+	Timestamp time.Time      `gorm:"column:created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Sender User `gorm:"foreignKey:UserID;references:ID"`
+}
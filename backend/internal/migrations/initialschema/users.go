@@ -0,0 +1,124 @@
+package initialschema
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User kullanıcılar tablosu
+type User struct {
+	ID                      uint64 `gorm:"primaryKey;autoIncrement"`
+	Username                string `gorm:"unique;not null;size:100"`
+	Email                   string `gorm:"unique;not null;size:255"`
+	PasswordHash            string `gorm:"not null;size:255"`
+	FirstName               string `gorm:"size:100"`
+	LastName                string `gorm:"size:100"`
+	ProfilePictureURL       string `gorm:"size:255"`
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	DeletedAt               gorm.DeletedAt `gorm:"index"`
+	PasswordChangedAt       *time.Time
+	EmailVerifiedAt         *time.Time
+	EmailVerificationSentAt *time.Time
+	LockedUntil             *time.Time
+	UnlockTokenHash         string `gorm:"size:64;index"`
+	TOTPSecret              string `gorm:"size:64"`
+	TOTPEnabledAt           *time.Time
+	TOTPLastStep            int64  `gorm:"not null;default:0"`
+	Role                    string `gorm:"size:20;not null;default:'user'"`
+	SuspendedAt             *time.Time
+	SuspensionReason        string `gorm:"size:255"`
+
+	Interests         []Interest      `gorm:"many2many:user_interests;"`
+	CreatedRooms      []Room          `gorm:"foreignKey:CreatorUserID"`
+	RoomMemberships   []RoomMember    `gorm:"foreignKey:UserID"`
+	CreatedEvents     []Event         `gorm:"foreignKey:CreatorUserID"`
+	EventVotes        []EventVote     `gorm:"foreignKey:UserID"`
+	SentProposals     []EventProposal `gorm:"foreignKey:SuggesterUserID"`
+	ReceivedProposals []EventProposal `gorm:"foreignKey:RecipientUserID"`
+}
+
+// Interest ilgi alanları tablosu
+type Interest struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"unique;not null;size:100"`
+	Category  string `gorm:"size:100"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Users []User `gorm:"many2many:user_interests;"`
+}
+
+// UserInterest kullanıcı ve ilgi alanı ara tablosu
+type UserInterest struct {
+	UserID     uint64 `gorm:"primaryKey;not null"`
+	InterestID uint64 `gorm:"primaryKey;not null"`
+	CreatedAt  time.Time
+
+	User     User     `gorm:"foreignKey:UserID"`
+	Interest Interest `gorm:"foreignKey:InterestID"`
+}
+
+// Friendship arkadaşlıklar tablosu
+type Friendship struct {
+	ID          uint64 `gorm:"primarykey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	RequesterID uint64         `gorm:"not null"`
+	Requester   User           `gorm:"foreignKey:RequesterID"`
+	AddresseeID uint64         `gorm:"not null"`
+	Addressee   User           `gorm:"foreignKey:AddresseeID"`
+	Status      string         `gorm:"not null"`
+}
+
+// Notification bildirimler tablosu
+type Notification struct {
+	ID        uint64 `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	UserID    uint64         `gorm:"index;not null"`
+	User      User           `gorm:"foreignKey:UserID"`
+	Type      string         `gorm:"type:varchar(50);not null;default:'default'"`
+	Message   string         `gorm:"not null"`
+	IsRead    bool           `gorm:"default:false"`
+	RelatedID *uint64        `gorm:"index"`
+}
+
+// UserSuggestion kullanıcı önerileri tablosu
+type UserSuggestion struct {
+	gorm.Model
+	UserID         uint64 `gorm:"index;not null"`
+	User           User   `gorm:"foreignKey:UserID"`
+	SuggestionText string `gorm:"type:text;not null"`
+}
+
+// Report şikayetler tablosu
+type Report struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement"`
+	ReporterID     uint64 `gorm:"not null;index"`
+	TargetType     string `gorm:"size:20;not null;index:idx_report_target"`
+	TargetID       uint64 `gorm:"not null;index:idx_report_target"`
+	Reason         string `gorm:"size:500;not null"`
+	Status         string `gorm:"size:20;not null;default:'open';index"`
+	ResolvedByID   *uint64
+	ResolutionNote string `gorm:"size:500"`
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+
+	Reporter User `gorm:"foreignKey:ReporterID"`
+}
+
+// AdminAuditLog yönetici işlemleri kaydı tablosu
+type AdminAuditLog struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	AdminID    uint64    `gorm:"not null;index"`
+	Action     string    `gorm:"size:50;not null;index"`
+	TargetType string    `gorm:"size:20;not null"`
+	TargetID   uint64    `gorm:"not null"`
+	Detail     string    `gorm:"size:500"`
+	CreatedAt  time.Time `gorm:"index"`
+}
@@ -0,0 +1,159 @@
+// Package migrations uygulamanın veritabanı şemasını oluşturan sürümlü migration'ları içerir.
+// Şemadaki her değişiklik (yeni tablo, sütun, yeniden adlandırma, veri düzeltmesi) buraya yeni bir sürüm olarak eklenir;
+// uygulanmış bir migration sonradan değiştirilmez. Migration'lar internal/models'taki modelleri kullanmaz: modeller
+// değiştikçe eski migration'ların şemayı da değişirdi. Her migration ihtiyaç duyduğu tablo ve sütunları kendi
+// içinde tanımlanan dondurulmuş yapılarla (ilk şema için initialschema paketi) veya SQL ile belirtir.
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"event/backend/internal/config"
+	"event/backend/pkg/database"
+	"event/backend/pkg/migrate"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// All tanımlı tüm migration'ları döndürür
+func All() []migrate.Migration {
+	return []migrate.Migration{
+		initialSchema,
+		seedInterests,
+		eventRecurrence,
+		calendarFeeds,
+		eventICalUID,
+		eventCapacity,
+		attendanceRSVP,
+	}
+}
+
+// NewMigrator uygulamanın migration'ları için bir migrate.Migrator oluşturur
+func NewMigrator(db *gorm.DB, cfg *config.Config) (*migrate.Migrator, error) {
+	return migrate.New(db, All(), cfg.DBMigrationLockTimeout)
+}
+
+// Prepare sunucu açılırken şemanın güncel olduğunu garanti eder. DB_AUTO_MIGRATE açıksa bekleyen migration'lar
+// uygulanır; kapalıysa bekleyen migration varken sunucu açılmaz ve "migrate up" komutunun çalıştırılması beklenir.
+func Prepare(ctx context.Context, db *gorm.DB, cfg *config.Config) error {
+	migrator, err := NewMigrator(db, cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.DBAutoMigrate {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			log.Printf("[Migrations.Prepare] %d migration uygulandı", len(applied))
+		}
+		return nil
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d bekleyen migration var (ilki %d_%s); önce \"migrate up\" çalıştırılmalı",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// addColumns modelin verilen alanlarından tabloda henüz olmayanları ekler
+func addColumns(tx *gorm.DB, model schema.Tabler, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if err := tx.Migrator().AddColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIndexes modelde tanımlı indekslerden tabloda henüz olmayanları oluşturur; indeks adı veya alan adı verilebilir
+func createIndexes(tx *gorm.DB, model schema.Tabler, names ...string) error {
+	for _, name := range names {
+		if tx.Migrator().HasIndex(model, name) {
+			continue
+		}
+		if err := tx.Migrator().CreateIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropIndexes tabloda bulunan indeksleri siler; indeks adı veya alan adı verilebilir
+func dropIndexes(tx *gorm.DB, model schema.Tabler, names ...string) error {
+	for _, name := range names {
+		if !tx.Migrator().HasIndex(model, name) {
+			continue
+		}
+		if err := tx.Migrator().DropIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropColumns modelin verilen alanlarının sütunlarını siler. Sütunların indeksleri önceden silinmelidir.
+func dropColumns(tx *gorm.DB, model schema.Tabler, fields ...string) error {
+	return keepIndexes(tx, model, func() error {
+		for _, field := range fields {
+			if !tx.Migrator().HasColumn(model, field) {
+				continue
+			}
+			if err := tx.Migrator().DropColumn(model, field); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// alterColumns sütunların tipini, varsayılanını ve boş olabilirliğini modeldeki tanıma getirir
+func alterColumns(tx *gorm.DB, model schema.Tabler, fields ...string) error {
+	return keepIndexes(tx, model, func() error {
+		for _, field := range fields {
+			if err := tx.Migrator().AlterColumn(model, field); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// keepIndexes SQLite'ta fn sonrasında tablonun indekslerini yeniden oluşturur. SQLite sütun silmek ve değiştirmek
+// için tabloyu yeniden kurar ve bu sırada tablonun tüm indeksleri kaybolur; diğer veritabanlarında fn aynen çalışır.
+func keepIndexes(tx *gorm.DB, model schema.Tabler, fn func() error) error {
+	if tx.Dialector.Name() != database.DriverSQLite {
+		return fn()
+	}
+	var indexes []struct{ Name, SQL string }
+	err := tx.Raw("SELECT name, sql FROM sqlite_master WHERE type = ? AND tbl_name = ? AND sql IS NOT NULL",
+		"index", model.TableName()).Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if tx.Migrator().HasIndex(model, index.Name) {
+			continue
+		}
+		if err := tx.Exec(index.SQL).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
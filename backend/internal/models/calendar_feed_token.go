@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// CalendarFeedToken kullanıcının takvim aboneliği (iCalendar) adreslerinde kullanılan gizli token.
+// Takvim uygulamaları Authorization başlığı gönderemediğinden token adresin içinde yer alır.
+// Her kullanıcının en fazla bir tokeni olur; yenilendiğinde eski adresler geçersizleşir.
+// Token'ın kendisi saklanmaz; yalnızca SHA-256 özeti tutulur.
+type CalendarFeedToken struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     uint64     `gorm:"uniqueIndex;not null" json:"user_id"`
+	TokenHash  string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
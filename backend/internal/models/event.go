@@ -16,12 +16,23 @@ type Event struct {
 	RoomID         *uint64        `gorm:"index" json:"room_id,omitempty"`
 	IsPrivate      bool           `gorm:"default:false" json:"is_private"`
 	ImageURL       string         `gorm:"size:255" json:"image_url,omitempty"`
+	Capacity       *int           `json:"capacity,omitempty"` // En fazla katılımcı sayısı; boşsa sınırsız. Dolunca yeni katılımcılar bekleme listesine alınır
 	FinalStartTime *time.Time     `json:"final_start_time,omitempty"`
 	FinalEndTime   *time.Time     `json:"final_end_time,omitempty"`
+	TimeZone       string         `gorm:"size:64" json:"time_zone,omitempty"`                       // Tekrarların açıldığı IANA saat dilimi; boşsa UTC
+	RecurrenceRule string         `gorm:"size:255" json:"recurrence_rule,omitempty"`                // "RRULE:" öneki olmadan RFC 5545 kuralı; seri FinalStartTime'dan başlar
+	ExDates        string         `gorm:"type:text" json:"exdates,omitempty"`                       // İptal edilen tekrarlar: virgülle ayrılmış UTC RFC 3339 başlangıçlar
+	SeriesID       *uint64        `gorm:"index" json:"series_id,omitempty"`                         // Tek bir tekrarı değiştiren kayıtta ait olduğu seri
+	RecurrenceID   *time.Time     `json:"recurrence_id,omitempty"`                                  // Tek bir tekrarı değiştiren kayıtta tekrarın asıl başlangıcı
+	Sequence       int            `gorm:"not null;default:0" json:"sequence"`                       // iCalendar SEQUENCE; etkinlik her değiştiğinde artar
+	ICalUID        string         `gorm:"column:ical_uid;size:255;index" json:"ical_uid,omitempty"` // .ics dosyasından içe aktarılan etkinliğin özgün UID'si
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Occurrence açılmış tekrarlarda tekrarın asıl başlangıcıdır; veritabanında tutulmaz
+	Occurrence *time.Time `gorm:"-" json:"occurrence,omitempty"`
+
 	// İlişkiler
 	Creator     User              `gorm:"foreignKey:CreatorUserID" json:"creator,omitempty"`
 	Room        *Room             `gorm:"foreignKey:RoomID;references:ID" json:"room,omitempty"`
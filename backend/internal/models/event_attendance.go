@@ -10,28 +10,37 @@ import (
 type EventAttendanceStatusType string
 
 const (
-	AttendanceAttending EventAttendanceStatusType = "attending"
-	AttendanceCancelled EventAttendanceStatusType = "cancelled" // Örnek, ileride kullanılabilir
+	AttendanceGoing      EventAttendanceStatusType = "going"
+	AttendanceMaybe      EventAttendanceStatusType = "maybe" // Kapasiteden yer tutmaz
+	AttendanceNotGoing   EventAttendanceStatusType = "not_going"
+	AttendanceWaitlisted EventAttendanceStatusType = "waitlisted" // Kapasite dolu; yer açılınca WaitlistPosition sırasıyla going olur
 )
 
+// IsRSVP durumun kullanıcının yanıt olarak verebileceği durumlardan biri olup olmadığını döndürür.
+// waitlisted yanıt değildir; kapasite dolduğunda sistem tarafından atanır.
+func (s EventAttendanceStatusType) IsRSVP() bool {
+	switch s {
+	case AttendanceGoing, AttendanceMaybe, AttendanceNotGoing:
+		return true
+	}
+	return false
+}
+
 // EventAttendance bir kullanıcının bir etkinliğe katılımını temsil eder
 type EventAttendance struct {
-	ID        uint64                    `gorm:"primaryKey;autoIncrement" json:"id"`
-	EventID   uint64                    `gorm:"uniqueIndex:idx_event_user" json:"event_id"`
-	Event     Event                     `gorm:"foreignKey:EventID" json:"event"` // İlişkili etkinlik
-	UserID    uint64                    `gorm:"uniqueIndex:idx_event_user" json:"user_id"`
-	User      User                      `gorm:"foreignKey:UserID" json:"user"` // Katılan kullanıcı
-	Status    EventAttendanceStatusType `gorm:"type:varchar(20);default:'attending'" json:"status"`
-	JoinedAt  time.Time                 `json:"joined_at"` // Katılma zamanı
-	CreatedAt time.Time                 `json:"created_at"`
-	UpdatedAt time.Time                 `json:"updated_at"`
-	DeletedAt gorm.DeletedAt            `gorm:"index" json:"deleted_at,omitempty"`
+	ID         uint64                    `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID    uint64                    `gorm:"uniqueIndex:idx_event_user_occurrence" json:"event_id"`
+	Event      Event                     `gorm:"foreignKey:EventID" json:"event"` // İlişkili etkinlik
+	UserID     uint64                    `gorm:"uniqueIndex:idx_event_user_occurrence" json:"user_id"`
+	User       User                      `gorm:"foreignKey:UserID" json:"user"`                                                                 // Katılan kullanıcı
+	Occurrence string                    `gorm:"size:32;not null;default:'';uniqueIndex:idx_event_user_occurrence" json:"occurrence,omitempty"` // Tekrarın UTC RFC 3339 başlangıcı; boşsa etkinliğin (serinin) tamamı
+	Status     EventAttendanceStatusType `gorm:"type:varchar(20);default:'going'" json:"status"`
+	Guests     int                       `gorm:"not null;default:0" json:"guests"` // Kullanıcıyla gelecek ek kişi sayısı; going iken kapasiteden yer tutar
+	Note       string                    `gorm:"size:500" json:"note,omitempty"`   // Yanıtla birlikte bırakılan isteğe bağlı not
+	JoinedAt   time.Time                 `json:"joined_at"`                        // Katılma zamanı
+	// WaitlistPosition bekleme listesindeki sıra (1'den başlar); bekleme listesinde değilse 0
+	WaitlistPosition int            `gorm:"not null;default:0" json:"waitlist_position,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
-
-// EventAttendanceStatus katılım durumlarını tanımlar (bu model için doğrudan kullanılmayabilir ama genel bir bilgi)
-// type EventAttendanceStatus string
-
-// const (
-// 	AttendingEvent EventAttendanceStatus = "attending"
-// 	CancelledEvent EventAttendanceStatus = "cancelled"
-// )
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// LoginThrottle bir anahtar (hesap veya IP) için art arda başarısız giriş denemelerini tutar.
+// Key örnekleri: "account:42", "ip:203.0.113.7"
+type LoginThrottle struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key           string     `gorm:"column:throttle_key;uniqueIndex;not null;size:191" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	BlockedUntil  *time.Time `json:"blocked_until,omitempty"`
+}
+
+// AuthAuditEvent kimlik doğrulama denetim kaydı türü
+type AuthAuditEvent string
+
+const (
+	AuditLoginFailed     AuthAuditEvent = "login_failed"
+	AuditLoginThrottled  AuthAuditEvent = "login_throttled"
+	AuditAccountLocked   AuthAuditEvent = "account_locked"
+	AuditAccountUnlocked AuthAuditEvent = "account_unlocked"
+)
+
+// AuthAuditLog güvenlikle ilgili kimlik doğrulama olaylarının denetim kaydı
+type AuthAuditLog struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    *uint64        `gorm:"index" json:"user_id,omitempty"`
+	Email     string         `gorm:"size:255" json:"email"`
+	IPAddress string         `gorm:"size:45" json:"ip_address"`
+	Event     AuthAuditEvent `gorm:"size:50;not null;index" json:"event"`
+	Detail    string         `gorm:"size:255" json:"detail"`
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+}
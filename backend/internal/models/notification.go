@@ -14,6 +14,7 @@ const (
 	NotificationTypeEventInvitation NotificationType = "event_invitation"
 	NotificationTypeRoomInvitation  NotificationType = "room_invitation"
 	NotificationTypeSystemMessage   NotificationType = "system_message"
+	NotificationTypeWaitlistPromote NotificationType = "event_waitlist_promoted" // Bekleme listesinden katılımcılığa geçiş
 	NotificationTypeDefault         NotificationType = "default"
 )
 
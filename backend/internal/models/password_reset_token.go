@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PasswordResetToken şifre sıfırlama tokeni modeli.
+// Token'ın kendisi saklanmaz; yalnızca SHA-256 özeti tutulur.
+type PasswordResetToken struct {
+	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint64     `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// IsUsable token'ın henüz kullanılmamış ve süresinin dolmamış olduğunu kontrol eder
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// PersonalAccessToken betik ve entegrasyonların API'ye erişmesi için kullanıcının oluşturduğu uzun ömürlü token.
+// Token'ın kendisi saklanmaz; yalnızca SHA-256 özeti ve listede gösterilecek kısa öneki tutulur.
+// Scopes boşlukla ayrılmış kapsam listesidir (örn. "events:write rooms:read").
+type PersonalAccessToken struct {
+	ID          uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint64     `gorm:"not null;index" json:"user_id"`
+	Name        string     `gorm:"not null;size:100" json:"name"`
+	TokenPrefix string     `gorm:"not null;size:20" json:"token_prefix"`
+	TokenHash   string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	Scopes      string     `gorm:"not null;size:500" json:"-"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// ScopeList kapsamları dilim olarak döndürür
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// IsActive token'ın iptal edilmemiş ve süresinin dolmamış olduğunu döndürür
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RefreshToken veritabanında takip edilen yenileme tokeni modeli.
+// Aynı girişten türeyen token'lar aynı FamilyID'yi paylaşır; her yenilemede
+// eski kayıt RotatedAt ile işaretlenir ve aileye yeni bir kayıt eklenir.
+type RefreshToken struct {
+	ID          uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint64     `gorm:"not null;index" json:"user_id"`
+	FamilyID    string     `gorm:"not null;size:64;index" json:"family_id"`
+	JTI         string     `gorm:"column:jti;uniqueIndex;not null;size:64" json:"-"`
+	DeviceLabel string     `gorm:"size:100" json:"device_label"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	RotatedAt   *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
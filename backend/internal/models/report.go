@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// ReportTargetType şikayet edilen içeriğin türü
+type ReportTargetType string
+
+const (
+	ReportTargetUser    ReportTargetType = "user"
+	ReportTargetEvent   ReportTargetType = "event"
+	ReportTargetRoom    ReportTargetType = "room"
+	ReportTargetMessage ReportTargetType = "message"
+)
+
+// ReportStatus şikayetin moderasyon durumu
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// Report kullanıcıların yöneticilere bildirdiği uygunsuz içerik veya kullanıcı şikayeti
+type Report struct {
+	ID             uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReporterID     uint64           `gorm:"not null;index" json:"reporter_id"`
+	TargetType     ReportTargetType `gorm:"size:20;not null;index:idx_report_target" json:"target_type"`
+	TargetID       uint64           `gorm:"not null;index:idx_report_target" json:"target_id"`
+	Reason         string           `gorm:"size:500;not null" json:"reason"`
+	Status         ReportStatus     `gorm:"size:20;not null;default:'open';index" json:"status"`
+	ResolvedByID   *uint64          `json:"resolved_by_id,omitempty"`
+	ResolutionNote string           `gorm:"size:500" json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time       `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+
+	Reporter User `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+}
+
+// AdminAuditLog yöneticilerin yaptığı moderasyon işlemlerinin kaydı
+type AdminAuditLog struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID    uint64    `gorm:"not null;index" json:"admin_id"`
+	Action     string    `gorm:"size:50;not null;index" json:"action"`
+	TargetType string    `gorm:"size:20;not null" json:"target_type"`
+	TargetID   uint64    `gorm:"not null" json:"target_id"`
+	Detail     string    `gorm:"size:500" json:"detail,omitempty"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
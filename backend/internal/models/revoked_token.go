@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RevokedToken süresi dolmadan iptal edilmiş bir erişim tokenini (jti) temsil eder.
+// Kayıtlar token'ın kendi son kullanma tarihinden sonra silinebilir.
+type RevokedToken struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	JTI       string    `gorm:"column:jti;uniqueIndex;not null;size:64" json:"jti"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Session bir giriş ile başlayan ve yenileme tokeni ailesi boyunca süren oturum.
+// FamilyID yenileme token'larının FamilyID'si ve erişim token'larındaki sid claim'i ile aynıdır.
+// Kullanıcı aracısı, IP ve son görülme zamanı her girişte ve token yenilemede güncellenir.
+type Session struct {
+	ID          uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint64     `gorm:"not null;index" json:"user_id"`
+	FamilyID    string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	DeviceLabel string     `gorm:"size:100" json:"device_label"`
+	UserAgent   string     `gorm:"size:255" json:"user_agent"`
+	IPAddress   string     `gorm:"size:45" json:"ip_address"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastSeenAt  time.Time  `gorm:"index" json:"last_seen_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RecoveryCode iki adımlı doğrulama için tek kullanımlık kurtarma kodu.
+// Kodun kendisi saklanmaz; yalnızca SHA-256 özeti tutulur.
+type RecoveryCode struct {
+	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint64     `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null;size:64;index" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
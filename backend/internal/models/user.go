@@ -3,10 +3,17 @@ package models
 import (
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// UserRole platform genelindeki kullanıcı rolü. Oda ve etkinlik yetkilerinden bağımsızdır.
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
 // User kullanıcı modeli
 type User struct {
 	ID                uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -20,6 +27,33 @@ type User struct {
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Hesap güvenliği
+	// PasswordChangedAt bu tarihten önce üretilmiş yenileme token'larını geçersiz kılar
+	PasswordChangedAt *time.Time `json:"-"`
+	// EmailVerifiedAt kullanıcının e-posta adresini doğruladığı zaman; nil ise doğrulanmamış
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// EmailVerificationSentAt son doğrulama e-postasının gönderildiği zaman (tekrar gönderme sınırı için)
+	EmailVerificationSentAt *time.Time `json:"-"`
+	// LockedUntil art arda hatalı girişler nedeniyle hesabın kilitli kaldığı süre
+	LockedUntil *time.Time `json:"-"`
+	// UnlockTokenHash kilit açma e-postasındaki tek kullanımlık token'ın SHA-256 özeti
+	UnlockTokenHash string `gorm:"size:64;index" json:"-"`
+
+	// İki adımlı doğrulama (TOTP)
+	// TOTPSecret kurulum başladığında üretilir; TOTPEnabledAt doluysa giriş için kod istenir.
+	// Durum başka kullanıcılara yayınlanmaz; yalnızca kullanıcının kendisi dtos.CurrentUserDTO ile görür.
+	TOTPSecret    string     `gorm:"size:64" json:"-"`
+	TOTPEnabledAt *time.Time `json:"-"`
+	// TOTPLastStep son kabul edilen kodun zaman adımı; aynı kodun tekrar kullanılmasını engeller
+	TOTPLastStep int64 `gorm:"not null;default:0" json:"-"`
+
+	// Platform rolü ve moderasyon. Kullanıcı profilleriyle (creator, katılımcı vb.) yayınlanmaz;
+	// yalnızca yönetici endpoint'leri dtos.AdminUserDTO ile döndürür.
+	Role UserRole `gorm:"size:20;not null;default:'user'" json:"-"`
+	// SuspendedAt doluysa hesap askıya alınmıştır; giriş yapamaz, token'ları ve WebSocket bağlantıları reddedilir
+	SuspendedAt      *time.Time `json:"-"`
+	SuspensionReason string     `gorm:"size:255" json:"-"`
+
 	// İlişkiler
 	Interests         []Interest      `gorm:"many2many:user_interests;" json:"interests,omitempty"`
 	CreatedRooms      []Room          `gorm:"foreignKey:CreatorUserID" json:"created_rooms,omitempty"`
@@ -43,7 +77,27 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
-// Kullanıcının şifresini kontrol eder
-func (u *User) CheckPassword(password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
+// IsEmailVerified kullanıcının e-posta adresini doğrulayıp doğrulamadığını döndürür
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsLocked hesabın verilen anda kilitli olup olmadığını döndürür
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// IsAdmin kullanıcının platform yöneticisi olup olmadığını döndürür
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
+// IsSuspended hesabın askıya alınıp alınmadığını döndürür
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// IsTwoFactorEnabled kullanıcının iki adımlı doğrulamayı etkinleştirip etkinleştirmediğini döndürür
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
 }
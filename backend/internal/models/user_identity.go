@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// UserIdentity bir kullanıcının harici kimlik sağlayıcıdaki (Google, GitHub, OIDC) hesabıyla bağlantısı
+type UserIdentity struct {
+	ID          uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint64     `gorm:"not null;index" json:"user_id"`
+	Provider    string     `gorm:"not null;size:50;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject     string     `gorm:"not null;size:191;uniqueIndex:idx_identity_provider_subject" json:"-"`
+	Email       string     `gorm:"size:255" json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// OAuthState yetkilendirme akışı başlatıldığında saklanan tek kullanımlık durum bilgisi.
+// State değeri CSRF'e, CodeVerifier PKCE'ye, Nonce ise id_token tekrarına karşı korur.
+// LinkUserID doluysa akış yeni giriş değil, mevcut hesaba sağlayıcı bağlamak için başlatılmıştır.
+type OAuthState struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	StateHash    string    `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	Provider     string    `gorm:"not null;size:50" json:"provider"`
+	CodeVerifier string    `gorm:"not null;size:128" json:"-"`
+	Nonce        string    `gorm:"size:64" json:"-"`
+	LinkUserID   *uint64   `json:"link_user_id,omitempty"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
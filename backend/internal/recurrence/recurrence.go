@@ -0,0 +1,303 @@
+// Package recurrence tekrarlanan etkinlik serilerini tarih aralıklarında tekrarlarına açar.
+// Bir seri, RecurrenceRule alanı dolu olan etkinliktir; ilk tekrarı FinalStartTime'dır ve süresi
+// FinalEndTime - FinalStartTime kadardır. Tek bir tekrarı değiştiren kayıtlar (override) SeriesID ve
+// RecurrenceID ile seriye bağlanır; iptal edilen tekrarlar serinin ExDates alanında tutulur.
+// Tekrarlar, asıl başlangıç zamanlarının UTC RFC 3339 biçimiyle (Key) tanımlanır.
+package recurrence
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/pkg/rrule"
+)
+
+// DefaultDuration bitiş zamanı olmayan serilerde bir tekrarın süresi
+const DefaultDuration = 2 * time.Hour
+
+// IsSeries etkinliğin açılabilir bir tekrar serisi olup olmadığını döndürür
+func IsSeries(event *models.Event) bool {
+	return event.RecurrenceRule != "" && event.SeriesID == nil && event.FinalStartTime != nil
+}
+
+// Location etkinliğin saat dilimini döndürür; boş veya geçersizse UTC
+func Location(event *models.Event) *time.Location {
+	if event.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(event.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Key tekrarın anahtarını (asıl başlangıcın UTC RFC 3339 biçimi) döndürür.
+// Anahtarlar sabit uzunlukta olduğundan metin olarak sıralandığında zaman sırasını korur.
+func Key(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ParseKey RFC 3339 biçimindeki tekrar zamanını ayrıştırır
+func ParseKey(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.New("geçersiz tekrar zamanı (RFC 3339 biçiminde olmalı)")
+	}
+	return t.UTC(), nil
+}
+
+// ExDates serinin iptal edilmiş tekrarlarını döndürür
+func ExDates(event *models.Event) []time.Time {
+	var result []time.Time
+	for _, item := range strings.Split(event.ExDates, ",") {
+		if t, err := ParseKey(strings.TrimSpace(item)); err == nil {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// FormatExDates iptal edilen tekrarları sıralı ve tekil olarak ExDates biçiminde döndürür
+func FormatExDates(dates []time.Time) string {
+	seen := make(map[string]bool, len(dates))
+	keys := make([]string, 0, len(dates))
+	for _, t := range dates {
+		key := Key(t)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// Duration serideki bir tekrarın süresini döndürür
+func Duration(event *models.Event) time.Duration {
+	if event.FinalStartTime != nil && event.FinalEndTime != nil && event.FinalEndTime.After(*event.FinalStartTime) {
+		return event.FinalEndTime.Sub(*event.FinalStartTime)
+	}
+	return DefaultDuration
+}
+
+// Rule serinin tekrar kuralını ve saat dilimindeki ilk başlangıcını döndürür
+func Rule(series *models.Event) (*rrule.Rule, time.Time, error) {
+	if !IsSeries(series) {
+		return nil, time.Time{}, errors.New("etkinlik tekrarlanan bir seri değil")
+	}
+	rule, err := rrule.Parse(series.RecurrenceRule)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return rule, series.FinalStartTime.In(Location(series)), nil
+}
+
+// Occurrences serinin asıl başlangıcı [from, to) aralığında olan ve iptal edilmemiş tekrarlarını döndürür
+func Occurrences(series *models.Event, from, to time.Time) ([]time.Time, error) {
+	rule, dtstart, err := Rule(series)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make(map[string]bool)
+	for _, t := range ExDates(series) {
+		excluded[Key(t)] = true
+	}
+
+	var result []time.Time
+	for _, t := range rule.Between(dtstart, from, to) {
+		if !excluded[Key(t)] {
+			result = append(result, t.UTC())
+		}
+	}
+	return result, nil
+}
+
+// Contains t'nin serinin iptal edilmemiş bir tekrarının asıl başlangıcı olup olmadığını döndürür
+func Contains(series *models.Event, t time.Time) (bool, error) {
+	rule, dtstart, err := Rule(series)
+	if err != nil {
+		return false, err
+	}
+	for _, exdate := range ExDates(series) {
+		if exdate.Equal(t) {
+			return false, nil
+		}
+	}
+	return rule.Includes(dtstart, t), nil
+}
+
+// Instance serinin bir tekrarını tek bir etkinlik olarak döndürür. Kimlik serinin kimliğidir;
+// Occurrence alanı tekrarın asıl başlangıcını taşır. override verilirse başlık, açıklama, konum,
+// görsel ve zamanlar ondan alınır.
+func Instance(series *models.Event, occurrence time.Time, override *models.Event) models.Event {
+	instance := *series
+	occurrence = occurrence.UTC()
+	start := occurrence
+	end := occurrence.Add(Duration(series))
+	if override != nil {
+		instance.Title = override.Title
+		instance.Description = override.Description
+		instance.Location = override.Location
+		instance.ImageURL = override.ImageURL
+		if override.FinalStartTime != nil {
+			start = *override.FinalStartTime
+		}
+		if override.FinalEndTime != nil {
+			end = *override.FinalEndTime
+		}
+	}
+	instance.FinalStartTime = &start
+	instance.FinalEndTime = &end
+	instance.Occurrence = &occurrence
+	return instance
+}
+
+// Expand etkinlikleri [from, to) aralığında başlayan tekrarlarına açar ve başlangıca göre sıralar.
+// Seri olmayan etkinlikler nihai başlangıçları aralıktaysa, nihai zamanı belirlenmemişse zaman
+// seçeneklerinden biri aralıktaysa olduğu gibi eklenir. overrides serilerin tekil tekrar kayıtlarıdır;
+// başka bir zamana taşınmış bir tekrar yeni zamanı aralıktaysa listelenir.
+func Expand(events []models.Event, overrides []models.Event, from, to time.Time) ([]models.Event, error) {
+	bySeries := make(map[uint64][]*models.Event)
+	for i := range overrides {
+		if overrides[i].SeriesID != nil && overrides[i].RecurrenceID != nil {
+			bySeries[*overrides[i].SeriesID] = append(bySeries[*overrides[i].SeriesID], &overrides[i])
+		}
+	}
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	var result []models.Event
+	for i := range events {
+		event := &events[i]
+		if !IsSeries(event) {
+			if event.FinalStartTime != nil {
+				if inRange(*event.FinalStartTime) {
+					result = append(result, *event)
+				}
+				continue
+			}
+			for _, option := range event.TimeOptions {
+				if inRange(option.StartTime) {
+					result = append(result, *event)
+					break
+				}
+			}
+			continue
+		}
+
+		occurrences, err := Occurrences(event, from, to)
+		if err != nil {
+			return nil, err
+		}
+		overridden := make(map[string]bool)
+		for _, override := range bySeries[event.ID] {
+			overridden[Key(*override.RecurrenceID)] = true
+		}
+		for _, occurrence := range occurrences {
+			if !overridden[Key(occurrence)] {
+				result = append(result, Instance(event, occurrence, nil))
+			}
+		}
+
+		for _, override := range bySeries[event.ID] {
+			instance := Instance(event, *override.RecurrenceID, override)
+			if !inRange(*instance.FinalStartTime) {
+				continue
+			}
+			ok, err := Contains(event, *override.RecurrenceID)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				result = append(result, instance)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return startOf(&result[i], from).Before(startOf(&result[j], from))
+	})
+	return result, nil
+}
+
+// startOf sıralama için etkinliğin başlangıcını döndürür; nihai zamanı yoksa from'dan sonraki ilk zaman seçeneği
+func startOf(event *models.Event, from time.Time) time.Time {
+	if event.FinalStartTime != nil {
+		return *event.FinalStartTime
+	}
+	var earliest time.Time
+	for _, option := range event.TimeOptions {
+		if !option.StartTime.Before(from) && (earliest.IsZero() || option.StartTime.Before(earliest)) {
+			earliest = option.StartTime
+		}
+	}
+	return earliest
+}
+
+// Split seriyi at tekrarından ikiye böler. head, at'ten önceki tekrarlarla sınırlanmış eski serinin kuralıdır;
+// tail, at'ten itibaren kalan tekrarları newStart'tan başlayarak üreten yeni serinin kuralıdır.
+// COUNT kullanan kurallarda sayı iki seri arasında paylaştırılır; diğerlerinde eski seri UNTIL ile kesilir.
+// at serinin ilk tekrarı olmamalıdır.
+func Split(series *models.Event, at, newStart time.Time) (head, tail string, err error) {
+	rule, dtstart, err := Rule(series)
+	if err != nil {
+		return "", "", err
+	}
+	if !at.After(dtstart) {
+		return "", "", errors.New("seri ilk tekrarından bölünemez")
+	}
+
+	headRule, tailRule := rule.Clone(), rule.Clone()
+	if rule.Count > 0 {
+		n := rule.CountBefore(dtstart, at)
+		headRule.Count = n
+		tailRule.Count = rule.Count - n
+	} else {
+		headRule.SetUntil(at.Add(-time.Second))
+	}
+
+	// Başlangıç başka bir güne taşındıysa haftanın/ayın günü seçicileri de aynı miktarda kaydırılır
+	loc := dtstart.Location()
+	if err := tailRule.ShiftDays(dayDiff(at.In(loc), newStart.In(loc))); err != nil {
+		return "", "", err
+	}
+	return headRule.String(), tailRule.String(), nil
+}
+
+// Reanchor serinin ilk tekrarı newStart'a taşındığında kullanılacak kuralı döndürür
+func Reanchor(series *models.Event, newStart time.Time) (string, error) {
+	rule, dtstart, err := Rule(series)
+	if err != nil {
+		return "", err
+	}
+	loc := dtstart.Location()
+	rule = rule.Clone()
+	if err := rule.ShiftDays(dayDiff(dtstart, newStart.In(loc))); err != nil {
+		return "", err
+	}
+	return rule.String(), nil
+}
+
+// Shift at tekrarı newStart'a taşındığında t tekrarının yeni başlangıcını döndürür. Kaydırma yerel takvimde
+// gün ve duvar saati olarak yapılır; böylece yaz saati geçişinin öbür yanındaki tekrarlar da yeni kuralın
+// ürettiği zamanlarla eşleşir.
+func Shift(t, at, newStart time.Time, loc *time.Location) time.Time {
+	days := dayDiff(at.In(loc), newStart.In(loc))
+	y, m, d := t.In(loc).Date()
+	hour, min, sec := newStart.In(loc).Clock()
+	return time.Date(y, m, d+days, hour, min, sec, 0, loc).UTC()
+}
+
+// dayDiff iki zaman arasındaki takvim günü farkını döndürür
+func dayDiff(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	da := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	db := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
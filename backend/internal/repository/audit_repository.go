@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository yönetici işlem kayıtları ve kimlik doğrulama denetim kayıtları için arayüz
+type AuditRepository interface {
+	CreateAdminLog(entry *models.AdminAuditLog) error
+	ListAdminLogs(offset, limit int) ([]models.AdminAuditLog, int64, error)
+	CreateAuthLog(record *models.AuthAuditLog) error
+}
+
+// auditRepository AuditRepository arayüzünü uygular
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository yeni bir audit repository oluşturur
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// CreateAdminLog yönetici işlemini kaydeder
+func (r *auditRepository) CreateAdminLog(entry *models.AdminAuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// ListAdminLogs yönetici işlem kayıtlarını en yeniden eskiye sayfalı olarak ve toplam sayıyla getirir
+func (r *auditRepository) ListAdminLogs(offset, limit int) ([]models.AdminAuditLog, int64, error) {
+	var total int64
+	if err := r.db.Model(&models.AdminAuditLog{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AdminAuditLog
+	if err := r.db.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// CreateAuthLog kimlik doğrulama olayını denetim kaydına yazar
+func (r *auditRepository) CreateAuthLog(record *models.AuthAuditLog) error {
+	return r.db.Create(record).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CalendarFeedTokenRepository takvim aboneliği token'ları için arayüz
+type CalendarFeedTokenRepository interface {
+	FindByUser(userID uint64) (*models.CalendarFeedToken, error)
+	FindByHashWithUser(tokenHash string) (*models.CalendarFeedToken, error)
+	Create(token *models.CalendarFeedToken) error
+	DeleteByUser(userID uint64) error
+	TouchLastUsed(id uint64, at time.Time) error
+}
+
+// calendarFeedTokenRepository CalendarFeedTokenRepository arayüzünü uygular
+type calendarFeedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedTokenRepository yeni bir calendar feed token repository oluşturur
+func NewCalendarFeedTokenRepository(db *gorm.DB) CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{
+		db: db,
+	}
+}
+
+// FindByUser kullanıcının takvim tokenini getirir
+func (r *calendarFeedTokenRepository) FindByUser(userID uint64) (*models.CalendarFeedToken, error) {
+	var token models.CalendarFeedToken
+	result := r.db.Where("user_id = ?", userID).First(&token)
+	return &token, result.Error
+}
+
+// FindByHashWithUser token'ı özetine göre sahibiyle birlikte getirir
+func (r *calendarFeedTokenRepository) FindByHashWithUser(tokenHash string) (*models.CalendarFeedToken, error) {
+	var token models.CalendarFeedToken
+	result := r.db.Preload("User").Where("token_hash = ?", tokenHash).First(&token)
+	return &token, result.Error
+}
+
+// Create yeni bir token kaydeder
+func (r *calendarFeedTokenRepository) Create(token *models.CalendarFeedToken) error {
+	return r.db.Create(token).Error
+}
+
+// DeleteByUser kullanıcının takvim tokenini siler
+func (r *calendarFeedTokenRepository) DeleteByUser(userID uint64) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.CalendarFeedToken{}).Error
+}
+
+// TouchLastUsed token'ın son kullanım zamanını günceller
+func (r *calendarFeedTokenRepository) TouchLastUsed(id uint64, at time.Time) error {
+	return r.db.Model(&models.CalendarFeedToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
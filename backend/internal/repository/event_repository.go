@@ -2,20 +2,46 @@ package repository
 
 import (
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
+	"event/backend/internal/recurrence"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // EventRepository etkinlik veritabanı işlemleri için arayüz
 type EventRepository interface {
 	FindAll() ([]models.Event, error)
-	FindByID(id uint) (models.Event, error)
+	FindByID(id uint64) (*models.Event, error)
+	FindByIDForUpdate(id uint64) (*models.Event, error)
+	FindByIDWithDetails(id uint64) (*models.Event, error)
+	FindPage(offset, limit int) ([]models.Event, int64, error)
+	FindVisibleTo(userID uint64) ([]models.Event, error)
+	FindByCreator(creatorID uint64, includePrivate bool) ([]models.Event, error)
+	FindFeed(roomIDs []uint64, limit int) ([]models.Event, error)
+	FindCalendarForUser(userID uint64, deletedSince time.Time) ([]models.Event, error)
+	FindCalendarForRoom(roomID uint64, includePrivate bool, deletedSince time.Time) ([]models.Event, error)
+	FindByICalUIDs(creatorID uint64, uids []string) ([]models.Event, error)
 	Create(event *models.Event) error
 	Update(event *models.Event) error
-	Delete(id uint) error
-	GetUpcomingEvents(after time.Time) ([]models.Event, error)
+	UpdateFields(id uint64, updates map[string]interface{}) error
+	IncrementSequence(ids ...uint64) error
+	Delete(id uint64) error
+	GetUpcomingEvents(after, before time.Time) ([]models.Event, error)
+	CountByRooms(roomIDs []uint64) (map[uint64]int64, error)
+
+	FindOverrides(seriesIDs ...uint64) ([]models.Event, error)
+
+	CreateTimeOption(option *models.EventTimeOption) error
+	DeleteTimeOptions(eventID uint64) error
+	FindTimeOption(id uint64) (*models.EventTimeOption, error)
+	FindTimeOptions(eventID uint64) ([]models.EventTimeOption, error)
+	FindTopTimeOption(eventID uint64) (*models.EventTimeOption, error)
+	IncrementVotes(optionID uint64) error
+
+	HasVoted(optionID, userID uint64) (bool, error)
+	CreateVote(vote *models.EventVote) error
+	CountVotes(optionID uint64) (int64, error)
 }
 
 // eventRepository EventRepository arayüzünü uygular
@@ -24,9 +50,9 @@ type eventRepository struct {
 }
 
 // NewEventRepository yeni bir event repository oluşturur
-func NewEventRepository() EventRepository {
+func NewEventRepository(db *gorm.DB) EventRepository {
 	return &eventRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
 
@@ -38,10 +64,160 @@ func (r *eventRepository) FindAll() ([]models.Event, error) {
 }
 
 // FindByID ID'ye göre etkinlik getirir
-func (r *eventRepository) FindByID(id uint) (models.Event, error) {
+func (r *eventRepository) FindByID(id uint64) (*models.Event, error) {
 	var event models.Event
 	result := r.db.First(&event, id)
-	return event, result.Error
+	return &event, result.Error
+}
+
+// FindByIDForUpdate etkinliği satır kilidiyle getirir; işlem içinde kullanılmalıdır.
+// Kapasite kontrolleri aynı etkinliğe eşzamanlı katılımları bu kilitle sıraya sokar.
+func (r *eventRepository) FindByIDForUpdate(id uint64) (*models.Event, error) {
+	var event models.Event
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, id)
+	return &event, result.Error
+}
+
+// FindByIDWithDetails etkinliği oluşturanı, odası ve zaman seçenekleriyle birlikte getirir
+func (r *eventRepository) FindByIDWithDetails(id uint64) (*models.Event, error) {
+	var event models.Event
+	result := r.db.Preload("Creator").
+		Preload("Room").
+		Preload("TimeOptions").
+		First(&event, id)
+	return &event, result.Error
+}
+
+// FindPage etkinlikleri en yeniden eskiye sayfalı olarak ve toplam kayıt sayısıyla getirir.
+// Tekil tekrar değişiklikleri (override) bu ve diğer liste sorgularında ayrı etkinlik olarak sayılmaz.
+func (r *eventRepository) FindPage(offset, limit int) ([]models.Event, int64, error) {
+	var total int64
+	if err := r.db.Model(&models.Event{}).Where("series_id IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []models.Event
+	if err := r.db.Where("series_id IS NULL").
+		Preload("Creator").
+		Preload("Room").
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// FindVisibleTo kullanıcının görebileceği etkinlikleri getirir:
+// kendi etkinlikleri, arkadaşlarının herkese açık etkinlikleri ve üye olduğu odaların etkinlikleri
+func (r *eventRepository) FindVisibleTo(userID uint64) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Where("series_id IS NULL").Where(`
+		(creator_user_id = ?) OR
+		(is_private = false AND creator_user_id IN (
+			SELECT CASE
+				WHEN requester_id = ? THEN addressee_id
+				ELSE requester_id
+			END
+			FROM friendships
+			WHERE (requester_id = ? OR addressee_id = ?)
+			AND status = 'accepted'
+		)) OR
+		(room_id IN (
+			SELECT room_id
+			FROM room_members
+			WHERE user_id = ?
+		))
+	`, userID, userID, userID, userID, userID).
+		Preload("Creator").
+		Preload("Room").
+		Preload("TimeOptions").
+		Find(&events).Error
+	return events, err
+}
+
+// FindByCreator bir kullanıcının oluşturduğu etkinlikleri en yeniden eskiye getirir.
+// includePrivate false ise yalnızca herkese açık etkinlikler döner.
+func (r *eventRepository) FindByCreator(creatorID uint64, includePrivate bool) ([]models.Event, error) {
+	var events []models.Event
+	query := r.db.Where("creator_user_id = ? AND series_id IS NULL", creatorID)
+	if !includePrivate {
+		query = query.Where("is_private = ?", false)
+	}
+
+	err := query.Preload("Creator").
+		Preload("Room").
+		Preload("TimeOptions").
+		Order("created_at DESC").
+		Find(&events).Error
+	return events, err
+}
+
+// FindFeed akış için son etkinlikleri getirir: herkese açık etkinlikler ve roomIDs içindeki odaların etkinlikleri
+func (r *eventRepository) FindFeed(roomIDs []uint64, limit int) ([]models.Event, error) {
+	var events []models.Event
+	query := r.db.Preload("Creator").Preload("Room").Where("series_id IS NULL").Order("created_at desc").Limit(limit)
+	if len(roomIDs) > 0 {
+		query = query.Where("is_private = ? OR room_id IN ?", false, roomIDs)
+	} else {
+		query = query.Where("is_private = ?", false)
+	}
+	err := query.Find(&events).Error
+	return events, err
+}
+
+// FindCalendarForUser kullanıcının oluşturduğu, katılacağını veya belki katılacağını bildirdiği ya da bekleme
+// listesinde olduğu, zamanı kesinleşmiş etkinlikleri getirir.
+// deletedSince'ten sonra silinen etkinlikler de (DeletedAt dolu olarak) döner; takvimlerde iptal edildi olarak gösterilir.
+// Tekrarlanan serilerde yalnızca tek bir tekrara katılmış olmak da yeterlidir.
+func (r *eventRepository) FindCalendarForUser(userID uint64, deletedSince time.Time) ([]models.Event, error) {
+	attended := r.db.Model(&models.EventAttendance{}).
+		Select("event_id").
+		Where("user_id = ? AND status IN ?", userID, []models.EventAttendanceStatusType{
+			models.AttendanceGoing, models.AttendanceMaybe, models.AttendanceWaitlisted,
+		})
+
+	var events []models.Event
+	err := r.calendarQuery(deletedSince).
+		Where("creator_user_id = ? OR id IN (?)", userID, attended).
+		Find(&events).Error
+	return events, err
+}
+
+// FindCalendarForRoom odanın zamanı kesinleşmiş etkinliklerini getirir. includePrivate false ise özel etkinlikler
+// döndürülmez. deletedSince'ten sonra silinen etkinlikler de döner.
+func (r *eventRepository) FindCalendarForRoom(roomID uint64, includePrivate bool, deletedSince time.Time) ([]models.Event, error) {
+	query := r.calendarQuery(deletedSince).Where("room_id = ?", roomID)
+	if !includePrivate {
+		query = query.Where("is_private = ?", false)
+	}
+
+	var events []models.Event
+	err := query.Find(&events).Error
+	return events, err
+}
+
+// calendarQuery takvim dışa aktarımı sorgularının ortak kısmı: silinenler dahil, tekil tekrar kayıtları hariç
+func (r *eventRepository) calendarQuery(deletedSince time.Time) *gorm.DB {
+	return r.db.Unscoped().
+		Preload("Creator", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("deleted_at IS NULL OR deleted_at >= ?", deletedSince).
+		Where("series_id IS NULL AND final_start_time IS NOT NULL").
+		Order("final_start_time")
+}
+
+// FindByICalUIDs kullanıcının .ics dosyasından içe aktardığı ve UID'si uids içinde olan etkinliklerini getirir.
+// Tekil tekrar kayıtları dönmez.
+func (r *eventRepository) FindByICalUIDs(creatorID uint64, uids []string) ([]models.Event, error) {
+	var events []models.Event
+	if len(uids) == 0 {
+		return events, nil
+	}
+	err := r.db.Where("creator_user_id = ? AND series_id IS NULL AND ical_uid IN ?", creatorID, uids).
+		Order("id").
+		Find(&events).Error
+	return events, err
 }
 
 // Create yeni bir etkinlik oluşturur
@@ -54,17 +230,44 @@ func (r *eventRepository) Update(event *models.Event) error {
 	return r.db.Save(event).Error
 }
 
-// Delete bir etkinliği siler
-func (r *eventRepository) Delete(id uint) error {
+// UpdateFields etkinliğin yalnızca verilen alanlarını günceller
+func (r *eventRepository) UpdateFields(id uint64, updates map[string]interface{}) error {
+	return r.db.Model(&models.Event{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// IncrementSequence etkinliklerin iCalendar SEQUENCE sayacını veritabanında atomik olarak bir artırır
+func (r *eventRepository) IncrementSequence(ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Event{}).
+		Where("id IN ?", ids).
+		Update("sequence", gorm.Expr("sequence + ?", 1)).Error
+}
+
+// Delete bir etkinliği ve tekrarlanan bir seriyse tekil tekrar değişikliklerini siler.
+// Silinen etkinlikler takvim aboneliklerinde iptal olarak yayınlandığından SEQUENCE sayaçları da artırılır.
+func (r *eventRepository) Delete(id uint64) error {
+	if err := r.db.Model(&models.Event{}).
+		Where("id = ? OR series_id = ?", id, id).
+		Update("sequence", gorm.Expr("sequence + ?", 1)).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("series_id = ?", id).Delete(&models.Event{}).Error; err != nil {
+		return err
+	}
 	return r.db.Delete(&models.Event{}, id).Error
 }
 
-// GetUpcomingEvents verilen zamandan sonraki etkinlikleri getirir
-func (r *eventRepository) GetUpcomingEvents(after time.Time) ([]models.Event, error) {
+// GetUpcomingEvents [after, before) aralığında başlayan etkinlikleri başlangıca göre sıralı getirir.
+// Tekrarlanan seriler aralıktaki tekrarlarına açılır; her tekrar ayrı bir etkinlik olarak döner.
+func (r *eventRepository) GetUpcomingEvents(after, before time.Time) ([]models.Event, error) {
 	var events []models.Event
 
-	// Önce final zamanı belirlenmiş etkinlikleri getir
-	result := r.db.Where("final_start_time >= ?", after).
+	// Önce final zamanı belirlenmiş tekil etkinlikleri ve başlangıcı aralığın sonundan önce olan serileri getir
+	result := r.db.Where("series_id IS NULL").
+		Where("(recurrence_rule = '' AND final_start_time >= ? AND final_start_time < ?) OR "+
+			"(recurrence_rule <> '' AND final_start_time < ?)", after, before, before).
 		Preload("Creator").
 		Preload("Room").
 		Preload("TimeOptions").
@@ -77,7 +280,8 @@ func (r *eventRepository) GetUpcomingEvents(after time.Time) ([]models.Event, er
 	// Zaman seçenekleri olan ama final zamanı belirlenmemiş etkinlikleri de getir
 	var eventsWithOptions []models.Event
 	result = r.db.Joins("JOIN event_time_options ON events.id = event_time_options.event_id").
-		Where("events.final_start_time IS NULL AND event_time_options.start_time >= ?", after).
+		Where("events.final_start_time IS NULL AND events.series_id IS NULL").
+		Where("event_time_options.start_time >= ? AND event_time_options.start_time < ?", after, before).
 		Where("events.id NOT IN (?)", r.db.Table("events").
 			Where("final_start_time IS NOT NULL").
 			Select("id")).
@@ -91,8 +295,111 @@ func (r *eventRepository) GetUpcomingEvents(after time.Time) ([]models.Event, er
 		return nil, result.Error
 	}
 
-	// İki listeyi birleştir
+	// İki listeyi birleştir ve serileri tekrarlarına aç
 	events = append(events, eventsWithOptions...)
 
-	return events, nil
+	var seriesIDs []uint64
+	for i := range events {
+		if recurrence.IsSeries(&events[i]) {
+			seriesIDs = append(seriesIDs, events[i].ID)
+		}
+	}
+	overrides, err := r.FindOverrides(seriesIDs...)
+	if err != nil {
+		return nil, err
+	}
+	return recurrence.Expand(events, overrides, after, before)
+}
+
+// FindOverrides verilen serilerin tekil tekrar değişikliklerini getirir
+func (r *eventRepository) FindOverrides(seriesIDs ...uint64) ([]models.Event, error) {
+	var overrides []models.Event
+	if len(seriesIDs) == 0 {
+		return overrides, nil
+	}
+	result := r.db.Where("series_id IN ?", seriesIDs).Order("recurrence_id").Find(&overrides)
+	return overrides, result.Error
+}
+
+// CountByRooms verilen odalardaki etkinlik sayılarını oda ID'sine göre döndürür
+func (r *eventRepository) CountByRooms(roomIDs []uint64) (map[uint64]int64, error) {
+	counts := make(map[uint64]int64)
+	if len(roomIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		RoomID uint64
+		Count  int64
+	}
+	if err := r.db.Model(&models.Event{}).
+		Select("room_id, count(*) as count").
+		Where("room_id IN ? AND series_id IS NULL", roomIDs).
+		Group("room_id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.RoomID] = row.Count
+	}
+	return counts, nil
+}
+
+// CreateTimeOption etkinliğe yeni bir zaman seçeneği ekler
+func (r *eventRepository) CreateTimeOption(option *models.EventTimeOption) error {
+	return r.db.Create(option).Error
+}
+
+// DeleteTimeOptions etkinliğin tüm zaman seçeneklerini siler
+func (r *eventRepository) DeleteTimeOptions(eventID uint64) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&models.EventTimeOption{}).Error
+}
+
+// FindTimeOption ID'ye göre zaman seçeneği getirir
+func (r *eventRepository) FindTimeOption(id uint64) (*models.EventTimeOption, error) {
+	var option models.EventTimeOption
+	result := r.db.First(&option, id)
+	return &option, result.Error
+}
+
+// FindTimeOptions etkinliğin zaman seçeneklerini getirir
+func (r *eventRepository) FindTimeOptions(eventID uint64) ([]models.EventTimeOption, error) {
+	var options []models.EventTimeOption
+	result := r.db.Where("event_id = ?", eventID).Find(&options)
+	return options, result.Error
+}
+
+// FindTopTimeOption etkinliğin en çok oy alan zaman seçeneğini getirir
+func (r *eventRepository) FindTopTimeOption(eventID uint64) (*models.EventTimeOption, error) {
+	var option models.EventTimeOption
+	result := r.db.Where("event_id = ?", eventID).Order("votes_count DESC").First(&option)
+	return &option, result.Error
+}
+
+// IncrementVotes zaman seçeneğinin oy sayısını veritabanında atomik olarak bir artırır
+func (r *eventRepository) IncrementVotes(optionID uint64) error {
+	return r.db.Model(&models.EventTimeOption{}).
+		Where("id = ?", optionID).
+		Update("votes_count", gorm.Expr("votes_count + ?", 1)).Error
+}
+
+// HasVoted kullanıcının zaman seçeneğine oy verip vermediğini döndürür
+func (r *eventRepository) HasVoted(optionID, userID uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EventVote{}).
+		Where("event_time_option_id = ? AND user_id = ?", optionID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateVote yeni bir oy kaydeder
+func (r *eventRepository) CreateVote(vote *models.EventVote) error {
+	return r.db.Create(vote).Error
+}
+
+// CountVotes zaman seçeneğine verilen oyları sayar
+func (r *eventRepository) CountVotes(optionID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.EventVote{}).Where("event_time_option_id = ?", optionID).Count(&count).Error
+	return count, err
 }
@@ -1,21 +1,80 @@
 package repository
 
 import (
-	"event/backend/pkg/database"
+	"event/backend/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// FriendshipRepository arkadaşlık veritabanı işlemleri için arayüz
 type FriendshipRepository interface {
-	// Gerekli metodları buraya ekleyeceğiz
+	FindByID(id uint64) (*models.Friendship, error)
+	FindBetween(userID1, userID2 uint64) (*models.Friendship, error)
+	AreFriends(userID1, userID2 uint64) (bool, error)
+	FindPendingFor(addresseeID uint64) ([]models.Friendship, error)
+	Create(friendship *models.Friendship) error
+	SetStatus(id uint64, status models.FriendshipStatus) error
+	Delete(id uint64) error
 }
 
+// friendshipRepository FriendshipRepository arayüzünü uygular
 type friendshipRepository struct {
 	db *gorm.DB
 }
 
-func NewFriendshipRepository() FriendshipRepository {
+// NewFriendshipRepository yeni bir friendship repository oluşturur
+func NewFriendshipRepository(db *gorm.DB) FriendshipRepository {
 	return &friendshipRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
+
+// FindByID ID'ye göre arkadaşlık kaydı getirir
+func (r *friendshipRepository) FindByID(id uint64) (*models.Friendship, error) {
+	var friendship models.Friendship
+	result := r.db.First(&friendship, id)
+	return &friendship, result.Error
+}
+
+// FindBetween iki kullanıcı arasındaki arkadaşlık kaydını yönünden bağımsız olarak getirir
+func (r *friendshipRepository) FindBetween(userID1, userID2 uint64) (*models.Friendship, error) {
+	var friendship models.Friendship
+	result := r.db.Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
+		userID1, userID2, userID2, userID1).First(&friendship)
+	return &friendship, result.Error
+}
+
+// AreFriends iki kullanıcı arasında kabul edilmiş bir arkadaşlık olup olmadığını döndürür
+func (r *friendshipRepository) AreFriends(userID1, userID2 uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Friendship{}).
+		Where("((requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)) AND status = ?",
+			userID1, userID2, userID2, userID1, models.FriendshipAccepted).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindPendingFor kullanıcıya gelen bekleyen istekleri iki tarafın bilgileriyle getirir
+func (r *friendshipRepository) FindPendingFor(addresseeID uint64) ([]models.Friendship, error) {
+	var requests []models.Friendship
+	result := r.db.Where("addressee_id = ? AND status = ?", addresseeID, models.FriendshipPending).
+		Preload("Requester").
+		Preload("Addressee").
+		Find(&requests)
+	return requests, result.Error
+}
+
+// Create yeni bir arkadaşlık kaydı oluşturur
+func (r *friendshipRepository) Create(friendship *models.Friendship) error {
+	return r.db.Create(friendship).Error
+}
+
+// SetStatus arkadaşlık kaydının durumunu günceller
+func (r *friendshipRepository) SetStatus(id uint64, status models.FriendshipStatus) error {
+	return r.db.Model(&models.Friendship{}).Where("id = ?", id).Update("status", string(status)).Error
+}
+
+// Delete arkadaşlık kaydını siler
+func (r *friendshipRepository) Delete(id uint64) error {
+	return r.db.Delete(&models.Friendship{}, id).Error
+}
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
 
 	"gorm.io/gorm"
 )
@@ -18,9 +17,9 @@ type interestRepository struct {
 }
 
 // NewInterestRepository yeni bir interest repository oluşturur
-func NewInterestRepository() InterestRepository {
+func NewInterestRepository(db *gorm.DB) InterestRepository {
 	return &interestRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
 
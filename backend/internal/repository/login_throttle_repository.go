@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginThrottleRepository hesap ve IP bazlı giriş sayaçları için arayüz
+type LoginThrottleRepository interface {
+	Find(key string) (*models.LoginThrottle, error)
+	FindForUpdate(key string) (*models.LoginThrottle, error)
+	EnsureExists(key string, at time.Time) error
+	Save(throttle *models.LoginThrottle) error
+	Delete(key string) error
+}
+
+// loginThrottleRepository LoginThrottleRepository arayüzünü uygular
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginThrottleRepository yeni bir login throttle repository oluşturur
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{
+		db: db,
+	}
+}
+
+// Find anahtarın sayacını getirir
+func (r *loginThrottleRepository) Find(key string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	result := r.db.Where("throttle_key = ?", key).First(&throttle)
+	return &throttle, result.Error
+}
+
+// FindForUpdate anahtarın sayacını satır kilidiyle getirir; işlem içinde kullanılmalıdır
+func (r *loginThrottleRepository) FindForUpdate(key string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("throttle_key = ?", key).First(&throttle)
+	return &throttle, result.Error
+}
+
+// EnsureExists anahtar için kayıt yoksa oluşturur; eşzamanlı isteklerde benzersiz anahtar çakışması yok sayılır
+func (r *loginThrottleRepository) EnsureExists(key string, at time.Time) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.LoginThrottle{Key: key, LastFailureAt: at}).Error
+}
+
+// Save sayacı kaydeder
+func (r *loginThrottleRepository) Save(throttle *models.LoginThrottle) error {
+	return r.db.Save(throttle).Error
+}
+
+// Delete anahtarın sayacını siler
+func (r *loginThrottleRepository) Delete(key string) error {
+	return r.db.Where("throttle_key = ?", key).Delete(&models.LoginThrottle{}).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MessageRepository sohbet mesajları veritabanı işlemleri için arayüz
+type MessageRepository interface {
+	Create(message *models.Message) error
+	FindByID(id uint64) (*models.Message, error)
+	FindByRoomWithSenders(roomID uint64) ([]models.Message, error)
+	CountUnread(roomID, userID uint64, since *time.Time) (int64, error)
+	Delete(id uint64) error
+}
+
+// messageRepository MessageRepository arayüzünü uygular
+type messageRepository struct {
+	db *gorm.DB
+}
+
+// NewMessageRepository yeni bir message repository oluşturur
+func NewMessageRepository(db *gorm.DB) MessageRepository {
+	return &messageRepository{
+		db: db,
+	}
+}
+
+// Create yeni bir mesaj kaydeder
+func (r *messageRepository) Create(message *models.Message) error {
+	return r.db.Create(message).Error
+}
+
+// FindByID ID'ye göre mesaj getirir
+func (r *messageRepository) FindByID(id uint64) (*models.Message, error) {
+	var message models.Message
+	result := r.db.First(&message, id)
+	return &message, result.Error
+}
+
+// FindByRoomWithSenders odanın mesajlarını gönderen bilgileriyle eskiden yeniye getirir
+func (r *messageRepository) FindByRoomWithSenders(roomID uint64) ([]models.Message, error) {
+	var messages []models.Message
+	result := r.db.Preload("Sender").Where("room_id = ?", roomID).Order("created_at asc").Find(&messages)
+	return messages, result.Error
+}
+
+// CountUnread odada başkalarının gönderdiği mesajları sayar. since verilirse yalnızca
+// o zamandan sonra gönderilen mesajlar sayılır.
+func (r *messageRepository) CountUnread(roomID, userID uint64, since *time.Time) (int64, error) {
+	query := r.db.Model(&models.Message{}).Where("room_id = ? AND sender_id <> ?", roomID, userID)
+	if since != nil {
+		query = query.Where("created_at > ?", *since)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// Delete mesajı siler (soft delete)
+func (r *messageRepository) Delete(id uint64) error {
+	return r.db.Delete(&models.Message{}, id).Error
+}
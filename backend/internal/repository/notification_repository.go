@@ -1,21 +1,45 @@
 package repository
 
 import (
-	"event/backend/pkg/database"
+	"event/backend/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// NotificationRepository bildirim veritabanı işlemleri için arayüz
 type NotificationRepository interface {
-	// Gerekli metodları buraya ekleyeceğiz
+	Create(notification *models.Notification) error
+	FindByUser(userID uint64) ([]models.Notification, error)
+	MarkRead(userID uint64, ids []uint64) error
 }
 
+// notificationRepository NotificationRepository arayüzünü uygular
 type notificationRepository struct {
 	db *gorm.DB
 }
 
-func NewNotificationRepository() NotificationRepository {
+// NewNotificationRepository yeni bir notification repository oluşturur
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
 	return &notificationRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
+
+// Create yeni bir bildirim kaydeder
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// FindByUser kullanıcının bildirimlerini en yeniden eskiye getirir
+func (r *notificationRepository) FindByUser(userID uint64) ([]models.Notification, error) {
+	var notifications []models.Notification
+	result := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&notifications)
+	return notifications, result.Error
+}
+
+// MarkRead kullanıcının verilen bildirimlerini okundu olarak işaretler
+func (r *notificationRepository) MarkRead(userID uint64, ids []uint64) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Update("is_read", true).Error
+}
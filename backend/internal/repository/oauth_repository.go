@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OAuthRepository dış sağlayıcı kimlikleri ve yetkilendirme durumları için arayüz
+type OAuthRepository interface {
+	DeleteExpiredStates(now time.Time) error
+	CreateState(state *models.OAuthState) error
+	FindState(stateHash, provider string) (*models.OAuthState, error)
+	DeleteState(id uint64) (bool, error)
+
+	FindIdentities(userID uint64) ([]models.UserIdentity, error)
+	FindIdentity(provider, subject string) (*models.UserIdentity, error)
+	CreateIdentity(identity *models.UserIdentity) error
+	TouchIdentityLogin(id uint64, at time.Time) error
+}
+
+// oauthRepository OAuthRepository arayüzünü uygular
+type oauthRepository struct {
+	db *gorm.DB
+}
+
+// NewOAuthRepository yeni bir oauth repository oluşturur
+func NewOAuthRepository(db *gorm.DB) OAuthRepository {
+	return &oauthRepository{
+		db: db,
+	}
+}
+
+// DeleteExpiredStates süresi dolmuş yetkilendirme durumlarını siler
+func (r *oauthRepository) DeleteExpiredStates(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.OAuthState{}).Error
+}
+
+// CreateState yeni bir yetkilendirme durumu kaydeder
+func (r *oauthRepository) CreateState(state *models.OAuthState) error {
+	return r.db.Create(state).Error
+}
+
+// FindState durum özetine ve sağlayıcıya göre yetkilendirme durumunu getirir
+func (r *oauthRepository) FindState(stateHash, provider string) (*models.OAuthState, error) {
+	var state models.OAuthState
+	result := r.db.Where("state_hash = ? AND provider = ?", stateHash, provider).First(&state)
+	return &state, result.Error
+}
+
+// DeleteState yetkilendirme durumunu siler; kayıt başka bir istek tarafından zaten silinmişse false döner
+func (r *oauthRepository) DeleteState(id uint64) (bool, error) {
+	result := r.db.Delete(&models.OAuthState{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindIdentities kullanıcıya bağlı dış kimlikleri bağlanma sırasına göre getirir
+func (r *oauthRepository) FindIdentities(userID uint64) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	result := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities)
+	return identities, result.Error
+}
+
+// FindIdentity sağlayıcı ve konu (subject) değerine göre dış kimliği getirir
+func (r *oauthRepository) FindIdentity(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	result := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity)
+	return &identity, result.Error
+}
+
+// CreateIdentity yeni bir dış kimlik bağlantısı kaydeder
+func (r *oauthRepository) CreateIdentity(identity *models.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// TouchIdentityLogin dış kimliğin son giriş zamanını günceller
+func (r *oauthRepository) TouchIdentityLogin(id uint64, at time.Time) error {
+	return r.db.Model(&models.UserIdentity{}).Where("id = ?", id).Update("last_login_at", at).Error
+}
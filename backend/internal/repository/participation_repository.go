@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ParticipationRepository etkinlik katılımları, davetleri ve katılım istekleri için arayüz
+type ParticipationRepository interface {
+	FindAttendance(eventID, userID uint64) (*models.EventAttendance, error)
+	FindAttendancesWithUsers(eventID uint64, occurrences ...string) ([]models.EventAttendance, error)
+	FindOccurrenceAttendances(eventID uint64) ([]models.EventAttendance, error)
+	FindAttendancesForEvents(eventIDs []uint64) ([]models.EventAttendance, error)
+	CountByStatus(eventID uint64) ([]AttendanceTotal, error)
+	FindWaitlist(eventID uint64) ([]models.EventAttendance, error)
+	MaxWaitlistPosition(eventID uint64) (int, error)
+	UpsertAttendance(attendance *models.EventAttendance, updateColumns ...string) error
+	SetAttendanceStatus(eventID, userID uint64, status models.EventAttendanceStatusType) error
+	UpdateAttendanceFields(id uint64, updates map[string]interface{}) error
+	DeleteOccurrenceAttendances(eventID uint64, occurrences ...string) error
+
+	FindInvitationWithDetails(id uint64) (*models.EventInvitation, error)
+	FindInvitationFor(eventID, inviteeID uint64) (*models.EventInvitation, error)
+	FindInvitationsWithInvitees(eventID uint64) ([]models.EventInvitation, error)
+	FindInvitationsForEvents(eventIDs []uint64) ([]models.EventInvitation, error)
+	FindPendingInvitationsFor(inviteeID uint64) ([]models.EventInvitation, error)
+	CreateInvitation(invitation *models.EventInvitation) error
+	SetInvitationStatus(id uint64, status models.EventInvitationStatusType) error
+
+	FindRequest(eventID, userID uint64) (*models.EventParticipationRequest, error)
+	FindRequestWithEvent(id uint64) (*models.EventParticipationRequest, error)
+	CreateRequest(request *models.EventParticipationRequest) error
+	SetRequestStatus(id uint64, status models.EventParticipationRequestStatusType) error
+}
+
+// participationRepository ParticipationRepository arayüzünü uygular
+type participationRepository struct {
+	db *gorm.DB
+}
+
+// NewParticipationRepository yeni bir participation repository oluşturur
+func NewParticipationRepository(db *gorm.DB) ParticipationRepository {
+	return &participationRepository{
+		db: db,
+	}
+}
+
+// FindAttendance kullanıcının etkinliğin tamamına (tekrarlanan etkinlikte tüm seriye) ait katılım kaydını getirir
+func (r *participationRepository) FindAttendance(eventID, userID uint64) (*models.EventAttendance, error) {
+	var attendance models.EventAttendance
+	result := r.db.Where("event_id = ? AND user_id = ? AND occurrence = ?", eventID, userID, "").First(&attendance)
+	return &attendance, result.Error
+}
+
+// FindAttendancesWithUsers etkinliğin katılım kayıtlarını kullanıcı bilgileriyle getirir.
+// occurrences verilmezse yalnızca etkinliğin tamamına ait kayıtlar, verilirse o tekrarlara ait kayıtlar döner.
+func (r *participationRepository) FindAttendancesWithUsers(eventID uint64, occurrences ...string) ([]models.EventAttendance, error) {
+	if len(occurrences) == 0 {
+		occurrences = []string{""}
+	}
+	var attendances []models.EventAttendance
+	result := r.db.Preload("User").Where("event_id = ? AND occurrence IN ?", eventID, occurrences).Find(&attendances)
+	return attendances, result.Error
+}
+
+// FindOccurrenceAttendances tekrarlanan etkinliğin tekrar bazındaki tüm katılım kayıtlarını getirir
+func (r *participationRepository) FindOccurrenceAttendances(eventID uint64) ([]models.EventAttendance, error) {
+	var attendances []models.EventAttendance
+	result := r.db.Where("event_id = ? AND occurrence <> ?", eventID, "").Find(&attendances)
+	return attendances, result.Error
+}
+
+// FindAttendancesForEvents verilen etkinliklerin tekrar bazındakiler dahil tüm katılım kayıtlarını kullanıcı bilgileriyle getirir
+func (r *participationRepository) FindAttendancesForEvents(eventIDs []uint64) ([]models.EventAttendance, error) {
+	var attendances []models.EventAttendance
+	if len(eventIDs) == 0 {
+		return attendances, nil
+	}
+	result := r.db.Preload("User").Where("event_id IN ?", eventIDs).Order("id").Find(&attendances)
+	return attendances, result.Error
+}
+
+// AttendanceTotal bir katılım durumundaki kayıt sayısı ve bu kayıtların ek misafir toplamı
+type AttendanceTotal struct {
+	Status models.EventAttendanceStatusType
+	Count  int64
+	Guests int64
+}
+
+// CountByStatus etkinliğin tamamına ait kayıtları durumlarına göre sayar
+func (r *participationRepository) CountByStatus(eventID uint64) ([]AttendanceTotal, error) {
+	var totals []AttendanceTotal
+	err := r.db.Model(&models.EventAttendance{}).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(guests), 0) AS guests").
+		Where("event_id = ? AND occurrence = ?", eventID, "").
+		Group("status").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// FindWaitlist etkinliğin bekleme listesini kullanıcı bilgileriyle sırasına göre getirir
+func (r *participationRepository) FindWaitlist(eventID uint64) ([]models.EventAttendance, error) {
+	var attendances []models.EventAttendance
+	result := r.db.Preload("User").
+		Where("event_id = ? AND occurrence = ? AND status = ?", eventID, "", models.AttendanceWaitlisted).
+		Order("waitlist_position, joined_at, id").
+		Find(&attendances)
+	return attendances, result.Error
+}
+
+// MaxWaitlistPosition etkinliğin bekleme listesindeki en büyük sırayı döndürür; liste boşsa 0
+func (r *participationRepository) MaxWaitlistPosition(eventID uint64) (int, error) {
+	var position int
+	err := r.db.Model(&models.EventAttendance{}).
+		Where("event_id = ? AND occurrence = ? AND status = ?", eventID, "", models.AttendanceWaitlisted).
+		Select("COALESCE(MAX(waitlist_position), 0)").
+		Scan(&position).Error
+	return position, err
+}
+
+// UpsertAttendance katılım kaydı oluşturur; aynı etkinlik, kullanıcı ve tekrar için kayıt varsa
+// yalnızca updateColumns alanlarını günceller
+func (r *participationRepository) UpsertAttendance(attendance *models.EventAttendance, updateColumns ...string) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}, {Name: "occurrence"}},
+		DoUpdates: clause.AssignmentColumns(updateColumns),
+	}).Create(attendance).Error
+}
+
+// SetAttendanceStatus kullanıcının etkinliğin tamamına ait katılım durumunu günceller
+func (r *participationRepository) SetAttendanceStatus(eventID, userID uint64, status models.EventAttendanceStatusType) error {
+	return r.db.Model(&models.EventAttendance{}).
+		Where("event_id = ? AND user_id = ? AND occurrence = ?", eventID, userID, "").
+		Update("status", status).Error
+}
+
+// UpdateAttendanceFields katılım kaydının yalnızca verilen alanlarını günceller
+func (r *participationRepository) UpdateAttendanceFields(id uint64, updates map[string]interface{}) error {
+	return r.db.Model(&models.EventAttendance{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteOccurrenceAttendances etkinliğin verilen tekrarlarına ait katılım kayıtlarını kalıcı olarak siler.
+// Kayıtlar benzersiz indekste yer tuttuğundan yumuşak silme kullanılmaz.
+func (r *participationRepository) DeleteOccurrenceAttendances(eventID uint64, occurrences ...string) error {
+	if len(occurrences) == 0 {
+		return nil
+	}
+	return r.db.Unscoped().
+		Where("event_id = ? AND occurrence IN ? AND occurrence <> ?", eventID, occurrences, "").
+		Delete(&models.EventAttendance{}).Error
+}
+
+// FindInvitationWithDetails daveti etkinlik ve davet edilen kullanıcı bilgileriyle getirir
+func (r *participationRepository) FindInvitationWithDetails(id uint64) (*models.EventInvitation, error) {
+	var invitation models.EventInvitation
+	result := r.db.Preload("Event").Preload("Invitee").First(&invitation, id)
+	return &invitation, result.Error
+}
+
+// FindInvitationFor kullanıcının etkinliğe yapılmış davetini getirir
+func (r *participationRepository) FindInvitationFor(eventID, inviteeID uint64) (*models.EventInvitation, error) {
+	var invitation models.EventInvitation
+	result := r.db.Where("event_id = ? AND invitee_id = ?", eventID, inviteeID).First(&invitation)
+	return &invitation, result.Error
+}
+
+// FindInvitationsWithInvitees etkinliğin davetlerini davet edilen kullanıcı bilgileriyle getirir
+func (r *participationRepository) FindInvitationsWithInvitees(eventID uint64) ([]models.EventInvitation, error) {
+	var invitations []models.EventInvitation
+	result := r.db.Preload("Invitee").Where("event_id = ?", eventID).Find(&invitations)
+	return invitations, result.Error
+}
+
+// FindInvitationsForEvents verilen etkinliklerin davetlerini davet edilen kullanıcı bilgileriyle getirir
+func (r *participationRepository) FindInvitationsForEvents(eventIDs []uint64) ([]models.EventInvitation, error) {
+	var invitations []models.EventInvitation
+	if len(eventIDs) == 0 {
+		return invitations, nil
+	}
+	result := r.db.Preload("Invitee").Where("event_id IN ?", eventIDs).Order("id").Find(&invitations)
+	return invitations, result.Error
+}
+
+// FindPendingInvitationsFor kullanıcının bekleyen etkinlik davetlerini en yeniden eskiye getirir
+func (r *participationRepository) FindPendingInvitationsFor(inviteeID uint64) ([]models.EventInvitation, error) {
+	var invitations []models.EventInvitation
+	result := r.db.Preload("Event").Preload("Inviter").
+		Where("invitee_id = ? AND status = ?", inviteeID, models.InvitationPending).
+		Order("created_at desc").
+		Find(&invitations)
+	return invitations, result.Error
+}
+
+// CreateInvitation yeni bir etkinlik daveti oluşturur
+func (r *participationRepository) CreateInvitation(invitation *models.EventInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// SetInvitationStatus davetin durumunu günceller
+func (r *participationRepository) SetInvitationStatus(id uint64, status models.EventInvitationStatusType) error {
+	return r.db.Model(&models.EventInvitation{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// FindRequest kullanıcının etkinliğe katılım isteğini getirir
+func (r *participationRepository) FindRequest(eventID, userID uint64) (*models.EventParticipationRequest, error) {
+	var request models.EventParticipationRequest
+	result := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&request)
+	return &request, result.Error
+}
+
+// FindRequestWithEvent katılım isteğini etkinlik bilgisiyle getirir
+func (r *participationRepository) FindRequestWithEvent(id uint64) (*models.EventParticipationRequest, error) {
+	var request models.EventParticipationRequest
+	result := r.db.Preload("Event").First(&request, id)
+	return &request, result.Error
+}
+
+// CreateRequest yeni bir katılım isteği oluşturur
+func (r *participationRepository) CreateRequest(request *models.EventParticipationRequest) error {
+	return r.db.Create(request).Error
+}
+
+// SetRequestStatus katılım isteğinin durumunu günceller
+func (r *participationRepository) SetRequestStatus(id uint64, status models.EventParticipationRequestStatusType) error {
+	return r.db.Model(&models.EventParticipationRequest{}).Where("id = ?", id).Update("status", status).Error
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetRepository şifre sıfırlama token'ları için arayüz
+type PasswordResetRepository interface {
+	InvalidateForUser(userID uint64, at time.Time) error
+	Create(token *models.PasswordResetToken) error
+	FindByHash(tokenHash string) (*models.PasswordResetToken, error)
+	MarkUsed(id uint64, at time.Time) (bool, error)
+}
+
+// passwordResetRepository PasswordResetRepository arayüzünü uygular
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository yeni bir password reset repository oluşturur
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+// InvalidateForUser kullanıcının kullanılmamış tüm sıfırlama token'larını kullanılmış işaretler
+func (r *passwordResetRepository) InvalidateForUser(userID uint64, at time.Time) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", at).Error
+}
+
+// Create yeni bir sıfırlama tokeni kaydeder
+func (r *passwordResetRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByHash token'ı özetine göre getirir
+func (r *passwordResetRepository) FindByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	result := r.db.Where("token_hash = ?", tokenHash).First(&token)
+	return &token, result.Error
+}
+
+// MarkUsed token'ı koşullu olarak kullanılmış işaretler; eşzamanlı iki istekten yalnızca biri true alır
+func (r *passwordResetRepository) MarkUsed(id uint64, at time.Time) (bool, error) {
+	result := r.db.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
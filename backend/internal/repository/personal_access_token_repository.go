@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository kişisel erişim token'ları için arayüz
+type PersonalAccessTokenRepository interface {
+	CountActive(userID uint64, now time.Time) (int64, error)
+	Create(token *models.PersonalAccessToken) error
+	FindByUser(userID uint64) ([]models.PersonalAccessToken, error)
+	FindForUser(id, userID uint64) (*models.PersonalAccessToken, error)
+	FindByHashWithUser(tokenHash string) (*models.PersonalAccessToken, error)
+	Revoke(id uint64, at time.Time) error
+	TouchLastUsed(id uint64, at time.Time) error
+}
+
+// personalAccessTokenRepository PersonalAccessTokenRepository arayüzünü uygular
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository yeni bir personal access token repository oluşturur
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		db: db,
+	}
+}
+
+// CountActive kullanıcının iptal edilmemiş ve süresi dolmamış token'larını sayar
+func (r *personalAccessTokenRepository) CountActive(userID uint64, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// Create yeni bir token kaydeder
+func (r *personalAccessTokenRepository) Create(token *models.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByUser kullanıcının token'larını en yeniden eskiye getirir
+func (r *personalAccessTokenRepository) FindByUser(userID uint64) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	result := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens)
+	return tokens, result.Error
+}
+
+// FindForUser kullanıcıya ait token'ı getirir
+func (r *personalAccessTokenRepository) FindForUser(id, userID uint64) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&token)
+	return &token, result.Error
+}
+
+// FindByHashWithUser token'ı özetine göre sahibiyle birlikte getirir
+func (r *personalAccessTokenRepository) FindByHashWithUser(tokenHash string) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	result := r.db.Preload("User").Where("token_hash = ?", tokenHash).First(&token)
+	return &token, result.Error
+}
+
+// Revoke token'ı (henüz iptal edilmemişse) iptal eder
+func (r *personalAccessTokenRepository) Revoke(id uint64, at time.Time) error {
+	return r.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastUsed token'ın son kullanım zamanını günceller
+func (r *personalAccessTokenRepository) TouchLastUsed(id uint64, at time.Time) error {
+	return r.db.Model(&models.PersonalAccessToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
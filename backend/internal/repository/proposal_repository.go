@@ -1,21 +1,71 @@
 package repository
 
 import (
-	"event/backend/pkg/database"
+	"event/backend/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// ProposalRepository etkinlik önerileri ve karşı öneriler için arayüz
 type ProposalRepository interface {
-	// Gerekli metodları buraya ekleyeceğiz
+	Create(proposal *models.EventProposal) error
+	FindByID(id uint64) (*models.EventProposal, error)
+	FindIncoming(recipientID uint64) ([]models.EventProposal, error)
+	FindOutgoing(suggesterID uint64) ([]models.EventProposal, error)
+	SetStatus(id uint64, status models.ProposalStatus) error
+	CreateCounterProposal(counter *models.CounterProposal) error
 }
 
+// proposalRepository ProposalRepository arayüzünü uygular
 type proposalRepository struct {
 	db *gorm.DB
 }
 
-func NewProposalRepository() ProposalRepository {
+// NewProposalRepository yeni bir proposal repository oluşturur
+func NewProposalRepository(db *gorm.DB) ProposalRepository {
 	return &proposalRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
+
+// Create yeni bir öneri oluşturur
+func (r *proposalRepository) Create(proposal *models.EventProposal) error {
+	return r.db.Create(proposal).Error
+}
+
+// FindByID ID'ye göre öneri getirir
+func (r *proposalRepository) FindByID(id uint64) (*models.EventProposal, error) {
+	var proposal models.EventProposal
+	result := r.db.First(&proposal, id)
+	return &proposal, result.Error
+}
+
+// FindIncoming kullanıcıya gelen önerileri öneren ve etkinlik bilgileriyle getirir
+func (r *proposalRepository) FindIncoming(recipientID uint64) ([]models.EventProposal, error) {
+	var proposals []models.EventProposal
+	result := r.db.Where("recipient_user_id = ?", recipientID).
+		Preload("Suggester").
+		Preload("Event").
+		Find(&proposals)
+	return proposals, result.Error
+}
+
+// FindOutgoing kullanıcının gönderdiği önerileri alıcı ve etkinlik bilgileriyle getirir
+func (r *proposalRepository) FindOutgoing(suggesterID uint64) ([]models.EventProposal, error) {
+	var proposals []models.EventProposal
+	result := r.db.Where("suggester_user_id = ?", suggesterID).
+		Preload("Recipient").
+		Preload("Event").
+		Find(&proposals)
+	return proposals, result.Error
+}
+
+// SetStatus önerinin durumunu günceller
+func (r *proposalRepository) SetStatus(id uint64, status models.ProposalStatus) error {
+	return r.db.Model(&models.EventProposal{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// CreateCounterProposal bir öneriye karşı öneri kaydeder
+func (r *proposalRepository) CreateCounterProposal(counter *models.CounterProposal) error {
+	return r.db.Create(counter).Error
+}
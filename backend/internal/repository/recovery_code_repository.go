@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository iki adımlı doğrulama kurtarma kodları için arayüz
+type RecoveryCodeRepository interface {
+	Replace(userID uint64, hashes []string, at time.Time) error
+	DeleteAll(userID uint64) error
+	Use(userID uint64, codeHash string, at time.Time) (bool, error)
+}
+
+// recoveryCodeRepository RecoveryCodeRepository arayüzünü uygular
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository yeni bir recovery code repository oluşturur
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Replace kullanıcının eski kurtarma kodlarını silip verilen özetlerle yenilerini kaydeder.
+// İki adım atomik olmalıysa UnitOfWork içinde çağrılmalıdır.
+func (r *recoveryCodeRepository) Replace(userID uint64, hashes []string, at time.Time) error {
+	if err := r.DeleteAll(userID); err != nil {
+		return err
+	}
+	records := make([]models.RecoveryCode, 0, len(hashes))
+	for _, h := range hashes {
+		records = append(records, models.RecoveryCode{UserID: userID, CodeHash: h, CreatedAt: at})
+	}
+	return r.db.Create(&records).Error
+}
+
+// DeleteAll kullanıcının tüm kurtarma kodlarını siler
+func (r *recoveryCodeRepository) DeleteAll(userID uint64) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
+
+// Use kullanılmamış kurtarma kodunu koşullu olarak kullanılmış işaretler; kod geçersizse false döner
+func (r *recoveryCodeRepository) Use(userID uint64, codeHash string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
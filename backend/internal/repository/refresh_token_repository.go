@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefreshTokenRepository yenileme token'ları için arayüz
+type RefreshTokenRepository interface {
+	Create(token *models.RefreshToken) error
+	FindByJTIForUpdate(jti string) (*models.RefreshToken, error)
+	FindByJTIForUser(jti string, userID uint64) (*models.RefreshToken, error)
+	MarkRotated(id uint64, at time.Time) (bool, error)
+	RevokeFamily(familyID string, at time.Time) error
+	RevokeAllForUser(userID uint64, at time.Time) error
+}
+
+// refreshTokenRepository RefreshTokenRepository arayüzünü uygular
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository yeni bir refresh token repository oluşturur
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+// Create yeni bir yenileme tokeni kaydeder
+func (r *refreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByJTIForUpdate token'ı satır kilidiyle getirir; işlem içinde kullanılmalıdır
+func (r *refreshTokenRepository) FindByJTIForUpdate(jti string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("jti = ?", jti).First(&token)
+	return &token, result.Error
+}
+
+// FindByJTIForUser kullanıcıya ait token'ı getirir
+func (r *refreshTokenRepository) FindByJTIForUser(jti string, userID uint64) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	result := r.db.Where("jti = ? AND user_id = ?", jti, userID).First(&token)
+	return &token, result.Error
+}
+
+// MarkRotated token'ı koşullu olarak döndürülmüş işaretler; token zaten döndürülmüşse false döner
+func (r *refreshTokenRepository) MarkRotated(id uint64, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL", id).
+		Update("rotated_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeFamily bir token ailesindeki tüm aktif token'ları iptal eder
+func (r *refreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+// RevokeAllForUser kullanıcının tüm aktif yenileme token'larını iptal eder
+func (r *refreshTokenRepository) RevokeAllForUser(userID uint64, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
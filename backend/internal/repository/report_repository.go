@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepository şikayet veritabanı işlemleri için arayüz
+type ReportRepository interface {
+	TargetExists(targetType models.ReportTargetType, targetID uint64) (bool, error)
+	FindOpen(reporterID uint64, targetType models.ReportTargetType, targetID uint64) (*models.Report, error)
+	FindByID(id uint64) (*models.Report, error)
+	List(status models.ReportStatus, offset, limit int) ([]models.Report, int64, error)
+	Create(report *models.Report) error
+	Resolve(id, adminID uint64, status models.ReportStatus, note string, at time.Time) (bool, error)
+	CloseForTarget(targetType models.ReportTargetType, targetID, adminID uint64, note string, at time.Time) error
+}
+
+// reportRepository ReportRepository arayüzünü uygular
+type reportRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRepository yeni bir report repository oluşturur
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{
+		db: db,
+	}
+}
+
+// TargetExists şikayet edilen içeriğin var olup olmadığını (silinmemiş olduğunu) döndürür
+func (r *reportRepository) TargetExists(targetType models.ReportTargetType, targetID uint64) (bool, error) {
+	var model interface{}
+	switch targetType {
+	case models.ReportTargetUser:
+		model = &models.User{}
+	case models.ReportTargetEvent:
+		model = &models.Event{}
+	case models.ReportTargetRoom:
+		model = &models.Room{}
+	case models.ReportTargetMessage:
+		model = &models.Message{}
+	default:
+		return false, errors.New("geçersiz şikayet türü (user, event, room veya message olmalı)")
+	}
+
+	var count int64
+	err := r.db.Model(model).Where("id = ?", targetID).Count(&count).Error
+	return count > 0, err
+}
+
+// FindOpen kullanıcının aynı içerik için açık şikayetini getirir
+func (r *reportRepository) FindOpen(reporterID uint64, targetType models.ReportTargetType, targetID uint64) (*models.Report, error) {
+	var report models.Report
+	result := r.db.Where("reporter_id = ? AND target_type = ? AND target_id = ? AND status = ?",
+		reporterID, targetType, targetID, models.ReportStatusOpen).First(&report)
+	return &report, result.Error
+}
+
+// FindByID ID'ye göre şikayet getirir
+func (r *reportRepository) FindByID(id uint64) (*models.Report, error) {
+	var report models.Report
+	result := r.db.First(&report, id)
+	return &report, result.Error
+}
+
+// List şikayetleri şikayet eden bilgisiyle en yeniden eskiye sayfalı olarak ve toplam sayıyla getirir.
+// status boşsa tüm şikayetler döner.
+func (r *reportRepository) List(status models.ReportStatus, offset, limit int) ([]models.Report, int64, error) {
+	query := r.db.Model(&models.Report{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reports []models.Report
+	if err := query.Preload("Reporter").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// Create yeni bir şikayet kaydeder
+func (r *reportRepository) Create(report *models.Report) error {
+	return r.db.Create(report).Error
+}
+
+// Resolve açık şikayeti koşullu olarak sonuçlandırır. Şikayet bu arada kapatıldıysa false döner.
+func (r *reportRepository) Resolve(id, adminID uint64, status models.ReportStatus, note string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Report{}).
+		Where("id = ? AND status = ?", id, models.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"resolved_by_id":  adminID,
+			"resolution_note": note,
+			"resolved_at":     at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CloseForTarget içerik hakkındaki tüm açık şikayetleri çözüldü olarak işaretler
+func (r *reportRepository) CloseForTarget(targetType models.ReportTargetType, targetID, adminID uint64, note string, at time.Time) error {
+	return r.db.Model(&models.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          models.ReportStatusResolved,
+			"resolved_by_id":  adminID,
+			"resolution_note": note,
+			"resolved_at":     at,
+		}).Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// ErrNotFound aranan kayıt bulunamadığında repository'lerin döndürdüğü hatadır.
+// Servisler errors.Is(err, repository.ErrNotFound) ile kontrol eder; GORM'a doğrudan bağımlı olmazlar.
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// Repositories uygulamanın tüm repository'lerini bir arada tutar.
+// UnitOfWork.WithTx aynı yapıyı tek bir veritabanı işlemine bağlı olarak kurar.
+type Repositories struct {
+	Users                UserRepository
+	Interests            InterestRepository
+	Events               EventRepository
+	Participation        ParticipationRepository
+	Rooms                RoomRepository
+	Messages             MessageRepository
+	Friendships          FriendshipRepository
+	Notifications        NotificationRepository
+	Proposals            ProposalRepository
+	Reports              ReportRepository
+	Audit                AuditRepository
+	Sessions             SessionRepository
+	RefreshTokens        RefreshTokenRepository
+	RecoveryCodes        RecoveryCodeRepository
+	LoginThrottles       LoginThrottleRepository
+	PersonalAccessTokens PersonalAccessTokenRepository
+	CalendarFeedTokens   CalendarFeedTokenRepository
+	OAuth                OAuthRepository
+	PasswordResets       PasswordResetRepository
+}
+
+// NewRepositories verilen bağlantı (veya işlem) ile tüm repository'leri oluşturur
+func NewRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Users:                NewUserRepository(db),
+		Interests:            NewInterestRepository(db),
+		Events:               NewEventRepository(db),
+		Participation:        NewParticipationRepository(db),
+		Rooms:                NewRoomRepository(db),
+		Messages:             NewMessageRepository(db),
+		Friendships:          NewFriendshipRepository(db),
+		Notifications:        NewNotificationRepository(db),
+		Proposals:            NewProposalRepository(db),
+		Reports:              NewReportRepository(db),
+		Audit:                NewAuditRepository(db),
+		Sessions:             NewSessionRepository(db),
+		RefreshTokens:        NewRefreshTokenRepository(db),
+		RecoveryCodes:        NewRecoveryCodeRepository(db),
+		LoginThrottles:       NewLoginThrottleRepository(db),
+		PersonalAccessTokens: NewPersonalAccessTokenRepository(db),
+		CalendarFeedTokens:   NewCalendarFeedTokenRepository(db),
+		OAuth:                NewOAuthRepository(db),
+		PasswordResets:       NewPasswordResetRepository(db),
+	}
+}
+
+// UnitOfWork birden fazla adımdan oluşan işlemlerin tek bir veritabanı işleminde (transaction)
+// commit edilmesini sağlar.
+type UnitOfWork interface {
+	// WithTx fn'i yeni bir işlem içinde çalıştırır. fn nil dönerse işlem commit edilir,
+	// hata dönerse (veya panic olursa) geri alınır ve hata aynen döndürülür.
+	// fn içinde yalnızca parametre olarak verilen repos kullanılmalıdır; dışarıdaki repository'ler
+	// işlemin parçası değildir.
+	WithTx(fn func(repos Repositories) error) error
+}
+
+// unitOfWork UnitOfWork arayüzünü GORM işlemleriyle uygular
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork yeni bir unit of work oluşturur
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+// WithTx fn'i işleme bağlı repository'lerle çalıştırır
+func (u *unitOfWork) WithTx(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
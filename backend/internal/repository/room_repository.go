@@ -1,15 +1,72 @@
 package repository
 
 import (
+	"database/sql"
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-// RoomRepository oda veritabanı işlemleri için arayüz
+// RoomRepository oda, oda üyeliği ve oda daveti veritabanı işlemleri için arayüz
 type RoomRepository interface {
 	GetUserRoomMemberships(userID uint64) ([]models.RoomMember, error)
+
+	Create(room *models.Room) error
+	FindByID(id uint64) (*models.Room, error)
+	FindByIDWithMembers(id uint64) (*models.Room, error)
+	FindByIDWithMemberUsers(id uint64) (*models.Room, error)
+	FindPrivateByName(name string) (*models.Room, error)
+	FindPublic() ([]models.Room, error)
+	FindByFilter(filter string, userID uint64) ([]models.Room, error)
+	FindWithActiveMembers(ids []uint64) ([]models.Room, error)
+	FindConversations(userID uint64) ([]ConversationRow, error)
+	UpdateFields(id uint64, updates map[string]interface{}) error
+	Delete(id uint64) error
+
+	AddMember(member *models.RoomMember) error
+	SaveMember(member *models.RoomMember) error
+	FindMember(roomID, userID uint64) (*models.RoomMember, error)
+	FindActiveMember(roomID, userID uint64) (*models.RoomMember, error)
+	FindActiveMembersWithUsers(roomID uint64) ([]models.RoomMember, error)
+	IsActiveMember(roomID, userID uint64) (bool, error)
+	IsActiveAdmin(roomID, userID uint64) (bool, error)
+	CountActiveMembers(roomID uint64) (int64, error)
+	CountActiveAdmins(roomID uint64) (int64, error)
+	ActiveMemberCounts(roomIDs []uint64) (map[uint64]int64, error)
+	ActiveRoomIDs(userID uint64) ([]uint64, error)
+	FilterActiveMemberRooms(userID uint64, roomIDs []uint64) ([]uint64, error)
+	MemberRoomIDs(userID uint64) ([]uint64, error)
+	ShareActiveRoom(userID1, userID2 uint64) (bool, error)
+	FindReadStatuses(userID uint64) ([]RoomReadStatus, error)
+	UpdateLastRead(roomID, userID uint64, at time.Time) error
+
+	FindInvitation(id uint64) (*models.RoomInvitation, error)
+	FindPendingInvitation(roomID, inviteeID uint64) (*models.RoomInvitation, error)
+	FindPendingInvitationsFor(inviteeID uint64) ([]models.RoomInvitation, error)
+	CreateInvitation(invitation *models.RoomInvitation) error
+	SetInvitationStatus(id uint64, status models.RoomInvitationStatusType) error
+}
+
+// ConversationRow kullanıcının sohbet listesindeki bir odanın son mesaj ve DM karşı tarafı bilgileri
+type ConversationRow struct {
+	ID                         uint64
+	Name                       string
+	IsDM                       bool
+	OtherUserID                sql.NullInt64
+	OtherUserFirstName         sql.NullString
+	OtherUserLastName          sql.NullString
+	OtherUserAvatarURL         sql.NullString
+	LastMessageContent         sql.NullString
+	LastMessageTimestamp       sql.NullTime
+	LastMessageSenderFirstName sql.NullString
+	LastMessageSenderLastName  sql.NullString
+}
+
+// RoomReadStatus kullanıcının bir odadaki son okuma zamanı
+type RoomReadStatus struct {
+	RoomID     uint64
+	LastReadAt *time.Time
 }
 
 // roomRepository RoomRepository arayüzünü uygular
@@ -18,9 +75,9 @@ type roomRepository struct {
 }
 
 // NewRoomRepository yeni bir room repository oluşturur
-func NewRoomRepository() RoomRepository {
+func NewRoomRepository(db *gorm.DB) RoomRepository {
 	return &roomRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
 
@@ -33,3 +90,318 @@ func (r *roomRepository) GetUserRoomMemberships(userID uint64) ([]models.RoomMem
 		Find(&memberships)
 	return memberships, result.Error
 }
+
+// Create yeni bir oda oluşturur
+func (r *roomRepository) Create(room *models.Room) error {
+	return r.db.Create(room).Error
+}
+
+// FindByID ID'ye göre oda getirir
+func (r *roomRepository) FindByID(id uint64) (*models.Room, error) {
+	var room models.Room
+	result := r.db.First(&room, id)
+	return &room, result.Error
+}
+
+// FindByIDWithMembers odayı üyelik kayıtları ve kurucusuyla getirir
+func (r *roomRepository) FindByIDWithMembers(id uint64) (*models.Room, error) {
+	var room models.Room
+	result := r.db.Preload("Members").Preload("Creator").First(&room, id)
+	return &room, result.Error
+}
+
+// FindByIDWithMemberUsers odayı üyelerin kullanıcı bilgileri ve kurucusuyla getirir
+func (r *roomRepository) FindByIDWithMemberUsers(id uint64) (*models.Room, error) {
+	var room models.Room
+	result := r.db.Preload("Members.User").Preload("Creator").First(&room, id)
+	return &room, result.Error
+}
+
+// FindPrivateByName verilen adla kayıtlı özel odayı getirir (örn. DM odaları)
+func (r *roomRepository) FindPrivateByName(name string) (*models.Room, error) {
+	var room models.Room
+	result := r.db.Where("name = ? AND is_public = ?", name, false).First(&room)
+	return &room, result.Error
+}
+
+// FindPublic herkese açık odaları kurucularıyla en yeniden eskiye getirir
+func (r *roomRepository) FindPublic() ([]models.Room, error) {
+	var rooms []models.Room
+	result := r.db.Preload("Creator").Where("is_public = ?", true).Order("created_at DESC").Find(&rooms)
+	return rooms, result.Error
+}
+
+// FindByFilter odaları filtreye göre getirir: public, private (üye olunan özel odalar),
+// mine (kurulan odalar), member (başkasının kurduğu ve üye olunan odalar) veya all
+func (r *roomRepository) FindByFilter(filter string, userID uint64) ([]models.Room, error) {
+	var rooms []models.Room
+	query := r.db.Preload("Creator")
+
+	switch filter {
+	case "public":
+		query = query.Where("is_public = ?", true)
+	case "private":
+		query = query.Joins("JOIN room_members ON room_members.room_id = rooms.id").
+			Where("rooms.is_public = ? AND room_members.user_id = ? AND room_members.is_active = ?", false, userID, true)
+	case "mine":
+		query = query.Where("creator_user_id = ?", userID)
+	case "member":
+		query = query.Joins("JOIN room_members ON room_members.room_id = rooms.id").
+			Where("room_members.user_id = ? AND rooms.creator_user_id != ? AND room_members.is_active = ?", userID, userID, true)
+	case "all":
+		fallthrough
+	default:
+		query = query.Joins("LEFT JOIN room_members ON room_members.room_id = rooms.id AND room_members.user_id = ? AND room_members.is_active = ?", userID, true).
+			Where("rooms.is_public = ? OR room_members.user_id = ?", true, userID)
+	}
+
+	err := query.Distinct().Order("created_at DESC").Find(&rooms).Error
+	return rooms, err
+}
+
+// FindWithActiveMembers odaları kurucuları ve aktif üyelerinin kullanıcı bilgileriyle getirir
+func (r *roomRepository) FindWithActiveMembers(ids []uint64) ([]models.Room, error) {
+	var rooms []models.Room
+	result := r.db.Preload("Creator").
+		Preload("Members.User", "is_active = ?", true).
+		Where("id IN ?", ids).
+		Order("updated_at DESC").
+		Find(&rooms)
+	return rooms, result.Error
+}
+
+// FindConversations kullanıcının aktif üyesi olduğu odaları son mesajları ve
+// (DM odalarında) karşı taraf bilgileriyle, son mesaja göre sıralı getirir
+func (r *roomRepository) FindConversations(userID uint64) ([]ConversationRow, error) {
+	const query = `
+		SELECT
+			r.id,
+			r.name,
+			CASE WHEN r.name LIKE 'DM_%' AND mc.member_count = 2 THEN 1 ELSE 0 END AS is_dm,
+			other_user.user_id AS other_user_id,
+			ou.first_name AS other_user_first_name,
+			ou.last_name AS other_user_last_name,
+			ou.profile_picture_url AS other_user_avatar_url,
+			lm.content AS last_message_content,
+			lm.created_at AS last_message_timestamp,
+			sender.first_name AS last_message_sender_first_name,
+			sender.last_name AS last_message_sender_last_name
+		FROM rooms r
+		JOIN room_members current_rm ON r.id = current_rm.room_id AND current_rm.user_id = ? AND current_rm.is_active = ?
+		LEFT JOIN (
+			SELECT room_id, COUNT(*) AS member_count
+			FROM room_members
+			WHERE is_active = ?
+			GROUP BY room_id
+		) mc ON r.id = mc.room_id
+		LEFT JOIN room_members other_user ON r.name LIKE 'DM_%' AND r.id = other_user.room_id AND other_user.user_id != ? AND other_user.is_active = ?
+		LEFT JOIN (
+			SELECT
+				m.room_id,
+				m.content,
+				m.created_at,
+				m.sender_id,
+				ROW_NUMBER() OVER(PARTITION BY m.room_id ORDER BY m.created_at DESC) as rn
+			FROM messages m
+			WHERE m.deleted_at IS NULL
+		) lm ON r.id = lm.room_id AND lm.rn = 1
+		LEFT JOIN users sender ON lm.sender_id = sender.id
+		LEFT JOIN users ou ON other_user.user_id = ou.id
+		WHERE r.deleted_at IS NULL
+		ORDER BY lm.created_at IS NULL, lm.created_at DESC
+    `
+
+	var rows []ConversationRow
+	err := r.db.Raw(query, userID, true, true, userID, true).Scan(&rows).Error
+	return rows, err
+}
+
+// UpdateFields odanın yalnızca verilen alanlarını günceller
+func (r *roomRepository) UpdateFields(id uint64, updates map[string]interface{}) error {
+	return r.db.Model(&models.Room{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete odayı siler (soft delete)
+func (r *roomRepository) Delete(id uint64) error {
+	return r.db.Delete(&models.Room{}, id).Error
+}
+
+// AddMember odaya yeni bir üyelik kaydı ekler
+func (r *roomRepository) AddMember(member *models.RoomMember) error {
+	return r.db.Create(member).Error
+}
+
+// SaveMember üyelik kaydını günceller
+func (r *roomRepository) SaveMember(member *models.RoomMember) error {
+	return r.db.Save(member).Error
+}
+
+// FindMember kullanıcının odadaki üyelik kaydını aktif olup olmadığına bakmadan getirir
+func (r *roomRepository) FindMember(roomID, userID uint64) (*models.RoomMember, error) {
+	var member models.RoomMember
+	result := r.db.Where("room_id = ? AND user_id = ?", roomID, userID).First(&member)
+	return &member, result.Error
+}
+
+// FindActiveMember kullanıcının odadaki aktif üyelik kaydını getirir
+func (r *roomRepository) FindActiveMember(roomID, userID uint64) (*models.RoomMember, error) {
+	var member models.RoomMember
+	result := r.db.Where("room_id = ? AND user_id = ? AND is_active = ?", roomID, userID, true).First(&member)
+	return &member, result.Error
+}
+
+// FindActiveMembersWithUsers odanın aktif üyeliklerini kullanıcı bilgileriyle getirir
+func (r *roomRepository) FindActiveMembersWithUsers(roomID uint64) ([]models.RoomMember, error) {
+	var members []models.RoomMember
+	result := r.db.Preload("User").Where("room_id = ? AND is_active = ?", roomID, true).Find(&members)
+	return members, result.Error
+}
+
+// IsActiveMember kullanıcının odanın aktif üyesi olup olmadığını döndürür
+func (r *roomRepository) IsActiveMember(roomID, userID uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RoomMember{}).
+		Where("user_id = ? AND room_id = ? AND is_active = ?", userID, roomID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsActiveAdmin kullanıcının odanın aktif yöneticisi olup olmadığını döndürür
+func (r *roomRepository) IsActiveAdmin(roomID, userID uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RoomMember{}).
+		Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, userID, "admin", true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountActiveMembers odanın aktif üyelerini sayar
+func (r *roomRepository) CountActiveMembers(roomID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RoomMember{}).Where("room_id = ? AND is_active = ?", roomID, true).Count(&count).Error
+	return count, err
+}
+
+// CountActiveAdmins odanın aktif yöneticilerini sayar
+func (r *roomRepository) CountActiveAdmins(roomID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RoomMember{}).
+		Where("room_id = ? AND role = ? AND is_active = ?", roomID, "admin", true).
+		Count(&count).Error
+	return count, err
+}
+
+// ActiveMemberCounts verilen odaların aktif üye sayılarını oda ID'sine göre döndürür
+func (r *roomRepository) ActiveMemberCounts(roomIDs []uint64) (map[uint64]int64, error) {
+	counts := make(map[uint64]int64)
+	if len(roomIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		RoomID uint64
+		Count  int64
+	}
+	if err := r.db.Model(&models.RoomMember{}).
+		Select("room_id, count(*) as count").
+		Where("room_id IN ? AND is_active = ?", roomIDs, true).
+		Group("room_id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.RoomID] = row.Count
+	}
+	return counts, nil
+}
+
+// ActiveRoomIDs kullanıcının aktif üyesi olduğu odaların ID'lerini döndürür
+func (r *roomRepository) ActiveRoomIDs(userID uint64) ([]uint64, error) {
+	var roomIDs []uint64
+	err := r.db.Model(&models.RoomMember{}).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Pluck("room_id", &roomIDs).Error
+	return roomIDs, err
+}
+
+// FilterActiveMemberRooms verilen odalardan kullanıcının aktif üyesi olduklarının ID'lerini döndürür
+func (r *roomRepository) FilterActiveMemberRooms(userID uint64, roomIDs []uint64) ([]uint64, error) {
+	var memberOf []uint64
+	if len(roomIDs) == 0 {
+		return memberOf, nil
+	}
+	err := r.db.Model(&models.RoomMember{}).
+		Where("room_id IN ? AND user_id = ? AND is_active = ?", roomIDs, userID, true).
+		Pluck("room_id", &memberOf).Error
+	return memberOf, err
+}
+
+// MemberRoomIDs kullanıcının (aktif olsun olmasın) üyelik kaydı bulunan odaların ID'lerini döndürür
+func (r *roomRepository) MemberRoomIDs(userID uint64) ([]uint64, error) {
+	var roomIDs []uint64
+	err := r.db.Model(&models.RoomMember{}).Where("user_id = ?", userID).Pluck("room_id", &roomIDs).Error
+	return roomIDs, err
+}
+
+// ShareActiveRoom iki kullanıcının da aktif üyesi olduğu ortak bir oda olup olmadığını döndürür
+func (r *roomRepository) ShareActiveRoom(userID1, userID2 uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RoomMember{}).
+		Where("user_id = ? AND is_active = ?", userID2, true).
+		Where("room_id IN (?)", r.db.Model(&models.RoomMember{}).Select("room_id").Where("user_id = ? AND is_active = ?", userID1, true)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindReadStatuses kullanıcının aktif üyesi olduğu odalardaki son okuma zamanlarını getirir
+func (r *roomRepository) FindReadStatuses(userID uint64) ([]RoomReadStatus, error) {
+	var statuses []RoomReadStatus
+	err := r.db.Model(&models.RoomMember{}).
+		Select("room_id, last_read_at").
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Scan(&statuses).Error
+	return statuses, err
+}
+
+// UpdateLastRead kullanıcının odadaki son okuma zamanını günceller
+func (r *roomRepository) UpdateLastRead(roomID, userID uint64, at time.Time) error {
+	return r.db.Model(&models.RoomMember{}).
+		Where("user_id = ? AND room_id = ?", userID, roomID).
+		Update("last_read_at", at).Error
+}
+
+// FindInvitation ID'ye göre oda daveti getirir
+func (r *roomRepository) FindInvitation(id uint64) (*models.RoomInvitation, error) {
+	var invitation models.RoomInvitation
+	result := r.db.First(&invitation, id)
+	return &invitation, result.Error
+}
+
+// FindPendingInvitation kullanıcıya odaya yapılmış bekleyen daveti getirir
+func (r *roomRepository) FindPendingInvitation(roomID, inviteeID uint64) (*models.RoomInvitation, error) {
+	var invitation models.RoomInvitation
+	result := r.db.Where("room_id = ? AND invitee_id = ? AND status = ?", roomID, inviteeID, models.RoomInvitationPending).
+		First(&invitation)
+	return &invitation, result.Error
+}
+
+// FindPendingInvitationsFor kullanıcının bekleyen oda davetlerini oda ve davet eden bilgileriyle getirir
+func (r *roomRepository) FindPendingInvitationsFor(inviteeID uint64) ([]models.RoomInvitation, error) {
+	var invitations []models.RoomInvitation
+	result := r.db.Where("invitee_id = ? AND status = ?", inviteeID, models.RoomInvitationPending).
+		Preload("Room").
+		Preload("Inviter").
+		Order("created_at DESC").
+		Find(&invitations)
+	return invitations, result.Error
+}
+
+// CreateInvitation yeni bir oda daveti oluşturur
+func (r *roomRepository) CreateInvitation(invitation *models.RoomInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// SetInvitationStatus oda davetinin durumunu günceller
+func (r *roomRepository) SetInvitationStatus(id uint64, status models.RoomInvitationStatusType) error {
+	return r.db.Model(&models.RoomInvitation{}).Where("id = ?", id).Update("status", status).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"event/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionRepository kullanıcı oturumları (cihazlar) için arayüz
+type SessionRepository interface {
+	FindActive(userID uint64, seenAfter time.Time) ([]models.Session, error)
+	Create(session *models.Session) error
+	Touch(familyID string, at time.Time, userAgent, ipAddress string) error
+	MarkRevoked(id uint64, at time.Time) error
+}
+
+// sessionRepository SessionRepository arayüzünü uygular
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository yeni bir session repository oluşturur
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+// FindActive kullanıcının sonlanmamış ve seenAfter'dan sonra görülmüş oturumlarını
+// son görülme zamanına göre getirir
+func (r *sessionRepository) FindActive(userID uint64, seenAfter time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	result := r.db.Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, seenAfter).
+		Order("last_seen_at DESC").
+		Find(&sessions)
+	return sessions, result.Error
+}
+
+// Create yeni bir oturum kaydı oluşturur
+func (r *sessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+// Touch oturumun son görülme zamanını ve (boş değillerse) istemci bilgilerini günceller
+func (r *sessionRepository) Touch(familyID string, at time.Time, userAgent, ipAddress string) error {
+	updates := map[string]interface{}{"last_seen_at": at}
+	if userAgent != "" {
+		updates["user_agent"] = userAgent
+	}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+	return r.db.Model(&models.Session{}).Where("family_id = ?", familyID).Updates(updates).Error
+}
+
+// MarkRevoked oturumu (henüz sonlanmamışsa) sonlanmış olarak işaretler
+func (r *sessionRepository) MarkRevoked(id uint64, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
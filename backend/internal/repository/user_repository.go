@@ -3,13 +3,16 @@ package repository
 import (
 	"event/backend/internal/models"
 	"event/backend/pkg/database"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository kullanıcı veritabanı işlemleri için arayüz
 type UserRepository interface {
 	FindByID(id uint64) (*models.User, error)
+	FindByIDForUpdate(id uint64) (*models.User, error)
 	FindByIDWithInterests(id uint64) (*models.User, error)
 	GetUserFriends(userID uint64) ([]models.User, error)
 	FindSuggestionsByUserID(userID uint64) ([]models.User, error)
@@ -21,6 +24,19 @@ type UserRepository interface {
 	Search(query string, currentUserID uint64) ([]models.User, error)
 	FindUserFriends(userID uint) ([]models.User, error)
 	UpdateUserInterests(userID uint, interestIDs []uint) (*models.User, error)
+
+	Create(user *models.User) error
+	FindByEmail(email string) (*models.User, error)
+	FindByEmailWithInterests(email string) (*models.User, error)
+	FindByEmailOrUsername(email, username string) (*models.User, error)
+	FindByUnlockTokenHash(tokenHash string) (*models.User, error)
+	UsernameTaken(username string) (bool, error)
+	UpdateFields(id uint64, updates map[string]interface{}) error
+	ReplacePasswordHash(id uint64, oldHash, newHash string) (bool, error)
+	AdvanceTOTPStep(id uint64, step int64) (bool, error)
+	MarkVerificationSent(id uint64, now, resendAfter time.Time) (bool, error)
+	Lock(id uint64, now, lockedUntil time.Time, unlockTokenHash string) (bool, error)
+	ClearLock(id uint64, unlockTokenHash string) (bool, error)
 }
 
 // userRepository UserRepository arayüzünü uygular
@@ -29,9 +45,9 @@ type userRepository struct {
 }
 
 // NewUserRepository yeni bir user repository oluşturur
-func NewUserRepository() UserRepository {
+func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{
-		db: database.GetDB(),
+		db: db,
 	}
 }
 
@@ -42,6 +58,14 @@ func (r *userRepository) FindByID(id uint64) (*models.User, error) {
 	return &user, result.Error
 }
 
+// FindByIDForUpdate kullanıcıyı satır kilidiyle getirir; işlem içinde kullanılmalıdır.
+// Kullanıcı başına sınırların kontrolünü aynı kullanıcının eşzamanlı istekleri arasında sıraya sokar.
+func (r *userRepository) FindByIDForUpdate(id uint64) (*models.User, error) {
+	var user models.User
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id)
+	return &user, result.Error
+}
+
 // FindByIDWithInterests ID ile bir kullanıcıyı ve ilgi alanlarını getirir
 func (r *userRepository) FindByIDWithInterests(id uint64) (*models.User, error) {
 	var user models.User
@@ -101,7 +125,7 @@ func (r *userRepository) FindSuggestionsByUserID(userID uint64) ([]models.User,
 	excludedIDs := append(friendIDs, userID)
 
 	var suggestions []models.User
-	query := r.db.Order("RAND()").Limit(10)
+	query := r.db.Order(database.RandomOrder(r.db)).Limit(10)
 
 	if len(excludedIDs) > 0 {
 		query = query.Where("id NOT IN ?", excludedIDs)
@@ -160,26 +184,22 @@ func (r *userRepository) FindAllWithInterests() ([]models.User, error) {
 
 // Update kullanıcıyı ve ilişkili ilgi alanlarını günceller
 func (r *userRepository) Update(user *models.User, interestIDs []uint) error {
-	tx := r.db.Begin()
-
-	if err := tx.Save(user).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if interestIDs != nil {
-		var interests []models.Interest
-		if err := tx.Where("id IN ?", interestIDs).Find(&interests).Error; err != nil {
-			tx.Rollback()
-			return err
-		}
-		if err := tx.Model(user).Association("Interests").Replace(interests); err != nil {
-			tx.Rollback()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit().Error
+		if interestIDs != nil {
+			var interests []models.Interest
+			if err := tx.Where("id IN ?", interestIDs).Find(&interests).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(user).Association("Interests").Replace(interests); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // Search, kullanıcı adlarına, adlarına veya soyadlarına göre kullanıcıları arar.
@@ -225,44 +245,123 @@ func (r *userRepository) FindUserFriends(userID uint) ([]models.User, error) {
 // UpdateUserInterests, bir kullanıcının ilgi alanlarını günceller.
 // Bu işlem bir transaction içinde gerçekleştirilir.
 func (r *userRepository) UpdateUserInterests(userID uint, interestIDs []uint) (*models.User, error) {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+	var user models.User
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 1. Kullanıcının mevcut tüm ilgi alanı ilişkilerini sil
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserInterest{}).Error; err != nil {
+			return err
+		}
 
-	// 1. Kullanıcının mevcut tüm ilgi alanı ilişkilerini sil
-	if err := tx.Where("user_id = ?", userID).Delete(&models.UserInterest{}).Error; err != nil {
-		tx.Rollback()
+		// 2. Yeni ilgi alanlarını oluştur
+		if len(interestIDs) > 0 {
+			var userInterests []models.UserInterest
+			for _, interestID := range interestIDs {
+				userInterests = append(userInterests, models.UserInterest{
+					UserID:     uint64(userID),
+					InterestID: uint64(interestID),
+				})
+			}
+
+			if err := tx.Create(&userInterests).Error; err != nil {
+				return err
+			}
+		}
+
+		// 3. Güncellenmiş kullanıcı bilgisini ve ilişkili ilgi alanlarını getir
+		return tx.Preload("Interests").First(&user, userID).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// 2. Yeni ilgi alanlarını oluştur
-	if len(interestIDs) > 0 {
-		var userInterests []models.UserInterest
-		for _, interestID := range interestIDs {
-			userInterests = append(userInterests, models.UserInterest{
-				UserID:     uint64(userID),
-				InterestID: uint64(interestID),
-			})
-		}
+	return &user, nil
+}
 
-		if err := tx.Create(&userInterests).Error; err != nil {
-			tx.Rollback()
-			return nil, err
-		}
-	}
+// Create yeni bir kullanıcı oluşturur
+func (r *userRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
 
-	// 3. Güncellenmiş kullanıcı bilgisini ve ilişkili ilgi alanlarını getir
+// FindByEmail e-posta adresine göre kullanıcı getirir
+func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 	var user models.User
-	if err := tx.Preload("Interests").First(&user, userID).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+	result := r.db.Where("email = ?", email).First(&user)
+	return &user, result.Error
+}
 
-	// Her şey yolundaysa transaction'ı onayla
-	if err := tx.Commit().Error; err != nil {
-		return nil, err
-	}
+// FindByEmailWithInterests e-posta adresine göre kullanıcıyı ilgi alanlarıyla getirir
+func (r *userRepository) FindByEmailWithInterests(email string) (*models.User, error) {
+	var user models.User
+	result := r.db.Preload("Interests").Where("email = ?", email).First(&user)
+	return &user, result.Error
+}
 
-	return &user, nil
+// FindByEmailOrUsername e-posta adresi veya kullanıcı adı eşleşen ilk kullanıcıyı getirir
+func (r *userRepository) FindByEmailOrUsername(email, username string) (*models.User, error) {
+	var user models.User
+	result := r.db.Where("email = ? OR username = ?", email, username).First(&user)
+	return &user, result.Error
+}
+
+// FindByUnlockTokenHash kilit açma tokeni özetine göre kullanıcı getirir
+func (r *userRepository) FindByUnlockTokenHash(tokenHash string) (*models.User, error) {
+	var user models.User
+	result := r.db.Where("unlock_token_hash = ?", tokenHash).First(&user)
+	return &user, result.Error
+}
+
+// UsernameTaken kullanıcı adının (silinmiş hesaplar dahil) kullanılıp kullanılmadığını döndürür
+func (r *userRepository) UsernameTaken(username string) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateFields kullanıcının yalnızca verilen alanlarını günceller
+func (r *userRepository) UpdateFields(id uint64, updates map[string]interface{}) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// ReplacePasswordHash şifre hash'ini yalnızca mevcut hash oldHash ise değiştirir
+func (r *userRepository) ReplacePasswordHash(id uint64, oldHash, newHash string) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND password_hash = ?", id, oldHash).
+		Update("password_hash", newHash)
+	return result.RowsAffected > 0, result.Error
+}
+
+// AdvanceTOTPStep son kullanılan TOTP adımını yalnızca ileri taşır; aynı veya daha eski adım için false döner
+func (r *userRepository) AdvanceTOTPStep(id uint64, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkVerificationSent doğrulama e-postası gönderim zamanını, son gönderim resendAfter'dan
+// önce yapılmışsa (veya hiç yapılmamışsa) now olarak işaretler
+func (r *userRepository) MarkVerificationSent(id uint64, now, resendAfter time.Time) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND (email_verification_sent_at IS NULL OR email_verification_sent_at <= ?)", id, resendAfter).
+		Update("email_verification_sent_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Lock hesabı lockedUntil'e kadar kilitler; hesap zaten kilitliyse false döner
+func (r *userRepository) Lock(id uint64, now, lockedUntil time.Time, unlockTokenHash string) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until <= ?)", id, now).
+		Updates(map[string]interface{}{
+			"locked_until":      lockedUntil,
+			"unlock_token_hash": unlockTokenHash,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClearLock kilit açma tokeni hâlâ unlockTokenHash ise hesabın kilidini kaldırır; token yalnızca bir kez kullanılabilir
+func (r *userRepository) ClearLock(id uint64, unlockTokenHash string) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND unlock_token_hash = ?", id, unlockTokenHash).
+		Updates(map[string]interface{}{"locked_until": nil, "unlock_token_hash": ""})
+	return result.RowsAffected > 0, result.Error
 }
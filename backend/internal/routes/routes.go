@@ -0,0 +1,330 @@
+package routes
+
+import (
+	"log"
+	"net/http"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/handlers"
+	"event/backend/internal/middlewares"
+	"event/backend/internal/services"
+	"event/backend/internal/utils"
+	appWS "event/backend/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouterInput, router'ı kurmak için gereken bağımlılıkları tanımlar.
+type RouterInput struct {
+	Config        *config.Config
+	Revocations   auth.RevocationStore
+	AccountStatus auth.AccountStatusChecker
+	Hub           *appWS.Hub
+
+	AuthService              *services.AuthService
+	PasswordResetService     *services.PasswordResetService
+	EmailVerificationService *services.EmailVerificationService
+	TwoFactorService         *services.TwoFactorService
+	OAuthService             *services.OAuthService
+	PersonalAccessTokens     *services.PersonalAccessTokenService
+	CalendarService          *services.CalendarService
+	SessionService           *services.SessionService
+	EventService             *services.EventService
+	RoomService              *services.RoomService
+	ChatService              *services.ChatService
+	ProposalService          *services.ProposalService
+	FriendshipService        *services.FriendshipService
+	NotificationService      *services.NotificationService
+	SuggestionService        *services.SuggestionService
+	UserService              *services.UserService
+	InterestService          services.InterestService
+	ReportService            *services.ReportService
+	AdminService             *services.AdminService
+}
+
+// SetupRouter tüm middleware'leri ve API rotalarını içeren Gin engine'ini oluşturur.
+func SetupRouter(input RouterInput) *gin.Engine {
+	if input.Config.Env == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+	// X-Forwarded-For yalnızca yapılandırılmış vekil sunuculardan kabul edilir; aksi halde istemci başlığı
+	// değiştirerek c.ClientIP()'yi ve IP başına giriş sınırlarını atlatabilirdi. Boş liste bağlantı adresini kullanır.
+	var trustedProxies []string
+	if len(input.Config.TrustedProxies) > 0 {
+		trustedProxies = input.Config.TrustedProxies
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Printf("[SetupRouter] trusted_proxies uygulanamadı, hiçbir vekile güvenilmeyecek: %v", err)
+		_ = router.SetTrustedProxies(nil)
+	}
+	router.Use(gin.Recovery())
+	router.Use(middlewares.Logger())
+	router.Use(middlewares.CORSMiddleware(input.Config))
+	router.Use(middlewares.BodyLimit(int64(input.Config.MaxRequestBodyBytes)))
+
+	// authRequired yalnızca JWT kabul eder. Kişisel erişim token'ları yalnızca scoped/scopedOptional
+	// ile bir kaynağa bağlanmış rotalarda, o kaynağın kapsamıyla kabul edilir.
+	authInput := middlewares.AuthMiddlewareInput{
+		Config:               input.Config,
+		Revocations:          input.Revocations,
+		AccountStatus:        input.AccountStatus,
+		PersonalAccessTokens: input.PersonalAccessTokens,
+	}
+	authRequired := middlewares.AuthMiddleware(authInput)
+	authOptional := middlewares.OptionalAuthMiddleware(authInput)
+	scoped := func(resource string) gin.HandlerFunc {
+		return middlewares.TokenAuthMiddleware(authInput, resource)
+	}
+	scopedOptional := func(resource string) gin.HandlerFunc {
+		return middlewares.OptionalTokenAuthMiddleware(authInput, resource)
+	}
+	eventsAuth, eventsOptional := scoped(auth.ResourceEvents), scopedOptional(auth.ResourceEvents)
+	// E-posta doğrulama politikası açıksa içerik oluşturma ve davet gönderme doğrulanmış hesap ister
+	verified := middlewares.RequireVerifiedEmail(input.Config, input.UserService)
+
+	authHandler := handlers.NewAuthHandler(handlers.AuthHandlerInput{
+		AuthService:              input.AuthService,
+		PasswordResetService:     input.PasswordResetService,
+		EmailVerificationService: input.EmailVerificationService,
+		TwoFactorService:         input.TwoFactorService,
+	})
+	oauthHandler := handlers.NewOAuthHandler(handlers.OAuthHandlerInput{
+		OAuthService: input.OAuthService,
+		Config:       input.Config,
+	})
+	tokenHandler := handlers.NewPersonalAccessTokenHandler(input.PersonalAccessTokens)
+	websocketHandler := handlers.NewWebSocketHandler(handlers.WebSocketHandlerInput{
+		Hub:           input.Hub,
+		Config:        input.Config,
+		RoomService:   input.RoomService,
+		Revocations:   input.Revocations,
+		AccountStatus: input.AccountStatus,
+	})
+	sessionHandler := handlers.NewSessionHandler(input.SessionService)
+	eventHandler := handlers.NewEventHandler(input.EventService)
+	calendarHandler := handlers.NewCalendarHandler(input.CalendarService)
+	roomHandler := handlers.NewRoomHandler(handlers.RoomHandlerInput{
+		RoomService: input.RoomService,
+		ChatService: input.ChatService,
+	})
+	friendshipHandler := handlers.NewFriendshipHandler(input.FriendshipService)
+	notificationHandler := handlers.NewNotificationHandler(input.NotificationService)
+	proposalHandler := handlers.NewProposalHandler(input.ProposalService)
+	userHandler := handlers.NewUserHandler(handlers.UserHandlerInput{
+		UserService:       input.UserService,
+		InterestService:   input.InterestService,
+		SuggestionService: input.SuggestionService,
+	})
+	reportHandler := handlers.NewReportHandler(input.ReportService)
+	adminHandler := handlers.NewAdminHandler(input.AdminService)
+	dashboardHandler := handlers.NewDashboardHandler(handlers.DashboardHandlerInput{
+		EventService:      input.EventService,
+		FriendshipService: input.FriendshipService,
+		UserService:       input.UserService,
+	})
+
+	router.NoRoute(func(c *gin.Context) {
+		utils.NotFoundResponse(c, "Endpoint bulunamadı")
+	})
+
+	api := router.Group("/api")
+	api.GET("/ping", func(c *gin.Context) {
+		utils.SuccessResponse(c, http.StatusOK, "pong", nil)
+	})
+
+	// WebSocket bağlantısı token'ı query parametresinden ve oda üyeliğini kendisi doğrular
+	api.GET("/ws/room/:roomId", websocketHandler.ServeWsRoom)
+
+	authGroup := api.Group("/auth")
+	{
+		authGroup.POST("/register", authHandler.Register)
+		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/forgot-password", authHandler.ForgotPassword)
+		authGroup.POST("/reset-password", authHandler.ResetPassword)
+		authGroup.POST("/verify-email", authHandler.VerifyEmail)
+		authGroup.POST("/unlock", authHandler.UnlockAccount)
+		authGroup.POST("/resend-verification", authRequired, authHandler.ResendVerification)
+		authGroup.GET("/me", authRequired, authHandler.Me)
+		authGroup.POST("/logout", authRequired, authHandler.Logout)
+		authGroup.POST("/change-password", authRequired, authHandler.ChangePassword)
+
+		// İki adımlı doğrulama
+		authGroup.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+		authGroup.POST("/2fa/setup", authRequired, authHandler.SetupTwoFactor)
+		authGroup.POST("/2fa/confirm", authRequired, authHandler.ConfirmTwoFactor)
+		authGroup.POST("/2fa/disable", authRequired, authHandler.DisableTwoFactor)
+
+		// Sosyal giriş (OAuth2 + PKCE / OpenID Connect)
+		authGroup.GET("/oauth/providers", oauthHandler.GetProviders)
+		authGroup.POST("/oauth/:provider/start", oauthHandler.Start)
+		// Bağlama akışının dönüşü, akışı başlatan kullanıcının oturumuyla gelmelidir
+		authGroup.POST("/oauth/:provider/callback", authOptional, oauthHandler.Callback)
+		authGroup.POST("/oauth/:provider/link", authRequired, oauthHandler.Link)
+		authGroup.GET("/identities", authRequired, oauthHandler.GetIdentities)
+
+		// Aktif oturumlar (cihazlar)
+		authGroup.GET("/sessions", authRequired, sessionHandler.GetSessions)
+		authGroup.DELETE("/sessions/:id", authRequired, sessionHandler.RevokeSession)
+		authGroup.POST("/sessions/revoke-others", authRequired, sessionHandler.RevokeOtherSessions)
+
+		// Kişisel erişim token'ları yalnızca oturum (JWT) ile yönetilebilir
+		authGroup.GET("/tokens", authRequired, tokenHandler.GetTokens)
+		authGroup.GET("/tokens/scopes", authRequired, tokenHandler.GetScopes)
+		authGroup.POST("/tokens", authRequired, tokenHandler.CreateToken)
+		authGroup.DELETE("/tokens/:id", authRequired, tokenHandler.RevokeToken)
+	}
+
+	// Etkinlik listeleri ve detayları anonim olarak da görüntülenebilir;
+	// token varsa özel etkinliklere erişim için kullanıcı context'e eklenir
+	events := api.Group("/events")
+	{
+		events.GET("", eventsOptional, eventHandler.GetEvents)
+		events.GET("/feed", eventsOptional, eventHandler.GetFeed)
+		events.GET("/me", eventsAuth, eventHandler.GetMyEvents)
+		events.POST("", eventsAuth, verified, eventHandler.CreateEvent)
+		events.POST("/import", eventsAuth, verified, calendarHandler.ImportEvents)
+		events.GET("/:id", eventsOptional, eventHandler.GetEvent)
+		events.PUT("/:id", eventsAuth, eventHandler.UpdateEvent)
+		events.DELETE("/:id", eventsAuth, eventHandler.DeleteEvent)
+		events.POST("/:id/attend", eventsAuth, eventHandler.AttendEvent)
+		events.DELETE("/:id/attend", eventsAuth, eventHandler.CancelAttendance)
+		events.GET("/:id/attendees", eventsOptional, eventHandler.GetAttendees)
+		events.GET("/:id/waitlist", eventsAuth, eventHandler.GetWaitlist)
+		events.PUT("/:id/waitlist", eventsAuth, eventHandler.ReorderWaitlist)
+		events.POST("/:id/waitlist/:userId/promote", eventsAuth, eventHandler.PromoteFromWaitlist)
+		events.GET("/:id/calendar.ics", eventsOptional, calendarHandler.ExportEvent)
+		events.GET("/:id/occurrences", eventsOptional, eventHandler.GetOccurrences)
+		events.PUT("/:id/occurrences/:occurrence", eventsAuth, eventHandler.UpdateOccurrence)
+		events.DELETE("/:id/occurrences/:occurrence", eventsAuth, eventHandler.DeleteOccurrence)
+		events.POST("/:id/occurrences/:occurrence/attend", eventsAuth, eventHandler.AttendOccurrence)
+		events.DELETE("/:id/occurrences/:occurrence/attend", eventsAuth, eventHandler.CancelOccurrenceAttendance)
+		events.GET("/:id/time-options", eventsOptional, eventHandler.GetTimeOptions)
+		events.POST("/:id/time-options/:optionId/vote", eventsAuth, eventHandler.VoteForTimeOption)
+		events.POST("/:id/finalize", eventsAuth, eventHandler.FinalizeEvent)
+		events.POST("/:id/invite", eventsAuth, verified, eventHandler.InviteUser)
+	}
+
+	// Takvim uygulamaları Authorization başlığı gönderemediğinden abonelik akışları adresteki gizli
+	// takvim tokeniyle doğrulanır; token yalnızca oturum (JWT) ile yönetilebilir
+	calendar := api.Group("/calendar")
+	{
+		calendar.GET("/token", authRequired, calendarHandler.GetFeedToken)
+		calendar.POST("/token", authRequired, calendarHandler.RotateFeedToken)
+		calendar.DELETE("/token", authRequired, calendarHandler.RevokeFeedToken)
+		calendar.GET("/feeds/:token/events.ics", calendarHandler.GetUserFeed)
+		calendar.GET("/feeds/:token/rooms/:roomId/events.ics", calendarHandler.GetRoomFeed)
+	}
+
+	requests := api.Group("/requests", eventsAuth)
+	{
+		requests.POST("/:id/approve", eventHandler.ApproveRequest)
+		requests.POST("/:id/decline", eventHandler.DeclineRequest)
+	}
+
+	eventInvitations := api.Group("/event-invitations", eventsAuth)
+	{
+		eventInvitations.GET("/", eventHandler.GetInvitations)
+		eventInvitations.POST("/:id/accept", eventHandler.AcceptInvitation)
+		eventInvitations.POST("/:id/decline", eventHandler.DeclineInvitation)
+	}
+
+	rooms := api.Group("/rooms", scoped(auth.ResourceRooms))
+	{
+		rooms.GET("", roomHandler.GetRooms)
+		rooms.POST("", verified, roomHandler.CreateRoom)
+		rooms.GET("/public", roomHandler.GetPublicRooms)
+		rooms.GET("/me", roomHandler.GetConversations)
+		rooms.GET("/unread-count", roomHandler.GetUnreadCount)
+		rooms.POST("/dm", roomHandler.GetOrCreateDMRoom)
+		rooms.POST("/group-chat", verified, roomHandler.CreateGroupChat)
+		rooms.GET("/invitations", roomHandler.GetInvitations)
+		rooms.POST("/invitations/:invitationId/accept", roomHandler.AcceptInvitation)
+		rooms.POST("/invitations/:invitationId/decline", roomHandler.DeclineInvitation)
+		rooms.GET("/:roomId", roomHandler.GetRoom)
+		rooms.PUT("/:roomId", roomHandler.UpdateRoom)
+		rooms.DELETE("/:roomId", roomHandler.DeleteRoom)
+		rooms.GET("/:roomId/members", roomHandler.GetMembers)
+		rooms.POST("/:roomId/members", roomHandler.AddMember)
+		rooms.DELETE("/:roomId/members/:userId", roomHandler.RemoveMember)
+		rooms.GET("/:roomId/messages", roomHandler.GetMessages)
+		rooms.POST("/:roomId/read", roomHandler.MarkAsRead)
+		rooms.POST("/:roomId/invite", verified, roomHandler.InviteUser)
+	}
+
+	friendships := api.Group("/friendships", scoped(auth.ResourceFriends))
+	{
+		friendships.GET("/", friendshipHandler.GetFriends)
+		friendships.GET("/requests/pending", friendshipHandler.GetPendingRequests)
+		friendships.GET("/suggestions", friendshipHandler.GetSuggestions)
+		friendships.GET("/status/:id", friendshipHandler.GetStatus)
+		friendships.POST("/request", friendshipHandler.SendRequest)
+		friendships.POST("/request/:id", friendshipHandler.SendRequestTo)
+		friendships.POST("/requests/:id/accept", friendshipHandler.AcceptRequest)
+		friendships.POST("/requests/:id/decline", friendshipHandler.DeclineRequest)
+		friendships.DELETE("/:id", friendshipHandler.DeleteFriendship)
+	}
+	// Eski profil sayfası aynı endpoint'i bu yoldan çağırıyor
+	api.GET("/buddies/status/:id", scoped(auth.ResourceFriends), friendshipHandler.GetStatus)
+
+	notifications := api.Group("/notifications", scoped(auth.ResourceNotifications))
+	{
+		notifications.GET("", notificationHandler.GetNotifications)
+		notifications.POST("/read", notificationHandler.MarkAsRead)
+	}
+
+	proposals := api.Group("/proposals", scoped(auth.ResourceProposals))
+	{
+		proposals.POST("", proposalHandler.CreateProposal)
+		proposals.GET("/incoming", proposalHandler.GetIncoming)
+		proposals.GET("/outgoing", proposalHandler.GetOutgoing)
+		proposals.POST("/:id/respond", proposalHandler.Respond)
+		proposals.POST("/:id/counter", proposalHandler.CounterPropose)
+	}
+
+	suggestions := api.Group("/suggestions", scoped(auth.ResourceProposals))
+	{
+		suggestions.GET("/events", userHandler.GetSuggestedEvents)
+		suggestions.POST("/:id/accept", proposalHandler.Accept)
+		suggestions.POST("/:id/reject", proposalHandler.Reject)
+	}
+
+	users := api.Group("/users", scoped(auth.ResourceUsers))
+	{
+		users.GET("/me", userHandler.GetMe)
+		users.PUT("/me", userHandler.UpdateMe)
+		users.POST("/me/interests", userHandler.UpdateMyInterests)
+		users.GET("/me/suggestions", userHandler.GetMySuggestions)
+		users.POST("/me/suggestions", userHandler.SaveMySuggestions)
+		users.DELETE("/me/suggestions", userHandler.DeleteMySuggestions)
+		users.GET("/search", userHandler.SearchUsers)
+		users.GET("/:id", userHandler.GetUser)
+		users.GET("/:id/events", eventHandler.GetUserEvents)
+		users.GET("/:id/friends", friendshipHandler.GetUserFriends)
+		users.GET("/:id/rooms", roomHandler.GetUserRooms)
+	}
+
+	api.GET("/interests", scopedOptional(auth.ResourceUsers), userHandler.GetInterests)
+	api.GET("/dashboard", authRequired, dashboardHandler.GetDashboard)
+	api.POST("/reports", authRequired, reportHandler.CreateReport)
+
+	// Yönetim endpoint'leri yalnızca JWT ile ve platform yöneticilerine açıktır
+	admin := api.Group("/admin", authRequired, middlewares.RequireAdmin(input.UserService))
+	{
+		admin.PUT("/users/:id/role", adminHandler.SetUserRole)
+		admin.POST("/users/:id/suspend", adminHandler.SuspendUser)
+		admin.POST("/users/:id/unsuspend", adminHandler.UnsuspendUser)
+		admin.GET("/users/:id/notifications", adminHandler.GetUserNotifications)
+		admin.DELETE("/events/:id", adminHandler.DeleteEvent)
+		admin.DELETE("/rooms/:id", adminHandler.DeleteRoom)
+		admin.DELETE("/messages/:id", adminHandler.DeleteMessage)
+		admin.GET("/reports", adminHandler.GetReports)
+		admin.POST("/reports/:id/resolve", adminHandler.ResolveReport)
+		admin.GET("/audit-logs", adminHandler.GetAuditLogs)
+	}
+
+	return router
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+)
+
+// Yönetici işlem kayıtlarında kullanılan işlem adları
+const (
+	AdminActionSetRole           = "set_role"
+	AdminActionSuspendUser       = "suspend_user"
+	AdminActionUnsuspendUser     = "unsuspend_user"
+	AdminActionDeleteEvent       = "delete_event"
+	AdminActionDeleteRoom        = "delete_room"
+	AdminActionDeleteMessage     = "delete_message"
+	AdminActionResolveReport     = "resolve_report"
+	AdminActionViewNotifications = "view_notifications"
+)
+
+var (
+	// ErrAdminSelfAction yöneticinin kendi hesabını askıya alması veya yetkisini kaldırması durumunda döner
+	ErrAdminSelfAction = errors.New("bu işlemi kendi hesabınız üzerinde yapamazsınız")
+	// ErrModerationTargetNotFound işlem yapılacak kullanıcı veya içerik bulunamadığında döner.
+	// Hata mesajı kaydın türüyle sarılır (örn. "etkinlik bulunamadı").
+	ErrModerationTargetNotFound = errors.New("bulunamadı")
+	// ErrReportAlreadyClosed sonuçlandırılmış bir şikayet tekrar sonuçlandırılmak istendiğinde döner
+	ErrReportAlreadyClosed = errors.New("şikayet zaten sonuçlandırılmış")
+)
+
+// AdminService platform yöneticilerinin moderasyon işlemlerini yürütür.
+// Her işlem AdminAuditLog'a kaydedilir.
+type AdminService struct {
+	repos    repository.Repositories
+	uow      repository.UnitOfWork
+	sessions SessionRevoker
+}
+
+// AdminServiceInput, AdminService için bağımlılıkları içerir.
+type AdminServiceInput struct {
+	Repositories   repository.Repositories
+	UnitOfWork     repository.UnitOfWork
+	SessionService SessionRevoker
+}
+
+// NewAdminService yeni bir AdminService oluşturur
+func NewAdminService(input AdminServiceInput) *AdminService {
+	return &AdminService{
+		repos:    input.Repositories,
+		uow:      input.UnitOfWork,
+		sessions: input.SessionService,
+	}
+}
+
+// SetRole kullanıcının platform rolünü değiştirir
+func (s *AdminService) SetRole(adminID, userID uint64, role models.UserRole) (*models.User, error) {
+	if role != models.UserRoleUser && role != models.UserRoleAdmin {
+		return nil, errors.New("geçersiz rol (user veya admin olmalı)")
+	}
+	if adminID == userID {
+		return nil, ErrAdminSelfAction
+	}
+
+	var user *models.User
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		var err error
+		if user, err = findUser(repos, userID); err != nil {
+			return err
+		}
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{"role": role}); err != nil {
+			return err
+		}
+		user.Role = role
+		return recordAdminAction(repos, adminID, AdminActionSetRole, "user", userID, string(role))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AdminService.SetRole] Kullanıcı rolü değiştirildi (UserID: %d, Rol: %s, Yönetici: %d)", userID, role, adminID)
+	return user, nil
+}
+
+// SuspendUser kullanıcının hesabını askıya alır; tüm oturumları sonlandırılır ve WebSocket bağlantıları kesilir.
+// Askıdaki hesaplar giriş yapamaz, JWT ve kişisel erişim token'ları reddedilir.
+func (s *AdminService) SuspendUser(adminID, userID uint64, reason string) (*models.User, error) {
+	if adminID == userID {
+		return nil, ErrAdminSelfAction
+	}
+	reason = strings.TrimSpace(reason)
+
+	var user *models.User
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		var err error
+		if user, err = findUser(repos, userID); err != nil {
+			return err
+		}
+		if user.IsAdmin() {
+			return errors.New("yöneticiler askıya alınamaz, önce yönetici yetkisini kaldırın")
+		}
+		if user.IsSuspended() {
+			return nil
+		}
+
+		now := time.Now()
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{
+			"suspended_at":      now,
+			"suspension_reason": reason,
+		}); err != nil {
+			return err
+		}
+		user.SuspendedAt = &now
+		user.SuspensionReason = reason
+		return recordAdminAction(repos, adminID, AdminActionSuspendUser, "user", userID, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Askıya alma kaydedildi; açık oturumlar middleware tarafından zaten reddedilir,
+	// burada yenileme token'ları da iptal edilip canlı bağlantılar kesilir
+	if _, err := s.sessions.RevokeAll(userID); err != nil {
+		log.Printf("[AdminService.SuspendUser] Oturumlar sonlandırılamadı (UserID: %d): %v", userID, err)
+	}
+
+	log.Printf("[AdminService.SuspendUser] Hesap askıya alındı (UserID: %d, Yönetici: %d)", userID, adminID)
+	return user, nil
+}
+
+// UnsuspendUser askıya alınmış hesabı yeniden etkinleştirir. Kullanıcının tekrar giriş yapması gerekir.
+func (s *AdminService) UnsuspendUser(adminID, userID uint64) (*models.User, error) {
+	var user *models.User
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		var err error
+		if user, err = findUser(repos, userID); err != nil {
+			return err
+		}
+		if !user.IsSuspended() {
+			return nil
+		}
+
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{
+			"suspended_at":      nil,
+			"suspension_reason": "",
+		}); err != nil {
+			return err
+		}
+		user.SuspendedAt = nil
+		user.SuspensionReason = ""
+		return recordAdminAction(repos, adminID, AdminActionUnsuspendUser, "user", userID, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AdminService.UnsuspendUser] Hesap yeniden etkinleştirildi (UserID: %d, Yönetici: %d)", userID, adminID)
+	return user, nil
+}
+
+// ForceDeleteEvent etkinliği sahibinden bağımsız olarak siler ve etkinlik sahibini bilgilendirir
+func (s *AdminService) ForceDeleteEvent(adminID, eventID uint64, reason string) error {
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		event, err := repos.Events.FindByID(eventID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("etkinlik %w", ErrModerationTargetNotFound)
+			}
+			return err
+		}
+		if err := repos.Events.Delete(event.ID); err != nil {
+			return err
+		}
+		if err := closeReports(repos, adminID, models.ReportTargetEvent, eventID); err != nil {
+			return err
+		}
+		if err := recordAdminAction(repos, adminID, AdminActionDeleteEvent, string(models.ReportTargetEvent), eventID, reason); err != nil {
+			return err
+		}
+		return notifyOwner(repos, event.CreatorUserID, fmt.Sprintf("\"%s\" etkinliğiniz yönetici tarafından kaldırıldı", event.Title), reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[AdminService.ForceDeleteEvent] Etkinlik silindi (EventID: %d, Yönetici: %d)", eventID, adminID)
+	return nil
+}
+
+// ForceDeleteRoom odayı kurucusundan bağımsız olarak siler (soft delete) ve kurucuyu bilgilendirir
+func (s *AdminService) ForceDeleteRoom(adminID, roomID uint64, reason string) error {
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		room, err := repos.Rooms.FindByID(roomID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("oda %w", ErrModerationTargetNotFound)
+			}
+			return err
+		}
+		if err := repos.Rooms.Delete(room.ID); err != nil {
+			return err
+		}
+		if err := closeReports(repos, adminID, models.ReportTargetRoom, roomID); err != nil {
+			return err
+		}
+		if err := recordAdminAction(repos, adminID, AdminActionDeleteRoom, string(models.ReportTargetRoom), roomID, reason); err != nil {
+			return err
+		}
+		return notifyOwner(repos, room.CreatorUserID, fmt.Sprintf("\"%s\" odanız yönetici tarafından kaldırıldı", room.Name), reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[AdminService.ForceDeleteRoom] Oda silindi (RoomID: %d, Yönetici: %d)", roomID, adminID)
+	return nil
+}
+
+// ForceDeleteMessage mesajı göndereninden bağımsız olarak siler (soft delete)
+func (s *AdminService) ForceDeleteMessage(adminID, messageID uint64, reason string) error {
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		message, err := repos.Messages.FindByID(messageID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("mesaj %w", ErrModerationTargetNotFound)
+			}
+			return err
+		}
+		if err := repos.Messages.Delete(message.ID); err != nil {
+			return err
+		}
+		if err := closeReports(repos, adminID, models.ReportTargetMessage, messageID); err != nil {
+			return err
+		}
+		return recordAdminAction(repos, adminID, AdminActionDeleteMessage, string(models.ReportTargetMessage), messageID, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[AdminService.ForceDeleteMessage] Mesaj silindi (MessageID: %d, Yönetici: %d)", messageID, adminID)
+	return nil
+}
+
+// ListReports şikayetleri en yeniden eskiye sayfalı olarak listeler. status boşsa tüm şikayetler döner.
+func (s *AdminService) ListReports(status models.ReportStatus, page, limit int) ([]models.Report, int64, error) {
+	return s.repos.Reports.List(status, (page-1)*limit, limit)
+}
+
+// ResolveReport açık bir şikayeti sonuçlandırır (resolved veya dismissed)
+func (s *AdminService) ResolveReport(adminID, reportID uint64, status models.ReportStatus, note string) (*models.Report, error) {
+	if status != models.ReportStatusResolved && status != models.ReportStatusDismissed {
+		return nil, errors.New("geçersiz şikayet durumu (resolved veya dismissed olmalı)")
+	}
+	note = strings.TrimSpace(note)
+
+	var report *models.Report
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		var err error
+		if report, err = repos.Reports.FindByID(reportID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrReportNotFound
+			}
+			return err
+		}
+		if report.Status != models.ReportStatusOpen {
+			return ErrReportAlreadyClosed
+		}
+
+		now := time.Now()
+		resolved, err := repos.Reports.Resolve(report.ID, adminID, status, note, now)
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			return ErrReportAlreadyClosed
+		}
+		report.Status = status
+		report.ResolvedByID = &adminID
+		report.ResolutionNote = note
+		report.ResolvedAt = &now
+
+		return recordAdminAction(repos, adminID, AdminActionResolveReport, "report", reportID, string(status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetUserNotifications kullanıcının bildirimlerini destek amacıyla salt okunur olarak döndürür.
+// Bildirimler okundu olarak işaretlenmez; her görüntüleme işlem kaydına yazılır.
+func (s *AdminService) GetUserNotifications(adminID, userID uint64) ([]models.Notification, error) {
+	if _, err := findUser(s.repos, userID); err != nil {
+		return nil, err
+	}
+	if err := recordAdminAction(s.repos, adminID, AdminActionViewNotifications, "user", userID, ""); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AdminService.GetUserNotifications] Yönetici %d, kullanıcı %d bildirimlerini görüntüledi", adminID, userID)
+	return s.repos.Notifications.FindByUser(userID)
+}
+
+// ListAuditLogs yönetici işlem kayıtlarını en yeniden eskiye sayfalı olarak listeler
+func (s *AdminService) ListAuditLogs(page, limit int) ([]models.AdminAuditLog, int64, error) {
+	return s.repos.Audit.ListAdminLogs((page-1)*limit, limit)
+}
+
+// notifyOwner silinen içeriğin sahibine sistem bildirimi gönderir. Bildirim silme işlemiyle
+// aynı işlemde kaydedilir; içerik silinmeden sahibine bildirim gitmez.
+func notifyOwner(repos repository.Repositories, userID uint64, message, reason string) error {
+	if reason = strings.TrimSpace(reason); reason != "" {
+		message += ". Gerekçe: " + reason
+	}
+	if _, err := notify(repos.Notifications, userID, models.NotificationTypeSystemMessage, message, nil); err != nil {
+		log.Printf("[AdminService.notifyOwner] Bildirim oluşturulamadı (UserID: %d): %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+// findUser kullanıcıyı ID ile yükler
+func findUser(repos repository.Repositories, userID uint64) (*models.User, error) {
+	user, err := repos.Users.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("kullanıcı %w", ErrModerationTargetNotFound)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// closeReports silinen içerik hakkındaki açık şikayetleri çözüldü olarak işaretler
+func closeReports(repos repository.Repositories, adminID uint64, targetType models.ReportTargetType, targetID uint64) error {
+	return repos.Reports.CloseForTarget(targetType, targetID, adminID, "İçerik kaldırıldı", time.Now())
+}
+
+// recordAdminAction yönetici işlemini işlem kaydına yazar
+func recordAdminAction(repos repository.Repositories, adminID uint64, action, targetType string, targetID uint64, detail string) error {
+	entry := models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Detail:     detail,
+	}
+	if err := repos.Audit.CreateAdminLog(&entry); err != nil {
+		return errors.New("yönetici işlem kaydı oluşturulamadı")
+	}
+	return nil
+}
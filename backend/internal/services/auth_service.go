@@ -2,41 +2,95 @@ package services
 
 import (
 	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"event/backend/internal/auth"
 	"event/backend/internal/config"
 	"event/backend/internal/models"
+	"event/backend/internal/repository"
 	"event/backend/internal/utils"
-	"event/backend/pkg/database"
-
-	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 // AuthService kimlik doğrulama işlemlerini yöneten servis
 type AuthService struct {
-	config *config.Config
+	repos          repository.Repositories
+	uow            repository.UnitOfWork
+	config         *config.Config
+	loginGuard     *LoginGuard
+	twoFactor      *TwoFactorService
+	sessions       *SessionService
+	passwords      auth.PasswordHasher
+	passwordPolicy *auth.PasswordPolicy
+	revocations    auth.RevocationStore
+
+	// dummyHash kayıtlı olmayan e-postalarla yapılan girişlerde karşılaştırılacak sabit hash
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+// AuthServiceInput, AuthService için bağımlılıkları içerir.
+type AuthServiceInput struct {
+	Repositories     repository.Repositories
+	UnitOfWork       repository.UnitOfWork
+	Config           *config.Config
+	LoginGuard       *LoginGuard
+	TwoFactorService *TwoFactorService
+	SessionService   *SessionService
+	PasswordHasher   auth.PasswordHasher
+	PasswordPolicy   *auth.PasswordPolicy
+	Revocations      auth.RevocationStore
 }
 
 // NewAuthService yeni bir AuthService örneği oluşturur
-func NewAuthService(cfg *config.Config) *AuthService {
+func NewAuthService(input AuthServiceInput) *AuthService {
 	return &AuthService{
-		config: cfg,
+		repos:          input.Repositories,
+		uow:            input.UnitOfWork,
+		config:         input.Config,
+		loginGuard:     input.LoginGuard,
+		twoFactor:      input.TwoFactorService,
+		sessions:       input.SessionService,
+		passwords:      input.PasswordHasher,
+		passwordPolicy: input.PasswordPolicy,
+		revocations:    input.Revocations,
 	}
 }
 
-// LoginResponse giriş yanıtını temsil eder
+// LoginResponse giriş yanıtını temsil eder.
+// İki adımlı doğrulama açık kullanıcılarda yalnızca MFARequired ve MFAToken doldurulur;
+// token çifti /auth/2fa/verify ile kod doğrulandıktan sonra alınır.
 type LoginResponse struct {
-	Token        string      `json:"token"`
-	RefreshToken string      `json:"refresh_token"`
-	User         models.User `json:"user"`
+	Token        string       `json:"token,omitempty"`
+	RefreshToken string       `json:"refresh_token,omitempty"`
+	User         *models.User `json:"user,omitempty"`
+	MFARequired  bool         `json:"mfa_required,omitempty"`
+	MFAToken     string       `json:"mfa_token,omitempty"`
 }
 
-// Register yeni kullanıcı kaydı yapar
-func (s *AuthService) Register(username, email, password, firstName, lastName string) (*LoginResponse, error) {
-	db := database.GetDB()
+// ClientInfo token üretilen istemciyi tanımlar.
+// SessionID, istek oturum açmış bir kullanıcıdan geliyorsa erişim tokenindeki oturumdur.
+type ClientInfo struct {
+	DeviceLabel string
+	IPAddress   string
+	UserAgent   string
+	SessionID   string
+}
+
+var (
+	// ErrInvalidRefreshToken geçersiz, süresi dolmuş veya iptal edilmiş yenileme tokeni için döner
+	ErrInvalidRefreshToken = errors.New("geçersiz yenileme tokeni")
+	// ErrRefreshTokenReused daha önce kullanılmış bir yenileme tokeni tekrar gönderildiğinde döner
+	ErrRefreshTokenReused = errors.New("yenileme tokeni daha önce kullanılmış, güvenlik nedeniyle oturum sonlandırıldı")
+	// ErrAccountSuspended yönetici tarafından askıya alınmış hesaplar için döner
+	ErrAccountSuspended = errors.New("hesabınız yönetici tarafından askıya alındı")
+)
 
+// Register yeni kullanıcı kaydı yapar
+func (s *AuthService) Register(username, email, password, firstName, lastName string, client ClientInfo) (*LoginResponse, error) {
 	// E-posta veya kullanıcı adı kontrolü
-	var existingUser models.User
-	if err := db.Where("email = ? OR username = ?", email, username).First(&existingUser).Error; err == nil {
+	if existingUser, err := s.repos.Users.FindByEmailOrUsername(email, username); err == nil {
 		if existingUser.Email == email {
 			return nil, errors.New("bu e-posta adresi zaten kullanımda")
 		}
@@ -45,8 +99,12 @@ func (s *AuthService) Register(username, email, password, firstName, lastName st
 		}
 	}
 
+	if err := s.passwordPolicy.Validate(password, username, email); err != nil {
+		return nil, err
+	}
+
 	// Şifreyi hashle
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := s.passwords.Hash(password)
 	if err != nil {
 		return nil, errors.New("şifre hashlenirken hata oluştu")
 	}
@@ -55,117 +113,303 @@ func (s *AuthService) Register(username, email, password, firstName, lastName st
 	user := models.User{
 		Username:     username,
 		Email:        email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		FirstName:    firstName,
 		LastName:     lastName,
 	}
 
-	if err := db.Create(&user).Error; err != nil {
-		return nil, errors.New("kullanıcı oluşturulurken hata oluştu")
+	// Kullanıcı ve ilk oturumu tek işlemde kaydedilir; token üretilemezse kayıt da geri alınır
+	var response *LoginResponse
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Users.Create(&user); err != nil {
+			return errors.New("kullanıcı oluşturulurken hata oluştu")
+		}
+		response, err = s.issueTokenPair(repos, &user, "", client)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return response, nil
+}
 
-	// JWT token oluştur
-	token, err := utils.GenerateToken(user.ID, user.Email, s.config)
-	if err != nil {
+// Login kullanıcı girişi yapar ve JWT token döndürür
+func (s *AuthService) Login(email, password string, client ClientInfo) (*LoginResponse, error) {
+	// Kullanıcıyı bul. Kayıtlı olmayan e-posta ile hatalı şifre aynı hatayı döndürür.
+	var found *models.User
+	user, err := s.repos.Users.FindByEmailWithInterests(email)
+	if err == nil {
+		found = user
+	} else if !errors.Is(err, repository.ErrNotFound) {
 		return nil, err
 	}
 
-	// Refresh token oluştur
-	refreshToken, err := utils.GenerateRefreshToken(user.ID, s.config)
-	if err != nil {
+	if err := s.loginGuard.CheckAllowed(found, email, client.IPAddress); err != nil {
 		return nil, err
 	}
 
-	return &LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		User:         user,
-	}, nil
-}
+	if found == nil {
+		// Yanıt süresinden e-postanın kayıtlı olup olmadığı anlaşılmasın diye yine de hash doğrulaması yapılır
+		_, _ = s.passwords.Verify(password, s.dummyPasswordHash())
+		s.loginGuard.RecordFailure(nil, email, client.IPAddress)
+		return nil, ErrInvalidCredentials
+	}
 
-// Login kullanıcı girişi yapar ve JWT token döndürür
-func (s *AuthService) Login(email, password string) (*LoginResponse, error) {
-	db := database.GetDB()
+	// Şifreyi kontrol et
+	if ok, err := s.passwords.Verify(password, user.PasswordHash); !ok {
+		if err != nil {
+			log.Printf("[AuthService.Login] Şifre hash'i doğrulanamadı (UserID: %d): %v", user.ID, err)
+		}
+		s.loginGuard.RecordFailure(user, email, client.IPAddress)
+		return nil, ErrInvalidCredentials
+	}
+	s.rehashIfNeeded(user, password)
 
-	// Kullanıcıyı bul
-	var user models.User
-	if err := db.Preload("Interests").Where("email = ?", email).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("kullanıcı bulunamadı")
+	// İki adımlı doğrulama açıksa sayaç ancak kod doğrulandıktan sonra sıfırlanır
+	if !user.IsTwoFactorEnabled() {
+		s.loginGuard.RecordSuccess(user)
+	}
+
+	return s.completeLogin(user, client)
+}
+
+// completeLogin kimliği doğrulanmış kullanıcı için girişi tamamlar. İki adımlı doğrulama açıksa
+// token çifti yerine kısa ömürlü bir mfa_pending tokeni döner; aksi halde yeni bir token ailesi başlatılır.
+func (s *AuthService) completeLogin(user *models.User, client ClientInfo) (*LoginResponse, error) {
+	if user.IsSuspended() {
+		return nil, ErrAccountSuspended
+	}
+	if user.IsTwoFactorEnabled() {
+		mfaToken, err := utils.GenerateMFAPendingToken(user.ID, s.config)
+		if err != nil {
+			return nil, err
 		}
-		return nil, err
+		return &LoginResponse{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	// Şifreyi kontrol et
-	if err := user.CheckPassword(password); err != nil {
-		return nil, errors.New("geçersiz şifre")
+	return s.startSession(user, client)
+}
+
+// VerifyMFALogin mfa_pending tokeni ve iki adımlı doğrulama kodu (veya kurtarma kodu) ile girişi tamamlar.
+// Hatalı kodlar hatalı şifre gibi sayılır ve kaba kuvvet korumasına tabidir.
+func (s *AuthService) VerifyMFALogin(mfaToken, code string, client ClientInfo) (*LoginResponse, error) {
+	userID, err := utils.ValidateMFAPendingToken(mfaToken, s.config)
+	if err != nil {
+		return nil, errors.New("doğrulama oturumu geçersiz veya süresi dolmuş, lütfen tekrar giriş yapın")
 	}
 
-	// JWT token oluştur
-	token, err := utils.GenerateToken(user.ID, user.Email, s.config)
+	user, err := s.GetUserByID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Refresh token oluştur
-	refreshToken, err := utils.GenerateRefreshToken(user.ID, s.config)
-	if err != nil {
+	if err := s.loginGuard.CheckAllowed(user, user.Email, client.IPAddress); err != nil {
 		return nil, err
 	}
 
-	return &LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		User:         user,
-	}, nil
+	if err := s.twoFactor.VerifyCode(user, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.loginGuard.RecordFailure(user, user.Email, client.IPAddress)
+		}
+		return nil, err
+	}
+	s.loginGuard.RecordSuccess(user)
+
+	return s.startSession(user, client)
+}
+
+// UnlockAccount kilit açma bağlantısındaki token ile kilitli hesabı açar
+func (s *AuthService) UnlockAccount(token, ip string) error {
+	return s.loginGuard.Unlock(token, ip)
 }
 
 // GetUserByID kullanıcıyı ID ile bulur
 func (s *AuthService) GetUserByID(id uint64) (*models.User, error) {
-	db := database.GetDB()
-	var user models.User
-
-	if err := db.Preload("Interests").First(&user, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	user, err := s.repos.Users.FindByIDWithInterests(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, errors.New("kullanıcı bulunamadı")
 		}
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
-// RefreshToken yenileme tokeni ile yeni bir JWT token alır
-func (s *AuthService) RefreshToken(refreshToken string) (*LoginResponse, error) {
+// RefreshToken yenileme tokenini tek kullanımlık olarak döndürür (rotation) ve yeni bir token çifti üretir.
+// Daha önce döndürülmüş bir token tekrar gönderilirse token çalınmış kabul edilir ve
+// aynı aileye ait tüm token'lar iptal edilir. Oturumun son görülme zamanı ve istemci bilgileri güncellenir.
+func (s *AuthService) RefreshToken(refreshToken string, client ClientInfo) (*LoginResponse, error) {
 	// Yenileme tokenini doğrula
-	userID, err := utils.ValidateRefreshToken(refreshToken, s.config)
+	info, err := utils.ParseRefreshToken(refreshToken, s.config)
+	if err != nil || info.JTI == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+
+	var (
+		response *LoginResponse
+		reused   bool
+	)
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		stored, err := repos.RefreshTokens.FindByJTIForUpdate(info.JTI)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		if stored.UserID != info.UserID || stored.RevokedAt != nil || !now.Before(stored.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		if stored.RotatedAt != nil {
+			// Token yeniden kullanıldı: aileyi iptal et ve işlemi commit et
+			reused = true
+			return repos.RefreshTokens.RevokeFamily(stored.FamilyID, now)
+		}
+
+		// Koşullu güncelleme: eşzamanlı iki yenilemeden yalnızca biri başarılı olur
+		rotated, err := repos.RefreshTokens.MarkRotated(stored.ID, now)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			reused = true
+			return repos.RefreshTokens.RevokeFamily(stored.FamilyID, now)
+		}
+
+		user, err := repos.Users.FindByIDWithInterests(stored.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return errors.New("kullanıcı bulunamadı")
+			}
+			return err
+		}
+
+		// Şifre değişikliğinden önce üretilmiş token'lar artık geçerli değil.
+		// JWT zamanları saniye hassasiyetinde olduğu için karşılaştırma da saniyeye yuvarlanır.
+		if user.PasswordChangedAt != nil && info.IssuedAt.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+			return ErrInvalidRefreshToken
+		}
+
+		// Cihaz etiketi girişte belirlenir, yenilemede değişmez
+		client.DeviceLabel = stored.DeviceLabel
+		response, err = s.issueTokenPair(repos, user, stored.FamilyID, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		log.Printf("[AuthService.RefreshToken] Yeniden kullanılan yenileme tokeni, aile iptal edildi (UserID: %d)", info.UserID)
+		return nil, ErrRefreshTokenReused
+	}
+
+	return response, nil
+}
+
+// Logout erişim tokenini süresi dolana kadar iptal eder ve tokenin oturumunu sonlandırır.
+// Oturum kimliği taşımayan eski token'larda yenileme tokeni gönderildiyse o girişten türeyen
+// tüm yenileme token'ları iptal edilir.
+func (s *AuthService) Logout(userID uint64, accessJTI string, accessExpiresAt time.Time, sessionID, refreshToken string) error {
+	if err := auth.RevokeToken(s.revocations, accessJTI, accessExpiresAt); err != nil {
+		log.Printf("[AuthService.Logout] Erişim tokeni iptal edilemedi (UserID: %d): %v", userID, err)
+		return errors.New("çıkış yapılamadı")
+	}
+
+	if sessionID != "" {
+		if err := s.sessions.RevokeByFamily(userID, sessionID); err != nil {
+			log.Printf("[AuthService.Logout] Oturum sonlandırılamadı (UserID: %d): %v", userID, err)
+			return errors.New("çıkış yapılamadı")
+		}
+		return nil
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	// Yenileme tokeni geçersizse bile erişim tokeni iptal edildiği için çıkış başarılı sayılır
+	info, err := utils.ParseRefreshToken(refreshToken, s.config)
+	if err != nil || info.JTI == "" || info.UserID != userID {
+		return nil
+	}
+
+	stored, err := s.repos.RefreshTokens.FindByJTIForUser(info.JTI, userID)
 	if err != nil {
-		return nil, errors.New("geçersiz yenileme tokeni")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
 	}
+	return s.repos.RefreshTokens.RevokeFamily(stored.FamilyID, time.Now())
+}
 
-	// Kullanıcıyı bul
+// ChangePassword giriş yapmış kullanıcının şifresini değiştirir.
+// Mevcut şifre doğrulanır, kullanıcının tüm yenileme token'ları iptal edilir ve diğer oturumlar sonlandırılır.
+// İsteği yapan oturum (client.SessionID) yeni bir token çiftiyle devam eder.
+func (s *AuthService) ChangePassword(userID uint64, currentPassword, newPassword string, client ClientInfo) (*LoginResponse, error) {
 	user, err := s.GetUserByID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Yeni JWT token oluştur
-	token, err := utils.GenerateToken(user.ID, user.Email, s.config)
-	if err != nil {
+	if !s.VerifyPassword(user, currentPassword) {
+		return nil, errors.New("mevcut şifre hatalı")
+	}
+	if currentPassword == newPassword {
+		return nil, errors.New("yeni şifre mevcut şifre ile aynı olamaz")
+	}
+	if err := s.passwordPolicy.Validate(newPassword, user.Username, user.Email); err != nil {
 		return nil, err
 	}
 
-	// Yeni refresh token oluştur
-	newRefreshToken, err := utils.GenerateRefreshToken(user.ID, s.config)
+	hashedPassword, err := s.passwords.Hash(newPassword)
+	if err != nil {
+		return nil, errors.New("şifre hashlenirken hata oluştu")
+	}
+
+	var (
+		response *LoginResponse
+		revoked  []models.Session
+	)
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		now := time.Now()
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{
+			"password_hash":       hashedPassword,
+			"password_changed_at": now,
+		}); err != nil {
+			return errors.New("şifre güncellenemedi")
+		}
+		user.PasswordHash = hashedPassword
+		user.PasswordChangedAt = &now
+
+		if err := repos.RefreshTokens.RevokeAllForUser(user.ID, now); err != nil {
+			return err
+		}
+
+		// Geçerli oturum aynı token ailesiyle devam eder; oturum bilinmiyorsa yeni oturum açılır
+		familyID := ""
+		if s.sessions.isActive(repos, user.ID, client.SessionID) {
+			familyID = client.SessionID
+		}
+		var err error
+		if revoked, err = s.sessions.revokeAllTx(repos, user.ID, familyID); err != nil {
+			return err
+		}
+
+		response, err = s.issueTokenPair(repos, user, familyID, client)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &LoginResponse{
-		Token:        token,
-		RefreshToken: newRefreshToken,
-		User:         *user,
-	}, nil
+	s.sessions.disconnect(revoked)
+	return response, nil
 }
 
 // GenerateToken kullanıcı için JWT token oluşturur
@@ -185,29 +429,112 @@ func (s *AuthService) GenerateToken(userID uint64) (string, error) {
 	return token, nil
 }
 
-// IsStrongPassword şifre güvenliğini kontrol eder
-// Minimum 8 karakter, en az 1 büyük harf, 1 küçük harf ve 1 rakam
-func IsStrongPassword(password string) bool {
-	if len(password) < 8 {
-		return false
+// startSession kullanıcı için yeni bir token ailesi ve oturum başlatır.
+// Oturum ve yenileme tokeni tek işlemde kaydedilir.
+func (s *AuthService) startSession(user *models.User, client ClientInfo) (*LoginResponse, error) {
+	var response *LoginResponse
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		var err error
+		response, err = s.issueTokenPair(repos, user, "", client)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return response, nil
+}
 
-	var (
-		hasUpper  bool
-		hasLower  bool
-		hasNumber bool
-	)
+// issueTokenPair kullanıcı için erişim ve yenileme tokeni üretir, yenileme tokenini kaydeder.
+// familyID boşsa yeni bir token ailesi ve oturum başlatılır; doluysa oturumun son görülme zamanı güncellenir.
+func (s *AuthService) issueTokenPair(repos repository.Repositories, user *models.User, familyID string, client ClientInfo) (*LoginResponse, error) {
+	// Askıya alınmış hesaplar için hiçbir yoldan (giriş, yenileme, şifre değişikliği) token üretilmez
+	if user.IsSuspended() {
+		return nil, ErrAccountSuspended
+	}
 
-	for _, char := range password {
-		switch {
-		case 'a' <= char && char <= 'z':
-			hasLower = true
-		case 'A' <= char && char <= 'Z':
-			hasUpper = true
-		case '0' <= char && char <= '9':
-			hasNumber = true
+	var err error
+	if familyID == "" {
+		if familyID, err = utils.GenerateRandomToken(16); err != nil {
+			return nil, err
+		}
+		if err := s.sessions.start(repos, user.ID, familyID, client); err != nil {
+			return nil, err
 		}
+	} else if err := s.sessions.touch(repos, familyID, client); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateSessionToken(user.ID, user.Email, familyID, s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	jti, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken(user.ID, jti, s.config)
+	if err != nil {
+		return nil, err
 	}
 
-	return hasUpper && hasLower && hasNumber
+	record := models.RefreshToken{
+		UserID:      user.ID,
+		FamilyID:    familyID,
+		JTI:         jti,
+		DeviceLabel: client.DeviceLabel,
+		ExpiresAt:   time.Now().Add(s.config.RefreshExpiration),
+		CreatedAt:   time.Now(),
+	}
+	if err := repos.RefreshTokens.Create(&record); err != nil {
+		return nil, errors.New("yenileme tokeni kaydedilemedi")
+	}
+
+	return &LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         user,
+	}, nil
+}
+
+// rehashIfNeeded doğrulanan şifrenin hash'i eski bir algoritma veya parametrelerle üretilmişse
+// güncel ayarlarla yeniden hashler. Hata girişi engellemez; bir sonraki girişte tekrar denenir.
+func (s *AuthService) rehashIfNeeded(user *models.User, password string) {
+	if !s.passwords.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	newHash, err := s.passwords.Hash(password)
+	if err != nil {
+		log.Printf("[AuthService.rehashIfNeeded] Şifre yeniden hashlenemedi (UserID: %d): %v", user.ID, err)
+		return
+	}
+
+	// Hash arada değiştiyse (ör. eşzamanlı şifre değişikliği) üzerine yazılmaz
+	replaced, err := s.repos.Users.ReplacePasswordHash(user.ID, user.PasswordHash, newHash)
+	if err != nil {
+		log.Printf("[AuthService.rehashIfNeeded] Yeni şifre hash'i kaydedilemedi (UserID: %d): %v", user.ID, err)
+		return
+	}
+	if replaced {
+		user.PasswordHash = newHash
+	}
+}
+
+// dummyPasswordHash kayıtlı olmayan e-postalarla yapılan girişlerde karşılaştırılacak sabit bir hash döndürür
+func (s *AuthService) dummyPasswordHash() string {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHash, _ = s.passwords.Hash("event-app-dummy-password")
+	})
+	return s.dummyHash
+}
+
+// VerifyPassword şifrenin kullanıcının kayıtlı hash'i ile eşleşip eşleşmediğini döndürür
+func (s *AuthService) VerifyPassword(user *models.User, password string) bool {
+	ok, err := s.passwords.Verify(password, user.PasswordHash)
+	if err != nil {
+		log.Printf("[AuthService.VerifyPassword] Şifre hash'i doğrulanamadı (UserID: %d): %v", user.ID, err)
+	}
+	return ok
 }
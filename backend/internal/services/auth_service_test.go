@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	env := testutil.New(t)
+	authService := env.Services().Auth
+
+	login, err := authService.Register("yenileme", "yenileme@example.com", "Guclu-Sifre-2026!", "Test", "Kullanıcı", services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("kayıt başarısız: %v", err)
+	}
+
+	rotated, err := authService.RefreshToken(login.RefreshToken, services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("ilk yenileme başarısız: %v", err)
+	}
+
+	// Döndürülmüş (eski) token'ın tekrar kullanılması çalınma belirtisidir
+	if _, err := authService.RefreshToken(login.RefreshToken, services.ClientInfo{}); !errors.Is(err, services.ErrRefreshTokenReused) {
+		t.Fatalf("ErrRefreshTokenReused beklenirdi: %v", err)
+	}
+
+	var tokens []models.RefreshToken
+	env.DB.Where("user_id = ?", login.User.ID).Find(&tokens)
+	if len(tokens) != 2 {
+		t.Fatalf("ailede iki yenileme tokeni olmalı, %d", len(tokens))
+	}
+	for _, token := range tokens {
+		if token.FamilyID != tokens[0].FamilyID {
+			t.Fatalf("token'lar aynı aileye ait olmalı: %s, %s", token.FamilyID, tokens[0].FamilyID)
+		}
+		if token.RevokedAt == nil {
+			t.Fatalf("ailedeki tüm token'lar iptal edilmeli (JTI: %s)", token.JTI)
+		}
+	}
+
+	// Meşru istemcinin elindeki en yeni token da artık kullanılamaz
+	if _, err := authService.RefreshToken(rotated.RefreshToken, services.ClientInfo{}); !errors.Is(err, services.ErrInvalidRefreshToken) {
+		t.Fatalf("iptal edilen ailenin yeni tokeni reddedilmeli: %v", err)
+	}
+}
+
+func TestLoginRehashesLegacyBcryptPassword(t *testing.T) {
+	env := testutil.New(t)
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Eski-Sifre-2019!"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt hash'i üretilemedi: %v", err)
+	}
+	user := env.User(func(u *models.User) { u.PasswordHash = string(legacy) })
+	authService := env.Services().Auth
+
+	// 72 baytı aşan ama ilk 72 baytı aynı olan şifre eski hash'le eşleşmemeli ve hash'i değiştirmemeli
+	if _, err := authService.Login(user.Email, "Eski-Sifre-2019!"+strings.Repeat("x", 72), services.ClientInfo{}); !errors.Is(err, services.ErrInvalidCredentials) {
+		t.Fatalf("ErrInvalidCredentials beklenirdi: %v", err)
+	}
+	if _, err := authService.Login(user.Email, "Eski-Sifre-2019!", services.ClientInfo{}); err != nil {
+		t.Fatalf("eski bcrypt hash'iyle giriş başarısız: %v", err)
+	}
+
+	var stored models.User
+	env.DB.First(&stored, user.ID)
+	if !strings.HasPrefix(stored.PasswordHash, "$argon2id$") {
+		t.Fatalf("giriş sonrası hash argon2id ile yenilenmeli: %s", stored.PasswordHash)
+	}
+	if _, err := authService.Login(user.Email, "Eski-Sifre-2019!", services.ClientInfo{}); err != nil {
+		t.Fatalf("yenilenen hash'le giriş başarısız: %v", err)
+	}
+}
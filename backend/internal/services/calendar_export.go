@@ -0,0 +1,322 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/recurrence"
+	"event/backend/pkg/ical"
+)
+
+const (
+	// calendarProdID üretilen takvimlerin PRODID değeri
+	calendarProdID = "-//Event//Event Calendar//TR"
+	// calendarRefreshInterval abonelik akışlarının takvim uygulamalarınca yenilenme sıklığı (ISO 8601 süre)
+	calendarRefreshInterval = "PT1H"
+	// calendarTimezoneHorizon VTIMEZONE geçişlerinin son etkinlikten (veya bugünden) sonra kaç yıl ileriye yazılacağı
+	calendarTimezoneHorizon = 2
+)
+
+// calendarAttendees etkinlik kimliğine göre katılım ve davet kayıtları
+type calendarAttendees struct {
+	attendances map[uint64][]models.EventAttendance
+	invitations map[uint64][]models.EventInvitation
+}
+
+// timezoneSpan bir saat diliminin takvimde kullanıldığı zaman aralığı
+type timezoneSpan struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// buildCalendar etkinliklerden bir VCALENDAR oluşturur. Tekrarlanan seriler RRULE ve EXDATE ile, tekil
+// değişiklikleri ve tekrar bazında katılımı olan tekrarlar aynı UID'li RECURRENCE-ID bileşenleriyle yazılır.
+// Silinmiş etkinlikler STATUS:CANCELLED olarak yer alır. feed true ise abonelik yenileme bilgileri eklenir.
+func (s *CalendarService) buildCalendar(name string, events []models.Event, feed bool) (*ical.Component, error) {
+	cal := ical.NewCalendar(calendarProdID)
+	cal.AddText("X-WR-CALNAME", name)
+	if feed {
+		cal.Add("REFRESH-INTERVAL", calendarRefreshInterval, ical.Param{Name: "VALUE", Value: "DURATION"})
+		cal.Add("X-PUBLISHED-TTL", calendarRefreshInterval)
+	}
+
+	ids := make([]uint64, 0, len(events))
+	var seriesIDs []uint64
+	for i := range events {
+		ids = append(ids, events[i].ID)
+		if recurrence.IsSeries(&events[i]) && !events[i].DeletedAt.Valid {
+			seriesIDs = append(seriesIDs, events[i].ID)
+		}
+	}
+	overrides, err := s.repos.Events.FindOverrides(seriesIDs...)
+	if err != nil {
+		return nil, err
+	}
+	overridesBySeries := make(map[uint64][]models.Event)
+	for _, override := range overrides {
+		overridesBySeries[*override.SeriesID] = append(overridesBySeries[*override.SeriesID], override)
+	}
+	attendees, err := s.loadAttendees(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var vevents []*ical.Component
+	spans := make(map[string]*timezoneSpan)
+	for i := range events {
+		event := &events[i]
+		loc := recurrence.Location(event)
+		trackTimezone(spans, loc, *event.FinalStartTime)
+
+		vevents = append(vevents, s.vevent(event, event, nil, loc, attendees.partStats(event, "")))
+		if !recurrence.IsSeries(event) || event.DeletedAt.Valid {
+			continue
+		}
+		occurrences, err := s.occurrenceEvents(event, overridesBySeries[event.ID], attendees, loc)
+		if err != nil {
+			return nil, err
+		}
+		vevents = append(vevents, occurrences...)
+		for _, override := range overridesBySeries[event.ID] {
+			if override.FinalStartTime != nil {
+				trackTimezone(spans, loc, *override.FinalStartTime)
+			}
+		}
+	}
+
+	// VTIMEZONE bileşenleri onları kullanan etkinliklerden önce yazılır
+	names := make([]string, 0, len(spans))
+	for name := range spans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	horizon := time.Now().AddDate(calendarTimezoneHorizon, 0, 0)
+	for _, name := range names {
+		span := spans[name]
+		to := span.to.AddDate(calendarTimezoneHorizon, 0, 0)
+		if to.Before(horizon) {
+			to = horizon
+		}
+		cal.AddComponent(ical.Timezone(span.loc, span.from.AddDate(0, 0, -1), to))
+	}
+	for _, vevent := range vevents {
+		cal.AddComponent(vevent)
+	}
+	return cal, nil
+}
+
+// trackTimezone UTC dışındaki saat dilimlerinin kullanıldığı aralığı genişletir
+func trackTimezone(spans map[string]*timezoneSpan, loc *time.Location, t time.Time) {
+	if loc == time.UTC {
+		return
+	}
+	span, ok := spans[loc.String()]
+	if !ok {
+		spans[loc.String()] = &timezoneSpan{loc: loc, from: t, to: t}
+		return
+	}
+	if t.Before(span.from) {
+		span.from = t
+	}
+	if t.After(span.to) {
+		span.to = t
+	}
+}
+
+// occurrenceEvents serinin tekil değişikliği veya tekrar bazında katılımı olan tekrarları için
+// RECURRENCE-ID bileşenlerini oluşturur. İptal edilmiş ya da seriden çıkmış tekrarlar atlanır.
+func (s *CalendarService) occurrenceEvents(series *models.Event, overrides []models.Event, attendees calendarAttendees, loc *time.Location) ([]*ical.Component, error) {
+	keys := make(map[string]time.Time)
+	for _, override := range overrides {
+		keys[recurrence.Key(*override.RecurrenceID)] = *override.RecurrenceID
+	}
+	for _, attendance := range attendees.attendances[series.ID] {
+		if attendance.Occurrence == "" {
+			continue
+		}
+		if t, err := recurrence.ParseKey(attendance.Occurrence); err == nil {
+			keys[attendance.Occurrence] = t
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var result []*ical.Component
+	for _, key := range sorted {
+		occurrence := keys[key]
+		ok, err := recurrence.Contains(series, occurrence)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		override := findOverride(overrides, occurrence)
+		instance := recurrence.Instance(series, occurrence, override)
+		result = append(result, s.vevent(series, &instance, override, loc, attendees.partStats(series, key)))
+	}
+	return result, nil
+}
+
+// vevent tek bir VEVENT oluşturur. series UID, düzenleyen ve tekrar kuralının kaynağıdır; content başlık,
+// açıklama ve zamanların kaynağıdır. content bir tekrarsa (Occurrence dolu) RECURRENCE-ID yazılır ve
+// SEQUENCE ile değişiklik zamanı varsa tekil değişiklik kaydından alınır.
+func (s *CalendarService) vevent(series, content, override *models.Event, loc *time.Location, partStats []attendeePartStat) *ical.Component {
+	revision := series
+	if override != nil {
+		revision = override
+	}
+
+	vevent := &ical.Component{Name: "VEVENT"}
+	vevent.AddText("UID", s.eventUID(series))
+	stamp := revision.UpdatedAt
+	if series.DeletedAt.Valid {
+		stamp = series.DeletedAt.Time
+	}
+	vevent.AddTime("DTSTAMP", stamp, nil)
+	vevent.AddTime("CREATED", revision.CreatedAt, nil)
+	vevent.AddTime("LAST-MODIFIED", revision.UpdatedAt, nil)
+	vevent.Add("SEQUENCE", fmt.Sprint(revision.Sequence))
+	if content.Occurrence != nil {
+		vevent.AddTime("RECURRENCE-ID", *content.Occurrence, loc)
+	}
+
+	start := *content.FinalStartTime
+	end := start.Add(recurrence.DefaultDuration)
+	if content.FinalEndTime != nil && content.FinalEndTime.After(start) {
+		end = *content.FinalEndTime
+	}
+	vevent.AddTime("DTSTART", start, loc)
+	vevent.AddTime("DTEND", end, loc)
+	if content.Occurrence == nil && recurrence.IsSeries(series) {
+		vevent.Add("RRULE", series.RecurrenceRule)
+		vevent.AddTimes("EXDATE", recurrence.ExDates(series), loc)
+	}
+
+	vevent.AddText("SUMMARY", content.Title)
+	if content.Description != "" {
+		vevent.AddText("DESCRIPTION", content.Description)
+	}
+	if content.Location != "" {
+		vevent.AddText("LOCATION", content.Location)
+	}
+	vevent.Add("URL", fmt.Sprintf("%s%d", s.eventURL, series.ID))
+	if series.IsPrivate {
+		vevent.Add("CLASS", "PRIVATE")
+	} else {
+		vevent.Add("CLASS", "PUBLIC")
+	}
+	if series.DeletedAt.Valid {
+		vevent.Add("STATUS", "CANCELLED")
+	} else {
+		vevent.Add("STATUS", "CONFIRMED")
+	}
+
+	if series.Creator.ID != 0 && series.Creator.Email != "" {
+		vevent.Add("ORGANIZER", "mailto:"+series.Creator.Email, ical.Param{Name: "CN", Value: displayName(&series.Creator)})
+	}
+	for _, attendee := range partStats {
+		vevent.Add("ATTENDEE", "mailto:"+attendee.user.Email,
+			ical.Param{Name: "CN", Value: displayName(&attendee.user)},
+			ical.Param{Name: "ROLE", Value: "REQ-PARTICIPANT"},
+			ical.Param{Name: "PARTSTAT", Value: attendee.partStat},
+		)
+	}
+	return vevent
+}
+
+// displayName kullanıcının takvimde gösterilecek adı; ad soyad yoksa kullanıcı adı
+func displayName(user *models.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// attendeePartStat bir katılımcı ve RFC 5545 PARTSTAT değeri
+type attendeePartStat struct {
+	user     models.User
+	partStat string
+}
+
+// loadAttendees etkinliklerin katılım ve davet kayıtlarını tek seferde yükler
+func (s *CalendarService) loadAttendees(eventIDs []uint64) (calendarAttendees, error) {
+	result := calendarAttendees{
+		attendances: make(map[uint64][]models.EventAttendance),
+		invitations: make(map[uint64][]models.EventInvitation),
+	}
+	attendances, err := s.repos.Participation.FindAttendancesForEvents(eventIDs)
+	if err != nil {
+		return result, err
+	}
+	for _, attendance := range attendances {
+		result.attendances[attendance.EventID] = append(result.attendances[attendance.EventID], attendance)
+	}
+	invitations, err := s.repos.Participation.FindInvitationsForEvents(eventIDs)
+	if err != nil {
+		return result, err
+	}
+	for _, invitation := range invitations {
+		result.invitations[invitation.EventID] = append(result.invitations[invitation.EventID], invitation)
+	}
+	return result, nil
+}
+
+// partStats etkinliğin katılımcılarını PARTSTAT değerleriyle kullanıcı ID'sine göre sıralı döndürür.
+// occurrence boş değilse o tekrara ait katılımlar seri düzeyindekilerin üzerine uygulanır.
+// Katılım kaydı olmayan davetliler davetin durumuna göre eklenir.
+func (a calendarAttendees) partStats(event *models.Event, occurrence string) []attendeePartStat {
+	byUser := make(map[uint64]*attendeePartStat)
+	apply := func(key string) {
+		for _, attendance := range a.attendances[event.ID] {
+			if attendance.Occurrence != key || attendance.User.ID == 0 || attendance.User.Email == "" {
+				continue
+			}
+			partStat := "DECLINED"
+			switch attendance.Status {
+			case models.AttendanceGoing:
+				partStat = "ACCEPTED"
+			case models.AttendanceMaybe, models.AttendanceWaitlisted:
+				partStat = "TENTATIVE" // Belki yanıtı veya bekleme listesi; katılım henüz kesin değil
+			}
+			byUser[attendance.UserID] = &attendeePartStat{user: attendance.User, partStat: partStat}
+		}
+	}
+	apply("")
+	if occurrence != "" {
+		apply(occurrence)
+	}
+
+	for _, invitation := range a.invitations[event.ID] {
+		if _, exists := byUser[invitation.InviteeID]; exists || invitation.Invitee.ID == 0 || invitation.Invitee.Email == "" {
+			continue
+		}
+		var partStat string
+		switch invitation.Status {
+		case models.InvitationPending:
+			partStat = "NEEDS-ACTION"
+		case models.InvitationAccepted:
+			partStat = "ACCEPTED"
+		case models.InvitationDeclined:
+			partStat = "DECLINED"
+		default:
+			continue
+		}
+		byUser[invitation.InviteeID] = &attendeePartStat{user: invitation.Invitee, partStat: partStat}
+	}
+
+	result := make([]attendeePartStat, 0, len(byUser))
+	for _, attendee := range byUser {
+		result = append(result, *attendee)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].user.ID < result[j].user.ID
+	})
+	return result
+}
@@ -0,0 +1,863 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event/backend/internal/models"
+	"event/backend/internal/recurrence"
+	"event/backend/internal/repository"
+	"event/backend/pkg/ical"
+)
+
+const (
+	// maxImportEvents tek bir içe aktarımda kabul edilen en fazla VEVENT sayısı
+	maxImportEvents = 500
+	// maxImportConflicts bir etkinlik için raporlanan en fazla çakışma sayısı
+	maxImportConflicts = 10
+	// maxImportTitleLength etkinlik başlığının en fazla uzunluğu; daha uzun başlıklar kısaltılır
+	maxImportTitleLength = 100
+	// maxImportLocationLength konumun en fazla uzunluğu; daha uzun konumlar kısaltılır
+	maxImportLocationLength = 255
+)
+
+// ImportAction içe aktarılan bir etkinlik için yapılan (önizlemede yapılacak) işlem
+type ImportAction string
+
+const (
+	// ImportCreate yeni bir etkinlik oluşturulur
+	ImportCreate ImportAction = "create"
+	// ImportUpdate aynı UID ile daha önce içe aktarılmış etkinlik güncellenir
+	ImportUpdate ImportAction = "update"
+	// ImportUnchanged aynı UID'li etkinlik zaten dosyadaki haliyle kayıtlıdır
+	ImportUnchanged ImportAction = "unchanged"
+	// ImportCancel dosyada iptal edilmiş (STATUS:CANCELLED) etkinliğin kayıtlı karşılığı silinir
+	ImportCancel ImportAction = "cancel"
+	// ImportSkip etkinlik hatalı, dosyada yinelenmiş veya iptal edilmiş olduğundan içe aktarılmaz
+	ImportSkip ImportAction = "skip"
+)
+
+// ImportCalendarDTO .ics içe aktarımı seçenekleri
+type ImportCalendarDTO struct {
+	RoomID   *uint64 // Etkinliklerin ekleneceği oda; kullanıcı odanın aktif üyesi olmalıdır
+	TimeZone string  // TZID'siz yerel (floating) zamanlar ve tam gün etkinlikler için IANA saat dilimi; boşsa UTC
+	DryRun   bool    // true ise hiçbir şey kaydedilmez, yalnızca yapılacak işlemler raporlanır
+}
+
+// ImportConflict içe aktarılan etkinliğin zamanı çakışan mevcut etkinlik
+type ImportConflict struct {
+	EventID    uint64     `json:"event_id"`
+	Title      string     `json:"title"`
+	StartTime  time.Time  `json:"start_time"`
+	EndTime    time.Time  `json:"end_time"`
+	Occurrence *time.Time `json:"occurrence,omitempty"`
+}
+
+// ImportItem dosyadaki tek bir etkinliğin (tekil tekrar değişiklikleriyle birlikte) içe aktarım sonucu
+type ImportItem struct {
+	UID            string           `json:"uid"`
+	Title          string           `json:"title"`
+	StartTime      *time.Time       `json:"start_time,omitempty"`
+	EndTime        *time.Time       `json:"end_time,omitempty"`
+	RecurrenceRule string           `json:"recurrence_rule,omitempty"`
+	Overrides      int              `json:"overrides,omitempty"` // Tekil olarak değiştirilmiş tekrar sayısı
+	Action         ImportAction     `json:"action"`
+	EventID        uint64           `json:"event_id,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	Warnings       []string         `json:"warnings,omitempty"`
+	Conflicts      []ImportConflict `json:"conflicts,omitempty"`
+}
+
+// ImportResult içe aktarımın (veya önizlemenin) özeti
+type ImportResult struct {
+	DryRun    bool         `json:"dry_run"`
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Cancelled int          `json:"cancelled"`
+	Skipped   int          `json:"skipped"`
+	Items     []ImportItem `json:"items"`
+}
+
+// importCandidate kaydedilmeye hazır bir etkinlik ve tekil tekrar değişiklikleri
+type importCandidate struct {
+	item      *ImportItem
+	event     models.Event
+	overrides []models.Event
+	existing  *models.Event
+}
+
+// importGroup dosyada aynı UID'yi taşıyan VEVENT'ler: ana kayıtlar ve RECURRENCE-ID'li tekrar değişiklikleri
+type importGroup struct {
+	uid       string
+	masters   []*ical.Component
+	overrides []*ical.Component
+}
+
+// ImportCalendar .ics dosyasındaki etkinlikleri kullanıcı adına içe aktarır. Her VEVENT bir CreateEventDTO'ya
+// çevrilir ve normal etkinlik oluşturma kurallarıyla doğrulanır; RRULE, EXDATE, TZID ve RECURRENCE-ID'li
+// tekrar değişiklikleri desteklenir. Daha önce aynı UID ile içe aktarılmış (veya bu uygulamadan dışa aktarılmış)
+// etkinlikler yeniden oluşturulmak yerine güncellenir.
+//
+// Hatalı etkinlikler atlanır ve sonuçta raporlanır; geçerli etkinlikler tek bir işlemde kaydedilir. DryRun
+// ile hiçbir şey kaydedilmeden aynı rapor döner. Önümüzdeki bir yıl içinde kullanıcının veya odanın mevcut
+// etkinlikleriyle zaman çakışmaları bilgi amaçlı raporlanır, içe aktarımı engellemez.
+func (s *CalendarService) ImportCalendar(userID uint64, data io.Reader, dto ImportCalendarDTO) (*ImportResult, error) {
+	floating, err := loadTimeZone(dto.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	if dto.RoomID != nil {
+		if _, err := s.repos.Rooms.FindByID(*dto.RoomID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, errors.New("oda bulunamadı")
+			}
+			return nil, err
+		}
+		if _, err := s.repos.Rooms.FindActiveMember(*dto.RoomID, userID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, errors.New("bu odaya etkinlik ekleme yetkiniz yok")
+			}
+			return nil, err
+		}
+	}
+
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz iCalendar dosyası: %w", err)
+	}
+	if cal.Name != "VCALENDAR" {
+		return nil, errors.New("geçersiz iCalendar dosyası: VCALENDAR bulunamadı")
+	}
+	vevents := cal.Children("VEVENT")
+	if len(vevents) == 0 {
+		return nil, errors.New("dosyada içe aktarılacak etkinlik yok")
+	}
+	if len(vevents) > maxImportEvents {
+		return nil, fmt.Errorf("bir dosyada en fazla %d etkinlik içe aktarılabilir", maxImportEvents)
+	}
+
+	result := &ImportResult{DryRun: dto.DryRun, Items: []ImportItem{}}
+	groups := groupImportEvents(vevents, result)
+
+	existing, err := s.findImported(userID, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sonuç öğeleri adaylardan önce ayrılır; adaylar öğelere işaretçi tutar
+	items := make([]ImportItem, len(groups))
+	var candidates []*importCandidate
+	claimed := make(map[uint64]bool)
+	for i, group := range groups {
+		item := &items[i]
+		item.UID = group.uid
+		candidate, err := s.parseImportEvent(userID, group, dto, floating, item)
+		if err != nil {
+			item.Action = ImportSkip
+			item.Error = err.Error()
+			continue
+		}
+
+		if match := existing[group.uid]; match != nil {
+			if claimed[match.ID] {
+				item.Action = ImportSkip
+				item.Error = fmt.Sprintf("etkinlik (ID: %d) dosyadaki başka bir UID ile zaten eşleşti", match.ID)
+				continue
+			}
+			claimed[match.ID] = true
+			candidate.existing = match
+			item.EventID = match.ID
+		}
+		if err := s.planImport(candidate, dto); err != nil {
+			item.Action = ImportSkip
+			item.Error = err.Error()
+			continue
+		}
+		if item.Action != ImportSkip {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if err := s.importConflicts(userID, dto.RoomID, candidates); err != nil {
+		return nil, err
+	}
+
+	if !dto.DryRun {
+		if err := s.uow.WithTx(func(repos repository.Repositories) error {
+			for _, candidate := range candidates {
+				if err := saveImported(repos, candidate); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			log.Printf("[CalendarService.ImportCalendar] İçe aktarım kaydedilemedi (UserID: %d): %v", userID, err)
+			return nil, errors.New("etkinlikler kaydedilemedi")
+		}
+	}
+
+	// Öğeler kaydedildikten sonra kopyalanır; kayıt sırasında oluşturulan etkinliklerin ID'leri öğelere yazılır
+	result.Items = append(items, result.Items...)
+	for _, item := range result.Items {
+		switch item.Action {
+		case ImportCreate:
+			result.Created++
+		case ImportUpdate:
+			result.Updated++
+		case ImportUnchanged:
+			result.Unchanged++
+		case ImportCancel:
+			result.Cancelled++
+		default:
+			result.Skipped++
+		}
+	}
+	if !dto.DryRun {
+		log.Printf("[CalendarService.ImportCalendar] Etkinlikler içe aktarıldı (UserID: %d, Oluşturulan: %d, Güncellenen: %d, İptal: %d, Atlanan: %d)",
+			userID, result.Created, result.Updated, result.Cancelled, result.Skipped)
+	}
+	return result, nil
+}
+
+// groupImportEvents VEVENT'leri dosyadaki ilk görünüş sırasıyla UID'ye göre gruplar. UID'siz, yinelenen ve
+// serisi dosyada olmayan kayıtlar atlanan öğe olarak result'a eklenir.
+func groupImportEvents(vevents []*ical.Component, result *ImportResult) []*importGroup {
+	var groups []*importGroup
+	byUID := make(map[string]*importGroup)
+	for _, vevent := range vevents {
+		uid := strings.TrimSpace(vevent.Text("UID"))
+		if uid == "" {
+			result.Items = append(result.Items, ImportItem{
+				Title:  vevent.Text("SUMMARY"),
+				Action: ImportSkip,
+				Error:  "UID eksik",
+			})
+			continue
+		}
+		group := byUID[uid]
+		if group == nil {
+			group = &importGroup{uid: uid}
+			byUID[uid] = group
+			groups = append(groups, group)
+		}
+		if vevent.Property("RECURRENCE-ID") != nil {
+			group.overrides = append(group.overrides, vevent)
+		} else {
+			group.masters = append(group.masters, vevent)
+		}
+	}
+
+	valid := groups[:0]
+	for _, group := range groups {
+		switch {
+		case len(group.masters) == 0:
+			result.Items = append(result.Items, ImportItem{
+				UID:    group.uid,
+				Title:  group.overrides[0].Text("SUMMARY"),
+				Action: ImportSkip,
+				Error:  "tekrarın ait olduğu etkinlik dosyada yok",
+			})
+			continue
+		case len(group.masters) > 1:
+			for _, duplicate := range group.masters[1:] {
+				result.Items = append(result.Items, ImportItem{
+					UID:    group.uid,
+					Title:  duplicate.Text("SUMMARY"),
+					Action: ImportSkip,
+					Error:  "aynı UID'li etkinlik dosyada birden fazla kez yer alıyor",
+				})
+			}
+		}
+		valid = append(valid, group)
+	}
+	return valid
+}
+
+// findImported gruplarla aynı UID'yi taşıyan, kullanıcının daha önce içe aktardığı etkinlikleri UID'ye göre döndürür.
+// Bu uygulamanın dışa aktardığı UID'ler (event-<id>@alan) kullanıcının kendi etkinliğiyle de eşleşir.
+func (s *CalendarService) findImported(userID uint64, groups []*importGroup) (map[string]*models.Event, error) {
+	uids := make([]string, 0, len(groups))
+	for _, group := range groups {
+		uids = append(uids, group.uid)
+	}
+	events, err := s.repos.Events.FindByICalUIDs(userID, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*models.Event, len(events))
+	for i := range events {
+		if found[events[i].ICalUID] == nil {
+			found[events[i].ICalUID] = &events[i]
+		}
+	}
+	for _, uid := range uids {
+		if found[uid] != nil {
+			continue
+		}
+		id, ok := s.exportedEventID(uid)
+		if !ok {
+			continue
+		}
+		event, err := s.repos.Events.FindByID(id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if event.CreatorUserID == userID && event.SeriesID == nil {
+			found[uid] = event
+		}
+	}
+	return found, nil
+}
+
+// exportedEventID bu uygulamanın dışa aktardığı bir UID'den etkinlik ID'sini çıkarır
+func (s *CalendarService) exportedEventID(uid string) (uint64, bool) {
+	value, ok := strings.CutPrefix(uid, "event-")
+	if !ok {
+		return 0, false
+	}
+	value, ok = strings.CutSuffix(value, "@"+s.uidDomain)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	return id, err == nil && id != 0
+}
+
+// eventUID etkinliğin iCalendar UID'si: içe aktarılmışsa özgün UID, değilse uygulamanın ürettiği UID
+func (s *CalendarService) eventUID(event *models.Event) string {
+	if event.ICalUID != "" {
+		return event.ICalUID
+	}
+	return fmt.Sprintf("event-%d@%s", event.ID, s.uidDomain)
+}
+
+// parseImportEvent grubun ana VEVENT'ini ve tekrar değişikliklerini bir etkinliğe çevirir. İptal edilmiş
+// (STATUS:CANCELLED) etkinliklerde item.Action ImportCancel olarak işaretlenir.
+func (s *CalendarService) parseImportEvent(userID uint64, group *importGroup, dto ImportCalendarDTO, floating *time.Location, item *ImportItem) (*importCandidate, error) {
+	master := group.masters[0]
+	title := strings.TrimSpace(master.Text("SUMMARY"))
+	item.Title = title
+	if title == "" {
+		return nil, errors.New("başlık (SUMMARY) eksik")
+	}
+	if utf8.RuneCountInString(title) > maxImportTitleLength {
+		title = truncateRunes(title, maxImportTitleLength)
+		item.Warnings = append(item.Warnings, fmt.Sprintf("başlık %d karaktere kısaltıldı", maxImportTitleLength))
+	}
+
+	start, end, timeZone, err := importTimes(master, dto.TimeZone, floating, recurrence.DefaultDuration)
+	if err != nil {
+		return nil, err
+	}
+	item.StartTime, item.EndTime = &start, &end
+
+	rules := master.PropertiesNamed("RRULE")
+	if len(rules) > 1 {
+		return nil, errors.New("birden fazla RRULE desteklenmiyor")
+	}
+	var rule string
+	if len(rules) == 1 {
+		rule = rules[0].Value
+	}
+	if master.Property("RDATE") != nil {
+		item.Warnings = append(item.Warnings, "RDATE desteklenmiyor, ek tekrar tarihleri atlandı")
+	}
+
+	exDates, err := importExDates(master, floating)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, overrides, err := importOverrides(group.overrides, floating)
+	if err != nil {
+		return nil, err
+	}
+	if rule != "" {
+		exDates = append(exDates, cancelled...)
+	}
+
+	class := strings.ToUpper(master.Text("CLASS"))
+	createDTO := CreateEventDTO{
+		Title:          title,
+		Description:    strings.TrimSpace(master.Text("DESCRIPTION")),
+		IsPrivate:      class == "PRIVATE" || class == "CONFIDENTIAL",
+		TimeOptions:    []string{start.Format(time.RFC3339)},
+		RecurrenceRule: rule,
+		TimeZone:       timeZone,
+	}
+	if dto.RoomID != nil {
+		roomID := uint(*dto.RoomID)
+		createDTO.RoomID = &roomID
+	}
+	for _, t := range exDates {
+		createDTO.ExDates = append(createDTO.ExDates, recurrence.Key(t))
+	}
+
+	event, _, err := newEvent(userID, createDTO)
+	if err != nil {
+		return nil, err
+	}
+	event.FinalStartTime = &start
+	event.FinalEndTime = &end
+	event.Location = strings.TrimSpace(master.Text("LOCATION"))
+	if utf8.RuneCountInString(event.Location) > maxImportLocationLength {
+		event.Location = truncateRunes(event.Location, maxImportLocationLength)
+		item.Warnings = append(item.Warnings, fmt.Sprintf("konum %d karaktere kısaltıldı", maxImportLocationLength))
+	}
+	event.ICalUID = group.uid
+	item.RecurrenceRule = event.RecurrenceRule
+
+	if strings.EqualFold(master.Text("STATUS"), "CANCELLED") {
+		item.Action = ImportCancel
+		return &importCandidate{item: item, event: event}, nil
+	}
+
+	candidate := &importCandidate{item: item, event: event}
+	for i := range overrides {
+		override := overrides[i]
+		if rule == "" {
+			item.Warnings = append(item.Warnings, "tekrar kuralı olmayan etkinliğin tekrar değişiklikleri atlandı")
+			break
+		}
+		ok, err := recurrence.Contains(&event, *override.RecurrenceID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("%s serinin bir tekrarı değil, değişiklik atlandı", recurrence.Key(*override.RecurrenceID)))
+			continue
+		}
+		if override.Title == "" {
+			override.Title = event.Title
+		}
+		if utf8.RuneCountInString(override.Title) > maxImportTitleLength {
+			override.Title = truncateRunes(override.Title, maxImportTitleLength)
+		}
+		if override.FinalStartTime == nil {
+			occurrenceStart := *override.RecurrenceID
+			override.FinalStartTime = &occurrenceStart
+		}
+		if override.FinalEndTime == nil {
+			occurrenceEnd := override.FinalStartTime.Add(recurrence.Duration(&event))
+			override.FinalEndTime = &occurrenceEnd
+		}
+		override.Location = truncateRunes(override.Location, maxImportLocationLength)
+		override.CreatorUserID = userID
+		override.RoomID = event.RoomID
+		override.IsPrivate = event.IsPrivate
+		override.TimeZone = event.TimeZone
+		candidate.overrides = append(candidate.overrides, override)
+	}
+	sort.Slice(candidate.overrides, func(i, j int) bool {
+		return candidate.overrides[i].RecurrenceID.Before(*candidate.overrides[j].RecurrenceID)
+	})
+	item.Overrides = len(candidate.overrides)
+	return candidate, nil
+}
+
+// importTimes VEVENT'in başlangıç ve bitişini ve etkinliğin saat dilimini döndürür. Bitiş DTEND, DURATION veya
+// tam gün etkinliklerde bir gün sonrası; hiçbiri yoksa başlangıç + defaultDuration'dır. UTC ("Z") zamanlı
+// etkinlikler UTC'de, TZID'li olanlar o dilimde, yerel (floating) zamanlılar defaultZone'da tekrarlanır.
+func importTimes(vevent *ical.Component, defaultZone string, floating *time.Location, defaultDuration time.Duration) (start, end time.Time, timeZone string, err error) {
+	dtstart := vevent.Property("DTSTART")
+	if dtstart == nil {
+		return start, end, "", errors.New("başlangıç zamanı (DTSTART) eksik")
+	}
+	start, allDay, err := dtstart.Time(floating)
+	if err != nil {
+		return start, end, "", err
+	}
+	switch {
+	case dtstart.Param("TZID") != "":
+		timeZone = dtstart.Param("TZID")
+	case !allDay && strings.HasSuffix(dtstart.Value, "Z"):
+		timeZone = ""
+	default:
+		timeZone = defaultZone
+	}
+
+	switch {
+	case vevent.Property("DTEND") != nil:
+		end, _, err = vevent.Property("DTEND").Time(floating)
+		if err != nil {
+			return start, end, "", err
+		}
+	case vevent.Property("DURATION") != nil:
+		duration, err := ical.ParseDuration(vevent.Property("DURATION").Value)
+		if err != nil {
+			return start, end, "", err
+		}
+		end = start.Add(duration)
+	case allDay:
+		end = start.AddDate(0, 0, 1)
+	default:
+		end = start.Add(defaultDuration)
+	}
+	if !end.After(start) {
+		return start, end, "", errors.New("bitiş zamanı başlangıçtan sonra olmalı")
+	}
+	return start, end, timeZone, nil
+}
+
+// importExDates VEVENT'in tüm EXDATE değerlerini döndürür
+func importExDates(vevent *ical.Component, floating *time.Location) ([]time.Time, error) {
+	var exDates []time.Time
+	for _, prop := range vevent.PropertiesNamed("EXDATE") {
+		times, _, err := prop.Times(floating)
+		if err != nil {
+			return nil, err
+		}
+		exDates = append(exDates, times...)
+	}
+	return exDates, nil
+}
+
+// importOverrides RECURRENCE-ID'li VEVENT'leri ayrıştırır. İptal edilen tekrarlar cancelled olarak, değiştirilen
+// tekrarlar kaydedilmemiş tekil değişiklik kayıtları olarak döner. Boş bırakılan başlık ve zamanlar seriden alınır.
+func importOverrides(vevents []*ical.Component, floating *time.Location) (cancelled []time.Time, overrides []models.Event, err error) {
+	seen := make(map[string]bool)
+	for _, vevent := range vevents {
+		recurrenceID, _, err := vevent.Property("RECURRENCE-ID").Time(floating)
+		if err != nil {
+			return nil, nil, err
+		}
+		recurrenceID = recurrenceID.UTC()
+		key := recurrence.Key(recurrenceID)
+		if seen[key] {
+			return nil, nil, fmt.Errorf("%s tekrarı dosyada birden fazla kez değiştirilmiş", key)
+		}
+		seen[key] = true
+
+		if strings.EqualFold(vevent.Text("STATUS"), "CANCELLED") {
+			cancelled = append(cancelled, recurrenceID)
+			continue
+		}
+
+		override := models.Event{
+			Title:        strings.TrimSpace(vevent.Text("SUMMARY")),
+			Description:  strings.TrimSpace(vevent.Text("DESCRIPTION")),
+			Location:     strings.TrimSpace(vevent.Text("LOCATION")),
+			RecurrenceID: &recurrenceID,
+		}
+		if dtstart := vevent.Property("DTSTART"); dtstart != nil {
+			start, _, err := dtstart.Time(floating)
+			if err != nil {
+				return nil, nil, err
+			}
+			override.FinalStartTime = &start
+			// Bitişi olmayan değişiklikte süre seriden alınır
+			if vevent.Property("DTEND") != nil || vevent.Property("DURATION") != nil {
+				_, end, _, err := importTimes(vevent, "", floating, 0)
+				if err != nil {
+					return nil, nil, err
+				}
+				override.FinalEndTime = &end
+			}
+		}
+		overrides = append(overrides, override)
+	}
+	return cancelled, overrides, nil
+}
+
+// planImport adayın mevcut kayda göre yapılacak işlemini belirler. Güncellenen etkinliklerin odası, oda
+// verilmediyse korunur.
+func (s *CalendarService) planImport(candidate *importCandidate, dto ImportCalendarDTO) error {
+	item, existing := candidate.item, candidate.existing
+	if item.Action == ImportCancel {
+		if existing == nil {
+			item.Action = ImportSkip
+			item.Warnings = append(item.Warnings, "iptal edilmiş etkinlik içe aktarılmadı")
+		}
+		return nil
+	}
+	if existing == nil {
+		item.Action = ImportCreate
+		return nil
+	}
+
+	event := &candidate.event
+	event.ID = existing.ID
+	event.Sequence = existing.Sequence
+	if existing.ICalUID == "" {
+		// Bu uygulamadan dışa aktarılmış etkinlik kendi UID'siyle eşleşmeye devam eder
+		event.ICalUID = ""
+	}
+	if dto.RoomID == nil {
+		event.RoomID = existing.RoomID
+	}
+	for i := range candidate.overrides {
+		candidate.overrides[i].RoomID = event.RoomID
+	}
+
+	currentOverrides, err := s.repos.Events.FindOverrides(existing.ID)
+	if err != nil {
+		return err
+	}
+	if sameImportedEvent(existing, event) && sameOverrides(currentOverrides, candidate.overrides) {
+		item.Action = ImportUnchanged
+	} else {
+		item.Action = ImportUpdate
+	}
+	return nil
+}
+
+// sameImportedEvent içe aktarımın yazdığı alanların değişip değişmediğini döndürür
+func sameImportedEvent(current, next *models.Event) bool {
+	return current.Title == next.Title &&
+		current.Description == next.Description &&
+		current.Location == next.Location &&
+		current.IsPrivate == next.IsPrivate &&
+		current.TimeZone == next.TimeZone &&
+		current.RecurrenceRule == next.RecurrenceRule &&
+		current.ExDates == next.ExDates &&
+		equalRoom(current.RoomID, next.RoomID) &&
+		equalTime(current.FinalStartTime, next.FinalStartTime) &&
+		equalTime(current.FinalEndTime, next.FinalEndTime)
+}
+
+// sameOverrides iki tekil tekrar değişikliği listesinin (RECURRENCE-ID sırasıyla) aynı olup olmadığını döndürür
+func sameOverrides(current, next []models.Event) bool {
+	if len(current) != len(next) {
+		return false
+	}
+	for i := range current {
+		if !equalTime(current[i].RecurrenceID, next[i].RecurrenceID) ||
+			!equalTime(current[i].FinalStartTime, next[i].FinalStartTime) ||
+			!equalTime(current[i].FinalEndTime, next[i].FinalEndTime) ||
+			current[i].Title != next[i].Title ||
+			current[i].Description != next[i].Description ||
+			current[i].Location != next[i].Location {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalRoom(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// truncateRunes metni en fazla n karaktere kısaltır
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:n]))
+}
+
+// saveImported adayı planlanan işleme göre kaydeder
+func saveImported(repos repository.Repositories, candidate *importCandidate) error {
+	event, item := &candidate.event, candidate.item
+	switch item.Action {
+	case ImportCreate:
+		if err := repos.Events.Create(event); err != nil {
+			return err
+		}
+		item.EventID = event.ID
+		if err := repos.Events.CreateTimeOption(&models.EventTimeOption{
+			EventID:   event.ID,
+			StartTime: *event.FinalStartTime,
+			EndTime:   *event.FinalEndTime,
+		}); err != nil {
+			return err
+		}
+		return createOverrides(repos, event.ID, candidate.overrides)
+
+	case ImportUpdate:
+		existing := candidate.existing
+		if err := repos.Events.UpdateFields(event.ID, map[string]interface{}{
+			"title":            event.Title,
+			"description":      event.Description,
+			"location":         event.Location,
+			"room_id":          event.RoomID,
+			"is_private":       event.IsPrivate,
+			"time_zone":        event.TimeZone,
+			"recurrence_rule":  event.RecurrenceRule,
+			"ex_dates":         event.ExDates,
+			"final_start_time": event.FinalStartTime,
+			"final_end_time":   event.FinalEndTime,
+		}); err != nil {
+			return err
+		}
+		if err := repos.Events.IncrementSequence(event.ID); err != nil {
+			return err
+		}
+		if !equalTime(existing.FinalStartTime, event.FinalStartTime) || !equalTime(existing.FinalEndTime, event.FinalEndTime) {
+			if err := repos.Events.DeleteTimeOptions(event.ID); err != nil {
+				return err
+			}
+			if err := repos.Events.CreateTimeOption(&models.EventTimeOption{
+				EventID:   event.ID,
+				StartTime: *event.FinalStartTime,
+				EndTime:   *event.FinalEndTime,
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Seri değiştiyse tekrar anahtarları da geçersizdir; tekrar bazındaki katılımlar da silinir
+		if existing.RecurrenceRule != event.RecurrenceRule || !equalTime(existing.FinalStartTime, event.FinalStartTime) {
+			if err := clearOccurrences(repos, event.ID); err != nil {
+				return err
+			}
+		} else {
+			overrides, err := repos.Events.FindOverrides(event.ID)
+			if err != nil {
+				return err
+			}
+			for _, override := range overrides {
+				if err := repos.Events.Delete(override.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return createOverrides(repos, event.ID, candidate.overrides)
+
+	case ImportCancel:
+		return repos.Events.Delete(candidate.existing.ID)
+	}
+	return nil
+}
+
+// createOverrides tekil tekrar değişikliklerini seriye bağlayarak oluşturur
+func createOverrides(repos repository.Repositories, seriesID uint64, overrides []models.Event) error {
+	for i := range overrides {
+		override := overrides[i]
+		override.SeriesID = &seriesID
+		if err := repos.Events.Create(&override); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importInterval açılmış bir etkinliğin veya tekrarın zaman aralığı
+type importInterval struct {
+	event      *models.Event
+	start, end time.Time
+}
+
+// importConflicts içe aktarılacak etkinliklerin önümüzdeki bir yıldaki tekrarlarını kullanıcının oluşturduğu
+// veya katıldığı ve hedef odanın mevcut etkinlikleriyle karşılaştırır; çakışmaları öğelere yazar.
+// Güncellenen veya silinecek etkinliklerin kendisi karşılaştırmaya katılmaz.
+func (s *CalendarService) importConflicts(userID uint64, roomID *uint64, candidates []*importCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+	from := time.Now()
+	to := from.Add(MaxEventRange)
+
+	replaced := make(map[uint64]bool)
+	for _, candidate := range candidates {
+		if candidate.existing != nil {
+			replaced[candidate.existing.ID] = true
+		}
+	}
+
+	events, err := s.repos.Events.FindCalendarForUser(userID, from)
+	if err != nil {
+		return err
+	}
+	if roomID != nil {
+		roomEvents, err := s.repos.Events.FindCalendarForRoom(*roomID, true, from)
+		if err != nil {
+			return err
+		}
+		events = append(events, roomEvents...)
+	}
+	seen := make(map[uint64]bool)
+	current := events[:0]
+	var seriesIDs []uint64
+	for _, event := range events {
+		if event.DeletedAt.Valid || replaced[event.ID] || seen[event.ID] {
+			continue
+		}
+		seen[event.ID] = true
+		current = append(current, event)
+		if recurrence.IsSeries(&event) {
+			seriesIDs = append(seriesIDs, event.ID)
+		}
+	}
+	overrides, err := s.repos.Events.FindOverrides(seriesIDs...)
+	if err != nil {
+		return err
+	}
+	expanded, err := recurrence.Expand(current, overrides, from, to)
+	if err != nil {
+		return err
+	}
+
+	intervals := make([]importInterval, 0, len(expanded))
+	var longest time.Duration
+	for i := range expanded {
+		interval := importInterval{
+			event: &expanded[i],
+			start: *expanded[i].FinalStartTime,
+			end:   expanded[i].FinalStartTime.Add(recurrence.Duration(&expanded[i])),
+		}
+		if d := interval.end.Sub(interval.start); d > longest {
+			longest = d
+		}
+		intervals = append(intervals, interval)
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	for _, candidate := range candidates {
+		if candidate.item.Action == ImportCancel {
+			continue
+		}
+		series := candidate.event
+		seriesOverrides := make([]models.Event, len(candidate.overrides))
+		for i := range candidate.overrides {
+			seriesOverrides[i] = candidate.overrides[i]
+			seriesOverrides[i].SeriesID = &series.ID
+		}
+		occurrences, err := recurrence.Expand([]models.Event{series}, seriesOverrides, from, to)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(occurrences) && len(candidate.item.Conflicts) < maxImportConflicts; i++ {
+			start := *occurrences[i].FinalStartTime
+			end := start.Add(recurrence.Duration(&occurrences[i]))
+			// Aralıklar başlangıca göre sıralı; en uzun etkinlikten daha önce başlayanlar çakışamaz
+			first := sort.Search(len(intervals), func(j int) bool {
+				return intervals[j].start.After(start.Add(-longest))
+			})
+			for j := first; j < len(intervals) && intervals[j].start.Before(end); j++ {
+				if !intervals[j].end.After(start) || len(candidate.item.Conflicts) >= maxImportConflicts {
+					continue
+				}
+				existing := intervals[j].event
+				candidate.item.Conflicts = append(candidate.item.Conflicts, ImportConflict{
+					EventID:    existing.ID,
+					Title:      existing.Title,
+					StartTime:  intervals[j].start,
+					EndTime:    intervals[j].end,
+					Occurrence: existing.Occurrence,
+				})
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+// ics satırlarını VCALENDAR içine alıp CRLF ile birleştirir
+func ics(lines ...string) string {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Test//TR"}, lines...)
+	all = append(all, "END:VCALENDAR")
+	return strings.Join(all, "\r\n") + "\r\n"
+}
+
+func importCalendar(t *testing.T, calendar *services.CalendarService, userID uint64, data string, dto services.ImportCalendarDTO) *services.ImportResult {
+	t.Helper()
+	result, err := calendar.ImportCalendar(userID, strings.NewReader(data), dto)
+	if err != nil {
+		t.Fatalf("içe aktarım başarısız: %v", err)
+	}
+	return result
+}
+
+func itemFor(t *testing.T, result *services.ImportResult, uid string) services.ImportItem {
+	t.Helper()
+	for _, item := range result.Items {
+		if item.UID == uid {
+			return item
+		}
+	}
+	t.Fatalf("%s için sonuç yok: %+v", uid, result.Items)
+	return services.ImportItem{}
+}
+
+var clubCalendar = ics(
+	"BEGIN:VEVENT",
+	"UID:kulup-1@ornek",
+	"DTSTART;TZID=Europe/Berlin:20270104T190000",
+	"DTEND;TZID=Europe/Berlin:20270104T203000",
+	"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=6",
+	"EXDATE;TZID=Europe/Berlin:20270111T190000",
+	"SUMMARY:Kitap kulübü",
+	`DESCRIPTION:Haftalık kitap\, sohbet`,
+	"LOCATION:Kütüphane",
+	"CLASS:PRIVATE",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:kulup-1@ornek",
+	"RECURRENCE-ID;TZID=Europe/Berlin:20270118T190000",
+	"DTSTART;TZID=Europe/Berlin:20270119T190000",
+	"DTEND;TZID=Europe/Berlin:20270119T200000",
+	"SUMMARY:Kitap kulübü (salı)",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:kulup-1@ornek",
+	"RECURRENCE-ID;TZID=Europe/Berlin:20270125T190000",
+	"STATUS:CANCELLED",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:piknik@ornek",
+	"DTSTART;VALUE=DATE:20270601",
+	"SUMMARY:Piknik",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:piknik@ornek",
+	"DTSTART;VALUE=DATE:20270602",
+	"SUMMARY:Piknik kopyası",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:zamansiz@ornek",
+	"SUMMARY:Zamansız",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:yillik@ornek",
+	"DTSTART:20270101T100000Z",
+	"RRULE:FREQ=YEARLY",
+	"SUMMARY:Yıllık toplantı",
+	"END:VEVENT",
+)
+
+func TestImportCalendar(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	calendar := env.Services().Calendar
+	owner := env.User()
+	dto := services.ImportCalendarDTO{TimeZone: "Europe/Istanbul", DryRun: true}
+
+	// Önizleme hiçbir şey kaydetmez
+	preview := importCalendar(t, calendar, owner.ID, clubCalendar, dto)
+	if !preview.DryRun || preview.Created != 2 || preview.Skipped != 3 || len(preview.Items) != 5 {
+		t.Fatalf("önizleme 2 oluşturma ve 3 atlama göstermeli: %+v", preview)
+	}
+	for uid, want := range map[string]string{"zamansiz@ornek": "DTSTART", "yillik@ornek": "YEARLY"} {
+		if item := itemFor(t, preview, uid); item.Action != services.ImportSkip || !strings.Contains(item.Error, want) {
+			t.Fatalf("%s atlanmalı ve hatada %s geçmeli: %+v", uid, want, item)
+		}
+	}
+	if created, _ := events.GetEventsCreatedByUser(owner.ID, owner.ID); len(created) != 0 {
+		t.Fatalf("önizleme etkinlik oluşturmamalı: %d", len(created))
+	}
+
+	dto.DryRun = false
+	result := importCalendar(t, calendar, owner.ID, clubCalendar, dto)
+	if result.Created != 2 || result.Skipped != 3 {
+		t.Fatalf("2 etkinlik oluşturulmalı: %+v", result)
+	}
+	club := itemFor(t, result, "kulup-1@ornek")
+	if club.Action != services.ImportCreate || club.EventID == 0 || club.Overrides != 1 {
+		t.Fatalf("seri tekrar değişikliğiyle oluşturulmalı: %+v", club)
+	}
+
+	var series models.Event
+	env.DB.First(&series, club.EventID)
+	if series.ICalUID != "kulup-1@ornek" || series.TimeZone != "Europe/Berlin" || !series.IsPrivate ||
+		series.Location != "Kütüphane" || series.Description != "Haftalık kitap, sohbet" {
+		t.Fatalf("seri alanları yanlış: %+v", series)
+	}
+	if !series.FinalStartTime.Equal(time.Date(2027, 1, 4, 18, 0, 0, 0, time.UTC)) ||
+		series.FinalEndTime.Sub(*series.FinalStartTime) != 90*time.Minute {
+		t.Fatalf("seri zamanı yanlış: %v - %v", series.FinalStartTime, series.FinalEndTime)
+	}
+	if series.RecurrenceRule != "FREQ=WEEKLY;COUNT=6;BYDAY=MO" {
+		t.Fatalf("tekrar kuralı yanlış: %s", series.RecurrenceRule)
+	}
+	if series.ExDates != "2027-01-11T18:00:00Z,2027-01-25T18:00:00Z" {
+		t.Fatalf("EXDATE ve iptal edilen tekrar ExDates'e yazılmalı: %s", series.ExDates)
+	}
+
+	occurrences, err := events.GetEventOccurrences(series.ID, owner.ID, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("tekrarlar alınamadı: %v", err)
+	}
+	if len(occurrences) != 4 || occurrences[1].Title != "Kitap kulübü (salı)" ||
+		!occurrences[1].FinalStartTime.Equal(time.Date(2027, 1, 19, 18, 0, 0, 0, time.UTC)) {
+		t.Fatalf("4 tekrar ve taşınmış tekrar bekleniyordu: %v", startsOf(occurrences))
+	}
+
+	var picnic models.Event
+	env.DB.First(&picnic, itemFor(t, result, "piknik@ornek").EventID)
+	if picnic.Title != "Piknik" || picnic.TimeZone != "Europe/Istanbul" ||
+		!picnic.FinalStartTime.Equal(time.Date(2027, 5, 31, 21, 0, 0, 0, time.UTC)) ||
+		picnic.FinalEndTime.Sub(*picnic.FinalStartTime) != 24*time.Hour {
+		t.Fatalf("tam gün etkinlik varsayılan dilimde bir gün sürmeli: %+v", picnic)
+	}
+
+	// Aynı dosya tekrar yüklendiğinde yeni etkinlik oluşmaz
+	again := importCalendar(t, calendar, owner.ID, clubCalendar, dto)
+	if again.Created != 0 || again.Unchanged != 2 {
+		t.Fatalf("tekrar yükleme değişiklik yapmamalı: %+v", again)
+	}
+
+	// Değişen etkinlik güncellenir ve SEQUENCE artar
+	changed := strings.Replace(clubCalendar, "SUMMARY:Kitap kulübü\r\n", "SUMMARY:Kitap kulübü buluşması\r\n", 1)
+	updated := importCalendar(t, calendar, owner.ID, changed, dto)
+	if updated.Updated != 1 || updated.Unchanged != 1 || itemFor(t, updated, "kulup-1@ornek").EventID != series.ID {
+		t.Fatalf("seri güncellenmeli: %+v", updated)
+	}
+	var reloaded models.Event
+	env.DB.First(&reloaded, series.ID)
+	if reloaded.Title != "Kitap kulübü buluşması" || reloaded.Sequence != series.Sequence+1 {
+		t.Fatalf("başlık güncellenmeli ve SEQUENCE artmalı: %+v", reloaded)
+	}
+	var overrides int64
+	env.DB.Model(&models.Event{}).Where("series_id = ?", series.ID).Count(&overrides)
+	if overrides != 1 {
+		t.Fatalf("tekrar değişikliği yinelenmemeli: %d", overrides)
+	}
+
+	// Dosyada iptal edilen etkinlik silinir
+	cancelled := strings.Replace(clubCalendar, "CLASS:PRIVATE\r\n", "CLASS:PRIVATE\r\nSTATUS:CANCELLED\r\n", 1)
+	removed := importCalendar(t, calendar, owner.ID, cancelled, dto)
+	if removed.Cancelled != 1 {
+		t.Fatalf("iptal edilen seri silinmeli: %+v", removed)
+	}
+	if _, _, err := events.GetEventByID(series.ID, owner.ID); err == nil {
+		t.Fatal("iptal edilen seri bulunmamalı")
+	}
+}
+
+func TestImportCalendarRoomAndConflicts(t *testing.T) {
+	env := testutil.New(t)
+	calendar := env.Services().Calendar
+	owner := env.User()
+	other := env.User()
+	room := env.Room(other)
+
+	existing := env.Event(owner)
+	overlap := existing.FinalStartTime.Add(time.Hour).UTC().Format("20060102T150405Z")
+	data := ics(
+		"BEGIN:VEVENT",
+		"UID:cakisan@ornek",
+		"DTSTART:"+overlap,
+		"DURATION:PT1H",
+		"SUMMARY:Çakışan toplantı",
+		"END:VEVENT",
+	)
+
+	roomID := room.ID
+	if _, err := calendar.ImportCalendar(owner.ID, strings.NewReader(data), services.ImportCalendarDTO{RoomID: &roomID}); err == nil {
+		t.Fatal("üye olunmayan odaya içe aktarım reddedilmeli")
+	}
+
+	env.Join(room, owner)
+	result := importCalendar(t, calendar, owner.ID, data, services.ImportCalendarDTO{RoomID: &roomID})
+	item := itemFor(t, result, "cakisan@ornek")
+	if item.Action != services.ImportCreate || len(item.Conflicts) != 1 || item.Conflicts[0].EventID != existing.ID {
+		t.Fatalf("mevcut etkinlikle çakışma raporlanmalı: %+v", item)
+	}
+	var imported models.Event
+	env.DB.First(&imported, item.EventID)
+	if imported.RoomID == nil || *imported.RoomID != room.ID || imported.FinalEndTime.Sub(*imported.FinalStartTime) != time.Hour {
+		t.Fatalf("etkinlik odaya DURATION süresiyle eklenmeli: %+v", imported)
+	}
+}
+
+func TestImportCalendarUpdatesExportedEvent(t *testing.T) {
+	env := testutil.New(t)
+	calendar := env.Services().Calendar
+	owner := env.User()
+	event := env.Event(owner)
+
+	exported, err := calendar.EventCalendar(event.ID, owner.ID)
+	if err != nil {
+		t.Fatalf("dışa aktarım başarısız: %v", err)
+	}
+	data := strings.Replace(exported.String(), "SUMMARY:"+event.Title, "SUMMARY:Yeni başlık", 1)
+
+	result := importCalendar(t, calendar, owner.ID, data, services.ImportCalendarDTO{})
+	item := result.Items[0]
+	if item.Action != services.ImportUpdate || item.EventID != event.ID || result.Created != 0 {
+		t.Fatalf("dışa aktarılan etkinlik kendi kaydını güncellemeli: %+v", result)
+	}
+
+	// Başka bir kullanıcı aynı dosyayı kendi etkinliği olarak içe aktarır
+	other := env.User()
+	copied := importCalendar(t, calendar, other.ID, data, services.ImportCalendarDTO{})
+	if copied.Created != 1 || copied.Items[0].EventID == event.ID {
+		t.Fatalf("başkasının etkinliği güncellenmemeli: %+v", copied)
+	}
+}
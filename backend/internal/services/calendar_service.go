@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+	"event/backend/pkg/ical"
+)
+
+// calendarCancelledRetention silinen etkinliklerin abonelik akışlarında iptal edildi olarak kalma süresi.
+// Takvim uygulamaları etkinliği ancak STATUS:CANCELLED ile gördüklerinde kendi kopyalarından kaldırır.
+const calendarCancelledRetention = 30 * 24 * time.Hour
+
+var (
+	// ErrInvalidCalendarToken bilinmeyen takvim tokeni veya askıya alınmış/silinmiş hesabın tokeni için döner
+	ErrInvalidCalendarToken = errors.New("geçersiz takvim tokeni")
+	// ErrCalendarTokenNotFound kullanıcının henüz takvim tokeni oluşturmadığında döner
+	ErrCalendarTokenNotFound = errors.New("takvim tokeni bulunamadı")
+)
+
+// CalendarService etkinlikleri iCalendar (.ics) olarak dışa aktarır ve takvim aboneliği token'larını yönetir
+type CalendarService struct {
+	repos     repository.Repositories
+	uow       repository.UnitOfWork
+	events    *EventService
+	eventURL  string
+	uidDomain string
+}
+
+// CalendarServiceInput, CalendarService için bağımlılıkları içerir.
+type CalendarServiceInput struct {
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
+	Config       *config.Config
+	EventService *EventService
+}
+
+// NewCalendarService yeni bir CalendarService oluşturur. Etkinlik bağlantıları ve UID'lerin alan adı
+// FrontendURL'den türetilir.
+func NewCalendarService(input CalendarServiceInput) *CalendarService {
+	frontendURL := strings.TrimRight(input.Config.FrontendURL, "/")
+	uidDomain := "event.local"
+	if u, err := url.Parse(frontendURL); err == nil && u.Hostname() != "" {
+		uidDomain = u.Hostname()
+	}
+	return &CalendarService{
+		repos:     input.Repositories,
+		uow:       input.UnitOfWork,
+		events:    input.EventService,
+		eventURL:  frontendURL + "/etkinlikler/",
+		uidDomain: uidDomain,
+	}
+}
+
+// CalendarFeedTokenResponse takvim tokeni bilgileri. Token yalnızca oluşturulduğu yanıtta döner.
+type CalendarFeedTokenResponse struct {
+	*models.CalendarFeedToken
+	Token string `json:"token,omitempty"`
+}
+
+// GetFeedToken kullanıcının takvim tokeninin bilgilerini döndürür
+func (s *CalendarService) GetFeedToken(userID uint64) (*models.CalendarFeedToken, error) {
+	token, err := s.repos.CalendarFeedTokens.FindByUser(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCalendarTokenNotFound
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// RotateFeedToken kullanıcı için yeni bir takvim tokeni oluşturur; varsa eskisi geçersiz olur
+func (s *CalendarService) RotateFeedToken(userID uint64) (*CalendarFeedTokenResponse, error) {
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	token := models.CalendarFeedToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(rawToken),
+		CreatedAt: time.Now(),
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.CalendarFeedTokens.DeleteByUser(userID); err != nil {
+			return err
+		}
+		return repos.CalendarFeedTokens.Create(&token)
+	})
+	if err != nil {
+		return nil, errors.New("takvim tokeni oluşturulamadı")
+	}
+
+	log.Printf("[CalendarService.RotateFeedToken] Takvim tokeni yenilendi (UserID: %d)", userID)
+	return &CalendarFeedTokenResponse{CalendarFeedToken: &token, Token: rawToken}, nil
+}
+
+// RevokeFeedToken kullanıcının takvim tokenini siler; abonelik adresleri çalışmaz olur
+func (s *CalendarService) RevokeFeedToken(userID uint64) error {
+	if _, err := s.GetFeedToken(userID); err != nil {
+		return err
+	}
+	if err := s.repos.CalendarFeedTokens.DeleteByUser(userID); err != nil {
+		return errors.New("takvim tokeni silinemedi")
+	}
+	log.Printf("[CalendarService.RevokeFeedToken] Takvim tokeni silindi (UserID: %d)", userID)
+	return nil
+}
+
+// authenticateFeed ham takvim tokenini doğrular ve sahibinin ID'sini döndürür. Son kullanım zamanı güncellenir.
+func (s *CalendarService) authenticateFeed(rawToken string) (uint64, error) {
+	token, err := s.repos.CalendarFeedTokens.FindByHashWithUser(utils.HashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return 0, ErrInvalidCalendarToken
+		}
+		return 0, err
+	}
+	// Silinmiş kullanıcının token'ı için Preload boş kullanıcı döndürür
+	if token.User.ID == 0 || token.User.IsSuspended() {
+		return 0, ErrInvalidCalendarToken
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		if err := s.repos.CalendarFeedTokens.TouchLastUsed(token.ID, now); err != nil {
+			log.Printf("[CalendarService.authenticateFeed] Son kullanım zamanı güncellenemedi (TokenID: %d): %v", token.ID, err)
+		}
+	}
+	return token.UserID, nil
+}
+
+// EventCalendar tek bir etkinliği indirilebilir bir takvim olarak döndürür. Erişim kuralları
+// etkinlik detayıyla aynıdır; zamanı kesinleşmemiş etkinlikler dışa aktarılamaz.
+func (s *CalendarService) EventCalendar(eventID, userID uint64) (*ical.Component, error) {
+	event, _, err := s.events.GetEventByID(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if event.SeriesID != nil || event.FinalStartTime == nil {
+		return nil, errors.New("etkinliğin zamanı henüz kesinleşmedi")
+	}
+	return s.buildCalendar(event.Title, []models.Event{*event}, false)
+}
+
+// UserFeed takvim tokeninin sahibinin oluşturduğu veya katıldığı etkinliklerin abonelik akışını döndürür
+func (s *CalendarService) UserFeed(rawToken string) (*ical.Component, error) {
+	userID, err := s.authenticateFeed(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.repos.Events.FindCalendarForUser(userID, time.Now().Add(-calendarCancelledRetention))
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCalendar("Etkinliklerim", events, true)
+}
+
+// RoomFeed odadaki etkinliklerin abonelik akışını döndürür. Özel odalar yalnızca aktif üyelere açıktır;
+// odanın özel etkinlikleri de yalnızca üyelere gösterilir.
+func (s *CalendarService) RoomFeed(rawToken string, roomID uint64) (*ical.Component, error) {
+	userID, err := s.authenticateFeed(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.repos.Rooms.FindByID(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("oda bulunamadı")
+		}
+		return nil, err
+	}
+	isMember := true
+	if _, err := s.repos.Rooms.FindActiveMember(roomID, userID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		isMember = false
+	}
+	if !room.IsPublic && !isMember {
+		return nil, errors.New("bu odaya erişim yetkiniz yok veya oda bulunamadı")
+	}
+
+	events, err := s.repos.Events.FindCalendarForRoom(roomID, isMember, time.Now().Add(-calendarCancelledRetention))
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCalendar(room.Name, events, true)
+}
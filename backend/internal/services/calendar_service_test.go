@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+// unfold katlanmış iCalendar satırlarını birleştirir
+func unfold(ics string) string {
+	return strings.ReplaceAll(ics, "\r\n ", "")
+}
+
+// veventWith UID'si uid olan ve RECURRENCE-ID içerip içermemesine göre seçilen VEVENT bloğunu döndürür
+func veventWith(t *testing.T, ics, uid string, override bool) string {
+	t.Helper()
+	for _, block := range strings.Split(ics, "BEGIN:VEVENT\r\n")[1:] {
+		if strings.Contains(block, "UID:"+uid+"\r\n") && strings.Contains(block, "RECURRENCE-ID") == override {
+			return block
+		}
+	}
+	t.Fatalf("%s için VEVENT bulunamadı:\n%s", uid, ics)
+	return ""
+}
+
+func TestCalendarFeedToken(t *testing.T) {
+	env := testutil.New(t)
+	calendar := env.Services().Calendar
+	owner := env.User()
+
+	if _, err := calendar.GetFeedToken(owner.ID); !errors.Is(err, services.ErrCalendarTokenNotFound) {
+		t.Fatalf("token yokken ErrCalendarTokenNotFound bekleniyordu: %v", err)
+	}
+	first, err := calendar.RotateFeedToken(owner.ID)
+	if err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	if _, err := calendar.UserFeed(first.Token); err != nil {
+		t.Fatalf("token ile akış alınamadı: %v", err)
+	}
+
+	// Yenilenen token eskisini geçersiz kılar
+	second, err := calendar.RotateFeedToken(owner.ID)
+	if err != nil {
+		t.Fatalf("token yenilenemedi: %v", err)
+	}
+	if _, err := calendar.UserFeed(first.Token); !errors.Is(err, services.ErrInvalidCalendarToken) {
+		t.Fatalf("eski token reddedilmeli: %v", err)
+	}
+	if _, err := calendar.UserFeed(second.Token); err != nil {
+		t.Fatalf("yeni token ile akış alınamadı: %v", err)
+	}
+
+	if err := calendar.RevokeFeedToken(owner.ID); err != nil {
+		t.Fatalf("token silinemedi: %v", err)
+	}
+	if _, err := calendar.UserFeed(second.Token); !errors.Is(err, services.ErrInvalidCalendarToken) {
+		t.Fatalf("silinen token reddedilmeli: %v", err)
+	}
+}
+
+func TestUserFeed(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	calendar := env.Services().Calendar
+	owner := env.User()
+	member := env.User()
+	other := env.User()
+
+	series := env.Event(owner, testutil.StartsAt(weekly(0)), testutil.Recurring("FREQ=WEEKLY;COUNT=4"), func(e *models.Event) {
+		e.TimeZone = "Europe/Istanbul"
+	})
+	attended := env.Event(other)
+	cancelled := env.Event(other)
+	notMine := env.Event(other)
+	for _, id := range []uint64{series.ID, attended.ID, cancelled.ID} {
+		if _, err := events.AttendEvent(id, member.ID); err != nil {
+			t.Fatalf("katılım eklenemedi: %v", err)
+		}
+	}
+	if err := events.CancelOccurrenceAttendance(series.ID, member.ID, weekly(1)); err != nil {
+		t.Fatalf("tekrar katılımı iptal edilemedi: %v", err)
+	}
+	if err := events.DeleteEvent(cancelled.ID, other.ID); err != nil {
+		t.Fatalf("etkinlik silinemedi: %v", err)
+	}
+
+	token, err := calendar.RotateFeedToken(member.ID)
+	if err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	cal, err := calendar.UserFeed(token.Token)
+	if err != nil {
+		t.Fatalf("akış alınamadı: %v", err)
+	}
+	ics := unfold(cal.String())
+
+	uid := func(e *models.Event) string { return fmt.Sprintf("event-%d@localhost", e.ID) }
+	if strings.Contains(ics, "UID:"+uid(notMine)+"\r\n") {
+		t.Fatal("katılınmayan etkinlik akışta olmamalı")
+	}
+	if block := veventWith(t, ics, uid(attended), false); !strings.Contains(block, "STATUS:CONFIRMED") {
+		t.Fatalf("katılınan etkinlik onaylı olmalı:\n%s", block)
+	}
+	if block := veventWith(t, ics, uid(cancelled), false); !strings.Contains(block, "STATUS:CANCELLED") || !strings.Contains(block, "SEQUENCE:1") {
+		t.Fatalf("silinen etkinlik artan SEQUENCE ile iptal edilmiş olmalı:\n%s", block)
+	}
+
+	master := veventWith(t, ics, uid(series), false)
+	for _, want := range []string{
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"DTSTART;TZID=Europe/Istanbul:20261102T210000",
+		"ORGANIZER;CN=" + owner.FirstName + " " + owner.LastName + ":mailto:" + owner.Email,
+		"PARTSTAT=ACCEPTED:mailto:" + member.Email,
+	} {
+		if !strings.Contains(master, want) {
+			t.Fatalf("seri %q içermeli:\n%s", want, master)
+		}
+	}
+	if !strings.Contains(ics, "BEGIN:VTIMEZONE\r\nTZID:Europe/Istanbul\r\n") {
+		t.Fatal("kullanılan saat dilimi için VTIMEZONE yazılmalı")
+	}
+
+	// Tekrar bazındaki yanıt o tekrarın RECURRENCE-ID bileşeninde görünür
+	occurrence := veventWith(t, ics, uid(series), true)
+	if !strings.Contains(occurrence, "RECURRENCE-ID;TZID=Europe/Istanbul:20261109T210000") ||
+		!strings.Contains(occurrence, "PARTSTAT=DECLINED:mailto:"+member.Email) {
+		t.Fatalf("iptal edilen tekrar ayrı bileşen olmalı:\n%s", occurrence)
+	}
+}
+
+func TestRoomFeedAccess(t *testing.T) {
+	env := testutil.New(t)
+	calendar := env.Services().Calendar
+	owner := env.User()
+	member := env.User()
+	outsider := env.User()
+	room := env.Room(owner, func(r *models.Room) { r.IsPublic = false })
+	env.Join(room, member)
+	env.Event(owner, testutil.InRoom(room))
+
+	memberToken, err := calendar.RotateFeedToken(member.ID)
+	if err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	cal, err := calendar.RoomFeed(memberToken.Token, room.ID)
+	if err != nil {
+		t.Fatalf("üye oda akışını alabilmeli: %v", err)
+	}
+	if got := strings.Count(cal.String(), "BEGIN:VEVENT"); got != 1 {
+		t.Fatalf("odada 1 etkinlik olmalı, %d", got)
+	}
+
+	outsiderToken, err := calendar.RotateFeedToken(outsider.ID)
+	if err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	if _, err := calendar.RoomFeed(outsiderToken.Token, room.ID); err == nil {
+		t.Fatal("özel odanın akışı üye olmayana kapalı olmalı")
+	}
+}
+
+func TestUpdateEventBumpsSequence(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner)
+
+	updated, err := events.UpdateEvent(event.ID, owner.ID, services.UpdateEventDTO{Title: event.Title})
+	if err != nil {
+		t.Fatalf("etkinlik güncellenemedi: %v", err)
+	}
+	if updated.Sequence != 0 {
+		t.Fatalf("değişiklik yoksa SEQUENCE artmamalı, %d", updated.Sequence)
+	}
+
+	updated, err = events.UpdateEvent(event.ID, owner.ID, services.UpdateEventDTO{Title: "Yeni başlık"})
+	if err != nil {
+		t.Fatalf("etkinlik güncellenemedi: %v", err)
+	}
+	if updated.Sequence != 1 || updated.Title != "Yeni başlık" {
+		t.Fatalf("başlık değişince SEQUENCE 1 olmalı: %+v", updated)
+	}
+}
@@ -4,30 +4,28 @@ import (
 	"errors"
 	"event/backend/internal/dtos"
 	"event/backend/internal/models"
-	"event/backend/pkg/database" // Veritabanı bağlantısı için
+	"event/backend/internal/repository"
 
 	"log"
 	"time"
-
-	"gorm.io/gorm"
 )
 
 // ChatServiceInput, ChatService için bağımlılıkları içerir.
 type ChatServiceInput struct {
-	DB          *gorm.DB
-	UserService *UserService
+	Messages    repository.MessageRepository
+	UserService UserFinder
 }
 
 // ChatService, sohbet mesajlarıyla ilgili işlemleri yönetir.
 type ChatService struct {
-	db          *gorm.DB
-	userService *UserService
+	messages    repository.MessageRepository
+	userService UserFinder
 }
 
 // NewChatService, yeni bir ChatService örneği oluşturur.
 func NewChatService(input ChatServiceInput) *ChatService {
 	return &ChatService{
-		db:          input.DB,
+		messages:    input.Messages,
 		userService: input.UserService,
 	}
 }
@@ -36,8 +34,6 @@ func NewChatService(input ChatServiceInput) *ChatService {
 // Mesajın UserID, RoomID ve Content alanları dolu gelmelidir.
 // Username alanı Hub tarafından mesaj yayınlanmadan önce doldurulabilir.
 func (s *ChatService) CreateMessage(roomID uint64, userID uint64, content string) (*models.Message, error) {
-	db := database.GetDB()
-
 	// Önce kullanıcıyı alalım (Preload için gerekli olacak)
 	user, err := s.userService.FindUserByID(userID)
 	if err != nil {
@@ -54,7 +50,7 @@ func (s *ChatService) CreateMessage(roomID uint64, userID uint64, content string
 		Timestamp: time.Now(),
 	}
 
-	if err := db.Create(&message).Error; err != nil {
+	if err := s.messages.Create(&message); err != nil {
 		log.Printf("[ChatService.CreateMessage] Hata: Mesaj veritabanına kaydedilemedi: %v", err)
 		return nil, err
 	}
@@ -68,11 +64,8 @@ func (s *ChatService) CreateMessage(roomID uint64, userID uint64, content string
 
 // GetMessagesByRoomID, bir odaya ait tüm mesajları kronolojik olarak getirir.
 func (s *ChatService) GetMessagesByRoomID(roomID uint64) ([]dtos.MessageDTO, error) {
-	db := database.GetDB()
-	var messages []models.Message
-
 	// Mesajları gönderen (Sender) bilgisiyle birlikte, oluşturulma tarihine göre eskiden yeniye doğru sıralayarak çek.
-	err := db.Preload("Sender").Where("room_id = ?", roomID).Order("created_at asc").Find(&messages).Error
+	messages, err := s.messages.FindByRoomWithSenders(roomID)
 	if err != nil {
 		log.Printf("[ChatService.GetMessagesByRoomID] Hata: Oda %d için mesajlar çekilemedi: %v", roomID, err)
 		return nil, err
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+	"event/backend/pkg/mailer"
+)
+
+// EmailVerificationService kayıt sonrası e-posta doğrulama akışını yönetir
+type EmailVerificationService struct {
+	users  repository.UserRepository
+	config *config.Config
+	mailer mailer.Mailer
+}
+
+// EmailVerificationServiceInput, EmailVerificationService için bağımlılıkları içerir.
+type EmailVerificationServiceInput struct {
+	Users  repository.UserRepository
+	Config *config.Config
+	Mailer mailer.Mailer
+}
+
+// NewEmailVerificationService yeni bir EmailVerificationService oluşturur
+func NewEmailVerificationService(input EmailVerificationServiceInput) *EmailVerificationService {
+	return &EmailVerificationService{
+		users:  input.Users,
+		config: input.Config,
+		mailer: input.Mailer,
+	}
+}
+
+var (
+	// ErrEmailAlreadyVerified e-posta zaten doğrulanmışsa döner
+	ErrEmailAlreadyVerified = errors.New("e-posta adresi zaten doğrulanmış")
+	// ErrVerificationThrottled doğrulama e-postası çok sık istendiğinde döner
+	ErrVerificationThrottled = errors.New("doğrulama e-postası kısa süre önce gönderildi, lütfen biraz bekleyin")
+	// ErrInvalidVerificationToken geçersiz veya süresi dolmuş doğrulama bağlantısı için döner
+	ErrInvalidVerificationToken = errors.New("doğrulama bağlantısı geçersiz veya süresi dolmuş")
+)
+
+// SendVerificationEmail kullanıcıya imzalı bir doğrulama bağlantısı gönderir.
+// Aynı kullanıcıya VerificationResendInterval içinde ikinci bir e-posta gönderilmez.
+func (s *EmailVerificationService) SendVerificationEmail(userID uint64) error {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return errors.New("kullanıcı bulunamadı")
+		}
+		return err
+	}
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	// Gönderim zamanını koşullu olarak işaretle: eşzamanlı iki istekten yalnızca biri e-posta gönderir
+	now := time.Now()
+	marked, err := s.users.MarkVerificationSent(user.ID, now, now.Add(-s.config.VerificationResendInterval))
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrVerificationThrottled
+	}
+
+	token, err := utils.GenerateEmailVerificationToken(user.ID, user.Email, s.config)
+	if err != nil {
+		return errors.New("doğrulama tokeni oluşturulamadı")
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(s.config.FrontendURL, "/"), url.QueryEscape(token))
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "E-posta adresinizi doğrulayın",
+		Body: fmt.Sprintf(
+			"Merhaba %s,\n\nHesabınızı etkinleştirmek için e-posta adresinizi aşağıdaki bağlantıdan doğrulayın:\n\n%s\n\n"+
+				"Bu bağlantı %s boyunca geçerlidir.\n",
+			user.Username, link, s.config.EmailVerificationTTL,
+		),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("[EmailVerificationService.SendVerificationEmail] E-posta gönderilemedi (UserID: %d): %v", user.ID, err)
+		return errors.New("doğrulama e-postası gönderilemedi")
+	}
+
+	return nil
+}
+
+// VerifyEmail bağlantıdaki imzalı token'ı doğrular ve kullanıcının e-postasını doğrulanmış olarak işaretler
+func (s *EmailVerificationService) VerifyEmail(token string) (*models.User, error) {
+	claims, err := utils.ValidateEmailVerificationToken(token, s.config)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := s.users.FindByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+
+	// Bağlantı gönderildikten sonra e-posta değiştiyse eski bağlantı kullanılamaz
+	if !strings.EqualFold(user.Email, claims.Email) {
+		return nil, ErrInvalidVerificationToken
+	}
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+
+	now := time.Now()
+	if err := s.users.UpdateFields(user.ID, map[string]interface{}{"email_verified_at": now}); err != nil {
+		return nil, errors.New("e-posta doğrulanamadı")
+	}
+	user.EmailVerifiedAt = &now
+
+	return user, nil
+}
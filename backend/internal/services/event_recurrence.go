@@ -0,0 +1,696 @@
+package services
+
+import (
+	"errors"
+	"event/backend/internal/models"
+	"event/backend/internal/recurrence"
+	"event/backend/internal/repository"
+	"event/backend/pkg/rrule"
+	"log"
+	"time"
+)
+
+// MaxEventRange tarih aralığı sorgularında izin verilen en uzun aralık
+const MaxEventRange = 366 * 24 * time.Hour
+
+// OccurrenceScope tekrar düzenleme ve silme işlemlerinin kapsamı
+type OccurrenceScope string
+
+const (
+	// ScopeThis yalnızca seçilen tekrarı etkiler
+	ScopeThis OccurrenceScope = "this"
+	// ScopeFollowing seçilen tekrarı ve sonrakileri etkiler; seri bu tekrardan ikiye bölünür
+	ScopeFollowing OccurrenceScope = "following"
+)
+
+// ParseOccurrenceScope kapsam parametresini doğrular; boşsa yalnızca seçilen tekrar kabul edilir
+func ParseOccurrenceScope(value string) (OccurrenceScope, error) {
+	switch OccurrenceScope(value) {
+	case "", ScopeThis:
+		return ScopeThis, nil
+	case ScopeFollowing:
+		return ScopeFollowing, nil
+	}
+	return "", errors.New("geçersiz kapsam (this veya following olmalı)")
+}
+
+// UpdateOccurrenceDTO tek bir tekrarı veya bir tekrardan sonraki seriyi güncellemek için veri transfer nesnesi
+type UpdateOccurrenceDTO struct {
+	Title       string `json:"title" binding:"omitempty,min=3,max=100"`
+	Description string `json:"description" binding:"omitempty,min=10,max=500"`
+	StartTime   string `json:"start_time"` // RFC 3339; boşsa tekrarın zamanı korunur
+	EndTime     string `json:"end_time"`   // RFC 3339; boşsa süre korunur
+}
+
+// loadTimeZone IANA saat dilimi adını doğrular; boş ad UTC'dir
+func loadTimeZone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("geçersiz saat dilimi: " + name)
+	}
+	return loc, nil
+}
+
+// parseRecurrenceRule kuralı ayrıştırır ve serinin ilk başlangıcının kurala uyduğunu doğrular
+func parseRecurrenceRule(value string, start time.Time, timeZone string) (*rrule.Rule, error) {
+	loc, err := loadTimeZone(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	start = start.In(loc)
+	if !rule.Includes(start, start) {
+		return nil, errors.New("etkinliğin başlangıç zamanı tekrar kuralına uymuyor")
+	}
+	return rule, nil
+}
+
+// applyRecurrence yeni etkinliğe saat dilimi ve tekrar bilgisini uygular.
+// Tekrarlanan etkinlik tek zaman seçeneğiyle oluşturulur ve o zamanla kesinleşir.
+func applyRecurrence(event *models.Event, dto CreateEventDTO) error {
+	if _, err := loadTimeZone(dto.TimeZone); err != nil {
+		return err
+	}
+	event.TimeZone = dto.TimeZone
+
+	if dto.RecurrenceRule == "" {
+		if len(dto.ExDates) > 0 {
+			return errors.New("iptal edilen tekrarlar yalnızca tekrar kuralıyla birlikte verilebilir")
+		}
+		return nil
+	}
+	if len(dto.TimeOptions) != 1 {
+		return errors.New("tekrarlanan etkinlik için tek bir zaman seçeneği verilmelidir")
+	}
+	start, err := time.Parse(time.RFC3339, dto.TimeOptions[0])
+	if err != nil {
+		return errors.New("geçersiz tarih formatı")
+	}
+	rule, err := parseRecurrenceRule(dto.RecurrenceRule, start, dto.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	exDates := make([]time.Time, 0, len(dto.ExDates))
+	for _, value := range dto.ExDates {
+		t, err := recurrence.ParseKey(value)
+		if err != nil {
+			return err
+		}
+		exDates = append(exDates, t)
+	}
+
+	end := start.Add(recurrence.DefaultDuration)
+	event.FinalStartTime = &start
+	event.FinalEndTime = &end
+	event.RecurrenceRule = rule.String()
+	event.ExDates = recurrence.FormatExDates(exDates)
+	return nil
+}
+
+// clearOccurrences serinin tekil tekrar değişikliklerini ve tekrar bazındaki katılımlarını siler
+func clearOccurrences(repos repository.Repositories, seriesID uint64) error {
+	overrides, err := repos.Events.FindOverrides(seriesID)
+	if err != nil {
+		return err
+	}
+	for _, override := range overrides {
+		if err := repos.Events.Delete(override.ID); err != nil {
+			return err
+		}
+	}
+	attendances, err := repos.Participation.FindOccurrenceAttendances(seriesID)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(attendances))
+	for _, attendance := range attendances {
+		keys = append(keys, attendance.Occurrence)
+	}
+	return repos.Participation.DeleteOccurrenceAttendances(seriesID, keys...)
+}
+
+// validateRange tarih aralığı sorgusunun sınırlarını doğrular
+func validateRange(from, to time.Time) error {
+	if !to.After(from) {
+		return errors.New("aralığın bitişi başlangıcından sonra olmalı")
+	}
+	if to.Sub(from) > MaxEventRange {
+		return errors.New("tarih aralığı en fazla 366 gün olabilir")
+	}
+	return nil
+}
+
+// expand etkinlikleri [from, to) aralığında başlayan tekrarlarına açar
+func (s *EventService) expand(events []models.Event, from, to time.Time) ([]models.Event, error) {
+	var seriesIDs []uint64
+	for i := range events {
+		if recurrence.IsSeries(&events[i]) {
+			seriesIDs = append(seriesIDs, events[i].ID)
+		}
+	}
+	overrides, err := s.repos.Events.FindOverrides(seriesIDs...)
+	if err != nil {
+		return nil, err
+	}
+	return recurrence.Expand(events, overrides, from, to)
+}
+
+// GetUserEventsInRange kullanıcının görebileceği etkinlikleri [from, to) aralığında başlangıca göre sıralı listeler.
+// Tekrarlanan etkinlikler aralıktaki her tekrarı için ayrı bir kayıt olarak döner.
+func (s *EventService) GetUserEventsInRange(userID uint64, from, to time.Time) ([]models.Event, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	events, err := s.repos.Events.FindVisibleTo(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.expand(events, from, to)
+}
+
+// GetEventOccurrences etkinliğin [from, to) aralığındaki tekrarlarını döndürür.
+// Tekrarlanmayan etkinlik aralıktaysa tek kayıt olarak döner.
+func (s *EventService) GetEventOccurrences(eventID, userID uint64, from, to time.Time) ([]models.Event, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+	event, _, err := s.GetEventByID(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.expand([]models.Event{*event}, from, to)
+}
+
+// findOccurrence etkinliği bulur, tekrarlanan bir seri olduğunu ve occurrence'ın iptal edilmemiş
+// bir tekrarı olduğunu doğrular
+func (s *EventService) findOccurrence(eventID uint64, occurrence time.Time) (*models.Event, error) {
+	series, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
+		return nil, err
+	}
+	if !recurrence.IsSeries(series) {
+		return nil, errors.New("etkinlik tekrarlanan bir etkinlik değil")
+	}
+	ok, err := recurrence.Contains(series, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("etkinliğin bu zamanda bir tekrarı yok")
+	}
+	return series, nil
+}
+
+// checkOccurrenceRSVP özel serilerde tekrar bazında yanıtı yalnızca etkinlik sahibine ve
+// seriye katılımı kabul edilmiş kullanıcılara açar. Bekleme listesindeki kullanıcılar henüz kabul edilmiş sayılmaz.
+func (s *EventService) checkOccurrenceRSVP(series *models.Event, userID uint64) error {
+	if !series.IsPrivate || series.CreatorUserID == userID {
+		return nil
+	}
+	attendance, err := s.repos.Participation.FindAttendance(series.ID, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if err != nil || attendance.Status == models.AttendanceWaitlisted {
+		return errors.New("özel etkinliğin tekrarlarına yanıt vermek için önce etkinliğe katılımınızın onaylanması gerekir")
+	}
+	return nil
+}
+
+// occurrenceHasRoom kullanıcının misafirleriyle birlikte kapasiteli bir serinin tekrarına sığıp sığmadığını
+// döndürür. Tekrardaki katılımcılar seri düzeyindeki katılımların üzerine tekrara özel kayıtlar uygulanarak
+// sayılır; tekrarlar için bekleme listesi tutulmaz. İşlem içinde, seri satırı kilitlendikten sonra çağrılmalıdır.
+func occurrenceHasRoom(repos repository.Repositories, series *models.Event, userID uint64, key string, guests int) (bool, error) {
+	if series.Capacity == nil {
+		return true, nil
+	}
+	attendances, err := repos.Participation.FindAttendancesWithUsers(series.ID)
+	if err != nil {
+		return false, err
+	}
+	overrides, err := repos.Participation.FindAttendancesWithUsers(series.ID, key)
+	if err != nil {
+		return false, err
+	}
+	effective := make(map[uint64]models.EventAttendance, len(attendances))
+	for _, attendance := range append(attendances, overrides...) {
+		effective[attendance.UserID] = attendance
+	}
+	seats := 1 + guests
+	for id, attendance := range effective {
+		if id != userID && attendance.Status == models.AttendanceGoing {
+			seats += 1 + attendance.Guests
+		}
+	}
+	return seats <= *series.Capacity, nil
+}
+
+// setOccurrenceAttendance kullanıcının tek bir tekrar için yanıtını kaydeder
+func (s *EventService) setOccurrenceAttendance(eventID, userID uint64, occurrence time.Time, dto RSVPDTO) error {
+	if err := dto.validate(); err != nil {
+		return err
+	}
+	series, err := s.findOccurrence(eventID, occurrence)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOccurrenceRSVP(series, userID); err != nil {
+		return err
+	}
+
+	attendance := models.EventAttendance{
+		EventID:    eventID,
+		UserID:     userID,
+		Occurrence: recurrence.Key(occurrence),
+		Status:     dto.Status,
+		Guests:     dto.Guests,
+		Note:       dto.Note,
+		JoinedAt:   time.Now(),
+	}
+	columns := []string{"status", "guests", "note", "joined_at"}
+	if dto.Status != models.AttendanceGoing || series.Capacity == nil {
+		return s.repos.Participation.UpsertAttendance(&attendance, columns...)
+	}
+
+	// Kapasiteli serilerde seri satırı kilitlenerek eşzamanlı katılımların tekrarı doldurması önlenir
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		locked, err := repos.Events.FindByIDForUpdate(eventID)
+		if err != nil {
+			return err
+		}
+		ok, err := occurrenceHasRoom(repos, locked, userID, attendance.Occurrence, dto.Guests)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("bu tekrarın kapasitesi dolu")
+		}
+		return repos.Participation.UpsertAttendance(&attendance, columns...)
+	})
+}
+
+// RSVPOccurrence kullanıcının tekrarlanan etkinliğin yalnızca bir tekrarına yanıtını kaydeder.
+// Seri düzeyindeki yanıt (RSVP) tüm tekrarlar için geçerlidir; bu kayıt onu o tekrar için geçersiz kılar.
+func (s *EventService) RSVPOccurrence(eventID, userID uint64, occurrence time.Time, dto RSVPDTO) error {
+	return s.setOccurrenceAttendance(eventID, userID, occurrence, dto)
+}
+
+// AttendEventOccurrence kullanıcıyı tekrarlanan etkinliğin yalnızca bir tekrarına katılımcı olarak ekler
+func (s *EventService) AttendEventOccurrence(eventID, userID uint64, occurrence time.Time) error {
+	return s.setOccurrenceAttendance(eventID, userID, occurrence, RSVPDTO{Status: models.AttendanceGoing})
+}
+
+// CancelOccurrenceAttendance kullanıcının tekrarlanan etkinliğin yalnızca bir tekrarına katılmayacağını kaydeder
+func (s *EventService) CancelOccurrenceAttendance(eventID, userID uint64, occurrence time.Time) error {
+	return s.setOccurrenceAttendance(eventID, userID, occurrence, RSVPDTO{Status: models.AttendanceNotGoing})
+}
+
+// GetOccurrenceAttendees tekrarlanan etkinliğin bir tekrarındaki katılımcıları döndürür; liste yalnızca
+// etkinliği görebilen kullanıcılara döner
+func (s *EventService) GetOccurrenceAttendees(eventID uint64, occurrence time.Time, viewerID uint64) (*EventAttendees, error) {
+	series, err := s.findOccurrence(eventID, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEventAccess(series, viewerID); err != nil {
+		return nil, err
+	}
+	return s.eventAttendees(series, recurrence.Key(occurrence), viewerID)
+}
+
+// occurrenceTimes düzenlenen tekrarın yeni başlangıç ve bitişini hesaplar; verilmeyen alanlar korunur
+func occurrenceTimes(current models.Event, dto UpdateOccurrenceDTO) (time.Time, time.Time, error) {
+	start, end := *current.FinalStartTime, *current.FinalEndTime
+	if dto.StartTime != "" {
+		t, err := time.Parse(time.RFC3339, dto.StartTime)
+		if err != nil {
+			return start, end, errors.New("geçersiz başlangıç zamanı")
+		}
+		end = t.Add(end.Sub(start))
+		start = t
+	}
+	if dto.EndTime != "" {
+		t, err := time.Parse(time.RFC3339, dto.EndTime)
+		if err != nil {
+			return start, end, errors.New("geçersiz bitiş zamanı")
+		}
+		end = t
+	}
+	if !end.After(start) {
+		return start, end, errors.New("bitiş zamanı başlangıç zamanından sonra olmalı")
+	}
+	return start.UTC(), end.UTC(), nil
+}
+
+// findOverride serinin verilen tekrarının yerine geçen kaydı döndürür; yoksa nil
+func findOverride(overrides []models.Event, occurrence time.Time) *models.Event {
+	for i := range overrides {
+		if overrides[i].RecurrenceID != nil && overrides[i].RecurrenceID.Equal(occurrence) {
+			return &overrides[i]
+		}
+	}
+	return nil
+}
+
+// UpdateOccurrence tekrarlanan etkinliğin bir tekrarını (ScopeThis) veya o tekrarı ve sonrakileri
+// (ScopeFollowing) günceller. Serinin geri kalanı değişmez.
+//
+// ScopeThis tekrarın yerine geçen bir kayıt oluşturur veya günceller. ScopeFollowing seriyi bu tekrardan
+// böler: eski seri önceki tekrarlarla sınırlanır, kalan tekrarlar değişikliklerle yeni bir seri olur;
+// sonraki tekrarlara ait iptaller, tekil değişiklikler ve katılımlar yeni seriye taşınır, seri düzeyindeki
+// katılımlar kopyalanır. Seçilen tekrar serinin ilkiyse bölmeye gerek kalmaz, seri yerinde güncellenir.
+// Dönen etkinlik ScopeThis için güncellenen tekrar, ScopeFollowing için yeni (veya güncellenen) seridir.
+func (s *EventService) UpdateOccurrence(eventID, userID uint64, occurrence time.Time, scope OccurrenceScope, dto UpdateOccurrenceDTO) (*models.Event, error) {
+	series, err := s.findOccurrence(eventID, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	if series.CreatorUserID != userID {
+		return nil, errors.New("bu etkinliği güncelleme yetkiniz yok")
+	}
+	overrides, err := s.repos.Events.FindOverrides(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	override := findOverride(overrides, occurrence)
+
+	if scope == ScopeThis {
+		return s.updateSingleOccurrence(series, occurrence, override, dto)
+	}
+
+	// Serinin düzeni, seçilen tekrarın (tekil değişikliği değil) asıl zamanına göre kaydırılır
+	start, end, err := occurrenceTimes(recurrence.Instance(series, occurrence, nil), dto)
+	if err != nil {
+		return nil, err
+	}
+	if occurrence.Equal(*series.FinalStartTime) {
+		return s.updateWholeSeries(series, overrides, start, end, dto)
+	}
+	return s.splitSeries(series, overrides, occurrence, start, end, dto)
+}
+
+// updateSingleOccurrence tek bir tekrarın yerine geçen kaydı oluşturur veya günceller
+func (s *EventService) updateSingleOccurrence(series *models.Event, occurrence time.Time, override *models.Event, dto UpdateOccurrenceDTO) (*models.Event, error) {
+	start, end, err := occurrenceTimes(recurrence.Instance(series, occurrence, override), dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if override == nil {
+		override = &models.Event{
+			Title:         series.Title,
+			Description:   series.Description,
+			Location:      series.Location,
+			CreatorUserID: series.CreatorUserID,
+			RoomID:        series.RoomID,
+			IsPrivate:     series.IsPrivate,
+			ImageURL:      series.ImageURL,
+			TimeZone:      series.TimeZone,
+			SeriesID:      &series.ID,
+		}
+		recurrenceID := occurrence.UTC()
+		override.RecurrenceID = &recurrenceID
+	}
+	if dto.Title != "" {
+		override.Title = dto.Title
+	}
+	if dto.Description != "" {
+		override.Description = dto.Description
+	}
+	override.FinalStartTime = &start
+	override.FinalEndTime = &end
+
+	if override.ID == 0 {
+		err = s.repos.Events.Create(override)
+	} else {
+		err = s.uow.WithTx(func(repos repository.Repositories) error {
+			if err := repos.Events.UpdateFields(override.ID, map[string]interface{}{
+				"title":            override.Title,
+				"description":      override.Description,
+				"final_start_time": start,
+				"final_end_time":   end,
+			}); err != nil {
+				return err
+			}
+			return repos.Events.IncrementSequence(override.ID)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	instance := recurrence.Instance(series, occurrence, override)
+	return &instance, nil
+}
+
+// moveOccurrences from serisinin at ve sonrasındaki iptallerini, tekil değişikliklerini ve tekrar bazındaki
+// katılımlarını to serisine taşır. Tekrar anahtarları, at tekrarının newStart'a taşınmasıyla aynı miktarda
+// (yerel takvimde gün ve saat olarak) kaydırılır. Kalan iptaller döndürülür.
+func moveOccurrences(repos repository.Repositories, from *models.Event, overrides []models.Event, to uint64,
+	at, newStart time.Time, dto UpdateOccurrenceDTO) (remaining, moved []time.Time, err error) {
+	loc := recurrence.Location(from)
+	shift := func(t time.Time) time.Time {
+		return recurrence.Shift(t, at, newStart, loc)
+	}
+
+	for _, exdate := range recurrence.ExDates(from) {
+		if exdate.Before(at) {
+			remaining = append(remaining, exdate)
+		} else {
+			moved = append(moved, shift(exdate))
+		}
+	}
+
+	for _, override := range overrides {
+		if override.RecurrenceID.Before(at) {
+			continue
+		}
+		updates := map[string]interface{}{
+			"series_id":     to,
+			"recurrence_id": shift(*override.RecurrenceID),
+		}
+		if dto.Title != "" {
+			updates["title"] = dto.Title
+		}
+		if dto.Description != "" {
+			updates["description"] = dto.Description
+		}
+		if err := repos.Events.UpdateFields(override.ID, updates); err != nil {
+			return nil, nil, err
+		}
+		if err := repos.Events.IncrementSequence(override.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	attendances, err := repos.Participation.FindOccurrenceAttendances(from.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, attendance := range attendances {
+		key, err := recurrence.ParseKey(attendance.Occurrence)
+		if err != nil || key.Before(at) {
+			continue
+		}
+		if err := repos.Participation.UpdateAttendanceFields(attendance.ID, map[string]interface{}{
+			"event_id":   to,
+			"occurrence": recurrence.Key(shift(key)),
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return remaining, moved, nil
+}
+
+// updateWholeSeries serinin ilk tekrarından itibaren yapılan düzenlemeyi serinin kendisine uygular
+func (s *EventService) updateWholeSeries(series *models.Event, overrides []models.Event, start, end time.Time, dto UpdateOccurrenceDTO) (*models.Event, error) {
+	rule, err := recurrence.Reanchor(series, start)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		_, moved, err := moveOccurrences(repos, series, overrides, series.ID, *series.FinalStartTime, start, dto)
+		if err != nil {
+			return err
+		}
+		updates := map[string]interface{}{
+			"final_start_time": start,
+			"final_end_time":   end,
+			"recurrence_rule":  rule,
+			"ex_dates":         recurrence.FormatExDates(moved),
+		}
+		if dto.Title != "" {
+			updates["title"] = dto.Title
+		}
+		if dto.Description != "" {
+			updates["description"] = dto.Description
+		}
+		if err := repos.Events.UpdateFields(series.ID, updates); err != nil {
+			return err
+		}
+		return repos.Events.IncrementSequence(series.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repos.Events.FindByID(series.ID)
+}
+
+// splitSeries seriyi at tekrarından böler ve kalan tekrarları değişikliklerle yeni bir seri olarak oluşturur
+func (s *EventService) splitSeries(series *models.Event, overrides []models.Event, at, start, end time.Time, dto UpdateOccurrenceDTO) (*models.Event, error) {
+	head, tail, err := recurrence.Split(series, at, start)
+	if err != nil {
+		return nil, err
+	}
+
+	next := models.Event{
+		Title:          series.Title,
+		Description:    series.Description,
+		Location:       series.Location,
+		CreatorUserID:  series.CreatorUserID,
+		RoomID:         series.RoomID,
+		IsPrivate:      series.IsPrivate,
+		ImageURL:       series.ImageURL,
+		TimeZone:       series.TimeZone,
+		RecurrenceRule: tail,
+		FinalStartTime: &start,
+		FinalEndTime:   &end,
+	}
+	if dto.Title != "" {
+		next.Title = dto.Title
+	}
+	if dto.Description != "" {
+		next.Description = dto.Description
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Events.Create(&next); err != nil {
+			return err
+		}
+		remaining, moved, err := moveOccurrences(repos, series, overrides, next.ID, at, start, dto)
+		if err != nil {
+			return err
+		}
+		if err := repos.Events.UpdateFields(next.ID, map[string]interface{}{
+			"ex_dates": recurrence.FormatExDates(moved),
+		}); err != nil {
+			return err
+		}
+		if err := repos.Events.UpdateFields(series.ID, map[string]interface{}{
+			"recurrence_rule": head,
+			"ex_dates":        recurrence.FormatExDates(remaining),
+		}); err != nil {
+			return err
+		}
+		if err := repos.Events.IncrementSequence(series.ID); err != nil {
+			return err
+		}
+
+		// Seriye katılanlar yeni serinin de katılımcısıdır
+		attendances, err := repos.Participation.FindAttendancesWithUsers(series.ID)
+		if err != nil {
+			return err
+		}
+		for _, attendance := range attendances {
+			copied := models.EventAttendance{
+				EventID:  next.ID,
+				UserID:   attendance.UserID,
+				Status:   attendance.Status,
+				JoinedAt: attendance.JoinedAt,
+			}
+			if err := repos.Participation.UpsertAttendance(&copied, "status"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[EventService.UpdateOccurrence] Seri %d, %s tekrarından bölündü; yeni seri %d", series.ID, recurrence.Key(at), next.ID)
+	return s.repos.Events.FindByID(next.ID)
+}
+
+// DeleteOccurrence tekrarlanan etkinliğin bir tekrarını (ScopeThis) veya o tekrarı ve sonrakileri
+// (ScopeFollowing) siler. Silinen tekrarların tekil değişiklikleri ve tekrar bazındaki katılımları da silinir.
+// İlk tekrardan itibaren silmek etkinliğin tamamını siler.
+func (s *EventService) DeleteOccurrence(eventID, userID uint64, occurrence time.Time, scope OccurrenceScope) error {
+	series, err := s.findOccurrence(eventID, occurrence)
+	if err != nil {
+		return err
+	}
+	if series.CreatorUserID != userID {
+		return errors.New("bu etkinliği silme yetkiniz yok")
+	}
+	if scope == ScopeFollowing && occurrence.Equal(*series.FinalStartTime) {
+		return s.DeleteEvent(eventID, userID)
+	}
+	overrides, err := s.repos.Events.FindOverrides(series.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// Silinecek tekrarlara ait tekil değişiklikler ve katılımlar
+		deleted := func(t time.Time) bool {
+			if scope == ScopeThis {
+				return t.Equal(occurrence)
+			}
+			return !t.Before(occurrence)
+		}
+		for _, override := range overrides {
+			if deleted(*override.RecurrenceID) {
+				if err := repos.Events.Delete(override.ID); err != nil {
+					return err
+				}
+			}
+		}
+		attendances, err := repos.Participation.FindOccurrenceAttendances(series.ID)
+		if err != nil {
+			return err
+		}
+		var keys []string
+		for _, attendance := range attendances {
+			if key, err := recurrence.ParseKey(attendance.Occurrence); err == nil && deleted(key) {
+				keys = append(keys, attendance.Occurrence)
+			}
+		}
+		if err := repos.Participation.DeleteOccurrenceAttendances(series.ID, keys...); err != nil {
+			return err
+		}
+		if err := repos.Events.IncrementSequence(series.ID); err != nil {
+			return err
+		}
+
+		if scope == ScopeThis {
+			exDates := append(recurrence.ExDates(series), occurrence)
+			return repos.Events.UpdateFields(series.ID, map[string]interface{}{
+				"ex_dates": recurrence.FormatExDates(exDates),
+			})
+		}
+
+		head, _, err := recurrence.Split(series, occurrence, occurrence)
+		if err != nil {
+			return err
+		}
+		var remaining []time.Time
+		for _, exdate := range recurrence.ExDates(series) {
+			if exdate.Before(occurrence) {
+				remaining = append(remaining, exdate)
+			}
+		}
+		return repos.Events.UpdateFields(series.ID, map[string]interface{}{
+			"recurrence_rule": head,
+			"ex_dates":        recurrence.FormatExDates(remaining),
+		})
+	})
+}
@@ -0,0 +1,266 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+// weekly 2 Kasım 2026 pazartesi 18:00 UTC'den başlayan haftalık tekrarlar
+func weekly(n int) time.Time {
+	return time.Date(2026, 11, 2, 18, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n)
+}
+
+func startsOf(events []models.Event) []time.Time {
+	result := make([]time.Time, len(events))
+	for i, event := range events {
+		result[i] = event.FinalStartTime.UTC()
+	}
+	return result
+}
+
+func TestGetUserEventsInRangeExpandsSeries(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+
+	series := env.Event(owner, testutil.StartsAt(weekly(0)), testutil.Recurring("FREQ=WEEKLY;COUNT=4"))
+	single := env.Event(owner, testutil.StartsAt(weekly(1).Add(time.Hour)))
+	env.Event(owner, testutil.StartsAt(weekly(10)))
+
+	from, to := weekly(0), weekly(4)
+	got, err := events.GetUserEventsInRange(owner.ID, from, to)
+	if err != nil {
+		t.Fatalf("aralık sorgusu başarısız: %v", err)
+	}
+	want := []time.Time{weekly(0), weekly(1), weekly(1).Add(time.Hour), weekly(2), weekly(3)}
+	starts := startsOf(got)
+	if len(starts) != len(want) {
+		t.Fatalf("%d etkinlik bekleniyordu, %d geldi: %v", len(want), len(starts), starts)
+	}
+	for i := range want {
+		if !starts[i].Equal(want[i]) {
+			t.Fatalf("%d. etkinlik %v olmalı, %v", i, want[i], starts[i])
+		}
+	}
+	if got[2].ID != single.ID || got[2].Occurrence != nil {
+		t.Fatal("tekrarlanmayan etkinlik olduğu gibi dönmeli")
+	}
+	if got[1].ID != series.ID || got[1].Occurrence == nil || !got[1].Occurrence.Equal(weekly(1)) {
+		t.Fatal("tekrar, serinin kimliği ve asıl başlangıcıyla dönmeli")
+	}
+
+	if _, err := events.GetUserEventsInRange(owner.ID, to, from); err == nil {
+		t.Fatal("ters aralık reddedilmeli")
+	}
+	if _, err := events.GetUserEventsInRange(owner.ID, from, from.AddDate(2, 0, 0)); err == nil {
+		t.Fatal("366 günden uzun aralık reddedilmeli")
+	}
+
+	// Tek bir tekrarın silinmesi yalnızca o tekrarı listeden çıkarır
+	if err := events.DeleteOccurrence(series.ID, owner.ID, weekly(2), services.ScopeThis); err != nil {
+		t.Fatalf("tekrar silinemedi: %v", err)
+	}
+	got, err = events.GetEventOccurrences(series.ID, owner.ID, from, to)
+	if err != nil {
+		t.Fatalf("tekrarlar alınamadı: %v", err)
+	}
+	if len(got) != 3 || !got[2].FinalStartTime.Equal(weekly(3)) {
+		t.Fatalf("silinen tekrar dışında 3 tekrar kalmalı: %v", startsOf(got))
+	}
+	if err := events.AttendEventOccurrence(series.ID, owner.ID, weekly(2)); err == nil {
+		t.Fatal("silinmiş tekrara katılım reddedilmeli")
+	}
+}
+
+func TestOccurrenceAttendance(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	regular := env.User()
+	dropIn := env.User()
+	series := env.Event(owner, testutil.StartsAt(weekly(0)), testutil.Recurring("FREQ=WEEKLY;COUNT=4"))
+
+	// Seriye katılım tüm tekrarlar için geçerlidir; tekrar bazındaki yanıt onu yalnızca o tekrar için değiştirir
+	if _, err := events.AttendEvent(series.ID, regular.ID); err != nil {
+		t.Fatalf("seriye katılım eklenemedi: %v", err)
+	}
+	if err := events.CancelOccurrenceAttendance(series.ID, regular.ID, weekly(1)); err != nil {
+		t.Fatalf("tekrar katılımı iptal edilemedi: %v", err)
+	}
+	if err := events.AttendEventOccurrence(series.ID, dropIn.ID, weekly(2)); err != nil {
+		t.Fatalf("tekrara katılım eklenemedi: %v", err)
+	}
+	if err := events.AttendEventOccurrence(series.ID, dropIn.ID, weekly(2).Add(time.Hour)); err == nil {
+		t.Fatal("serinin tekrarı olmayan bir zamana katılım reddedilmeli")
+	}
+
+	cases := []struct {
+		name       string
+		occurrence *time.Time
+		want       map[uint64]string
+	}{
+		{"seri", nil, map[uint64]string{regular.ID: "going"}},
+		{"iptal edilen tekrar", timePtr(weekly(1)), map[uint64]string{regular.ID: "not_going"}},
+		{"tek seferlik katılım", timePtr(weekly(2)), map[uint64]string{regular.ID: "going", dropIn.ID: "going"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attendees *services.EventAttendees
+			var err error
+			if tc.occurrence == nil {
+				attendees, err = events.GetEventAttendees(series.ID, owner.ID)
+			} else {
+				attendees, err = events.GetOccurrenceAttendees(series.ID, *tc.occurrence, owner.ID)
+			}
+			if err != nil {
+				t.Fatalf("katılımcılar alınamadı: %v", err)
+			}
+			got := statusesByID(t, attendees)
+			if len(got) != len(tc.want) {
+				t.Fatalf("katılımcılar %v olmalı, %v", tc.want, got)
+			}
+			for id, status := range tc.want {
+				if got[id] != status {
+					t.Fatalf("kullanıcı %d durumu %q olmalı, %q", id, status, got[id])
+				}
+			}
+		})
+	}
+
+	// Seri düzeyindeki sayaç tekrar bazındaki kayıtlardan etkilenmez
+	_, counts, err := events.GetEventByID(series.ID, owner.ID)
+	if err != nil {
+		t.Fatalf("etkinlik alınamadı: %v", err)
+	}
+	if counts.Going != 1 || counts.NotGoing != 0 {
+		t.Fatalf("seri katılımcı sayısı 1 olmalı, %+v", counts)
+	}
+}
+
+func TestOccurrenceAttendancePrivateSeries(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	stranger := env.User()
+	series := env.Event(owner, testutil.Private, testutil.StartsAt(weekly(0)), testutil.Recurring("FREQ=WEEKLY;COUNT=4"))
+
+	if err := events.AttendEventOccurrence(series.ID, stranger.ID, weekly(1)); err == nil {
+		t.Fatal("özel serinin tekrarına onaysız katılım reddedilmeli")
+	}
+	if err := events.AttendEventOccurrence(series.ID, owner.ID, weekly(1)); err != nil {
+		t.Fatalf("etkinlik sahibi tekrara katılabilmeli: %v", err)
+	}
+}
+
+func TestUpdateOccurrence(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	regular := env.User()
+	dropIn := env.User()
+	series := env.Event(owner, testutil.StartsAt(weekly(0)), testutil.Recurring("FREQ=WEEKLY;BYDAY=MO;COUNT=6"))
+	if _, err := events.AttendEvent(series.ID, regular.ID); err != nil {
+		t.Fatalf("seriye katılım eklenemedi: %v", err)
+	}
+	if err := events.AttendEventOccurrence(series.ID, dropIn.ID, weekly(4)); err != nil {
+		t.Fatalf("tekrara katılım eklenemedi: %v", err)
+	}
+
+	if _, err := events.UpdateOccurrence(series.ID, regular.ID, weekly(1), services.ScopeThis, services.UpdateOccurrenceDTO{Title: "Başkası"}); err == nil {
+		t.Fatal("etkinlik sahibi olmayan tekrarı düzenleyememeli")
+	}
+
+	// Yalnızca bu tekrar: bir saat ileri alınır, diğer tekrarlar değişmez
+	moved := weekly(1).Add(time.Hour)
+	instance, err := events.UpdateOccurrence(series.ID, owner.ID, weekly(1), services.ScopeThis, services.UpdateOccurrenceDTO{
+		Title:     "Ertelenen buluşma",
+		StartTime: moved.Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("tekrar güncellenemedi: %v", err)
+	}
+	if instance.ID != series.ID || !instance.FinalStartTime.Equal(moved) || !instance.FinalEndTime.Equal(moved.Add(2*time.Hour)) {
+		t.Fatalf("güncellenen tekrar yanlış: %+v", instance)
+	}
+
+	from, to := weekly(0), weekly(6)
+	got, err := events.GetEventOccurrences(series.ID, owner.ID, from, to)
+	if err != nil {
+		t.Fatalf("tekrarlar alınamadı: %v", err)
+	}
+	if len(got) != 6 {
+		t.Fatalf("6 tekrar olmalı, %d", len(got))
+	}
+	for i, occurrence := range got {
+		wantTitle := series.Title
+		if i == 1 {
+			wantTitle = "Ertelenen buluşma"
+		}
+		if occurrence.Title != wantTitle {
+			t.Fatalf("%d. tekrarın başlığı %q olmalı, %q", i, wantTitle, occurrence.Title)
+		}
+	}
+	if !got[1].FinalStartTime.Equal(moved) || !got[1].Occurrence.Equal(weekly(1)) {
+		t.Fatal("taşınan tekrar yeni zamanında ve asıl anahtarıyla listelenmeli")
+	}
+
+	// Bu ve sonrakiler: 4. tekrardan itibaren salı 19:00'a taşınır
+	tuesday := weekly(3).AddDate(0, 0, 1).Add(time.Hour)
+	next, err := events.UpdateOccurrence(series.ID, owner.ID, weekly(3), services.ScopeFollowing, services.UpdateOccurrenceDTO{
+		Title:     "Salı buluşması",
+		StartTime: tuesday.Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("seri bölünemedi: %v", err)
+	}
+	if next.ID == series.ID || next.RecurrenceRule != "FREQ=WEEKLY;COUNT=3;BYDAY=TU" {
+		t.Fatalf("kalan tekrarlar yeni bir seri olmalı, kural: %q", next.RecurrenceRule)
+	}
+
+	old, err := events.GetEventOccurrences(series.ID, owner.ID, from, to)
+	if err != nil {
+		t.Fatalf("eski serinin tekrarları alınamadı: %v", err)
+	}
+	if len(old) != 3 || old[0].Title != series.Title {
+		t.Fatalf("eski seride ilk 3 tekrar değişmeden kalmalı: %v", startsOf(old))
+	}
+
+	tail, err := events.GetEventOccurrences(next.ID, owner.ID, from, to.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("yeni serinin tekrarları alınamadı: %v", err)
+	}
+	if len(tail) != 3 || !tail[0].FinalStartTime.Equal(tuesday) || tail[2].Title != "Salı buluşması" {
+		t.Fatalf("yeni seri salı 19:00'dan başlayan 3 tekrar olmalı: %v", startsOf(tail))
+	}
+
+	// Sonraki tekrarın katılımı yeni serinin karşılık gelen tekrarına taşınır, seri katılımcıları kopyalanır
+	attendees, err := events.GetOccurrenceAttendees(next.ID, tuesday.AddDate(0, 0, 7), owner.ID)
+	if err != nil {
+		t.Fatalf("taşınan tekrarın katılımcıları alınamadı: %v", err)
+	}
+	statuses := statusesByID(t, attendees)
+	if statuses[regular.ID] != "going" || statuses[dropIn.ID] != "going" {
+		t.Fatalf("taşınan katılımlar korunmalı: %v", statuses)
+	}
+	if _, err := events.GetOccurrenceAttendees(series.ID, weekly(4), owner.ID); err == nil {
+		t.Fatal("bölünen tekrar artık eski serinin tekrarı olmamalı")
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// statusesByID katılımcı listesini kullanıcı ID'sine göre durumlara çevirir
+func statusesByID(t *testing.T, attendees *services.EventAttendees) map[uint64]string {
+	t.Helper()
+	statuses := make(map[uint64]string)
+	for _, attendee := range attendees.Attendees {
+		statuses[attendee.ID] = attendee.Status
+	}
+	return statuses
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+)
+
+const (
+	maxRSVPGuests     = 10
+	maxRSVPNoteLength = 500
+)
+
+// RSVPDTO kullanıcının etkinliğe verdiği yanıt
+type RSVPDTO struct {
+	Status models.EventAttendanceStatusType `json:"status" binding:"omitempty,oneof=going maybe not_going"` // Boşsa going
+	Guests int                              `json:"guests" binding:"min=0,max=10"`                          // Kullanıcıyla gelecek ek kişi sayısı
+	Note   string                           `json:"note" binding:"max=500"`
+}
+
+// validate yanıtı doğrular ve varsayılanları uygular. not_going yanıtında misafir tutulmaz.
+func (dto *RSVPDTO) validate() error {
+	if dto.Status == "" {
+		dto.Status = models.AttendanceGoing
+	}
+	if !dto.Status.IsRSVP() {
+		return fmt.Errorf("geçersiz katılım durumu: %s", dto.Status)
+	}
+	if dto.Guests < 0 || dto.Guests > maxRSVPGuests {
+		return fmt.Errorf("ek misafir sayısı 0 ile %d arasında olmalıdır", maxRSVPGuests)
+	}
+	dto.Note = strings.TrimSpace(dto.Note)
+	if utf8.RuneCountInString(dto.Note) > maxRSVPNoteLength {
+		return fmt.Errorf("not en fazla %d karakter olabilir", maxRSVPNoteLength)
+	}
+	if dto.Status == models.AttendanceNotGoing {
+		dto.Guests = 0
+	}
+	return nil
+}
+
+// AttendanceCounts etkinliğin katılım durumlarına göre sayıları
+type AttendanceCounts struct {
+	Going      int64 `json:"going"`
+	Maybe      int64 `json:"maybe"`
+	NotGoing   int64 `json:"not_going"`
+	Waitlisted int64 `json:"waitlisted"`
+	Invited    int64 `json:"invited,omitempty"` // Yanıt vermemiş davetliler; yalnızca katılımcı listesinde sayılır
+	Guests     int64 `json:"guests"`            // Katılacakların getirdiği ek misafirler
+}
+
+// Seats kapasiteden düşülen toplam yeri döndürür: katılacaklar ve getirdikleri misafirler
+func (c AttendanceCounts) Seats() int64 {
+	return c.Going + c.Guests
+}
+
+// add bir katılım kaydını durumuna göre sayılara ekler
+func (c *AttendanceCounts) add(status models.EventAttendanceStatusType, count, guests int64) {
+	switch status {
+	case models.AttendanceGoing:
+		c.Going += count
+		c.Guests += guests
+	case models.AttendanceMaybe:
+		c.Maybe += count
+	case models.AttendanceNotGoing:
+		c.NotGoing += count
+	case models.AttendanceWaitlisted:
+		c.Waitlisted += count
+	}
+}
+
+// countAttendance etkinliğin tamamına ait katılımları durumlarına göre sayar
+func countAttendance(repos repository.Repositories, eventID uint64) (AttendanceCounts, error) {
+	var counts AttendanceCounts
+	totals, err := repos.Participation.CountByStatus(eventID)
+	if err != nil {
+		return counts, err
+	}
+	for _, total := range totals {
+		counts.add(total.Status, total.Count, total.Guests)
+	}
+	return counts, nil
+}
+
+// setRSVP kullanıcının etkinliğin tamamına verdiği yanıtı kaydeder. going yanıtı kapasite doluysa, bekleme
+// listesinde sırası gelmemiş kullanıcılar varsa veya misafirlerle birlikte sığmıyorsa kullanıcıyı bekleme
+// listesinin sonuna ekler; bekleme listesindeki kullanıcının sırası yanıtını güncellediğinde korunur. Zaten
+// katılan kullanıcı misafir sayısını ancak boş yer varsa artırabilir. Boşalan yerler bekleme listesindekilere
+// verilir. Etkinlik satırı kilitlendiğinden eşzamanlı yanıtlar kapasiteyi aşamaz; işlem içinde çağrılmalıdır.
+func setRSVP(repos repository.Repositories, eventID, userID uint64, dto RSVPDTO) (*models.EventAttendance, error) {
+	if err := dto.validate(); err != nil {
+		return nil, err
+	}
+	event, err := repos.Events.FindByIDForUpdate(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
+		return nil, err
+	}
+
+	current, err := repos.Participation.FindAttendance(eventID, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		current = nil
+	} else if err != nil {
+		return nil, err
+	}
+	attendance := models.EventAttendance{
+		EventID:  eventID,
+		UserID:   userID,
+		Status:   dto.Status,
+		Guests:   dto.Guests,
+		Note:     dto.Note,
+		JoinedAt: time.Now(),
+	}
+	if current != nil {
+		attendance.ID = current.ID
+		attendance.JoinedAt = current.JoinedAt
+	}
+
+	if dto.Status == models.AttendanceGoing && event.Capacity != nil {
+		counts, err := countAttendance(repos, eventID)
+		if err != nil {
+			return nil, err
+		}
+		capacity := int64(*event.Capacity)
+		seats := int64(1 + dto.Guests)
+		if seats > capacity {
+			return nil, fmt.Errorf("etkinliğin kapasitesi %d kişi; misafirlerinizle birlikte sığmıyorsunuz", capacity)
+		}
+
+		switch {
+		case current != nil && current.Status == models.AttendanceGoing:
+			if free := capacity - counts.Seats() + int64(1+current.Guests); seats > free {
+				return nil, fmt.Errorf("yeterli yer yok; en fazla %d ek misafir getirebilirsiniz", free-1)
+			}
+		case current != nil && current.Status == models.AttendanceWaitlisted:
+			attendance.Status = models.AttendanceWaitlisted
+			attendance.WaitlistPosition = current.WaitlistPosition
+		case counts.Waitlisted > 0 || counts.Seats()+seats > capacity:
+			last, err := repos.Participation.MaxWaitlistPosition(eventID)
+			if err != nil {
+				return nil, err
+			}
+			attendance.Status = models.AttendanceWaitlisted
+			attendance.WaitlistPosition = last + 1
+		}
+	}
+
+	if current == nil {
+		err = repos.Participation.UpsertAttendance(&attendance, "status", "guests", "note", "joined_at", "waitlist_position")
+	} else {
+		err = repos.Participation.UpdateAttendanceFields(current.ID, map[string]interface{}{
+			"status":            attendance.Status,
+			"guests":            attendance.Guests,
+			"note":              attendance.Note,
+			"waitlist_position": attendance.WaitlistPosition,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Capacity != nil {
+		if err := fillFromWaitlist(repos, event); err != nil {
+			return nil, err
+		}
+		// Bekleme listesindeki kullanıcı misafirlerini azaltınca sırası gelmiş olabilir
+		if attendance.Status == models.AttendanceWaitlisted {
+			if promoted, err := repos.Participation.FindAttendance(eventID, userID); err == nil {
+				return promoted, nil
+			}
+		}
+	}
+	return &attendance, nil
+}
+
+// RSVP kullanıcının etkinliğe yanıtını (going, maybe veya not_going), ek misafir sayısını ve notunu kaydeder.
+// Özel etkinlikte henüz katılım kaydı olmayan kullanıcı için katılım isteği oluşturulur ve nil katılım döner;
+// istek onaylanınca kullanıcı going olarak eklenir.
+func (s *EventService) RSVP(eventID, userID uint64, dto RSVPDTO) (*models.EventAttendance, error) {
+	if err := dto.validate(); err != nil {
+		return nil, err
+	}
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
+		return nil, err
+	}
+
+	if event.IsPrivate && event.CreatorUserID != userID {
+		_, err := s.repos.Participation.FindAttendance(eventID, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			if dto.Status == models.AttendanceNotGoing {
+				return nil, errors.New("bu etkinliğe bir katılım kaydınız yok")
+			}
+			return nil, s.requestParticipation(event, userID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var attendance *models.EventAttendance
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		attendance, err = setRSVP(repos, eventID, userID, dto)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
@@ -0,0 +1,194 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func rsvp(t *testing.T, events *services.EventService, eventID, userID uint64, dto services.RSVPDTO) *models.EventAttendance {
+	t.Helper()
+	attendance, err := events.RSVP(eventID, userID, dto)
+	if err != nil {
+		t.Fatalf("yanıt kaydedilemedi: %v", err)
+	}
+	return attendance
+}
+
+func TestRSVPStatesAndCounts(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner)
+	going, maybe, notGoing := env.User(), env.User(), env.User()
+
+	rsvp(t, events, event.ID, going.ID, services.RSVPDTO{Guests: 2, Note: "  Eşim ve kızım da geliyor  "})
+	rsvp(t, events, event.ID, maybe.ID, services.RSVPDTO{Status: models.AttendanceMaybe, Guests: 1})
+	declined := rsvp(t, events, event.ID, notGoing.ID, services.RSVPDTO{Status: models.AttendanceNotGoing, Guests: 3})
+	if declined.Guests != 0 {
+		t.Fatalf("katılmayan kullanıcının misafiri tutulmamalı: %+v", declined)
+	}
+
+	for _, invalid := range []services.RSVPDTO{
+		{Status: "attending"},
+		{Status: models.AttendanceWaitlisted},
+		{Guests: -1},
+		{Guests: 11},
+		{Note: strings.Repeat("ş", 501)},
+	} {
+		if _, err := events.RSVP(event.ID, going.ID, invalid); err == nil {
+			t.Fatalf("geçersiz yanıt reddedilmeli: %+v", invalid)
+		}
+	}
+
+	_, counts, err := events.GetEventByID(event.ID, 0)
+	if err != nil {
+		t.Fatalf("etkinlik alınamadı: %v", err)
+	}
+	want := services.AttendanceCounts{Going: 1, Maybe: 1, NotGoing: 1, Guests: 2}
+	if counts != want || counts.Seats() != 3 {
+		t.Fatalf("sayılar %+v olmalı, %+v", want, counts)
+	}
+
+	// Notlar yalnızca etkinlik sahibine ve yazan kullanıcıya gösterilir
+	notes := func(viewerID uint64) string {
+		attendees, err := events.GetEventAttendees(event.ID, viewerID)
+		if err != nil {
+			t.Fatalf("katılımcılar alınamadı: %v", err)
+		}
+		if attendees.Counts != want {
+			t.Fatalf("katılımcı listesi sayıları %+v olmalı, %+v", want, attendees.Counts)
+		}
+		for _, attendee := range attendees.Attendees {
+			if attendee.ID == going.ID {
+				if attendee.Status != "going" || attendee.Guests != 2 {
+					t.Fatalf("katılımcı durumu ve misafirleri yanlış: %+v", attendee)
+				}
+				return attendee.Note
+			}
+		}
+		t.Fatal("katılımcı listede yok")
+		return ""
+	}
+	for _, viewerID := range []uint64{owner.ID, going.ID} {
+		if note := notes(viewerID); note != "Eşim ve kızım da geliyor" {
+			t.Fatalf("kullanıcı %d notu görmeli: %q", viewerID, note)
+		}
+	}
+	for _, viewerID := range []uint64{maybe.ID, 0} {
+		if note := notes(viewerID); note != "" {
+			t.Fatalf("kullanıcı %d notu görmemeli: %q", viewerID, note)
+		}
+	}
+
+	// Yanıt değiştirilebilir; katılımı iptal etmek not_going yanıtıdır
+	if changed := rsvp(t, events, event.ID, maybe.ID, services.RSVPDTO{}); changed.Status != models.AttendanceGoing || changed.Guests != 0 {
+		t.Fatalf("belki yanıtı going olmalı: %+v", changed)
+	}
+	if err := events.CancelAttendance(event.ID, going.ID); err != nil {
+		t.Fatalf("katılım iptal edilemedi: %v", err)
+	}
+	_, counts, _ = events.GetEventByID(event.ID, 0)
+	if want := (services.AttendanceCounts{Going: 1, NotGoing: 2}); counts != want {
+		t.Fatalf("sayılar %+v olmalı, %+v", want, counts)
+	}
+}
+
+func TestRSVPGuestsCountAgainstCapacity(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner, testutil.Capacity(4))
+	family, friend, couple := env.User(), env.User(), env.User()
+
+	if _, err := events.RSVP(event.ID, family.ID, services.RSVPDTO{Guests: 4}); err == nil {
+		t.Fatal("misafirlerle birlikte kapasiteyi aşan yanıt reddedilmeli")
+	}
+	rsvp(t, events, event.ID, family.ID, services.RSVPDTO{Guests: 2})
+
+	// Misafirleriyle sığmayan kullanıcı bekleme listesine alınır; belki yanıtı yer tutmaz
+	if waiting := rsvp(t, events, event.ID, couple.ID, services.RSVPDTO{Guests: 1}); waiting.Status != models.AttendanceWaitlisted {
+		t.Fatalf("sığmayan kullanıcı bekleme listesine alınmalı: %+v", waiting)
+	}
+	if tentative := rsvp(t, events, event.ID, friend.ID, services.RSVPDTO{Status: models.AttendanceMaybe}); tentative.Status != models.AttendanceMaybe {
+		t.Fatalf("belki yanıtı bekleme listesine alınmamalı: %+v", tentative)
+	}
+	if _, err := events.RSVP(event.ID, family.ID, services.RSVPDTO{Guests: 3}); err != nil {
+		t.Fatalf("boş yere misafir eklenebilmeli: %v", err)
+	}
+	if _, err := events.RSVP(event.ID, family.ID, services.RSVPDTO{Guests: 4}); err == nil {
+		t.Fatal("kapasiteyi aşan misafir artışı reddedilmeli")
+	}
+
+	// Misafir azalınca açılan yerler bekleme listesindekine verilir
+	rsvp(t, events, event.ID, family.ID, services.RSVPDTO{Guests: 1})
+	var promoted models.EventAttendance
+	env.DB.Where("event_id = ? AND user_id = ?", event.ID, couple.ID).First(&promoted)
+	if promoted.Status != models.AttendanceGoing || promoted.Guests != 1 {
+		t.Fatalf("bekleyen kullanıcı misafiriyle katılımcı yapılmalı: %+v", promoted)
+	}
+	_, counts, _ := events.GetEventByID(event.ID, owner.ID)
+	if counts.Seats() != 4 || counts.Maybe != 1 {
+		t.Fatalf("kapasite tam dolu olmalı: %+v", counts)
+	}
+}
+
+func TestRSVPPrivateEvent(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	guest := env.User()
+	event := env.Event(owner, testutil.Private)
+
+	if _, err := events.RSVP(event.ID, guest.ID, services.RSVPDTO{Status: models.AttendanceNotGoing}); err == nil {
+		t.Fatal("katılım kaydı olmadan katılmama yanıtı reddedilmeli")
+	}
+	if attendance := rsvp(t, events, event.ID, guest.ID, services.RSVPDTO{Status: models.AttendanceMaybe}); attendance != nil {
+		t.Fatalf("onaylanmamış kullanıcı için katılım isteği oluşturulmalı: %+v", attendance)
+	}
+
+	var request models.EventParticipationRequest
+	env.DB.Where("event_id = ? AND user_id = ?", event.ID, guest.ID).First(&request)
+	if err := events.ApproveParticipationRequest(request.ID, owner.ID); err != nil {
+		t.Fatalf("istek onaylanamadı: %v", err)
+	}
+
+	// Onaylanan kullanıcı yanıtını değiştirebilir
+	if attendance := rsvp(t, events, event.ID, guest.ID, services.RSVPDTO{Status: models.AttendanceMaybe, Guests: 1}); attendance.Status != models.AttendanceMaybe {
+		t.Fatalf("onaylanan kullanıcının yanıtı kaydedilmeli: %+v", attendance)
+	}
+}
+
+func TestRSVPAttendeesOfPrivateEvent(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner, friend, outsider := env.User(), env.User(), env.User()
+	env.Friends(owner, friend)
+	event := env.Event(owner, testutil.Private)
+	series := env.Event(owner, testutil.Private, testutil.Recurring("FREQ=WEEKLY;COUNT=2"))
+	rsvp(t, events, event.ID, owner.ID, services.RSVPDTO{Guests: 1, Note: "Pasta bende"})
+	rsvp(t, events, series.ID, owner.ID, services.RSVPDTO{})
+
+	// Katılımcı listesi, sayılar ve misafirler etkinliği göremeyen kullanıcılara gösterilmez
+	for _, viewerID := range []uint64{0, outsider.ID} {
+		if _, err := events.GetEventAttendees(event.ID, viewerID); err == nil {
+			t.Fatalf("kullanıcı %d özel etkinliğin katılımcılarını görmemeli", viewerID)
+		}
+		if _, err := events.GetOccurrenceAttendees(series.ID, *series.FinalStartTime, viewerID); err == nil {
+			t.Fatalf("kullanıcı %d özel serinin tekrarındaki katılımcıları görmemeli", viewerID)
+		}
+	}
+
+	for _, viewerID := range []uint64{owner.ID, friend.ID} {
+		attendees, err := events.GetEventAttendees(event.ID, viewerID)
+		if err != nil || attendees.Counts.Going != 1 || attendees.Counts.Guests != 1 {
+			t.Fatalf("kullanıcı %d katılımcıları görebilmeli: %+v, %v", viewerID, attendees, err)
+		}
+		if _, err := events.GetOccurrenceAttendees(series.ID, *series.FinalStartTime, viewerID); err != nil {
+			t.Fatalf("kullanıcı %d tekrarın katılımcılarını görebilmeli: %v", viewerID, err)
+		}
+	}
+}
@@ -3,51 +3,39 @@ package services
 import (
 	"errors"
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
+	"event/backend/internal/repository"
 	"fmt"
 	"log"
 	"time"
-
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // EventService etkinlik işlemlerini yöneten servis
 type EventService struct {
-	db *gorm.DB
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+// EventServiceInput, EventService için bağımlılıkları içerir.
+type EventServiceInput struct {
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
 }
 
 // NewEventService yeni bir EventService örneği oluşturur
-func NewEventService() *EventService {
+func NewEventService(input EventServiceInput) *EventService {
 	return &EventService{
-		db: database.GetDB(),
+		repos: input.Repositories,
+		uow:   input.UnitOfWork,
 	}
 }
 
 // GetAllPublicEvents tüm herkese açık etkinlikleri listeler (pagination ile)
 func (s *EventService) GetAllPublicEvents(page, limit int) ([]models.Event, int64, error) {
-	var events []models.Event
-	var total int64
-
-	// Toplam kayıt sayısını hesapla (is_private filtresi kaldırıldı)
-	if err := s.db.Model(&models.Event{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
 	// Offset hesapla
 	offset := (page - 1) * limit
 
-	// Herkese açık etkinlikleri getir (is_private filtresi kaldırıldı)
-	if err := s.db.Preload("Creator").
-		Preload("Room").
-		Limit(limit).
-		Offset(offset).
-		Order("created_at DESC").
-		Find(&events).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return events, total, nil
+	// Etkinlikleri toplam kayıt sayısıyla getir (is_private filtresi kaldırıldı)
+	return s.repos.Events.FindPage(offset, limit)
 }
 
 // CreateEventDTO yeni etkinlik oluşturma için veri transfer nesnesi
@@ -57,7 +45,13 @@ type CreateEventDTO struct {
 	RoomID      *uint    `json:"room_id"`
 	IsPrivate   bool     `json:"is_private"`
 	ImageURL    string   `json:"image_url" binding:"omitempty,url"`
+	Capacity    *int     `json:"capacity" binding:"omitempty,min=1"`    // Boşsa katılımcı sayısı sınırsızdır
 	TimeOptions []string `json:"time_options" binding:"required,min=1"` // ISO 8601 formatında tarih listesi
+	// Tekrarlanan etkinlikler için RFC 5545 kuralı (ör. FREQ=WEEKLY;BYDAY=TU;COUNT=10).
+	// Kural verilirse tek bir zaman seçeneği olmalıdır; etkinlik bu zamanla kesinleşir ve seri oradan başlar.
+	RecurrenceRule string   `json:"recurrence_rule" binding:"omitempty,max=255"`
+	TimeZone       string   `json:"time_zone" binding:"omitempty,max=64"` // IANA saat dilimi; tekrarlar bu dilimin yerel saatinde üretilir
+	ExDates        []string `json:"exdates"`                              // İptal edilen tekrarların RFC 3339 başlangıçları
 }
 
 // UpdateEventDTO etkinlik güncelleme için veri transfer nesnesi
@@ -65,17 +59,48 @@ type UpdateEventDTO struct {
 	Title       string   `json:"title" binding:"omitempty,min=3,max=100"`
 	Description string   `json:"description" binding:"omitempty,min=10,max=500"`
 	IsPrivate   *bool    `json:"is_private"`
+	Capacity    *int     `json:"capacity" binding:"omitempty,min=0"`     // 0 kapasite sınırını kaldırır
 	TimeOptions []string `json:"time_options" binding:"omitempty,min=1"` // ISO 8601 formatında tarih listesi
+	// RecurrenceRule tüm serinin kuralını değiştirir; boş metin tekrarı kaldırır
+	RecurrenceRule *string `json:"recurrence_rule" binding:"omitempty,max=255"`
+	TimeZone       *string `json:"time_zone" binding:"omitempty,max=64"`
 }
 
 // CreateEvent yeni bir etkinlik oluşturur
 func (s *EventService) CreateEvent(creatorID uint64, dto CreateEventDTO) (*models.Event, error) {
-	// Transaction başlat
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
+	event, starts, err := newEvent(creatorID, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Events.Create(&event); err != nil {
+			return err
+		}
+
+		// Zaman seçeneklerini ekle
+		for _, start := range starts {
+			// Örnek: Her bir zaman için 2 saatlik bir aralık ekliyoruz
+			timeOption := models.EventTimeOption{
+				EventID:   event.ID,
+				StartTime: start,
+				EndTime:   start.Add(2 * time.Hour),
+			}
+			if err := repos.Events.CreateTimeOption(&timeOption); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return &event, nil
+}
+
+// newEvent DTO'dan henüz kaydedilmemiş bir etkinlik oluşturur ve zaman seçeneklerinin başlangıçlarını döndürür
+func newEvent(creatorID uint64, dto CreateEventDTO) (models.Event, []time.Time, error) {
 	var eventRoomIDPointer *uint64
 	if dto.RoomID != nil {
 		// DTO'dan gelen *uint değerini alıp *uint64'e çeviriyoruz
@@ -83,7 +108,6 @@ func (s *EventService) CreateEvent(creatorID uint64, dto CreateEventDTO) (*model
 		eventRoomIDPointer = &tempRoomID
 	}
 
-	// Etkinliği oluştur
 	event := models.Event{
 		Title:         dto.Title,
 		Description:   dto.Description,
@@ -91,132 +115,95 @@ func (s *EventService) CreateEvent(creatorID uint64, dto CreateEventDTO) (*model
 		RoomID:        eventRoomIDPointer, // *uint64 tipindeki işaretçiyi ata
 		IsPrivate:     dto.IsPrivate,
 		ImageURL:      dto.ImageURL,
+		Capacity:      dto.Capacity,
 	}
 
-	if err := tx.Create(&event).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
-
-	// Zaman seçeneklerini ekle
+	starts := make([]time.Time, 0, len(dto.TimeOptions))
 	for _, timeStr := range dto.TimeOptions {
 		parsedTime, err := time.Parse(time.RFC3339, timeStr)
 		if err != nil {
-			tx.Rollback()
-			return nil, errors.New("geçersiz tarih formatı")
-		}
-		// Örnek: Her bir timeStr için 2 saatlik bir aralık ekliyoruz
-		endTime := parsedTime.Add(2 * time.Hour)
-		timeOption := models.EventTimeOption{
-			EventID:   event.ID,
-			StartTime: parsedTime,
-			EndTime:   endTime,
-		}
-		if err := tx.Create(&timeOption).Error; err != nil {
-			tx.Rollback()
-			return nil, err
+			return event, nil, errors.New("geçersiz tarih formatı")
 		}
+		starts = append(starts, parsedTime)
 	}
-
-	// Transaction'ı tamamla
-	if err := tx.Commit().Error; err != nil {
-		return nil, err
+	if err := applyRecurrence(&event, dto); err != nil {
+		return event, nil, err
 	}
-
-	return &event, nil
+	return event, starts, nil
 }
 
 // GetUserEvents kullanıcının görebileceği etkinlikleri listeler
 func (s *EventService) GetUserEvents(userID uint64) ([]models.Event, error) {
-	var events []models.Event
-
 	// Kullanıcının görebileceği etkinlikleri getir:
 	// 1. Kendi etkinlikleri
 	// 2. Arkadaşlarının herkese açık etkinlikleri
 	// 3. Üye olduğu odaların etkinlikleri
-	if err := s.db.Where(`
-		(creator_user_id = ?) OR
-		(is_private = false AND creator_user_id IN (
-			SELECT CASE 
-				WHEN requester_id = ? THEN addressee_id
-				ELSE requester_id
-			END
-			FROM friendships
-			WHERE (requester_id = ? OR addressee_id = ?)
-			AND status = 'accepted'
-		)) OR
-		(room_id IN (
-			SELECT room_id
-			FROM room_members
-			WHERE user_id = ?
-		))
-	`, userID, userID, userID, userID, userID).
-		Preload("Creator").
-		Preload("Room").
-		Preload("TimeOptions").
-		Find(&events).Error; err != nil {
-		return nil, err
-	}
+	return s.repos.Events.FindVisibleTo(userID)
+}
 
-	return events, nil
+// GetEventsCreatedByUser bir kullanıcının oluşturduğu etkinlikleri listeler.
+// Görüntüleyen kişi etkinliklerin sahibi değilse sadece herkese açık etkinlikler döner.
+func (s *EventService) GetEventsCreatedByUser(creatorID uint64, viewerID uint64) ([]models.Event, error) {
+	return s.repos.Events.FindByCreator(creatorID, creatorID == viewerID)
 }
 
 // GetEventByID belirli bir etkinliğin detaylarını getirir
-func (s *EventService) GetEventByID(eventID uint64, userID uint64) (*models.Event, int64, error) {
-	var event models.Event
+func (s *EventService) GetEventByID(eventID uint64, userID uint64) (*models.Event, AttendanceCounts, error) {
 	log.Printf("[EventService] GetEventByID çağrıldı. eventID: %d, userID: %d", eventID, userID)
 
-	if err := s.db.Preload("Creator").
-		Preload("Room").
-		Preload("TimeOptions").
-		First(&event, eventID).Error; err != nil {
+	event, err := s.repos.Events.FindByIDWithDetails(eventID)
+	if err != nil {
 		log.Printf("[EventService] Etkinlik bulunurken hata: %v", err)
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, 0, errors.New("etkinlik bulunamadı")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, AttendanceCounts{}, errors.New("etkinlik bulunamadı")
 		}
-		return nil, 0, err // Diğer veritabanı hataları
+		return nil, AttendanceCounts{}, err // Diğer veritabanı hataları
 	}
 	log.Printf("[EventService] Etkinlik bulundu: %+v", event)
-	log.Printf("[EventService] Creator bilgisi: ID=%d, Username=%s, FirstName=%s, LastName=%s", 
+	log.Printf("[EventService] Creator bilgisi: ID=%d, Username=%s, FirstName=%s, LastName=%s",
 		event.Creator.ID, event.Creator.Username, event.Creator.FirstName, event.Creator.LastName)
 
-	// Katılımcı sayısını hesapla
-	var attendeesCount int64
-	// EventAttendee modelinin ve ilişkili tablonun doğru olduğunu varsayıyoruz.
-	// Eğer farklıysa, model adı (EventAttendee) ve where koşulu (EventID) güncellenmeli.
-	if err := s.db.Model(&models.EventAttendance{}).Where("event_id = ? AND status = ?", eventID, models.AttendanceAttending).Count(&attendeesCount).Error; err != nil {
-		log.Printf("[EventService] Etkinlik katılımcı sayısı alınırken hata: %v", err)
-		// Hata durumunda katılımcı sayısını 0 kabul edip devam edebilir veya hatayı yukarı fırlatabiliriz.
+	if err := s.checkEventAccess(event, userID); err != nil {
+		return nil, AttendanceCounts{}, err
+	}
+
+	// Katılım durumlarına göre sayıları hesapla
+	counts, err := countAttendance(s.repos, eventID)
+	if err != nil {
+		log.Printf("[EventService] Etkinlik katılımcı sayıları alınırken hata: %v", err)
+		// Hata durumunda sayıları 0 kabul edip devam edebilir veya hatayı yukarı fırlatabiliriz.
 		// Şimdilik loglayıp 0 ile devam edelim, böylece etkinlik detayı yine de gösterilebilir.
-		attendeesCount = 0
+		counts = AttendanceCounts{}
 	}
-	log.Printf("[EventService] Etkinlik katılımcı sayısı: %d", attendeesCount)
+	log.Printf("[EventService] Etkinlik katılımcı sayıları: %+v", counts)
+
+	log.Println("[EventService] GetEventByID başarıyla tamamlandı. Etkinlik ve katılımcı sayıları döndürülüyor.")
+	return event, counts, nil
+}
 
-	// Erişim kontrolü
+// checkEventAccess kullanıcının etkinliği görüntüleyip görüntüleyemeyeceğini denetler. Herkese açık etkinlikler
+// herkese açıktır; özel etkinlikleri yalnızca sahibi, sahibinin arkadaşları ve etkinliğin odasının üyeleri görebilir.
+// Anonim istekler için userID 0 verilir.
+func (s *EventService) checkEventAccess(event *models.Event, userID uint64) error {
 	if event.IsPrivate {
 		log.Println("[EventService] Etkinlik özel (IsPrivate = true)")
 		if userID == 0 {
 			log.Println("[EventService] Anonim kullanıcı özel etkinliğe erişmeye çalışıyor.")
-			return nil, 0, errors.New("bu özel etkinliği görüntülemek için giriş yapmalısınız")
+			return errors.New("bu özel etkinliği görüntülemek için giriş yapmalısınız")
 		}
 		log.Printf("[EventService] Giriş yapmış kullanıcı (ID: %d) özel etkinliğe erişiyor.", userID)
 
 		if event.CreatorUserID != userID { // Kullanıcı etkinliğin sahibi değilse
 			log.Printf("[EventService] Kullanıcı (ID: %d) etkinliğin sahibi değil (Sahip ID: %d). Arkadaşlık kontrol edilecek.", userID, event.CreatorUserID)
 			// Arkadaşlık kontrolü
-			var friendship models.Friendship
-			errFriendship := s.db.Where("((requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)) AND status = 'accepted'",
-				userID, event.CreatorUserID, event.CreatorUserID, userID).
-				First(&friendship).Error
+			isFriend, errFriendship := s.repos.Friendships.AreFriends(userID, event.CreatorUserID)
 			log.Printf("[EventService] Arkadaşlık sorgusu sonucu errFriendship: %v", errFriendship)
 
-			isFriend := false
-			if errFriendship == nil { // Hata yoksa arkadaştır
-				isFriend = true
-				log.Println("[EventService] Kullanıcı etkinliğin sahibiyle arkadaş.")
-			} else if !errors.Is(errFriendship, gorm.ErrRecordNotFound) { // Kayıt bulunamadı dışında bir hata ise
+			if errFriendship != nil { // Veritabanı hatası
 				log.Printf("[EventService] Arkadaşlık sorgusunda beklenmedik hata: %v", errFriendship)
-				return nil, 0, errFriendship // Bu gerçek bir DB hatası, yukarı fırlat
+				return errFriendship // Bu gerçek bir DB hatası, yukarı fırlat
+			} else if isFriend {
+				log.Println("[EventService] Kullanıcı etkinliğin sahibiyle arkadaş.")
 			} else {
 				log.Println("[EventService] Kullanıcı etkinliğin sahibiyle arkadaş değil (kayıt bulunamadı).")
 			}
@@ -225,29 +212,27 @@ func (s *EventService) GetEventByID(eventID uint64, userID uint64) (*models.Even
 				log.Println("[EventService] Kullanıcı arkadaş değil. Oda üyeliği kontrol edilecek.")
 				if event.RoomID != nil {
 					log.Printf("[EventService] Etkinliğin odası var (RoomID: %d). Üyelik kontrol ediliyor.", *event.RoomID)
-					var member models.RoomMember
-					errMember := s.db.Where("room_id = ? AND user_id = ?", *event.RoomID, userID).
-						First(&member).Error
+					_, errMember := s.repos.Rooms.FindMember(*event.RoomID, userID)
 					log.Printf("[EventService] Oda üyeliği sorgusu sonucu errMember: %v", errMember)
 
 					isMember := false
 					if errMember == nil { // Hata yoksa üyedir
 						isMember = true
 						log.Println("[EventService] Kullanıcı odaya üye.")
-					} else if !errors.Is(errMember, gorm.ErrRecordNotFound) { // Kayıt bulunamadı dışında bir hata ise
+					} else if !errors.Is(errMember, repository.ErrNotFound) { // Kayıt bulunamadı dışında bir hata ise
 						log.Printf("[EventService] Oda üyeliği sorgusunda beklenmedik hata: %v", errMember)
-						return nil, 0, errMember // Bu gerçek bir DB hatası, yukarı fırlat
+						return errMember // Bu gerçek bir DB hatası, yukarı fırlat
 					} else {
 						log.Println("[EventService] Kullanıcı odaya üye değil (kayıt bulunamadı).")
 					}
 
 					if !isMember { // Ne arkadaş ne de üye ise erişemez
 						log.Println("[EventService] Kullanıcı ne sahip, ne arkadaş, ne de oda üyesi. Erişim reddedildi.")
-						return nil, 0, errors.New("bu özel etkinliğe erişim yetkiniz yok (ne sahip, ne arkadaş, ne de oda üyesi)")
+						return errors.New("bu özel etkinliğe erişim yetkiniz yok (ne sahip, ne arkadaş, ne de oda üyesi)")
 					}
 				} else { // Oda yoksa ve arkadaş da değilse (ve sahip de değilse) erişemez
 					log.Println("[EventService] Etkinliğin odası yok ve kullanıcı arkadaş değil. Erişim reddedildi.")
-					return nil, 0, errors.New("bu özel etkinliğe erişim yetkiniz yok (ne sahip, ne arkadaş)")
+					return errors.New("bu özel etkinliğe erişim yetkiniz yok (ne sahip, ne arkadaş)")
 				}
 			}
 		} else {
@@ -257,17 +242,15 @@ func (s *EventService) GetEventByID(eventID uint64, userID uint64) (*models.Even
 		log.Println("[EventService] Etkinlik herkese açık (IsPrivate = false). Erişim verildi.")
 	}
 
-	log.Println("[EventService] GetEventByID başarıyla tamamlandı. Etkinlik ve katılımcı sayısı döndürülüyor.")
-	return &event, attendeesCount, nil
+	return nil
 }
 
 // UpdateEvent etkinliği günceller
 func (s *EventService) UpdateEvent(eventID uint64, userID uint64, dto UpdateEventDTO) (*models.Event, error) {
-	var event models.Event
-
 	// Etkinliği bul ve sahibini kontrol et
-	if err := s.db.First(&event, eventID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return nil, errors.New("etkinlik bulunamadı")
 		}
 		return nil, err
@@ -277,57 +260,150 @@ func (s *EventService) UpdateEvent(eventID uint64, userID uint64, dto UpdateEven
 		return nil, errors.New("bu etkinliği güncelleme yetkiniz yok")
 	}
 
-	// Güncelleme verilerini hazırla
+	// Güncelleme verilerini hazırla; yalnızca gerçekten değişen alanlar yazılır
 	updates := make(map[string]interface{})
-	if dto.Title != "" {
+	if dto.Title != "" && dto.Title != event.Title {
 		updates["title"] = dto.Title
 	}
-	if dto.Description != "" {
+	if dto.Description != "" && dto.Description != event.Description {
 		updates["description"] = dto.Description
 	}
-	if dto.IsPrivate != nil {
+	if dto.IsPrivate != nil && *dto.IsPrivate != event.IsPrivate {
 		updates["is_private"] = *dto.IsPrivate
 	}
-
-	// Etkinliği güncelle
-	if err := s.db.Model(&event).Updates(updates).Error; err != nil {
-		return nil, err
+	if dto.TimeZone != nil {
+		if _, err := loadTimeZone(*dto.TimeZone); err != nil {
+			return nil, err
+		}
+		if *dto.TimeZone != event.TimeZone {
+			updates["time_zone"] = *dto.TimeZone
+		}
+	}
+	// Kapasite takvim verisi olmadığından tek başına değişmesi SEQUENCE'ı artırmaz
+	var capacity *int
+	capacityChanged := false
+	if dto.Capacity != nil {
+		if *dto.Capacity > 0 {
+			capacity = dto.Capacity
+		}
+		capacityChanged = (capacity == nil) != (event.Capacity == nil) ||
+			(capacity != nil && *capacity != *event.Capacity)
+	}
+	clearRecurrence := false
+	if dto.RecurrenceRule != nil {
+		if *dto.RecurrenceRule == "" {
+			if event.RecurrenceRule != "" || event.ExDates != "" {
+				updates["recurrence_rule"] = ""
+				updates["ex_dates"] = ""
+			}
+			clearRecurrence = event.RecurrenceRule != ""
+		} else {
+			if event.FinalStartTime == nil {
+				return nil, errors.New("tekrar kuralı eklemek için önce etkinliğin zamanı kesinleşmelidir")
+			}
+			timeZone := event.TimeZone
+			if dto.TimeZone != nil {
+				timeZone = *dto.TimeZone
+			}
+			rule, err := parseRecurrenceRule(*dto.RecurrenceRule, *event.FinalStartTime, timeZone)
+			if err != nil {
+				return nil, err
+			}
+			if rule.String() != event.RecurrenceRule {
+				updates["recurrence_rule"] = rule.String()
+			}
+		}
 	}
 
-	// Zaman seçeneklerini güncellemek (opsiyonel):
-	// Bu kısım daha karmaşık olabilir. Mevcutları silip yenilerini eklemek bir yöntemdir.
-	if len(dto.TimeOptions) > 0 {
-		// Mevcut zaman seçeneklerini sil
-		if err := s.db.Where("event_id = ?", eventID).Delete(&models.EventTimeOption{}).Error; err != nil {
+	// Zaman seçenekleri verildiyse mevcutlardan farklı olduklarında silinip yenileri eklenir
+	var timeOptions []time.Time
+	for _, timeStr := range dto.TimeOptions {
+		parsedTime, err := time.Parse(time.RFC3339, timeStr)
+		if err != nil {
+			return nil, errors.New("geçersiz tarih formatı")
+		}
+		timeOptions = append(timeOptions, parsedTime)
+	}
+	replaceOptions := false
+	if len(timeOptions) > 0 {
+		current, err := s.repos.Events.FindTimeOptions(eventID)
+		if err != nil {
 			return nil, err
 		}
-		// Yeni zaman seçeneklerini ekle
-		for _, timeStr := range dto.TimeOptions {
-			parsedTime, err := time.Parse(time.RFC3339, timeStr)
+		replaceOptions = timeOptionsChanged(current, timeOptions)
+	}
+
+	// Hiçbir şey değişmediyse SEQUENCE artırılmaz; takvim uygulamaları etkinliği güncellenmiş saymaz
+	if len(updates) == 0 && !replaceOptions && !capacityChanged {
+		return event, nil
+	}
+
+	// Alanlar ve zaman seçenekleri tek işlemde güncellenir
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		// Artan kapasite bekleme listesindekilerle doldurulur; azalan kapasitede mevcut katılımcılar korunur
+		if capacityChanged {
+			locked, err := repos.Events.FindByIDForUpdate(eventID)
 			if err != nil {
-				return nil, errors.New("geçersiz tarih formatı")
+				return err
 			}
-			timeOption := models.EventTimeOption{
-				EventID:   eventID,
-				StartTime: parsedTime,
+			if err := repos.Events.UpdateFields(eventID, map[string]interface{}{"capacity": capacity}); err != nil {
+				return err
 			}
-			if err := s.db.Create(&timeOption).Error; err != nil {
-				return nil, err
+			locked.Capacity = capacity
+			if err := fillFromWaitlist(repos, locked); err != nil {
+				return err
 			}
 		}
+		if len(updates) == 0 && !replaceOptions {
+			return nil
+		}
+
+		if len(updates) > 0 {
+			if err := repos.Events.UpdateFields(eventID, updates); err != nil {
+				return err
+			}
+		}
+		if err := repos.Events.IncrementSequence(eventID); err != nil {
+			return err
+		}
+
+		// Tekrar kaldırıldıysa tekil tekrar değişiklikleri ve tekrar bazındaki katılımlar anlamını yitirir
+		if clearRecurrence {
+			if err := clearOccurrences(repos, eventID); err != nil {
+				return err
+			}
+		}
+
+		if replaceOptions {
+			if err := repos.Events.DeleteTimeOptions(eventID); err != nil {
+				return err
+			}
+			for _, startTime := range timeOptions {
+				timeOption := models.EventTimeOption{
+					EventID:   eventID,
+					StartTime: startTime,
+				}
+				if err := repos.Events.CreateTimeOption(&timeOption); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Güncellenmiş etkinliği geri döndür
-	return &event, nil
+	return s.repos.Events.FindByID(eventID)
 }
 
 // DeleteEvent bir etkinliği siler
 func (s *EventService) DeleteEvent(eventID uint64, userID uint64) error {
-	var event models.Event
-
 	// Etkinliği bul ve sahibini kontrol et
-	if err := s.db.First(&event, eventID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			return errors.New("etkinlik bulunamadı")
 		}
 		return err
@@ -338,22 +414,18 @@ func (s *EventService) DeleteEvent(eventID uint64, userID uint64) error {
 	}
 
 	// Etkinliği sil
-	if err := s.db.Delete(&event).Error; err != nil {
-		return err
-	}
-	return nil
+	return s.repos.Events.Delete(event.ID)
 }
 
 // VoteForTimeOption kullanıcı bir zaman seçeneğine oy verir
 func (s *EventService) VoteForTimeOption(eventID uint64, optionID uint64, userID uint64) error {
-	var event models.Event
-	if err := s.db.First(&event, eventID).Error; err != nil {
+	if _, err := s.repos.Events.FindByID(eventID); err != nil {
 		return errors.New("etkinlik bulunamadı")
 	}
 	// TODO: Özel etkinlikler için erişim kontrolü eklenebilir.
 
-	var timeOption models.EventTimeOption
-	if err := s.db.First(&timeOption, optionID).Error; err != nil {
+	timeOption, err := s.repos.Events.FindTimeOption(optionID)
+	if err != nil {
 		return errors.New("zaman seçeneği bulunamadı")
 	}
 
@@ -362,37 +434,33 @@ func (s *EventService) VoteForTimeOption(eventID uint64, optionID uint64, userID
 	}
 
 	// Kullanıcının daha önce bu seçeneğe oy verip vermediğini kontrol et
-	var existingVote models.EventVote
-	err := s.db.Where("user_id = ? AND event_time_option_id = ?", userID, optionID).First(&existingVote).Error
-	if err == nil {
-		return errors.New("bu seçeneğe zaten oy verdiniz")
-	}
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
+	voted, err := s.repos.Events.HasVoted(optionID, userID)
+	if err != nil {
 		return err // Veritabanı hatası
 	}
-
-	// Yeni oy oluştur
-	vote := models.EventVote{
-		EventTimeOptionID: optionID,
-		UserID:            userID,
-		VotedAt:           time.Now(),
-	}
-
-	if err := s.db.Create(&vote).Error; err != nil {
-		return err
+	if voted {
+		return errors.New("bu seçeneğe zaten oy verdiniz")
 	}
 
-	// Zaman seçeneğindeki oy sayısını artır (transaction ile daha güvenli olabilir)
-	timeOption.VotesCount++
-	return s.db.Save(&timeOption).Error
+	// Oy ve seçeneğin oy sayısı tek işlemde kaydedilir; sayaç veritabanında artırılır
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		vote := models.EventVote{
+			EventTimeOptionID: optionID,
+			UserID:            userID,
+			VotedAt:           time.Now(),
+		}
+		if err := repos.Events.CreateVote(&vote); err != nil {
+			return err
+		}
+		return repos.Events.IncrementVotes(optionID)
+	})
 }
 
 // FinalizeEvent etkinliği sonlandırır ve nihai zamanı belirler
 func (s *EventService) FinalizeEvent(eventID uint64, userID uint64, selectedOptionID *uint64) error {
-	var event models.Event
-
 	// Etkinliği bul ve sahibini kontrol et
-	if err := s.db.First(&event, eventID).Error; err != nil {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
 		return errors.New("etkinlik bulunamadı")
 	}
 
@@ -402,16 +470,16 @@ func (s *EventService) FinalizeEvent(eventID uint64, userID uint64, selectedOpti
 
 	if selectedOptionID == nil {
 		// Eğer bir seçenek belirtilmemişse, en çok oy alanı otomatik olarak seç
-		var topOption models.EventTimeOption
-		if err := s.db.Where("event_id = ?", eventID).Order("votes_count DESC").First(&topOption).Error; err != nil {
+		topOption, err := s.repos.Events.FindTopTimeOption(eventID)
+		if err != nil {
 			return errors.New("oylanan seçenek bulunamadı")
 		}
 		event.FinalStartTime = &topOption.StartTime
 		event.FinalEndTime = &topOption.EndTime
 	} else {
 		// Belirtilen seçeneği bul
-		var selectedOption models.EventTimeOption
-		if err := s.db.First(&selectedOption, *selectedOptionID).Error; err != nil {
+		selectedOption, err := s.repos.Events.FindTimeOption(*selectedOptionID)
+		if err != nil {
 			return errors.New("seçilen zaman seçeneği bulunamadı")
 		}
 		if selectedOption.EventID != eventID {
@@ -422,127 +490,111 @@ func (s *EventService) FinalizeEvent(eventID uint64, userID uint64, selectedOpti
 	}
 
 	// Etkinliği güncelle
-	return s.db.Save(&event).Error
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Events.UpdateFields(event.ID, map[string]interface{}{
+			"final_start_time": event.FinalStartTime,
+			"final_end_time":   event.FinalEndTime,
+		}); err != nil {
+			return err
+		}
+		return repos.Events.IncrementSequence(event.ID)
+	})
 }
 
-// AttendEvent kullanıcının bir etkinliğe katılmasını sağlar.
-// Eğer etkinlik özelse, katılım isteği oluşturur.
-// Eğer herkese açıksa, doğrudan katılım sağlar.
-func (s *EventService) AttendEvent(eventID, userID uint64) error {
-	// Önce etkinliği bulalım ve özel olup olmadığını kontrol edelim.
-	var event models.Event
-	if err := s.db.First(&event, eventID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("etkinlik bulunamadı")
+// timeOptionsChanged verilen başlangıç zamanlarının etkinliğin mevcut zaman seçeneklerinden farklı olup olmadığını döndürür
+func timeOptionsChanged(current []models.EventTimeOption, starts []time.Time) bool {
+	if len(current) != len(starts) {
+		return true
+	}
+	remaining := make(map[int64]int, len(current))
+	for _, option := range current {
+		remaining[option.StartTime.Unix()]++
+	}
+	for _, start := range starts {
+		if remaining[start.Unix()] == 0 {
+			return true
 		}
-		return err
+		remaining[start.Unix()]--
 	}
+	return false
+}
 
-	// Etkinlik ÖZEL ise
-	if event.IsPrivate {
-		// Mevcut bir istek var mı diye kontrol et (pending, approved fark etmez)
-		var existingRequest models.EventParticipationRequest
-		err := s.db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&existingRequest).Error
-		if err == nil {
-			// Zaten bir istek var, durumuna göre mesaj döndür
-			if existingRequest.Status == models.RequestPending {
-				return errors.New("bu etkinliğe katılım isteğiniz zaten beklemede")
-			}
-			return errors.New("bu etkinliğe zaten bir katılım isteğiniz mevcut veya daha önce işlenmiş")
-		}
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			// Beklenmedik bir veritabanı hatası
-			return err
+// AttendEvent kullanıcının bir etkinliğe katılmasını sağlar; RSVP'nin ek misafir ve not içermeyen going yanıtıdır.
+// Eğer etkinlik özelse, katılım isteği oluşturur ve nil katılım döndürür.
+// Eğer herkese açıksa, doğrudan katılım sağlar; kapasite doluysa kullanıcı bekleme listesine alınır.
+func (s *EventService) AttendEvent(eventID, userID uint64) (*models.EventAttendance, error) {
+	return s.RSVP(eventID, userID, RSVPDTO{Status: models.AttendanceGoing})
+}
+
+// requestParticipation özel etkinlik için katılım isteği oluşturur ve etkinlik sahibine bildirim gönderir
+func (s *EventService) requestParticipation(event *models.Event, userID uint64) error {
+	// Mevcut bir istek var mı diye kontrol et (pending, approved fark etmez)
+	existingRequest, err := s.repos.Participation.FindRequest(event.ID, userID)
+	if err == nil {
+		// Zaten bir istek var, durumuna göre mesaj döndür
+		if existingRequest.Status == models.RequestPending {
+			return errors.New("bu etkinliğe katılım isteğiniz zaten beklemede")
 		}
+		return errors.New("bu etkinliğe zaten bir katılım isteğiniz mevcut veya daha önce işlenmiş")
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		// Beklenmedik bir veritabanı hatası
+		return err
+	}
+
+	// Kullanıcı adı bildirim metni için alınır
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("'%s' kullanıcısı '%s' adlı özel etkinliğinize katılmak istiyor.", user.Username, event.Title)
 
-		// Yeni katılım isteği oluştur
+	// İstek ve etkinlik sahibine giden bildirim tek işlemde kaydedilir
+	return s.uow.WithTx(func(repos repository.Repositories) error {
 		request := models.EventParticipationRequest{
-			EventID: eventID,
+			EventID: event.ID,
 			UserID:  userID,
 			Status:  models.RequestPending,
 		}
-		if err := s.db.Create(&request).Error; err != nil {
+		if err := repos.Participation.CreateRequest(&request); err != nil {
 			return err
 		}
 
-		// Kullanıcı adını almak için küçük bir sorgu
-		var user models.User
-		s.db.First(&user, userID)
-		notificationService := NewNotificationService() // Servisi instantiate et
-		msg := fmt.Sprintf("'%s' kullanıcısı '%s' adlı özel etkinliğinize katılmak istiyor.", user.Username, event.Title)
-
-		// BİLDİRİM DÜZELTMESİ: related_entity_id olarak event.ID yerine request.ID gönderilmeli
-		_, err = notificationService.CreateNotification(event.CreatorUserID, "event_join_request", msg, &request.ID)
-		if err != nil {
-			log.Printf("Katılım isteği oluşturuldu ama bildirim gönderilemedi: %v", err)
-			// Sadece logla, ana işlem başarılı oldu
-		}
-
-		return nil // İstek başarıyla oluşturuldu
-	}
-
-	// Etkinlik HERKESE AÇIK ise (Mevcut UPSERT mantığı)
-	attendance := models.EventAttendance{
-		EventID:  eventID,
-		UserID:   userID,
-		Status:   models.AttendanceAttending,
-		JoinedAt: time.Now(),
-	}
-
-	err := s.db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"status"}),
-	}).Create(&attendance).Error
-
-	return err
+		// related_entity_id olarak event.ID yerine request.ID gönderilir
+		_, err := notify(repos.Notifications, event.CreatorUserID, "event_join_request", msg, &request.ID)
+		return err
+	})
 }
 
 // ApproveParticipationRequest bir katılım isteğini onaylar.
 func (s *EventService) ApproveParticipationRequest(requestID uint64, approverID uint64) error {
-	tx := s.db.Begin()
-
-	// İsteği bul
-	var request models.EventParticipationRequest
-	if err := tx.Preload("Event").First(&request, requestID).Error; err != nil {
-		tx.Rollback()
-		return errors.New("katılım isteği bulunamadı")
-	}
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// İsteği bul
+		request, err := repos.Participation.FindRequestWithEvent(requestID)
+		if err != nil {
+			return errors.New("katılım isteği bulunamadı")
+		}
 
-	// Onaylayanın etkinlik sahibi olduğunu doğrula
-	if request.Event.CreatorUserID != approverID {
-		tx.Rollback()
-		return errors.New("bu isteği onaylama yetkiniz yok")
-	}
+		// Onaylayanın etkinlik sahibi olduğunu doğrula
+		if request.Event.CreatorUserID != approverID {
+			return errors.New("bu isteği onaylama yetkiniz yok")
+		}
 
-	// İsteğin durumunu güncelle
-	request.Status = models.RequestApproved
-	if err := tx.Save(&request).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+		// İsteğin durumunu güncelle
+		if err := repos.Participation.SetRequestStatus(request.ID, models.RequestApproved); err != nil {
+			return err
+		}
 
-	// Onaylanan kullanıcıyı katılımcı olarak ekle
-	attendance := models.EventAttendance{
-		EventID:  request.EventID,
-		UserID:   request.UserID,
-		Status:   models.AttendanceAttending,
-		JoinedAt: time.Now(),
-	}
-	if err := tx.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"status"}),
-	}).Create(&attendance).Error; err != nil {
-		tx.Rollback()
+		// Onaylanan kullanıcıyı katılımcı olarak ekle; kapasite doluysa bekleme listesine alınır
+		_, err = joinEvent(repos, request.EventID, request.UserID)
 		return err
-	}
-
-	return tx.Commit().Error
+	})
 }
 
 // DeclineParticipationRequest bir katılım isteğini reddeder.
 func (s *EventService) DeclineParticipationRequest(requestID uint64, declinerID uint64) error {
-	var request models.EventParticipationRequest
-	if err := s.db.Preload("Event").First(&request, requestID).Error; err != nil {
+	request, err := s.repos.Participation.FindRequestWithEvent(requestID)
+	if err != nil {
 		return errors.New("katılım isteği bulunamadı")
 	}
 
@@ -551,109 +603,149 @@ func (s *EventService) DeclineParticipationRequest(requestID uint64, declinerID
 		return errors.New("bu isteği reddetme yetkiniz yok")
 	}
 
-	request.Status = models.RequestRejected
-	return s.db.Save(&request).Error
+	return s.repos.Participation.SetRequestStatus(request.ID, models.RequestRejected)
 }
 
-// CancelAttendance kullanıcının etkinliğe katılımını iptal eder.
+// CancelAttendance kullanıcının etkinliğe katılımını iptal eder; katılım kaydı not_going olur.
+// Boşalan yer bekleme listesindeki sıradaki kullanıcıya verilir ve kullanıcıya bildirim gönderilir.
 func (s *EventService) CancelAttendance(eventID, userID uint64) error {
-	// Sadece durumu güncelle
-	return s.db.Model(&models.EventAttendance{}).
-		Where("event_id = ? AND user_id = ?", eventID, userID).
-		Update("status", "not_attending").Error
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		if _, err := repos.Participation.FindAttendance(eventID, userID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		_, err := setRSVP(repos, eventID, userID, RSVPDTO{Status: models.AttendanceNotGoing})
+		return err
+	})
+}
+
+// AttendeeInfo katılımcı listesindeki bir kullanıcı; frontend'in Attendee interface'i ile uyumludur
+type AttendeeInfo struct {
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatarUrl"`
+	Status    string `json:"status"` // going, maybe, not_going, waitlisted veya yanıt vermemiş davetliler için invited
+	Guests    int    `json:"guests,omitempty"`
+	Note      string `json:"note,omitempty"` // Yalnızca etkinlik sahibine ve notu yazan kullanıcıya gösterilir
 }
 
-// GetEventAttendees bir etkinliğe katılanların ve davet edilenlerin listesini döndürür.
-// Frontend'in Attendee interface'i ile uyumlu format döndürür
-func (s *EventService) GetEventAttendees(eventID uint64) ([]interface{}, error) {
+// attendeeInvited yanıt vermemiş davetlilerin katılımcı listesindeki durumu
+const attendeeInvited = "invited"
+
+// EventAttendees etkinliğin katılımcı listesi ve durumlara göre sayıları
+type EventAttendees struct {
+	Attendees []AttendeeInfo   `json:"attendees"`
+	Counts    AttendanceCounts `json:"counts"`
+}
+
+// GetEventAttendees bir etkinliğe yanıt verenlerin ve davet edilenlerin listesini durumlara göre sayılarıyla döndürür.
+// Liste yalnızca etkinliği görebilen kullanıcılara döner (bkz. checkEventAccess). viewerID notların kime
+// gösterileceğini de belirler; anonim istekler için 0 verilir.
+func (s *EventService) GetEventAttendees(eventID, viewerID uint64) (*EventAttendees, error) {
 	// Etkinlik bilgisini al
-	var event models.Event
-	if err := s.db.First(&event, eventID).Error; err != nil {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
 		return nil, err
 	}
-
-	// Frontend'in beklediği AttendeeInfo formatı
-	type AttendeeInfo struct {
-		ID        uint64 `json:"id"`
-		Name      string `json:"name"`
-		AvatarURL string `json:"avatarUrl"`
-		Status    string `json:"status"`
+	if err := s.checkEventAccess(event, viewerID); err != nil {
+		return nil, err
 	}
+	return s.eventAttendees(event, "", viewerID)
+}
 
-	// Katılımcıları ve davet edilenleri map ile birleştir (user ID'ye göre)
+// eventAttendees etkinliğin katılımcı listesini oluşturur. occurrence boş değilse tekrarlanan etkinliğin
+// o tekrarı için, seri düzeyindeki katılımların üzerine tekrara özel katılımlar uygulanarak hesaplanır.
+func (s *EventService) eventAttendees(event *models.Event, occurrence string, viewerID uint64) (*EventAttendees, error) {
+	eventID := event.ID
+
+	// Katılımcıları ve davet edilenleri map ile birleştir (user ID'ye göre); order ilk görülme sırasını korur
 	attendeeMap := make(map[uint64]*AttendeeInfo)
+	var order []uint64
+	put := func(info AttendeeInfo) {
+		if _, exists := attendeeMap[info.ID]; !exists {
+			order = append(order, info.ID)
+		}
+		attendeeMap[info.ID] = &info
+	}
 
-	// 1. Katılan kullanıcıları al (EventAttendance)
-	var attendances []models.EventAttendance
-	if err := s.db.Preload("User").
-		Where("event_id = ?", eventID).
-		Find(&attendances).Error; err != nil {
+	// 1. Yanıt veren kullanıcıları al (EventAttendance)
+	attendances, err := s.repos.Participation.FindAttendancesWithUsers(eventID)
+	if err != nil {
 		return nil, err
 	}
-
-	for _, a := range attendances {
-		status := "attending"
-		switch string(a.Status) {
-		case string(models.AttendanceAttending):
-			status = "attending"
-		case "not_attending", "cancelled":
-			status = "declined"
-		default:
-			status = "attending"
+	if occurrence != "" {
+		// Tekrara özel kayıtlar listede sonra geldiği için aynı kullanıcının seri kaydını ezer
+		occurrenceAttendances, err := s.repos.Participation.FindAttendancesWithUsers(eventID, occurrence)
+		if err != nil {
+			return nil, err
 		}
+		attendances = append(attendances, occurrenceAttendances...)
+	}
 
-		attendeeMap[a.User.ID] = &AttendeeInfo{
+	for _, a := range attendances {
+		info := AttendeeInfo{
 			ID:        a.User.ID,
 			Name:      a.User.FirstName + " " + a.User.LastName,
 			AvatarURL: a.User.ProfilePictureURL,
-			Status:    status,
+			Status:    string(a.Status),
+			Guests:    a.Guests,
 		}
+		if viewerID != 0 && (viewerID == event.CreatorUserID || viewerID == a.UserID) {
+			info.Note = a.Note
+		}
+		put(info)
 	}
 
 	// 2. Özel etkinlik ise davet edilenleri de al (EventInvitation)
 	if event.IsPrivate {
-		var invitations []models.EventInvitation
-		if err := s.db.Preload("Invitee").
-			Where("event_id = ?", eventID).
-			Find(&invitations).Error; err != nil {
+		invitations, err := s.repos.Participation.FindInvitationsWithInvitees(eventID)
+		if err != nil {
 			log.Printf("Davetliler alınırken hata (normal olabilir): %v", err)
 		} else {
 			for _, inv := range invitations {
-				// Eğer bu kullanıcı zaten attendanceMap'te varsa status'unu güncelle
+				// Eğer bu kullanıcı zaten attendeeMap'te varsa status'unu güncelle
 				if existing, exists := attendeeMap[inv.Invitee.ID]; exists {
-					// Zaten katılım durumu var, invitation status'una göre güncelle
+					// Zaten katılım durumu var, reddedilen davet katılmama olarak gösterilir
 					if inv.Status == models.InvitationDeclined {
-						existing.Status = "declined"
-					}
-				} else {
-					// Yeni davet edilen kullanıcı
-					status := "invited"
-					switch inv.Status {
-					case models.InvitationPending:
-						status = "invited"
-					case models.InvitationAccepted:
-						status = "attending"
-					case models.InvitationDeclined:
-						status = "declined"
-					default:
-						status = "invited"
+						existing.Status = string(models.AttendanceNotGoing)
+						existing.Guests = 0
 					}
+					continue
+				}
 
-					attendeeMap[inv.Invitee.ID] = &AttendeeInfo{
-						ID:        inv.Invitee.ID,
-						Name:      inv.Invitee.FirstName + " " + inv.Invitee.LastName,
-						AvatarURL: inv.Invitee.ProfilePictureURL,
-						Status:    status,
-					}
+				// Yeni davet edilen kullanıcı
+				status := attendeeInvited
+				switch inv.Status {
+				case models.InvitationAccepted:
+					status = string(models.AttendanceGoing)
+				case models.InvitationDeclined:
+					status = string(models.AttendanceNotGoing)
 				}
+				put(AttendeeInfo{
+					ID:        inv.Invitee.ID,
+					Name:      inv.Invitee.FirstName + " " + inv.Invitee.LastName,
+					AvatarURL: inv.Invitee.ProfilePictureURL,
+					Status:    status,
+				})
 			}
 		}
 	}
 
-	// Map'i slice'a çevir
-	result := make([]interface{}, 0, len(attendeeMap))
-	for _, attendee := range attendeeMap {
-		result = append(result, *attendee)
+	// Map'i slice'a çevir ve durumları say
+	result := &EventAttendees{Attendees: make([]AttendeeInfo, 0, len(order))}
+	for _, id := range order {
+		attendee := attendeeMap[id]
+		if attendee.Status == attendeeInvited {
+			result.Counts.Invited++
+		} else {
+			result.Counts.add(models.EventAttendanceStatusType(attendee.Status), 1, int64(attendee.Guests))
+		}
+		result.Attendees = append(result.Attendees, *attendee)
 	}
 
 	return result, nil
@@ -669,8 +761,8 @@ func (s *EventService) GetEventTimeOptions(eventID uint64, userID uint64) ([]int
 		HasVoted  bool   `json:"hasVoted"`
 	}
 
-	var options []models.EventTimeOption
-	if err := s.db.Where("event_id = ?", eventID).Find(&options).Error; err != nil {
+	options, err := s.repos.Events.FindTimeOptions(eventID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -678,15 +770,17 @@ func (s *EventService) GetEventTimeOptions(eventID uint64, userID uint64) ([]int
 	// Şimdilik basit tutalım
 	result := make([]interface{}, len(options))
 	for i, option := range options {
-		var votesCount int64
-		s.db.Model(&models.EventVote{}).Where("event_time_option_id = ?", option.ID).Count(&votesCount)
+		votesCount, err := s.repos.Events.CountVotes(option.ID)
+		if err != nil {
+			return nil, err
+		}
 
 		// Kullanıcının oy verip vermediğini kontrol et
 		hasVoted := false
 		if userID > 0 {
-			var voteCount int64
-			s.db.Model(&models.EventVote{}).Where("event_time_option_id = ? AND user_id = ?", option.ID, userID).Count(&voteCount)
-			hasVoted = voteCount > 0
+			if hasVoted, err = s.repos.Events.HasVoted(option.ID, userID); err != nil {
+				return nil, err
+			}
 		}
 
 		result[i] = TimeOptionWithVotes{
@@ -704,8 +798,8 @@ func (s *EventService) GetEventTimeOptions(eventID uint64, userID uint64) ([]int
 // InviteUserToEvent bir kullanıcıyı etkinliğe davet eder.
 func (s *EventService) InviteUserToEvent(eventID, inviterID, inviteeID uint64) (*models.EventInvitation, error) {
 	// Etkinliği ve davet eden kişinin yetkisini kontrol et
-	var event models.Event
-	if err := s.db.First(&event, eventID).Error; err != nil {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
 		return nil, errors.New("etkinlik bulunamadı")
 	}
 
@@ -713,8 +807,7 @@ func (s *EventService) InviteUserToEvent(eventID, inviterID, inviteeID uint64) (
 		// Oda admini olup olmadığını da kontrol edebiliriz
 		isRoomAdmin := false
 		if event.RoomID != nil {
-			var member models.RoomMember
-			if err := s.db.Where("room_id = ? AND user_id = ? AND role = 'admin'", *event.RoomID, inviterID).First(&member).Error; err == nil {
+			if member, err := s.repos.Rooms.FindMember(*event.RoomID, inviterID); err == nil && member.Role == "admin" {
 				isRoomAdmin = true
 			}
 		}
@@ -724,15 +817,13 @@ func (s *EventService) InviteUserToEvent(eventID, inviterID, inviteeID uint64) (
 	}
 
 	// Davet edilen kullanıcının zaten katılımcı olup olmadığını kontrol et
-	var attendance models.EventAttendance
-	if err := s.db.Where("event_id = ? AND user_id = ?", eventID, inviteeID).First(&attendance).Error; err == nil {
+	if _, err := s.repos.Participation.FindAttendance(eventID, inviteeID); err == nil {
 		return nil, errors.New("kullanıcı zaten etkinliğe katılıyor")
 	}
 
 	// Davetin zaten var olup olmadığını kontrol et
-	var existingInvitation models.EventInvitation
-	if err := s.db.Where("event_id = ? AND invitee_id = ?", eventID, inviteeID).First(&existingInvitation).Error; err == nil {
-		return &existingInvitation, errors.New("bu kullanıcı zaten davet edilmiş")
+	if existingInvitation, err := s.repos.Participation.FindInvitationFor(eventID, inviteeID); err == nil {
+		return existingInvitation, errors.New("bu kullanıcı zaten davet edilmiş")
 	}
 
 	// Yeni davet oluştur
@@ -743,16 +834,16 @@ func (s *EventService) InviteUserToEvent(eventID, inviterID, inviteeID uint64) (
 		Status:    "pending",
 	}
 
-	if err := s.db.Create(&invitation).Error; err != nil {
-		return nil, err
-	}
-
-	// Bildirim oluştur
-	notificationService := NewNotificationService()
-	_, err := notificationService.CreateNotification(inviteeID, "event_invitation", fmt.Sprintf("Etkinliğe davet edildiniz: %s", event.Title), &invitation.ID)
+	// Davet ve davet edilene giden bildirim tek işlemde kaydedilir
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Participation.CreateInvitation(&invitation); err != nil {
+			return err
+		}
+		_, err := notify(repos.Notifications, inviteeID, "event_invitation", fmt.Sprintf("Etkinliğe davet edildiniz: %s", event.Title), &invitation.ID)
+		return err
+	})
 	if err != nil {
-		log.Printf("Davet gönderildi ama bildirim oluşturulamadı: %v", err)
-		// Bu hatayı yukarıya fırlatmak yerine sadece loglayabiliriz. Davet işlemi başarılı oldu.
+		return nil, err
 	}
 
 	return &invitation, nil
@@ -761,137 +852,105 @@ func (s *EventService) InviteUserToEvent(eventID, inviterID, inviteeID uint64) (
 // GetEventsForFeed kullanıcının ana sayfa akışı için etkinlikleri getirir.
 // userID 0 ise, herkese açık son etkinlikleri getirir.
 func (s *EventService) GetEventsForFeed(userID uint64) ([]models.Event, error) {
-	var events []models.Event
-	query := s.db.Preload("Creator").Preload("Room").Order("created_at desc").Limit(20)
-
+	// Giriş yapmamış kullanıcı için sadece herkese açık etkinlikler
+	var roomIDs []uint64
 	if userID != 0 {
 		// Giriş yapmış kullanıcı için daha karmaşık bir mantık eklenebilir.
 		// Örneğin, arkadaşlarının katıldığı veya ilgi alanlarına uyan etkinlikler.
 		// Şimdilik, herkese açık ve üye olduğu özel odalardaki etkinlikleri getirelim.
-		var roomIDs []uint64
-		s.db.Model(&models.RoomMember{}).Where("user_id = ?", userID).Pluck("room_id", &roomIDs)
-		query = query.Where("is_private = ? OR room_id IN (?)", false, roomIDs)
-	} else {
-		// Giriş yapmamış kullanıcı için sadece herkese açık etkinlikler
-		query = query.Where("is_private = ?", false)
+		var err error
+		if roomIDs, err = s.repos.Rooms.MemberRoomIDs(userID); err != nil {
+			return nil, err
+		}
 	}
 
-	err := query.Find(&events).Error
-	return events, err
+	return s.repos.Events.FindFeed(roomIDs, 20)
 }
 
 // AcceptEventInvitation kullanıcının etkinlik davetini kabul eder.
+// Davet durumu, katılım kaydı ve etkinlik sahibine giden bildirim tek işlemde kaydedilir.
 func (s *EventService) AcceptEventInvitation(invitationID uint64, userID uint64) error {
-	tx := s.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// Daveti bul ve kontrol et
+		invitation, err := repos.Participation.FindInvitationWithDetails(invitationID)
+		if err != nil {
+			return errors.New("davet bulunamadı")
 		}
-	}()
 
-	// Daveti bul ve kontrol et
-	var invitation models.EventInvitation
-	if err := tx.Preload("Event").Preload("Invitee").First(&invitation, invitationID).Error; err != nil {
-		tx.Rollback()
-		return errors.New("davet bulunamadı")
-	}
+		// Davet edilen kullanıcının kendisi olduğunu doğrula
+		if invitation.InviteeID != userID {
+			return errors.New("bu daveti kabul etme yetkiniz yok")
+		}
 
-	// Davet edilen kullanıcının kendisi olduğunu doğrula
-	if invitation.InviteeID != userID {
-		tx.Rollback()
-		return errors.New("bu daveti kabul etme yetkiniz yok")
-	}
+		// Davet durumu pending olmalı
+		if invitation.Status != models.InvitationPending {
+			return errors.New("bu davet zaten işlem görmüş")
+		}
 
-	// Davet durumu pending olmalı
-	if invitation.Status != models.InvitationPending {
-		tx.Rollback()
-		return errors.New("bu davet zaten işlem görmüş")
-	}
+		// Davet durumunu güncelle
+		if err := repos.Participation.SetInvitationStatus(invitation.ID, models.InvitationAccepted); err != nil {
+			return err
+		}
 
-	// Davet durumunu güncelle
-	invitation.Status = models.InvitationAccepted
-	if err := tx.Save(&invitation).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+		// Kullanıcıyı etkinliğe katılımcı olarak ekle; kapasite doluysa bekleme listesine alınır
+		attendance, err := joinEvent(repos, invitation.EventID, userID)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("%s kullanıcısı '%s' etkinliğine katıldı", invitation.Invitee.FirstName, invitation.Event.Title)
+		if attendance.Status == models.AttendanceWaitlisted {
+			msg = fmt.Sprintf("%s kullanıcısı '%s' etkinliğinin davetini kabul etti ve bekleme listesine alındı", invitation.Invitee.FirstName, invitation.Event.Title)
+		}
 
-	// Kullanıcıyı etkinliğe katılımcı olarak ekle
-	attendance := models.EventAttendance{
-		EventID:  invitation.EventID,
-		UserID:   userID,
-		Status:   models.AttendanceAttending,
-		JoinedAt: time.Now(),
-	}
-	if err := tx.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"status", "joined_at"}),
-	}).Create(&attendance).Error; err != nil {
-		tx.Rollback()
+		// Etkinlik sahibine bildirim gönder
+		_, err = notify(
+			repos.Notifications,
+			invitation.Event.CreatorUserID,
+			"event_invitation_accepted",
+			msg,
+			&invitation.EventID,
+		)
 		return err
-	}
-
-	// Etkinlik sahibine bildirim gönder
-	notificationService := NewNotificationService()
-	_, err := notificationService.CreateNotification(
-		invitation.Event.CreatorUserID,
-		"event_invitation_accepted",
-		fmt.Sprintf("%s kullanıcısı '%s' etkinliğine katıldı", invitation.Invitee.FirstName, invitation.Event.Title),
-		&invitation.EventID,
-	)
-	if err != nil {
-		log.Printf("Kabul bildirimi gönderilemedi: %v", err)
-	}
-
-	return tx.Commit().Error
+	})
 }
 
 // DeclineEventInvitation kullanıcının etkinlik davetini reddeder.
 func (s *EventService) DeclineEventInvitation(invitationID uint64, userID uint64) error {
-	// Daveti bul ve kontrol et
-	var invitation models.EventInvitation
-	if err := s.db.Preload("Event").Preload("Invitee").First(&invitation, invitationID).Error; err != nil {
-		return errors.New("davet bulunamadı")
-	}
-
-	// Davet edilen kullanıcının kendisi olduğunu doğrula
-	if invitation.InviteeID != userID {
-		return errors.New("bu daveti reddetme yetkiniz yok")
-	}
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// Daveti bul ve kontrol et
+		invitation, err := repos.Participation.FindInvitationWithDetails(invitationID)
+		if err != nil {
+			return errors.New("davet bulunamadı")
+		}
 
-	// Davet durumu pending olmalı
-	if invitation.Status != models.InvitationPending {
-		return errors.New("bu davet zaten işlem görmüş")
-	}
+		// Davet edilen kullanıcının kendisi olduğunu doğrula
+		if invitation.InviteeID != userID {
+			return errors.New("bu daveti reddetme yetkiniz yok")
+		}
 
-	// Davet durumunu güncelle
-	invitation.Status = models.InvitationDeclined
-	if err := s.db.Save(&invitation).Error; err != nil {
-		return err
-	}
+		// Davet durumu pending olmalı
+		if invitation.Status != models.InvitationPending {
+			return errors.New("bu davet zaten işlem görmüş")
+		}
 
-	// Etkinlik sahibine bildirim gönder
-	notificationService := NewNotificationService()
-	_, err := notificationService.CreateNotification(
-		invitation.Event.CreatorUserID,
-		"event_invitation_declined",
-		fmt.Sprintf("%s kullanıcısı '%s' etkinliğine daveti reddetti", invitation.Invitee.FirstName, invitation.Event.Title),
-		&invitation.EventID,
-	)
-	if err != nil {
-		log.Printf("Red bildirimi gönderilemedi: %v", err)
-	}
+		// Davet durumunu güncelle
+		if err := repos.Participation.SetInvitationStatus(invitation.ID, models.InvitationDeclined); err != nil {
+			return err
+		}
 
-	return nil
+		// Etkinlik sahibine bildirim gönder
+		_, err = notify(
+			repos.Notifications,
+			invitation.Event.CreatorUserID,
+			"event_invitation_declined",
+			fmt.Sprintf("%s kullanıcısı '%s' etkinliğine daveti reddetti", invitation.Invitee.FirstName, invitation.Event.Title),
+			&invitation.EventID,
+		)
+		return err
+	})
 }
 
 // GetUserEventInvitations kullanıcının etkinlik davetlerini getirir.
 func (s *EventService) GetUserEventInvitations(userID uint64) ([]models.EventInvitation, error) {
-	var invitations []models.EventInvitation
-	if err := s.db.Preload("Event").Preload("Inviter").
-		Where("invitee_id = ? AND status = ?", userID, models.InvitationPending).
-		Order("created_at desc").
-		Find(&invitations).Error; err != nil {
-		return nil, err
-	}
-	return invitations, nil
+	return s.repos.Participation.FindPendingInvitationsFor(userID)
 }
@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/testutil"
+)
+
+func TestGetEventByIDPrivateAccess(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+
+	owner := env.User()
+	friend := env.User()
+	roomMate := env.User()
+	stranger := env.User()
+	env.Friends(owner, friend)
+	room := env.Room(owner)
+	env.Join(room, roomMate)
+
+	private := env.Event(owner, testutil.Private, testutil.InRoom(room))
+	public := env.Event(owner)
+
+	cases := []struct {
+		name    string
+		userID  uint64
+		allowed bool
+	}{
+		{"sahibi", owner.ID, true},
+		{"arkadaşı", friend.ID, true},
+		{"oda üyesi", roomMate.ID, true},
+		{"yabancı", stranger.ID, false},
+		{"anonim", 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, _, err := events.GetEventByID(private.ID, tc.userID)
+			if tc.allowed {
+				if err != nil {
+					t.Fatalf("erişim bekleniyordu, hata: %v", err)
+				}
+				if event.ID != private.ID {
+					t.Fatalf("yanlış etkinlik döndü: %d", event.ID)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("özel etkinliğe erişim reddedilmeliydi")
+			}
+		})
+	}
+
+	t.Run("herkese açık etkinlik", func(t *testing.T) {
+		for _, userID := range []uint64{stranger.ID, 0} {
+			if _, _, err := events.GetEventByID(public.ID, userID); err != nil {
+				t.Fatalf("kullanıcı %d herkese açık etkinliği göremedi: %v", userID, err)
+			}
+		}
+	})
+
+	t.Run("ortak odası olmayan özel etkinlik", func(t *testing.T) {
+		noRoom := env.Event(owner, testutil.Private)
+		if _, _, err := events.GetEventByID(noRoom.ID, roomMate.ID); err == nil {
+			t.Fatal("odasız özel etkinliğe yalnızca sahibi ve arkadaşları erişebilmeli")
+		}
+	})
+}
+
+func TestGetEventByIDCountsAttendees(t *testing.T) {
+	env := testutil.New(t)
+	owner := env.User()
+	event := env.Event(owner)
+	for i := 0; i < 2; i++ {
+		if _, err := env.Services().Events.AttendEvent(event.ID, env.User().ID); err != nil {
+			t.Fatalf("katılım eklenemedi: %v", err)
+		}
+	}
+
+	_, counts, err := env.Services().Events.GetEventByID(event.ID, owner.ID)
+	if err != nil {
+		t.Fatalf("etkinlik alınamadı: %v", err)
+	}
+	if counts.Going != 2 {
+		t.Fatalf("katılımcı sayısı 2 olmalı, %+v", counts)
+	}
+}
+
+func TestAcceptEventInvitation(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+
+	owner := env.User()
+	invitee := env.User()
+	other := env.User()
+	event := env.Event(owner, testutil.Private)
+
+	invitation, err := events.InviteUserToEvent(event.ID, owner.ID, invitee.ID)
+	if err != nil {
+		t.Fatalf("davet gönderilemedi: %v", err)
+	}
+
+	if _, err := events.InviteUserToEvent(event.ID, other.ID, invitee.ID); err == nil {
+		t.Fatal("sahibi veya oda yöneticisi olmayan kullanıcı davet gönderememeli")
+	}
+	if err := events.AcceptEventInvitation(invitation.ID, other.ID); err == nil {
+		t.Fatal("davet yalnızca davet edilen tarafından kabul edilebilmeli")
+	}
+
+	if err := events.AcceptEventInvitation(invitation.ID, invitee.ID); err != nil {
+		t.Fatalf("davet kabul edilemedi: %v", err)
+	}
+
+	var stored models.EventInvitation
+	env.DB.First(&stored, invitation.ID)
+	if stored.Status != models.InvitationAccepted {
+		t.Fatalf("davet durumu %q olmalı, %q", models.InvitationAccepted, stored.Status)
+	}
+
+	var attendance models.EventAttendance
+	if err := env.DB.Where("event_id = ? AND user_id = ?", event.ID, invitee.ID).First(&attendance).Error; err != nil {
+		t.Fatalf("katılım kaydı oluşmadı: %v", err)
+	}
+	if attendance.Status != models.AttendanceGoing {
+		t.Fatalf("katılım durumu %q olmalı, %q", models.AttendanceGoing, attendance.Status)
+	}
+
+	// Bildirim, kabul ile aynı işlemde etkinlik sahibine yazılır
+	var notified int64
+	env.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND related_id = ?", owner.ID, "event_invitation_accepted", event.ID).
+		Count(&notified)
+	if notified != 1 {
+		t.Fatalf("etkinlik sahibine 1 kabul bildirimi gitmeli, %d", notified)
+	}
+
+	if err := events.AcceptEventInvitation(invitation.ID, invitee.ID); err == nil {
+		t.Fatal("işlenmiş davet tekrar kabul edilememeli")
+	}
+	if _, err := events.InviteUserToEvent(event.ID, owner.ID, invitee.ID); err == nil {
+		t.Fatal("katılımcı tekrar davet edilememeli")
+	}
+}
+
+func TestParticipationRequestApproval(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+
+	owner := env.User()
+	requester := env.User()
+	event := env.Event(owner, testutil.Private)
+
+	if _, err := events.AttendEvent(event.ID, requester.ID); err != nil {
+		t.Fatalf("katılım isteği oluşturulamadı: %v", err)
+	}
+
+	var request models.EventParticipationRequest
+	if err := env.DB.Where("event_id = ? AND user_id = ?", event.ID, requester.ID).First(&request).Error; err != nil {
+		t.Fatalf("katılım isteği bulunamadı: %v", err)
+	}
+	if request.Status != models.RequestPending {
+		t.Fatalf("istek durumu %q olmalı, %q", models.RequestPending, request.Status)
+	}
+
+	var attendances int64
+	env.DB.Model(&models.EventAttendance{}).Where("event_id = ?", event.ID).Count(&attendances)
+	if attendances != 0 {
+		t.Fatal("özel etkinliğe istek onaylanmadan katılım eklenmemeli")
+	}
+
+	if _, err := events.AttendEvent(event.ID, requester.ID); err == nil {
+		t.Fatal("bekleyen istek varken ikinci istek reddedilmeli")
+	}
+	if err := events.ApproveParticipationRequest(request.ID, requester.ID); err == nil {
+		t.Fatal("istek yalnızca etkinlik sahibi tarafından onaylanabilmeli")
+	}
+
+	if err := events.ApproveParticipationRequest(request.ID, owner.ID); err != nil {
+		t.Fatalf("istek onaylanamadı: %v", err)
+	}
+
+	env.DB.First(&request, request.ID)
+	if request.Status != models.RequestApproved {
+		t.Fatalf("istek durumu %q olmalı, %q", models.RequestApproved, request.Status)
+	}
+	var attendance models.EventAttendance
+	if err := env.DB.Where("event_id = ? AND user_id = ?", event.ID, requester.ID).First(&attendance).Error; err != nil {
+		t.Fatalf("onaydan sonra katılım kaydı oluşmadı: %v", err)
+	}
+	if attendance.Status != models.AttendanceGoing {
+		t.Fatalf("katılım durumu %q olmalı, %q", models.AttendanceGoing, attendance.Status)
+	}
+}
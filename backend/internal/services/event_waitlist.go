@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+)
+
+// joinEvent kullanıcıyı etkinliğin tamamına going olarak ekler; onaylanan katılım istekleri ve kabul edilen
+// davetler için kullanılır. Kapasite doluysa kullanıcı bekleme listesinin sonuna alınır. Zaten katılımcı veya
+// bekleme listesindeyse kayıt (misafirleri ve notuyla) değişmez. İşlem içinde çağrılmalıdır.
+func joinEvent(repos repository.Repositories, eventID, userID uint64) (*models.EventAttendance, error) {
+	current, err := repos.Participation.FindAttendance(eventID, userID)
+	if err == nil && (current.Status == models.AttendanceGoing || current.Status == models.AttendanceWaitlisted) {
+		return current, nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	return setRSVP(repos, eventID, userID, RSVPDTO{Status: models.AttendanceGoing})
+}
+
+// fillFromWaitlist boş yer kaldıkça bekleme listesindeki kullanıcıları sırayla going yapar ve her birine
+// bildirim gönderir. Sıradaki kullanıcı misafirleriyle birlikte sığmıyorsa arkasındakiler öne geçirilmez.
+// event FindByIDForUpdate ile kilitlenmiş olmalıdır; işlem içinde çağrılmalıdır.
+func fillFromWaitlist(repos repository.Repositories, event *models.Event) error {
+	counts, err := countAttendance(repos, event.ID)
+	if err != nil {
+		return err
+	}
+	waitlist, err := repos.Participation.FindWaitlist(event.ID)
+	if err != nil {
+		return err
+	}
+	seats := counts.Seats()
+	for _, attendance := range waitlist {
+		needed := int64(1 + attendance.Guests)
+		if event.Capacity != nil && seats+needed > int64(*event.Capacity) {
+			break
+		}
+		if err := promote(repos, event, &attendance); err != nil {
+			return err
+		}
+		seats += needed
+	}
+	return nil
+}
+
+// promote bekleme listesindeki kullanıcıyı going yapar ve bildirim gönderir
+func promote(repos repository.Repositories, event *models.Event, attendance *models.EventAttendance) error {
+	if err := repos.Participation.UpdateAttendanceFields(attendance.ID, map[string]interface{}{
+		"status":            models.AttendanceGoing,
+		"waitlist_position": 0,
+	}); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("'%s' etkinliğinde yer açıldı, bekleme listesinden katılımcılar arasına alındınız.", event.Title)
+	if _, err := notify(repos.Notifications, attendance.UserID, models.NotificationTypeWaitlistPromote, msg, &event.ID); err != nil {
+		return err
+	}
+	log.Printf("[EventService.promote] Bekleme listesinden katılımcı yapıldı (EventID: %d, UserID: %d)", event.ID, attendance.UserID)
+	return nil
+}
+
+// findOrganizedEvent etkinliği satır kilidiyle getirir ve userID'nin etkinliğin sahibi olduğunu doğrular
+func findOrganizedEvent(repos repository.Repositories, eventID, userID uint64) (*models.Event, error) {
+	event, err := repos.Events.FindByIDForUpdate(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
+		return nil, err
+	}
+	if event.CreatorUserID != userID {
+		return nil, errors.New("bu etkinliğin bekleme listesini yönetme yetkiniz yok")
+	}
+	return event, nil
+}
+
+// GetWaitlist etkinliğin bekleme listesini sırasıyla döndürür; yalnızca etkinlik sahibi görebilir
+func (s *EventService) GetWaitlist(eventID, userID uint64) ([]models.EventAttendance, error) {
+	event, err := s.repos.Events.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("etkinlik bulunamadı")
+		}
+		return nil, err
+	}
+	if event.CreatorUserID != userID {
+		return nil, errors.New("bu etkinliğin bekleme listesini görme yetkiniz yok")
+	}
+	return s.repos.Participation.FindWaitlist(eventID)
+}
+
+// ReorderWaitlist bekleme listesini verilen kullanıcı sırasına göre yeniden düzenler. userIDs bekleme
+// listesindeki kullanıcıların tamamını, her birini bir kez içermelidir.
+func (s *EventService) ReorderWaitlist(eventID, organizerID uint64, userIDs []uint64) ([]models.EventAttendance, error) {
+	var waitlist []models.EventAttendance
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		if _, err := findOrganizedEvent(repos, eventID, organizerID); err != nil {
+			return err
+		}
+		current, err := repos.Participation.FindWaitlist(eventID)
+		if err != nil {
+			return err
+		}
+		byUser := make(map[uint64]*models.EventAttendance, len(current))
+		for i := range current {
+			byUser[current[i].UserID] = &current[i]
+		}
+		if len(userIDs) != len(current) {
+			return errors.New("yeni sıra bekleme listesindeki tüm kullanıcıları içermelidir")
+		}
+
+		waitlist = make([]models.EventAttendance, 0, len(userIDs))
+		for i, userID := range userIDs {
+			attendance := byUser[userID]
+			if attendance == nil {
+				return fmt.Errorf("kullanıcı (ID: %d) bekleme listesinde değil veya birden fazla kez verildi", userID)
+			}
+			delete(byUser, userID)
+			attendance.WaitlistPosition = i + 1
+			if err := repos.Participation.UpdateAttendanceFields(attendance.ID, map[string]interface{}{
+				"waitlist_position": attendance.WaitlistPosition,
+			}); err != nil {
+				return err
+			}
+			waitlist = append(waitlist, *attendance)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return waitlist, nil
+}
+
+// PromoteFromWaitlist bekleme listesindeki kullanıcıyı sırasını beklemeden katılımcı yapar. Etkinlik sahibinin
+// açık kararı olduğundan kapasite doluysa veya kullanıcı misafirleriyle sığmıyorsa da uygulanır; kalan
+// kullanıcıların sırası korunur.
+func (s *EventService) PromoteFromWaitlist(eventID, organizerID, userID uint64) error {
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		event, err := findOrganizedEvent(repos, eventID, organizerID)
+		if err != nil {
+			return err
+		}
+		attendance, err := repos.Participation.FindAttendance(eventID, userID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if err != nil || attendance.Status != models.AttendanceWaitlisted {
+			return errors.New("kullanıcı bu etkinliğin bekleme listesinde değil")
+		}
+		return promote(repos, event, attendance)
+	})
+}
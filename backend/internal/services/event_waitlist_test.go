@@ -0,0 +1,249 @@
+package services_test
+
+import (
+	"sync"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func attend(t *testing.T, events *services.EventService, eventID, userID uint64) *models.EventAttendance {
+	t.Helper()
+	attendance, err := events.AttendEvent(eventID, userID)
+	if err != nil {
+		t.Fatalf("katılım başarısız: %v", err)
+	}
+	return attendance
+}
+
+func waitlistUserIDs(t *testing.T, events *services.EventService, eventID, ownerID uint64) []uint64 {
+	t.Helper()
+	waitlist, err := events.GetWaitlist(eventID, ownerID)
+	if err != nil {
+		t.Fatalf("bekleme listesi alınamadı: %v", err)
+	}
+	ids := make([]uint64, len(waitlist))
+	for i, attendance := range waitlist {
+		ids[i] = attendance.UserID
+	}
+	return ids
+}
+
+func promotedNotifications(env *testutil.Env, userID, eventID uint64) int64 {
+	var count int64
+	env.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND related_id = ?", userID, models.NotificationTypeWaitlistPromote, eventID).
+		Count(&count)
+	return count
+}
+
+func TestWaitlistPromotion(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner, testutil.Capacity(2))
+	first, second, third, fourth := env.User(), env.User(), env.User(), env.User()
+
+	for _, user := range []*models.User{first, second} {
+		if attendance := attend(t, events, event.ID, user.ID); attendance.Status != models.AttendanceGoing {
+			t.Fatalf("boş yer varken katılımcı olunmalı: %+v", attendance)
+		}
+	}
+	for i, user := range []*models.User{third, fourth} {
+		attendance := attend(t, events, event.ID, user.ID)
+		if attendance.Status != models.AttendanceWaitlisted || attendance.WaitlistPosition != i+1 {
+			t.Fatalf("kapasite doluyken bekleme listesine %d. sıradan alınmalı: %+v", i+1, attendance)
+		}
+	}
+	if again := attend(t, events, event.ID, third.ID); again.WaitlistPosition != 1 {
+		t.Fatalf("tekrar katılım sırayı değiştirmemeli: %+v", again)
+	}
+
+	_, counts, err := events.GetEventByID(event.ID, owner.ID)
+	if err != nil || counts.Going != 2 || counts.Waitlisted != 2 {
+		t.Fatalf("bekleme listesi katılımcı sayısına eklenmemeli: %+v, %v", counts, err)
+	}
+	if _, err := events.GetWaitlist(event.ID, first.ID); err == nil {
+		t.Fatal("bekleme listesini yalnızca etkinlik sahibi görebilmeli")
+	}
+
+	// İptal edilen yer sıradaki kullanıcıya verilir ve kullanıcı bilgilendirilir
+	if err := events.CancelAttendance(event.ID, first.ID); err != nil {
+		t.Fatalf("katılım iptal edilemedi: %v", err)
+	}
+	var promoted models.EventAttendance
+	env.DB.Where("event_id = ? AND user_id = ?", event.ID, third.ID).First(&promoted)
+	if promoted.Status != models.AttendanceGoing || promoted.WaitlistPosition != 0 {
+		t.Fatalf("sıradaki kullanıcı katılımcı yapılmalı: %+v", promoted)
+	}
+	if promotedNotifications(env, third.ID, event.ID) != 1 || promotedNotifications(env, fourth.ID, event.ID) != 0 {
+		t.Fatal("yalnızca yer verilen kullanıcıya bildirim gitmeli")
+	}
+	if ids := waitlistUserIDs(t, events, event.ID, owner.ID); len(ids) != 1 || ids[0] != fourth.ID {
+		t.Fatalf("bekleme listesinde yalnızca son kullanıcı kalmalı: %v", ids)
+	}
+
+	// Bekleme listesindeki kullanıcı da sırasından vazgeçebilir
+	if err := events.CancelAttendance(event.ID, fourth.ID); err != nil {
+		t.Fatalf("bekleme listesinden çıkılamadı: %v", err)
+	}
+	if ids := waitlistUserIDs(t, events, event.ID, owner.ID); len(ids) != 0 {
+		t.Fatalf("bekleme listesi boşalmalı: %v", ids)
+	}
+
+	// Kapasite artırılınca bekleme listesi doldurulur
+	late := env.User()
+	if attendance := attend(t, events, event.ID, late.ID); attendance.Status != models.AttendanceWaitlisted {
+		t.Fatalf("kapasite doluyken bekleme listesine alınmalı: %+v", attendance)
+	}
+	capacity := 0
+	if _, err := events.UpdateEvent(event.ID, owner.ID, services.UpdateEventDTO{Capacity: &capacity}); err != nil {
+		t.Fatalf("kapasite kaldırılamadı: %v", err)
+	}
+	if promotedNotifications(env, late.ID, event.ID) != 1 {
+		t.Fatal("kapasite kaldırılınca bekleyen kullanıcı katılımcı yapılmalı")
+	}
+	var updated models.Event
+	env.DB.First(&updated, event.ID)
+	if updated.Capacity != nil || updated.Sequence != event.Sequence {
+		t.Fatalf("kapasite kaldırılmalı ve SEQUENCE değişmemeli: %+v", updated)
+	}
+}
+
+func TestWaitlistApprovalRespectsCapacity(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner, testutil.Private, testutil.Capacity(1))
+	requester, invitee := env.User(), env.User()
+
+	if err := env.DB.Create(&models.EventAttendance{EventID: event.ID, UserID: env.User().ID, Status: models.AttendanceGoing}).Error; err != nil {
+		t.Fatalf("katılım eklenemedi: %v", err)
+	}
+
+	if attendance := attend(t, events, event.ID, requester.ID); attendance != nil {
+		t.Fatalf("özel etkinlikte katılım isteği oluşturulmalı: %+v", attendance)
+	}
+	var request models.EventParticipationRequest
+	env.DB.Where("event_id = ? AND user_id = ?", event.ID, requester.ID).First(&request)
+	if err := events.ApproveParticipationRequest(request.ID, owner.ID); err != nil {
+		t.Fatalf("istek onaylanamadı: %v", err)
+	}
+
+	invitation, err := events.InviteUserToEvent(event.ID, owner.ID, invitee.ID)
+	if err != nil {
+		t.Fatalf("davet gönderilemedi: %v", err)
+	}
+	if err := events.AcceptEventInvitation(invitation.ID, invitee.ID); err != nil {
+		t.Fatalf("davet kabul edilemedi: %v", err)
+	}
+
+	if ids := waitlistUserIDs(t, events, event.ID, owner.ID); len(ids) != 2 || ids[0] != requester.ID || ids[1] != invitee.ID {
+		t.Fatalf("onaylanan istek ve kabul edilen davet kapasite doluyken bekleme listesine alınmalı: %v", ids)
+	}
+	_, counts, _ := events.GetEventByID(event.ID, owner.ID)
+	if counts.Going != 1 {
+		t.Fatalf("kapasite aşılmamalı: %+v", counts)
+	}
+}
+
+func TestWaitlistOrganizerControls(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	event := env.Event(owner, testutil.Capacity(1))
+	attend(t, events, event.ID, env.User().ID)
+	a, b, c := env.User(), env.User(), env.User()
+	for _, user := range []*models.User{a, b, c} {
+		attend(t, events, event.ID, user.ID)
+	}
+
+	if _, err := events.ReorderWaitlist(event.ID, a.ID, []uint64{c.ID, a.ID, b.ID}); err == nil {
+		t.Fatal("bekleme listesini yalnızca etkinlik sahibi düzenleyebilmeli")
+	}
+	for _, invalid := range [][]uint64{{c.ID, a.ID}, {c.ID, a.ID, a.ID}, {c.ID, a.ID, owner.ID}} {
+		if _, err := events.ReorderWaitlist(event.ID, owner.ID, invalid); err == nil {
+			t.Fatalf("eksik, yinelenen veya listede olmayan kullanıcı içeren sıra reddedilmeli: %v", invalid)
+		}
+	}
+	if _, err := events.ReorderWaitlist(event.ID, owner.ID, []uint64{c.ID, a.ID, b.ID}); err != nil {
+		t.Fatalf("bekleme listesi sıralanamadı: %v", err)
+	}
+	if ids := waitlistUserIDs(t, events, event.ID, owner.ID); ids[0] != c.ID || ids[1] != a.ID || ids[2] != b.ID {
+		t.Fatalf("yeni sıra uygulanmalı: %v", ids)
+	}
+
+	// Etkinlik sahibi kapasite doluyken de sırayı atlayarak kullanıcı alabilir
+	if err := events.PromoteFromWaitlist(event.ID, owner.ID, b.ID); err != nil {
+		t.Fatalf("kullanıcı katılımcı yapılamadı: %v", err)
+	}
+	if err := events.PromoteFromWaitlist(event.ID, owner.ID, b.ID); err == nil {
+		t.Fatal("bekleme listesinde olmayan kullanıcı katılımcı yapılamamalı")
+	}
+	if promotedNotifications(env, b.ID, event.ID) != 1 {
+		t.Fatal("katılımcı yapılan kullanıcıya bildirim gitmeli")
+	}
+	if ids := waitlistUserIDs(t, events, event.ID, owner.ID); len(ids) != 2 || ids[0] != c.ID || ids[1] != a.ID {
+		t.Fatalf("kalan kullanıcıların sırası korunmalı: %v", ids)
+	}
+}
+
+func TestWaitlistConcurrentJoins(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	event := env.Event(env.User(), testutil.Capacity(3))
+
+	users := make([]*models.User, 10)
+	for i := range users {
+		users[i] = env.User()
+	}
+
+	// Eşzamanlı katılımlardan bazıları kilit hatasıyla dönebilir; başarılı olanlar kapasiteyi aşmamalıdır
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	joined := 0
+	for _, user := range users {
+		wg.Add(1)
+		go func(userID uint64) {
+			defer wg.Done()
+			if _, err := events.AttendEvent(event.ID, userID); err == nil {
+				mu.Lock()
+				joined++
+				mu.Unlock()
+			}
+		}(user.ID)
+	}
+	wg.Wait()
+
+	var attending, waitlisted int64
+	env.DB.Model(&models.EventAttendance{}).Where("event_id = ? AND status = ?", event.ID, models.AttendanceGoing).Count(&attending)
+	env.DB.Model(&models.EventAttendance{}).Where("event_id = ? AND status = ?", event.ID, models.AttendanceWaitlisted).Count(&waitlisted)
+	if attending > 3 || int(attending+waitlisted) != joined {
+		t.Fatalf("kapasite aşılmamalı: %d katılımcı, %d bekleyen, %d başarılı katılım", attending, waitlisted, joined)
+	}
+}
+
+func TestOccurrenceCapacity(t *testing.T) {
+	env := testutil.New(t)
+	events := env.Services().Events
+	owner := env.User()
+	series := env.Event(owner, testutil.Recurring("FREQ=WEEKLY;COUNT=4"), testutil.Capacity(1))
+	regular, guest := env.User(), env.User()
+	attend(t, events, series.ID, regular.ID)
+
+	second := series.FinalStartTime.AddDate(0, 0, 7)
+	if err := events.AttendEventOccurrence(series.ID, guest.ID, second); err == nil {
+		t.Fatal("seri katılımcısıyla dolu tekrara katılım reddedilmeli")
+	}
+	if err := events.CancelOccurrenceAttendance(series.ID, regular.ID, second); err != nil {
+		t.Fatalf("tekrar için katılım iptal edilemedi: %v", err)
+	}
+	if err := events.AttendEventOccurrence(series.ID, guest.ID, second); err != nil {
+		t.Fatalf("boşalan tekrara katılınamadı: %v", err)
+	}
+	if err := events.AttendEventOccurrence(series.ID, regular.ID, second); err == nil {
+		t.Fatal("dolan tekrara yeniden katılım reddedilmeli")
+	}
+}
@@ -4,44 +4,39 @@ import (
 	"errors"
 	"event/backend/internal/models"
 	"event/backend/internal/repository"
-	"event/backend/pkg/database"
 	"sort"
-
-	"gorm.io/gorm"
 )
 
 // FriendshipService arkadaşlık işlemlerini yöneten servis
 type FriendshipService struct {
-	userRepo repository.UserRepository
+	friendships repository.FriendshipRepository
+	userRepo    repository.UserRepository
 }
 
 // NewFriendshipService yeni bir FriendshipService örneği oluşturur
-func NewFriendshipService(userRepo repository.UserRepository) *FriendshipService {
+func NewFriendshipService(friendships repository.FriendshipRepository, userRepo repository.UserRepository) *FriendshipService {
 	return &FriendshipService{
-		userRepo: userRepo,
+		friendships: friendships,
+		userRepo:    userRepo,
 	}
 }
 
 // CreateFriendshipRequest arkadaşlık isteği oluşturur
 func (s *FriendshipService) CreateFriendshipRequest(requesterID, addresseeID uint64) error {
-	db := database.GetDB()
-
 	// Kendisiyle arkadaşlık kurma kontrolü
 	if requesterID == addresseeID {
 		return errors.New("kendinizle arkadaşlık kuramazsınız")
 	}
 
 	// Mevcut arkadaşlık kontrolü
-	var existingFriendship models.Friendship
-	err := db.Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
-		requesterID, addresseeID, addresseeID, requesterID).First(&existingFriendship).Error
+	_, err := s.friendships.FindBetween(requesterID, addresseeID)
 
 	if err == nil {
 		// Kayıt bulundu, yani zaten bir ilişki var.
 		return errors.New("bu kullanıcı ile zaten bir arkadaşlık ilişkisi var veya bekleyen bir istek mevcut")
 	}
 
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
+	if !errors.Is(err, repository.ErrNotFound) {
 		// Kayıt bulunamadı hatası dışında bir hata oluştu.
 		return err
 	}
@@ -53,16 +48,14 @@ func (s *FriendshipService) CreateFriendshipRequest(requesterID, addresseeID uin
 		Status:      "pending",
 	}
 
-	return db.Create(&friendship).Error
+	return s.friendships.Create(&friendship)
 }
 
 // AcceptFriendshipRequest arkadaşlık isteğini kabul eder
 func (s *FriendshipService) AcceptFriendshipRequest(friendshipID, userID uint64) error {
-	db := database.GetDB()
-
 	// Arkadaşlık isteğini bul
-	var friendship models.Friendship
-	if err := db.First(&friendship, friendshipID).Error; err != nil {
+	friendship, err := s.friendships.FindByID(friendshipID)
+	if err != nil {
 		return errors.New("arkadaşlık isteği bulunamadı")
 	}
 
@@ -77,17 +70,14 @@ func (s *FriendshipService) AcceptFriendshipRequest(friendshipID, userID uint64)
 	}
 
 	// İsteği kabul et
-	friendship.Status = "accepted"
-	return db.Save(&friendship).Error
+	return s.friendships.SetStatus(friendship.ID, models.FriendshipAccepted)
 }
 
 // DeclineFriendshipRequest arkadaşlık isteğini reddeder
 func (s *FriendshipService) DeclineFriendshipRequest(friendshipID, userID uint64) error {
-	db := database.GetDB()
-
 	// Arkadaşlık isteğini bul
-	var friendship models.Friendship
-	if err := db.First(&friendship, friendshipID).Error; err != nil {
+	friendship, err := s.friendships.FindByID(friendshipID)
+	if err != nil {
 		return errors.New("arkadaşlık isteği bulunamadı")
 	}
 
@@ -102,17 +92,14 @@ func (s *FriendshipService) DeclineFriendshipRequest(friendshipID, userID uint64
 	}
 
 	// İsteği reddet
-	friendship.Status = "declined"
-	return db.Save(&friendship).Error
+	return s.friendships.SetStatus(friendship.ID, models.FriendshipDeclined)
 }
 
 // DeleteFriendship arkadaşlığı sonlandırır
 func (s *FriendshipService) DeleteFriendship(friendshipID, userID uint64) error {
-	db := database.GetDB()
-
 	// Arkadaşlığı bul
-	var friendship models.Friendship
-	if err := db.First(&friendship, friendshipID).Error; err != nil {
+	friendship, err := s.friendships.FindByID(friendshipID)
+	if err != nil {
 		return errors.New("arkadaşlık bulunamadı")
 	}
 
@@ -122,7 +109,7 @@ func (s *FriendshipService) DeleteFriendship(friendshipID, userID uint64) error
 	}
 
 	// Arkadaşlığı sil
-	return db.Delete(&friendship).Error
+	return s.friendships.Delete(friendship.ID)
 }
 
 // GetFriends kullanıcının arkadaşlarını listeler
@@ -132,18 +119,32 @@ func (s *FriendshipService) GetFriends(userID uint64) ([]models.User, error) {
 
 // GetPendingRequests gelen arkadaşlık isteklerini listeler
 func (s *FriendshipService) GetPendingRequests(userID uint64) ([]models.Friendship, error) {
-	db := database.GetDB()
-	var requests []models.Friendship
-
-	// Kullanıcıya gelen bekleyen istekleri getir
-	if err := db.Where("addressee_id = ? AND status = ?", userID, "pending").
-		Preload("Requester"). // İsteği gönderen kullanıcı bilgilerini de getir
-		Preload("Addressee"). // İsteği alan kullanıcı bilgilerini de getir
-		Find(&requests).Error; err != nil {
+	// Kullanıcıya gelen bekleyen istekleri gönderen ve alan kullanıcı bilgileriyle getir
+	return s.friendships.FindPendingFor(userID)
+}
+
+// FriendshipStatusDTO iki kullanıcı arasındaki arkadaşlık durumunu temsil eder
+type FriendshipStatusDTO struct {
+	Status       string  `json:"status"` // "none", "pending", "accepted", "declined"
+	FriendshipID *uint64 `json:"friendshipId,omitempty"`
+	IsRequester  bool    `json:"isRequester"`
+}
+
+// GetFriendshipStatus iki kullanıcı arasındaki arkadaşlık durumunu döndürür
+func (s *FriendshipService) GetFriendshipStatus(userID, otherUserID uint64) (*FriendshipStatusDTO, error) {
+	friendship, err := s.friendships.FindBetween(userID, otherUserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &FriendshipStatusDTO{Status: "none"}, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return requests, nil
+	return &FriendshipStatusDTO{
+		Status:       friendship.Status,
+		FriendshipID: &friendship.ID,
+		IsRequester:  friendship.RequesterID == userID,
+	}, nil
 }
 
 // FriendSuggestion bir arkadaş önerisini temsil eder
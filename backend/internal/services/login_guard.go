@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+	"event/backend/pkg/mailer"
+)
+
+// LoginGuard giriş denemelerini hesap ve IP bazında izleyerek kaba kuvvet saldırılarını yavaşlatır.
+// Eşik aşıldığında üstel bekleme uygulanır; hesap eşiği aşılırsa hesap geçici olarak kilitlenir
+// ve kullanıcıya kilit açma bağlantısı gönderilir. Hesap sayacı kullanıcı kaydına değil e-postaya bağlıdır;
+// kayıtlı olmayan e-postalar da aynı sayaç ve aynı yanıtlarla yavaşlatılır, böylece yanıtlardan
+// e-postanın kayıtlı olup olmadığı anlaşılamaz.
+type LoginGuard struct {
+	repos  repository.Repositories
+	uow    repository.UnitOfWork
+	config *config.Config
+	mailer mailer.Mailer
+}
+
+// LoginGuardInput, LoginGuard için bağımlılıkları içerir.
+type LoginGuardInput struct {
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
+	Config       *config.Config
+	Mailer       mailer.Mailer
+}
+
+// NewLoginGuard yeni bir LoginGuard oluşturur
+func NewLoginGuard(input LoginGuardInput) *LoginGuard {
+	return &LoginGuard{
+		repos:  input.Repositories,
+		uow:    input.UnitOfWork,
+		config: input.Config,
+		mailer: input.Mailer,
+	}
+}
+
+var (
+	// ErrInvalidCredentials e-posta bulunamadığında da şifre hatalı olduğunda da aynı şekilde döner
+	ErrInvalidCredentials = errors.New("e-posta veya şifre hatalı")
+	// ErrInvalidUnlockToken geçersiz veya kullanılmış kilit açma bağlantısı için döner
+	ErrInvalidUnlockToken = errors.New("kilit açma bağlantısı geçersiz veya daha önce kullanılmış")
+)
+
+// LoginBlockedError çok fazla hatalı deneme sonrası bekleme süresi dolmadan yapılan girişlerde döner.
+// Kilitli hesaplar için de aynı hata döner; kilit açma bağlantısı yalnızca hesap sahibine e-postayla gider.
+type LoginBlockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("çok fazla hatalı giriş denemesi, lütfen %d saniye sonra tekrar deneyin", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// accountThrottleKey e-postanın hesap sayacı anahtarıdır. E-posta normalize edilip özetlenir; böylece anahtar
+// sütun sınırına sığar ve sayaç tablosunda e-posta adresleri tutulmaz.
+func accountThrottleKey(email string) string {
+	return "account:" + utils.HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// CheckAllowed şifre kontrolünden önce çağrılır; IP veya e-posta beklemedeyse ya da hesap kilitliyse
+// LoginBlockedError döner. user nil olabilir (e-posta kayıtlı değilse); yanıt kayıtlı e-postalarla aynıdır.
+func (g *LoginGuard) CheckAllowed(user *models.User, email, ip string) error {
+	now := time.Now()
+
+	if ip != "" {
+		if wait := g.blockedFor(ipThrottleKey(ip), now); wait > 0 {
+			g.audit(user, email, ip, models.AuditLoginThrottled, "ip")
+			return &LoginBlockedError{RetryAfter: wait}
+		}
+	}
+
+	detail := "account"
+	wait := g.blockedFor(accountThrottleKey(email), now)
+	// Kilit, sayacın kilit eşiğindeki beklemesiyle aynı anda ve aynı süreyle konur; e-postadaki bağlantıyla
+	// erken açılabildiği için ayrıca kontrol edilir
+	if user != nil && user.IsLocked(now) {
+		detail = "account_locked"
+		if locked := user.LockedUntil.Sub(now); locked > wait {
+			wait = locked
+		}
+	}
+	if wait > 0 {
+		g.audit(user, email, ip, models.AuditLoginThrottled, detail)
+		return &LoginBlockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordFailure hatalı bir girişi IP ve e-posta sayaçlarına işler. Sayaç kayıtlı olmayan e-postalar için de tutulur.
+// Kilit eşiğinde e-posta AccountLockoutDuration boyunca bekletilir; e-posta kayıtlıysa hesap da aynı süreyle
+// kilitlenir ve kilit açma e-postası gönderilir.
+func (g *LoginGuard) RecordFailure(user *models.User, email, ip string) {
+	g.audit(user, email, ip, models.AuditLoginFailed, "")
+
+	if ip != "" {
+		if _, _, err := g.registerFailure(ipThrottleKey(ip), g.config.IPBackoffThreshold, 0); err != nil {
+			log.Printf("[LoginGuard.RecordFailure] IP sayacı güncellenemedi (IP: %s): %v", ip, err)
+		}
+	}
+
+	_, lockedUntil, err := g.registerFailure(accountThrottleKey(email), g.config.AccountBackoffThreshold, g.config.AccountLockoutThreshold)
+	if err != nil {
+		log.Printf("[LoginGuard.RecordFailure] Hesap sayacı güncellenemedi (Email: %s): %v", email, err)
+		return
+	}
+	if user != nil && lockedUntil != nil {
+		g.lockAccount(user, *lockedUntil, ip)
+	}
+}
+
+// RecordSuccess başarılı girişten sonra hesabın hata sayacını sıfırlar.
+// IP sayacı bilerek sıfırlanmaz; aksi halde saldırgan kendi hesabıyla giriş yaparak sayacı temizleyebilirdi.
+func (g *LoginGuard) RecordSuccess(user *models.User) {
+	if err := g.repos.LoginThrottles.Delete(accountThrottleKey(user.Email)); err != nil {
+		log.Printf("[LoginGuard.RecordSuccess] Hesap sayacı sıfırlanamadı (UserID: %d): %v", user.ID, err)
+	}
+}
+
+// Unlock e-postadaki tek kullanımlık bağlantı ile hesabın kilidini açar
+func (g *LoginGuard) Unlock(rawToken, ip string) error {
+	user, err := g.repos.Users.FindByUnlockTokenHash(utils.HashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrInvalidUnlockToken
+		}
+		return err
+	}
+
+	err = g.uow.WithTx(func(repos repository.Repositories) error {
+		// Koşullu güncelleme ile token yalnızca bir kez kullanılabilir
+		cleared, err := repos.Users.ClearLock(user.ID, user.UnlockTokenHash)
+		if err != nil {
+			return err
+		}
+		if !cleared {
+			return ErrInvalidUnlockToken
+		}
+		return repos.LoginThrottles.Delete(accountThrottleKey(user.Email))
+	})
+	if err != nil {
+		return err
+	}
+
+	g.audit(user, user.Email, ip, models.AuditAccountUnlocked, "")
+	return nil
+}
+
+// blockedFor anahtar için kalan bekleme süresini döndürür; bekleme yoksa 0
+func (g *LoginGuard) blockedFor(key string, now time.Time) time.Duration {
+	throttle, err := g.repos.LoginThrottles.Find(key)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Printf("[LoginGuard.blockedFor] Sayaç okunamadı (Key: %s): %v", key, err)
+		}
+		return 0
+	}
+	if throttle.BlockedUntil == nil || !now.Before(*throttle.BlockedUntil) {
+		return 0
+	}
+	return throttle.BlockedUntil.Sub(now)
+}
+
+// registerFailure anahtarın hata sayacını artırır ve eşik aşıldıysa üstel bekleme süresi belirler.
+// Sayaç lockoutThreshold'a (0 ise kapalı) ulaştığında bekleme en az AccountLockoutDuration olur ve bu
+// bekleme bitişi de döner. Son hatadan bu yana LoginFailureWindow geçtiyse sayaç baştan başlar.
+// Güncel hata sayısını döndürür.
+func (g *LoginGuard) registerFailure(key string, threshold, lockoutThreshold int) (int, *time.Time, error) {
+	now := time.Now()
+	var failures int
+	var lockedUntil *time.Time
+
+	err := g.uow.WithTx(func(repos repository.Repositories) error {
+		// Kayıt yoksa oluştur; eşzamanlı isteklerde benzersiz anahtar çakışması yok sayılır
+		if err := repos.LoginThrottles.EnsureExists(key, now); err != nil {
+			return err
+		}
+
+		throttle, err := repos.LoginThrottles.FindForUpdate(key)
+		if err != nil {
+			return err
+		}
+
+		if now.Sub(throttle.LastFailureAt) > g.config.LoginFailureWindow {
+			throttle.Failures = 0
+		}
+		throttle.Failures++
+		throttle.LastFailureAt = now
+		if threshold > 0 && throttle.Failures >= threshold {
+			blockedUntil := now.Add(g.backoff(throttle.Failures - threshold))
+			throttle.BlockedUntil = &blockedUntil
+		}
+		if lockoutThreshold > 0 && throttle.Failures >= lockoutThreshold {
+			until := now.Add(g.config.AccountLockoutDuration)
+			if throttle.BlockedUntil == nil || throttle.BlockedUntil.Before(until) {
+				throttle.BlockedUntil = &until
+			}
+			lockedUntil = throttle.BlockedUntil
+		}
+
+		failures = throttle.Failures
+		return repos.LoginThrottles.Save(throttle)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return failures, lockedUntil, nil
+}
+
+// backoff eşik aşıldıktan sonraki n. hata için bekleme süresini hesaplar: base * 2^n, en fazla LoginBackoffMax
+func (g *LoginGuard) backoff(n int) time.Duration {
+	if n > 30 {
+		return g.config.LoginBackoffMax
+	}
+	wait := g.config.LoginBackoffBase * time.Duration(1<<uint(n))
+	if wait > g.config.LoginBackoffMax || wait <= 0 {
+		return g.config.LoginBackoffMax
+	}
+	return wait
+}
+
+// lockAccount hesabı lockedUntil'e kadar kilitler ve kilit açma bağlantısını e-postayla gönderir
+func (g *LoginGuard) lockAccount(user *models.User, lockedUntil time.Time, ip string) {
+	now := time.Now()
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		log.Printf("[LoginGuard.lockAccount] Kilit açma tokeni oluşturulamadı (UserID: %d): %v", user.ID, err)
+		return
+	}
+
+	// Hesap zaten kilitliyse yeni bağlantı gönderilmez
+	locked, err := g.repos.Users.Lock(user.ID, now, lockedUntil, utils.HashToken(rawToken))
+	if err != nil {
+		log.Printf("[LoginGuard.lockAccount] Hesap kilitlenemedi (UserID: %d): %v", user.ID, err)
+		return
+	}
+	if !locked {
+		return
+	}
+
+	g.audit(user, user.Email, ip, models.AuditAccountLocked, fmt.Sprintf("kilit bitişi: %s", lockedUntil.Format(time.RFC3339)))
+
+	link := fmt.Sprintf("%s/unlock-account?token=%s", strings.TrimRight(g.config.FrontendURL, "/"), rawToken)
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Hesabınız geçici olarak kilitlendi",
+		Body: fmt.Sprintf(
+			"Merhaba %s,\n\nHesabınıza çok sayıda hatalı giriş denemesi yapıldığı için hesabınız %s boyunca kilitlendi.\n\n"+
+				"Bu denemeleri siz yaptıysanız aşağıdaki bağlantıyla kilidi hemen açabilirsiniz:\n\n%s\n\n"+
+				"Siz yapmadıysanız kilidin süresinin dolmasını bekleyebilir ve şifrenizi değiştirmeyi düşünebilirsiniz.\n",
+			user.Username, g.config.AccountLockoutDuration, link,
+		),
+	}
+	if err := g.mailer.Send(msg); err != nil {
+		log.Printf("[LoginGuard.lockAccount] Kilit açma e-postası gönderilemedi (UserID: %d): %v", user.ID, err)
+	}
+}
+
+// audit bir kimlik doğrulama olayını denetim kaydına yazar; hata giriş akışını etkilemez
+func (g *LoginGuard) audit(user *models.User, email, ip string, event models.AuthAuditEvent, detail string) {
+	record := models.AuthAuditLog{
+		Email:     email,
+		IPAddress: ip,
+		Event:     event,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}
+	if user != nil {
+		record.UserID = &user.ID
+	}
+	if err := g.repos.Audit.CreateAuthLog(&record); err != nil {
+		log.Printf("[LoginGuard.audit] Denetim kaydı yazılamadı (Event: %s): %v", event, err)
+	}
+}
@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func TestLoginThrottleDoesNotRevealRegisteredEmails(t *testing.T) {
+	env := testutil.New(t)
+	env.Config.AccountBackoffThreshold = 2
+	env.Config.AccountLockoutThreshold = 3
+	env.Config.IPBackoffThreshold = 0
+	auth := env.Services().Auth
+
+	registered := env.User()
+	unknown := "kayitsiz@example.com"
+
+	// loginUntilBlocked yanlış şifreyle girişi deneme engellenene kadar tekrarlar ve engel hatasını döndürür
+	loginUntilBlocked := func(email string) (int, *services.LoginBlockedError) {
+		for attempt := 1; attempt <= 10; attempt++ {
+			_, err := auth.Login(email, "yanlis-sifre", services.ClientInfo{})
+			var blocked *services.LoginBlockedError
+			if errors.As(err, &blocked) {
+				return attempt, blocked
+			}
+			if !errors.Is(err, services.ErrInvalidCredentials) {
+				t.Fatalf("%s için beklenmeyen hata: %v", email, err)
+			}
+			// Üstel bekleme sırasında sayaç artmaz; kilit eşiğine ulaşmak için beklemeyi geçmiş say
+			env.DB.Model(&models.LoginThrottle{}).Where("failures < ?", env.Config.AccountLockoutThreshold).
+				Update("blocked_until", nil)
+		}
+		t.Fatalf("%s için giriş engellenmedi", email)
+		return 0, nil
+	}
+
+	registeredAttempts, registeredBlock := loginUntilBlocked(registered.Email)
+	unknownAttempts, unknownBlock := loginUntilBlocked(unknown)
+
+	if registeredAttempts != unknownAttempts {
+		t.Fatalf("engel aynı denemede gelmeli: kayıtlı %d, kayıtsız %d", registeredAttempts, unknownAttempts)
+	}
+	if diff := registeredBlock.RetryAfter - unknownBlock.RetryAfter; diff > time.Second || diff < -time.Second {
+		t.Fatalf("bekleme süreleri aynı olmalı: kayıtlı %s, kayıtsız %s", registeredBlock.RetryAfter, unknownBlock.RetryAfter)
+	}
+	if registeredBlock.RetryAfter < env.Config.AccountLockoutDuration-time.Minute {
+		t.Fatalf("kilit eşiğinde bekleme kilit süresi kadar olmalı: %s", registeredBlock.RetryAfter)
+	}
+
+	var locked models.User
+	env.DB.First(&locked, registered.ID)
+	if locked.LockedUntil == nil || locked.UnlockTokenHash == "" {
+		t.Fatal("kayıtlı hesap kilitlenmeli ve kilit açma token'ı üretilmeli")
+	}
+
+	// E-posta büyük/küçük harf ve boşluk farkıyla yazılsa da aynı sayaç kullanılır
+	if _, err := auth.Login("  "+strings.ToUpper(unknown)+" ", "yanlis-sifre", services.ClientInfo{}); !errors.As(err, new(*services.LoginBlockedError)) {
+		t.Fatalf("normalize edilmiş e-posta da engellenmeli: %v", err)
+	}
+}
@@ -2,32 +2,32 @@ package services
 
 import (
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
-
-	"gorm.io/gorm"
+	"event/backend/internal/repository"
 )
 
 type NotificationService struct {
-	db *gorm.DB
+	notifications repository.NotificationRepository
 }
 
-func NewNotificationService() *NotificationService {
-	return &NotificationService{db: database.GetDB()}
+func NewNotificationService(notifications repository.NotificationRepository) *NotificationService {
+	return &NotificationService{notifications: notifications}
 }
 
 func (s *NotificationService) GetNotificationsForUser(userID uint64) ([]models.Notification, error) {
-	var notifications []models.Notification
-	err := s.db.Where("user_id = ?", userID).Order("created_at desc").Find(&notifications).Error
-	return notifications, err
+	return s.notifications.FindByUser(userID)
 }
 
 func (s *NotificationService) MarkNotificationsAsRead(userID uint64, notificationIDs []uint64) error {
-	return s.db.Model(&models.Notification{}).
-		Where("user_id = ? AND id IN ?", userID, notificationIDs).
-		Update("is_read", true).Error
+	return s.notifications.MarkRead(userID, notificationIDs)
 }
 
 func (s *NotificationService) CreateNotification(userID uint64, notificationType models.NotificationType, message string, relatedID *uint64) (*models.Notification, error) {
+	return notify(s.notifications, userID, notificationType, message, relatedID)
+}
+
+// notify kullanıcıya bildirim oluşturur. Diğer servisler bildirimi asıl değişiklikle aynı işlemde
+// kaydetmek için UnitOfWork içindeki repos.Notifications ile çağırır.
+func notify(notifications repository.NotificationRepository, userID uint64, notificationType models.NotificationType, message string, relatedID *uint64) (*models.Notification, error) {
 	notification := &models.Notification{
 		UserID:    userID,
 		Type:      notificationType,
@@ -35,7 +35,7 @@ func (s *NotificationService) CreateNotification(userID uint64, notificationType
 		RelatedID: relatedID,
 	}
 
-	if err := s.db.Create(notification).Error; err != nil {
+	if err := notifications.Create(notification); err != nil {
 		return nil, err
 	}
 	return notification, nil
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+	"event/backend/pkg/oauth"
+)
+
+// OAuthService harici kimlik sağlayıcılarla (Google, GitHub, genel OIDC) giriş, otomatik kayıt
+// ve mevcut hesaba sağlayıcı bağlama işlemlerini yönetir
+type OAuthService struct {
+	repos       repository.Repositories
+	uow         repository.UnitOfWork
+	config      *config.Config
+	authService *AuthService
+	providers   map[string]oauth.Provider
+}
+
+// OAuthServiceInput, OAuthService için bağımlılıkları içerir.
+type OAuthServiceInput struct {
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
+	Config       *config.Config
+	AuthService  *AuthService
+	Providers    map[string]oauth.Provider
+}
+
+// NewOAuthService yeni bir OAuthService oluşturur
+func NewOAuthService(input OAuthServiceInput) *OAuthService {
+	providers := input.Providers
+	if providers == nil {
+		providers = make(map[string]oauth.Provider)
+	}
+	return &OAuthService{
+		repos:       input.Repositories,
+		uow:         input.UnitOfWork,
+		config:      input.Config,
+		authService: input.AuthService,
+		providers:   providers,
+	}
+}
+
+// OAuthCallbackResponse sağlayıcıdan dönüşte istemciye verilen yanıt.
+// Giriş akışında LoginResponse alanları, bağlama akışında Linked ve Identity doldurulur.
+type OAuthCallbackResponse struct {
+	*LoginResponse
+	Linked   bool                 `json:"linked,omitempty"`
+	Identity *models.UserIdentity `json:"identity,omitempty"`
+}
+
+var (
+	// ErrInvalidOAuthState state bulunamadığında, kullanıldığında veya süresi dolduğunda döner
+	ErrInvalidOAuthState = errors.New("giriş isteği geçersiz veya süresi dolmuş, lütfen tekrar deneyin")
+	// ErrIdentityLinkedToOther sağlayıcı hesabı başka bir kullanıcıya bağlıysa döner
+	ErrIdentityLinkedToOther = errors.New("bu hesap başka bir kullanıcıya bağlı")
+	// ErrOAuthLinkForbidden bağlama akışı, akışı başlatan kullanıcının oturumu dışında tamamlanmaya çalışılırsa döner
+	ErrOAuthLinkForbidden = errors.New("hesap bağlama isteği bu oturuma ait değil, lütfen giriş yapıp tekrar deneyin")
+)
+
+// usernameCleaner kullanıcı adında izin verilmeyen karakterleri bulur
+var usernameCleaner = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// ProviderNames yapılandırılmış sağlayıcıların adlarını sıralı döndürür
+func (s *OAuthService) ProviderNames() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartAuthorization yetkilendirme akışını başlatır; kullanıcının yönlendirileceği adresi ve akışın state değerini
+// döndürür. State, dönüşün akışı başlatan tarayıcıdan geldiğini doğrulamak için istemcide saklanmalıdır.
+// linkUserID verilirse dönüşte sağlayıcı hesabı bu kullanıcıya bağlanır.
+func (s *OAuthService) StartAuthorization(ctx context.Context, providerName string, linkUserID *uint64) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", oauth.ErrProviderNotFound
+	}
+
+	state, err := oauth.GenerateState()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oauth.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oauth.GenerateNonce()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, oauth.CodeChallengeS256(verifier), nonce)
+	if err != nil {
+		log.Printf("[OAuthService.StartAuthorization] Yetkilendirme adresi oluşturulamadı (Provider: %s): %v", providerName, err)
+		return "", "", errors.New("kimlik sağlayıcıya ulaşılamadı")
+	}
+
+	now := time.Now()
+
+	// Süresi dolmuş durum kayıtlarını temizle
+	if err := s.repos.OAuth.DeleteExpiredStates(now); err != nil {
+		log.Printf("[OAuthService.StartAuthorization] Eski state kayıtları silinemedi: %v", err)
+	}
+
+	record := models.OAuthState{
+		StateHash:    utils.HashToken(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    now.Add(s.config.OAuthStateTTL),
+		CreatedAt:    now,
+	}
+	if err := s.repos.OAuth.CreateState(&record); err != nil {
+		return "", "", errors.New("giriş isteği kaydedilemedi")
+	}
+
+	return authURL, state, nil
+}
+
+// HandleCallback sağlayıcıdan dönen kod ve state ile akışı tamamlar.
+// Giriş akışında kullanıcı bulunur, bağlanır veya otomatik olarak kaydedilir. Bağlama akışı yalnızca
+// akışı başlatan kullanıcı tarafından (callerID) tamamlanabilir; anonim istekler için callerID 0'dır.
+func (s *OAuthService) HandleCallback(ctx context.Context, providerName, code, state string, callerID uint64, client ClientInfo) (*OAuthCallbackResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, oauth.ErrProviderNotFound
+	}
+
+	stored, err := s.consumeState(providerName, state)
+	if err != nil {
+		return nil, err
+	}
+	// Bağlama state'i başka birine kabul ettirilirse saldırganın sağlayıcı hesabı kurbanın hesabına bağlanabilirdi
+	if stored.LinkUserID != nil && *stored.LinkUserID != callerID {
+		return nil, ErrOAuthLinkForbidden
+	}
+
+	info, err := provider.Exchange(ctx, code, stored.CodeVerifier, stored.Nonce)
+	if err != nil {
+		log.Printf("[OAuthService.HandleCallback] Kod değişimi başarısız (Provider: %s): %v", providerName, err)
+		return nil, errors.New("kimlik sağlayıcı ile doğrulama başarısız oldu")
+	}
+
+	if stored.LinkUserID != nil {
+		identity, err := s.linkIdentity(*stored.LinkUserID, providerName, info)
+		if err != nil {
+			return nil, err
+		}
+		return &OAuthCallbackResponse{Linked: true, Identity: identity}, nil
+	}
+
+	user, err := s.resolveUser(providerName, info)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.authService.completeLogin(user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthCallbackResponse{LoginResponse: resp}, nil
+}
+
+// GetIdentities kullanıcının bağlı sağlayıcı hesaplarını listeler
+func (s *OAuthService) GetIdentities(userID uint64) ([]models.UserIdentity, error) {
+	return s.repos.OAuth.FindIdentities(userID)
+}
+
+// consumeState state kaydını bulur ve tek kullanımlık olması için siler
+func (s *OAuthService) consumeState(providerName, state string) (*models.OAuthState, error) {
+	stored, err := s.repos.OAuth.FindState(utils.HashToken(state), providerName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidOAuthState
+		}
+		return nil, err
+	}
+
+	deleted, err := s.repos.OAuth.DeleteState(stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted || !time.Now().Before(stored.ExpiresAt) {
+		return nil, ErrInvalidOAuthState
+	}
+	return stored, nil
+}
+
+// resolveUser sağlayıcı kimliğine karşılık gelen kullanıcıyı bulur.
+// Sırasıyla: bağlı kimlik, doğrulanmış e-posta ile eşleşen hesap, otomatik kayıt.
+func (s *OAuthService) resolveUser(providerName string, info *oauth.UserInfo) (*models.User, error) {
+	now := time.Now()
+
+	identity, err := s.repos.OAuth.FindIdentity(providerName, info.Subject)
+	if err == nil {
+		if err := s.repos.OAuth.TouchIdentityLogin(identity.ID, now); err != nil {
+			log.Printf("[OAuthService.resolveUser] Son giriş zamanı güncellenemedi (IdentityID: %d): %v", identity.ID, err)
+		}
+		return s.authService.GetUserByID(identity.UserID)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(info.Email))
+	if email == "" {
+		return nil, errors.New("kimlik sağlayıcı e-posta adresinizi paylaşmadı")
+	}
+
+	user, err := s.repos.Users.FindByEmail(email)
+	switch {
+	case err == nil:
+		// Aynı e-postayla kayıtlı hesap yalnızca sağlayıcı e-postayı doğruladıysa otomatik bağlanır;
+		// aksi halde başkası adına açılmış bir sağlayıcı hesabıyla hesap ele geçirilebilirdi
+		if !info.EmailVerified {
+			return nil, errors.New("bu e-posta adresiyle kayıtlı bir hesap var; giriş yapıp hesabınızı bağlayın")
+		}
+		if _, err := s.linkIdentity(user.ID, providerName, info); err != nil {
+			return nil, err
+		}
+		if !user.IsEmailVerified() {
+			if err := s.repos.Users.UpdateFields(user.ID, map[string]interface{}{"email_verified_at": now}); err != nil {
+				log.Printf("[OAuthService.resolveUser] E-posta doğrulandı olarak işaretlenemedi (UserID: %d): %v", user.ID, err)
+			}
+		}
+		return s.authService.GetUserByID(user.ID)
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	}
+
+	return s.registerUser(providerName, email, info)
+}
+
+// registerUser sağlayıcı bilgileriyle yeni bir kullanıcı ve bağlı kimlik oluşturur
+func (s *OAuthService) registerUser(providerName, email string, info *oauth.UserInfo) (*models.User, error) {
+	// Kullanıcı sosyal girişle kaydolduğu için şifre bilinmez; şifre sıfırlama ile belirlenebilir
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := s.authService.passwords.Hash(randomPassword)
+	if err != nil {
+		return nil, errors.New("şifre hashlenirken hata oluştu")
+	}
+
+	now := time.Now()
+	var user models.User
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		username, err := uniqueUsername(repos.Users, usernameCandidate(email, info))
+		if err != nil {
+			return err
+		}
+
+		user = models.User{
+			Username:          username,
+			Email:             email,
+			PasswordHash:      passwordHash,
+			FirstName:         info.GivenName,
+			LastName:          info.FamilyName,
+			ProfilePictureURL: info.Picture,
+		}
+		if info.EmailVerified {
+			user.EmailVerifiedAt = &now
+		}
+		if err := repos.Users.Create(&user); err != nil {
+			return err
+		}
+
+		return repos.OAuth.CreateIdentity(&models.UserIdentity{
+			UserID:      user.ID,
+			Provider:    providerName,
+			Subject:     info.Subject,
+			Email:       email,
+			CreatedAt:   now,
+			LastLoginAt: &now,
+		})
+	})
+	if err != nil {
+		log.Printf("[OAuthService.registerUser] Kullanıcı oluşturulamadı (Provider: %s): %v", providerName, err)
+		return nil, errors.New("kullanıcı oluşturulurken hata oluştu")
+	}
+
+	log.Printf("[OAuthService.registerUser] Sosyal girişle yeni kullanıcı (UserID: %d, Provider: %s)", user.ID, providerName)
+	return &user, nil
+}
+
+// linkIdentity sağlayıcı hesabını kullanıcıya bağlar; zaten bu kullanıcıya bağlıysa mevcut kaydı döndürür
+func (s *OAuthService) linkIdentity(userID uint64, providerName string, info *oauth.UserInfo) (*models.UserIdentity, error) {
+	now := time.Now()
+
+	existing, err := s.repos.OAuth.FindIdentity(providerName, info.Subject)
+	if err == nil {
+		if existing.UserID != userID {
+			return nil, ErrIdentityLinkedToOther
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	identity := models.UserIdentity{
+		UserID:      userID,
+		Provider:    providerName,
+		Subject:     info.Subject,
+		Email:       strings.ToLower(strings.TrimSpace(info.Email)),
+		CreatedAt:   now,
+		LastLoginAt: &now,
+	}
+	if err := s.repos.OAuth.CreateIdentity(&identity); err != nil {
+		return nil, errors.New("hesap bağlanamadı")
+	}
+	return &identity, nil
+}
+
+// usernameCandidate sağlayıcı bilgilerinden temizlenmiş bir kullanıcı adı önerisi üretir
+func usernameCandidate(email string, info *oauth.UserInfo) string {
+	candidates := []string{info.PreferredUsername, strings.SplitN(email, "@", 2)[0], info.Name}
+	for _, c := range candidates {
+		c = usernameCleaner.ReplaceAllString(strings.ToLower(strings.TrimSpace(c)), "")
+		c = strings.Trim(c, "._-")
+		if len(c) > 30 {
+			c = c[:30]
+		}
+		if len(c) >= 3 {
+			return c
+		}
+	}
+	return "user"
+}
+
+// uniqueUsername adayın kullanılmayan bir sürümünü bulur: aday, aday2, aday3... ve gerekirse rastgele ek
+func uniqueUsername(users repository.UserRepository, base string) (string, error) {
+	isTaken := users.UsernameTaken
+
+	for i := 1; i <= 20; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+		taken, err := isTaken(name)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return name, nil
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		suffix, err := utils.GenerateRandomToken(3)
+		if err != nil {
+			return "", err
+		}
+		name := base + "_" + suffix
+		taken, err := isTaken(name)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return name, nil
+		}
+	}
+	return "", errors.New("benzersiz kullanıcı adı üretilemedi")
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+	"event/backend/pkg/oauth"
+)
+
+// stubProvider her kodu aynı sağlayıcı kullanıcısıyla değiştiren sahte kimlik sağlayıcıdır
+type stubProvider struct {
+	info oauth.UserInfo
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) AuthCodeURL(_ context.Context, state, _, _ string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (p *stubProvider) Exchange(_ context.Context, _, _, _ string) (*oauth.UserInfo, error) {
+	info := p.info
+	return &info, nil
+}
+
+func TestOAuthLinkCallbackRequiresInitiatingUser(t *testing.T) {
+	env := testutil.New(t)
+	provider := &stubProvider{info: oauth.UserInfo{Subject: "attacker-subject", Email: "attacker@example.com", EmailVerified: true}}
+	svc := services.NewOAuthService(services.OAuthServiceInput{
+		Repositories: env.App.Repositories,
+		UnitOfWork:   repository.NewUnitOfWork(env.DB),
+		Config:       env.Config,
+		AuthService:  env.Services().Auth,
+		Providers:    map[string]oauth.Provider{"stub": provider},
+	})
+	ctx := context.Background()
+
+	owner := env.User()
+	victim := env.User()
+
+	start := func() string {
+		_, state, err := svc.StartAuthorization(ctx, "stub", &owner.ID)
+		if err != nil {
+			t.Fatalf("bağlama akışı başlatılamadı: %v", err)
+		}
+		return state
+	}
+
+	// Akışı başlatan kullanıcı dışında biri (veya anonim bir istek) state'i tamamlayamaz
+	for _, callerID := range []uint64{victim.ID, 0} {
+		_, err := svc.HandleCallback(ctx, "stub", "code", start(), callerID, services.ClientInfo{})
+		if !errors.Is(err, services.ErrOAuthLinkForbidden) {
+			t.Fatalf("kullanıcı %d için ErrOAuthLinkForbidden beklenirdi: %v", callerID, err)
+		}
+	}
+	var count int64
+	env.DB.Model(&models.UserIdentity{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("reddedilen akışlar kimlik bağlamamalı, %d kayıt var", count)
+	}
+
+	resp, err := svc.HandleCallback(ctx, "stub", "code", start(), owner.ID, services.ClientInfo{})
+	if err != nil {
+		t.Fatalf("akışı başlatan kullanıcı bağlayabilmeli: %v", err)
+	}
+	if !resp.Linked || resp.Identity == nil || resp.Identity.UserID != owner.ID {
+		t.Fatalf("kimlik akışı başlatan kullanıcıya bağlanmalı: %+v", resp)
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+	"event/backend/pkg/mailer"
+)
+
+// PasswordResetService şifremi unuttum / şifre sıfırlama akışını yönetir
+type PasswordResetService struct {
+	repos          repository.Repositories
+	uow            repository.UnitOfWork
+	config         *config.Config
+	mailer         mailer.Mailer
+	passwords      auth.PasswordHasher
+	passwordPolicy *auth.PasswordPolicy
+	sessions       *SessionService
+}
+
+// PasswordResetServiceInput, PasswordResetService için bağımlılıkları içerir.
+type PasswordResetServiceInput struct {
+	Repositories   repository.Repositories
+	UnitOfWork     repository.UnitOfWork
+	Config         *config.Config
+	Mailer         mailer.Mailer
+	PasswordHasher auth.PasswordHasher
+	PasswordPolicy *auth.PasswordPolicy
+	SessionService *SessionService
+}
+
+// NewPasswordResetService yeni bir PasswordResetService oluşturur
+func NewPasswordResetService(input PasswordResetServiceInput) *PasswordResetService {
+	return &PasswordResetService{
+		repos:          input.Repositories,
+		uow:            input.UnitOfWork,
+		config:         input.Config,
+		mailer:         input.Mailer,
+		passwords:      input.PasswordHasher,
+		passwordPolicy: input.PasswordPolicy,
+		sessions:       input.SessionService,
+	}
+}
+
+// ErrInvalidResetToken geçersiz, kullanılmış veya süresi dolmuş token için döner
+var ErrInvalidResetToken = errors.New("şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş")
+
+// RequestPasswordReset kullanıcıya şifre sıfırlama bağlantısı gönderir.
+// E-posta adresinin kayıtlı olup olmadığı dışarıya sızdırılmaz; kayıtlı değilse sessizce nil döner.
+func (s *PasswordResetService) RequestPasswordReset(email string) error {
+	user, err := s.repos.Users.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Printf("[PasswordResetService.RequestPasswordReset] Kayıtlı olmayan e-posta için istek: %s", email)
+			return nil
+		}
+		return err
+	}
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return errors.New("sıfırlama tokeni oluşturulamadı")
+	}
+
+	now := time.Now()
+	resetToken := models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: now.Add(s.config.PasswordResetTTL),
+		CreatedAt: now,
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		// Daha önce gönderilmiş ve kullanılmamış bağlantılar geçersiz kılınır; yalnızca son bağlantı çalışır
+		if err := repos.PasswordResets.InvalidateForUser(user.ID, now); err != nil {
+			return err
+		}
+		return repos.PasswordResets.Create(&resetToken)
+	})
+	if err != nil {
+		return errors.New("sıfırlama tokeni kaydedilemedi")
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(s.config.FrontendURL, "/"), rawToken)
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Şifre sıfırlama isteği",
+		Body: fmt.Sprintf(
+			"Merhaba %s,\n\nŞifrenizi sıfırlamak için aşağıdaki bağlantıyı kullanabilirsiniz:\n\n%s\n\n"+
+				"Bu bağlantı %s boyunca geçerlidir ve yalnızca bir kez kullanılabilir.\n"+
+				"Bu isteği siz yapmadıysanız bu e-postayı görmezden gelebilirsiniz.\n",
+			user.Username, link, s.config.PasswordResetTTL,
+		),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		log.Printf("[PasswordResetService.RequestPasswordReset] E-posta gönderilemedi (UserID: %d): %v", user.ID, err)
+		return errors.New("şifre sıfırlama e-postası gönderilemedi")
+	}
+
+	return nil
+}
+
+// ResetPassword token'ı doğrular ve kullanıcının şifresini değiştirir.
+// Token tek kullanımlıktır; başarılı sıfırlama kullanıcının tüm yenileme token'larını geçersiz kılar
+// ve tüm oturumlarını sonlandırır.
+func (s *PasswordResetService) ResetPassword(rawToken, newPassword string) error {
+	now := time.Now()
+
+	var revoked []models.Session
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		resetToken, err := repos.PasswordResets.FindByHash(utils.HashToken(rawToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrInvalidResetToken
+			}
+			return err
+		}
+		if !resetToken.IsUsable(now) {
+			return ErrInvalidResetToken
+		}
+
+		user, err := repos.Users.FindByID(resetToken.UserID)
+		if err != nil {
+			return ErrInvalidResetToken
+		}
+		// Politika hatasında işlem geri alınır ve token kullanılabilir kalır
+		if err := s.passwordPolicy.Validate(newPassword, user.Username, user.Email); err != nil {
+			return err
+		}
+		hashedPassword, err := s.passwords.Hash(newPassword)
+		if err != nil {
+			return errors.New("şifre hashlenirken hata oluştu")
+		}
+
+		// Koşullu güncelleme: aynı token ile eşzamanlı iki istekten yalnızca biri başarılı olur
+		used, err := repos.PasswordResets.MarkUsed(resetToken.ID, now)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidResetToken
+		}
+
+		if err := repos.Users.UpdateFields(resetToken.UserID, map[string]interface{}{
+			"password_hash":       hashedPassword,
+			"password_changed_at": now,
+		}); err != nil {
+			return errors.New("şifre güncellenemedi")
+		}
+
+		if err := repos.RefreshTokens.RevokeAllForUser(resetToken.UserID, now); err != nil {
+			return err
+		}
+		if revoked, err = s.sessions.revokeAllTx(repos, resetToken.UserID, ""); err != nil {
+			return err
+		}
+
+		log.Printf("[PasswordResetService.ResetPassword] Şifre sıfırlandı (UserID: %d)", resetToken.UserID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.sessions.disconnect(revoked)
+	return nil
+}
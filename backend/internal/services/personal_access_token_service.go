@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+)
+
+// lastUsedResolution son kullanım zamanının en fazla bu sıklıkla güncellenmesini sağlar;
+// her istekte veritabanına yazılmasını önler
+const lastUsedResolution = time.Minute
+
+// PersonalAccessTokenService kullanıcıların betikler için oluşturduğu kişisel erişim token'larını yönetir
+type PersonalAccessTokenService struct {
+	tokens repository.PersonalAccessTokenRepository
+	uow    repository.UnitOfWork
+	config *config.Config
+}
+
+// PersonalAccessTokenServiceInput, PersonalAccessTokenService için bağımlılıkları içerir.
+type PersonalAccessTokenServiceInput struct {
+	Tokens     repository.PersonalAccessTokenRepository
+	UnitOfWork repository.UnitOfWork
+	Config     *config.Config
+}
+
+// NewPersonalAccessTokenService yeni bir PersonalAccessTokenService oluşturur
+func NewPersonalAccessTokenService(input PersonalAccessTokenServiceInput) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		tokens: input.Tokens,
+		uow:    input.UnitOfWork,
+		config: input.Config,
+	}
+}
+
+// PersonalAccessTokenResponse token listesinde ve oluşturma yanıtında dönen bilgiler.
+// Token yalnızca oluşturulduğu yanıtta döner; daha sonra tekrar görüntülenemez.
+type PersonalAccessTokenResponse struct {
+	*models.PersonalAccessToken
+	Scopes []string `json:"scopes"`
+	Token  string   `json:"token,omitempty"`
+}
+
+var (
+	// ErrInvalidPersonalAccessToken bilinmeyen, iptal edilmiş veya süresi dolmuş token için döner
+	ErrInvalidPersonalAccessToken = errors.New("geçersiz, süresi dolmuş veya iptal edilmiş erişim tokeni")
+	// ErrPersonalAccessTokenNotFound kullanıcıya ait olmayan veya bulunmayan token için döner
+	ErrPersonalAccessTokenNotFound = errors.New("erişim tokeni bulunamadı")
+	// ErrPersonalAccessTokenLimit kullanıcının aktif token sayısı sınıra ulaştığında döner
+	ErrPersonalAccessTokenLimit = errors.New("aktif erişim tokeni sınırına ulaşıldı, kullanılmayan token'ları iptal edin")
+)
+
+// Create kullanıcı için yeni bir kişisel erişim tokeni oluşturur.
+// expiresAt nil ise yapılandırmadaki varsayılan süre kullanılır.
+func (s *PersonalAccessTokenService) Create(userID uint64, name string, scopes []string, expiresAt *time.Time) (*PersonalAccessTokenResponse, error) {
+	now := time.Now()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("token adı boş olamaz")
+	}
+	normalized, err := auth.NormalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := now.Add(s.config.PersonalAccessTokenDefaultTTL)
+	if expiresAt != nil {
+		expiry = *expiresAt
+	}
+	if !expiry.After(now) {
+		return nil, errors.New("son kullanma tarihi gelecekte olmalıdır")
+	}
+	if expiry.After(now.Add(s.config.PersonalAccessTokenMaxTTL)) {
+		return nil, errors.New("son kullanma tarihi izin verilen en uzun süreyi aşıyor")
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	rawToken := auth.PersonalAccessTokenPrefix + secret
+
+	token := models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenPrefix: rawToken[:len(auth.PersonalAccessTokenPrefix)+8],
+		TokenHash:   utils.HashToken(rawToken),
+		Scopes:      strings.Join(normalized, " "),
+		ExpiresAt:   expiry,
+		CreatedAt:   now,
+	}
+	// Sayım ve kayıt kullanıcı satırı kilitliyken yapılır; eşzamanlı istekler sınırı aşamaz
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if _, err := repos.Users.FindByIDForUpdate(userID); err != nil {
+			return err
+		}
+		active, err := repos.PersonalAccessTokens.CountActive(userID, now)
+		if err != nil {
+			return err
+		}
+		if int(active) >= s.config.MaxPersonalAccessTokens {
+			return ErrPersonalAccessTokenLimit
+		}
+		if err := repos.PersonalAccessTokens.Create(&token); err != nil {
+			return errors.New("erişim tokeni oluşturulamadı")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[PersonalAccessTokenService.Create] Token oluşturuldu (UserID: %d, TokenID: %d, Kapsamlar: %s)", userID, token.ID, token.Scopes)
+	return &PersonalAccessTokenResponse{PersonalAccessToken: &token, Scopes: normalized, Token: rawToken}, nil
+}
+
+// List kullanıcının token'larını en yeniden eskiye listeler
+func (s *PersonalAccessTokenService) List(userID uint64) ([]PersonalAccessTokenResponse, error) {
+	tokens, err := s.tokens.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]PersonalAccessTokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = PersonalAccessTokenResponse{PersonalAccessToken: &tokens[i], Scopes: tokens[i].ScopeList()}
+	}
+	return responses, nil
+}
+
+// Revoke kullanıcının token'ını iptal eder. Zaten iptal edilmiş token için hata dönmez.
+func (s *PersonalAccessTokenService) Revoke(userID, tokenID uint64) error {
+	token, err := s.tokens.FindForUser(tokenID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPersonalAccessTokenNotFound
+		}
+		return err
+	}
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.tokens.Revoke(token.ID, time.Now()); err != nil {
+		return errors.New("erişim tokeni iptal edilemedi")
+	}
+
+	log.Printf("[PersonalAccessTokenService.Revoke] Token iptal edildi (UserID: %d, TokenID: %d)", userID, token.ID)
+	return nil
+}
+
+// Authenticate ham token'ı doğrular ve sahibini yükleyerek döndürür. Son kullanım zamanı güncellenir.
+func (s *PersonalAccessTokenService) Authenticate(rawToken string) (*models.PersonalAccessToken, error) {
+	now := time.Now()
+
+	token, err := s.tokens.FindByHashWithUser(utils.HashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidPersonalAccessToken
+		}
+		return nil, err
+	}
+	// Silinmiş kullanıcının token'ı için Preload boş kullanıcı döndürür
+	if !token.IsActive(now) || token.User.ID == 0 {
+		return nil, ErrInvalidPersonalAccessToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		if err := s.tokens.TouchLastUsed(token.ID, now); err != nil {
+			log.Printf("[PersonalAccessTokenService.Authenticate] Son kullanım zamanı güncellenemedi (TokenID: %d): %v", token.ID, err)
+		} else {
+			token.LastUsedAt = &now
+		}
+	}
+
+	return token, nil
+}
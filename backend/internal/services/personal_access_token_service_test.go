@@ -0,0 +1,40 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func TestPersonalAccessTokenLimit(t *testing.T) {
+	env := testutil.New(t)
+	env.Config.MaxPersonalAccessTokens = 2
+	user := env.User()
+	tokens := env.Services().PersonalAccessTokens
+
+	created, err := tokens.Create(user.ID, "betik-1", []string{"rooms:read"}, nil)
+	if err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	if _, err := tokens.Create(user.ID, "betik-2", []string{"rooms:read"}, nil); err != nil {
+		t.Fatalf("token oluşturulamadı: %v", err)
+	}
+	if _, err := tokens.Create(user.ID, "betik-3", []string{"rooms:read"}, nil); !errors.Is(err, services.ErrPersonalAccessTokenLimit) {
+		t.Fatalf("ErrPersonalAccessTokenLimit beklenirdi: %v", err)
+	}
+
+	// İptal edilen token sınırdan düşer
+	if err := tokens.Revoke(user.ID, created.ID); err != nil {
+		t.Fatalf("token iptal edilemedi: %v", err)
+	}
+	if _, err := tokens.Create(user.ID, "betik-3", []string{"rooms:read"}, nil); err != nil {
+		t.Fatalf("iptalden sonra token oluşturulabilmeli: %v", err)
+	}
+
+	// Olmayan kullanıcı için satır kilidi alınamaz ve token oluşturulmaz
+	if _, err := tokens.Create(user.ID+100, "betik", []string{"rooms:read"}, nil); err == nil {
+		t.Fatal("olmayan kullanıcı için token oluşturulmamalı")
+	}
+}
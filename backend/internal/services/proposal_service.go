@@ -4,15 +4,18 @@ import (
 	"encoding/json"
 	"errors"
 	"event/backend/internal/models"
-	"event/backend/pkg/database"
+	"event/backend/internal/repository"
 )
 
 // ProposalService etkinlik önerileri/davetleri işlemlerini yöneten servis
-type ProposalService struct{}
+type ProposalService struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
 
 // NewProposalService yeni bir ProposalService örneği oluşturur
-func NewProposalService() *ProposalService {
-	return &ProposalService{}
+func NewProposalService(repos repository.Repositories, uow repository.UnitOfWork) *ProposalService {
+	return &ProposalService{repos: repos, uow: uow}
 }
 
 // CreateProposalDTO yeni öneri/davet oluşturma için veri transfer nesnesi
@@ -24,11 +27,8 @@ type CreateProposalDTO struct {
 
 // CreateProposal yeni bir öneri/davet oluşturur
 func (s *ProposalService) CreateProposal(suggesterID uint64, dto CreateProposalDTO) (*models.EventProposal, error) {
-	db := database.GetDB()
-
 	// Alıcı kullanıcının varlığını kontrol et
-	var recipient models.User
-	if err := db.First(&recipient, dto.RecipientUserID).Error; err != nil {
+	if _, err := s.repos.Users.FindByID(dto.RecipientUserID); err != nil {
 		return nil, errors.New("alıcı kullanıcı bulunamadı")
 	}
 
@@ -38,11 +38,15 @@ func (s *ProposalService) CreateProposal(suggesterID uint64, dto CreateProposalD
 	}
 
 	// Arkadaşlık veya oda üyeliği kontrolü
-	var friendship models.Friendship
-	var roomMember models.RoomMember
-	isFriend := db.Where("((requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)) AND status = 'accepted'",
-		suggesterID, dto.RecipientUserID, dto.RecipientUserID, suggesterID).First(&friendship).Error == nil
-	isRoomMember := db.Where("user_id = ? AND is_active = true", dto.RecipientUserID).First(&roomMember).Error == nil
+	isFriend, err := s.repos.Friendships.AreFriends(suggesterID, dto.RecipientUserID)
+	if err != nil {
+		return nil, err
+	}
+	// İki kullanıcının da aktif üyesi olduğu ortak bir oda olmalı
+	isRoomMember, err := s.repos.Rooms.ShareActiveRoom(suggesterID, dto.RecipientUserID)
+	if err != nil {
+		return nil, err
+	}
 
 	if !isFriend && !isRoomMember {
 		return nil, errors.New("sadece arkadaşlarınıza veya oda üyelerine öneri yapabilirsiniz")
@@ -50,8 +54,8 @@ func (s *ProposalService) CreateProposal(suggesterID uint64, dto CreateProposalD
 
 	// Etkinlik ID'si varsa, etkinliğin varlığını ve erişim yetkisini kontrol et
 	if dto.EventID != nil {
-		var event models.Event
-		if err := db.First(&event, dto.EventID).Error; err != nil {
+		event, err := s.repos.Events.FindByID(*dto.EventID)
+		if err != nil {
 			return nil, errors.New("etkinlik bulunamadı")
 		}
 
@@ -75,10 +79,10 @@ func (s *ProposalService) CreateProposal(suggesterID uint64, dto CreateProposalD
 		RecipientUserID:          dto.RecipientUserID,
 		EventID:                  dto.EventID,
 		ProposedEventDetailsJSON: proposedDetails,
-		Status:                   "pending",
+		Status:                   models.ProposalPending,
 	}
 
-	if err := db.Create(&proposal).Error; err != nil {
+	if err := s.repos.Proposals.Create(&proposal); err != nil {
 		return nil, err
 	}
 
@@ -87,41 +91,19 @@ func (s *ProposalService) CreateProposal(suggesterID uint64, dto CreateProposalD
 
 // GetIncomingProposals kullanıcıya gelen önerileri/davetleri listeler
 func (s *ProposalService) GetIncomingProposals(userID uint64) ([]models.EventProposal, error) {
-	db := database.GetDB()
-	var proposals []models.EventProposal
-
-	if err := db.Where("recipient_user_id = ?", userID).
-		Preload("Suggester").
-		Preload("Event").
-		Find(&proposals).Error; err != nil {
-		return nil, err
-	}
-
-	return proposals, nil
+	return s.repos.Proposals.FindIncoming(userID)
 }
 
 // GetOutgoingProposals kullanıcının gönderdiği önerileri/davetleri listeler
 func (s *ProposalService) GetOutgoingProposals(userID uint64) ([]models.EventProposal, error) {
-	db := database.GetDB()
-	var proposals []models.EventProposal
-
-	if err := db.Where("suggester_user_id = ?", userID).
-		Preload("Recipient").
-		Preload("Event").
-		Find(&proposals).Error; err != nil {
-		return nil, err
-	}
-
-	return proposals, nil
+	return s.repos.Proposals.FindOutgoing(userID)
 }
 
 // RespondToProposal öneriye cevap verir
 func (s *ProposalService) RespondToProposal(proposalID uint64, userID uint64, response string) error {
-	db := database.GetDB()
-
 	// Öneriyi bul
-	var proposal models.EventProposal
-	if err := db.First(&proposal, proposalID).Error; err != nil {
+	proposal, err := s.repos.Proposals.FindByID(proposalID)
+	if err != nil {
 		return errors.New("öneri bulunamadı")
 	}
 
@@ -141,17 +123,14 @@ func (s *ProposalService) RespondToProposal(proposalID uint64, userID uint64, re
 	}
 
 	// Öneriyi güncelle
-	proposal.Status = models.ProposalStatus(response)
-	return db.Save(&proposal).Error
+	return s.repos.Proposals.SetStatus(proposal.ID, models.ProposalStatus(response))
 }
 
 // CreateCounterProposal karşı öneri oluşturur
 func (s *ProposalService) CreateCounterProposal(proposalID uint64, userID uint64, counterEventDetails json.RawMessage) error {
-	db := database.GetDB()
-
 	// Orijinal öneriyi bul
-	var originalProposal models.EventProposal
-	if err := db.First(&originalProposal, proposalID).Error; err != nil {
+	originalProposal, err := s.repos.Proposals.FindByID(proposalID)
+	if err != nil {
 		return errors.New("öneri bulunamadı")
 	}
 
@@ -165,34 +144,22 @@ func (s *ProposalService) CreateCounterProposal(proposalID uint64, userID uint64
 		return errors.New("bu öneriye karşı öneri yapılamaz")
 	}
 
-	// Transaction başlat
-	tx := db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-
-	// Orijinal öneriyi güncelle
-	originalProposal.Status = "counter_proposed"
-	if err := tx.Save(&originalProposal).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// Karşı öneriyi oluştur
+	// Detaylar transaction başlamadan doğrulanır; hata halinde açık transaction kalmaz
 	var newDetails models.JSONB
 	if err := json.Unmarshal(counterEventDetails, &newDetails); err != nil {
 		return errors.New("karşı öneri detayları geçersiz JSON")
 	}
-	counterProposal := models.CounterProposal{
-		OriginalProposalID:  proposalID,
-		NewEventDetailsJSON: newDetails,
-	}
 
-	if err := tx.Create(&counterProposal).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// Orijinal öneriyi güncelle
+		if err := repos.Proposals.SetStatus(originalProposal.ID, models.ProposalCounterProposed); err != nil {
+			return err
+		}
 
-	// Transaction'ı tamamla
-	return tx.Commit().Error
+		// Karşı öneriyi oluştur
+		return repos.Proposals.CreateCounterProposal(&models.CounterProposal{
+			OriginalProposalID:  proposalID,
+			NewEventDetailsJSON: newDetails,
+		})
+	})
 }
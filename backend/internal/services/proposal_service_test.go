@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"event/backend/internal/models"
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func TestCreateProposalRequiresRelationship(t *testing.T) {
+	env := testutil.New(t)
+	proposals := env.Services().Proposals
+
+	suggester := env.User()
+	friend := env.User()
+	roomMate := env.User()
+	stranger := env.User()
+	env.Friends(suggester, friend)
+	env.Join(env.Room(suggester), roomMate)
+	// Yabancı başka bir odanın üyesi; ortak oda olmadığı için öneri alamaz
+	env.Room(stranger)
+
+	details := json.RawMessage(`{"title":"Kahve"}`)
+	for _, recipient := range []*models.User{friend, roomMate} {
+		proposal, err := proposals.CreateProposal(suggester.ID, services.CreateProposalDTO{
+			RecipientUserID:      recipient.ID,
+			ProposedEventDetails: &details,
+		})
+		if err != nil {
+			t.Fatalf("kullanıcı %d için öneri oluşturulamadı: %v", recipient.ID, err)
+		}
+		if proposal.Status != models.ProposalPending {
+			t.Fatalf("öneri durumu %q olmalı, %q", models.ProposalPending, proposal.Status)
+		}
+	}
+
+	if _, err := proposals.CreateProposal(suggester.ID, services.CreateProposalDTO{RecipientUserID: stranger.ID}); err == nil {
+		t.Fatal("arkadaş veya ortak oda üyesi olmayan kullanıcıya öneri yapılamamalı")
+	}
+	if _, err := proposals.CreateProposal(suggester.ID, services.CreateProposalDTO{RecipientUserID: suggester.ID}); err == nil {
+		t.Fatal("kullanıcı kendine öneri yapamamalı")
+	}
+}
+
+func TestCreateCounterProposal(t *testing.T) {
+	env := testutil.New(t)
+	proposals := env.Services().Proposals
+
+	suggester := env.User()
+	recipient := env.User()
+	env.Friends(suggester, recipient)
+	proposal := env.Proposal(suggester, recipient, models.JSONB{"title": "Sinema"})
+
+	counter := json.RawMessage(`{"title":"Sinema","location":"Kadıköy"}`)
+
+	if err := proposals.CreateCounterProposal(proposal.ID, suggester.ID, counter); err == nil {
+		t.Fatal("karşı öneriyi yalnızca alıcı yapabilmeli")
+	}
+
+	// Geçersiz JSON öneriyi değiştirmemeli ve açık transaction bırakmamalı
+	if err := proposals.CreateCounterProposal(proposal.ID, recipient.ID, json.RawMessage(`{bozuk`)); err == nil {
+		t.Fatal("geçersiz JSON reddedilmeli")
+	}
+	var stored models.EventProposal
+	env.DB.First(&stored, proposal.ID)
+	if stored.Status != models.ProposalPending {
+		t.Fatalf("geçersiz karşı öneriden sonra durum %q kalmalı, %q", models.ProposalPending, stored.Status)
+	}
+
+	if err := proposals.CreateCounterProposal(proposal.ID, recipient.ID, counter); err != nil {
+		t.Fatalf("karşı öneri oluşturulamadı: %v", err)
+	}
+
+	env.DB.First(&stored, proposal.ID)
+	if stored.Status != models.ProposalCounterProposed {
+		t.Fatalf("öneri durumu %q olmalı, %q", models.ProposalCounterProposed, stored.Status)
+	}
+	var counters []models.CounterProposal
+	env.DB.Where("original_proposal_id = ?", proposal.ID).Find(&counters)
+	if len(counters) != 1 {
+		t.Fatalf("tek karşı öneri olmalı, %d", len(counters))
+	}
+	if counters[0].NewEventDetailsJSON["location"] != "Kadıköy" {
+		t.Fatalf("karşı öneri detayları kaydedilmedi: %v", counters[0].NewEventDetailsJSON)
+	}
+
+	if err := proposals.CreateCounterProposal(proposal.ID, recipient.ID, counter); err == nil {
+		t.Fatal("bekleyen durumda olmayan öneriye karşı öneri yapılamamalı")
+	}
+}
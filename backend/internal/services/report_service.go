@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+)
+
+// maxReportReasonLength şikayet gerekçesinin en fazla karakter sayısı (Report.Reason sütunuyla aynı)
+const maxReportReasonLength = 500
+
+var (
+	// ErrReportTargetNotFound şikayet edilen içerik bulunamadığında döner
+	ErrReportTargetNotFound = errors.New("şikayet edilen içerik bulunamadı")
+	// ErrReportNotFound bulunmayan şikayet için döner
+	ErrReportNotFound = errors.New("şikayet bulunamadı")
+)
+
+// ReportService kullanıcıların kullanıcı, etkinlik, oda ve mesajları yöneticilere şikayet etmesini sağlar
+type ReportService struct {
+	reports repository.ReportRepository
+}
+
+// NewReportService yeni bir ReportService oluşturur
+func NewReportService(reports repository.ReportRepository) *ReportService {
+	return &ReportService{reports: reports}
+}
+
+// CreateReport yeni bir şikayet kaydeder. Aynı kullanıcının aynı içerik için açık bir şikayeti varsa
+// yeni kayıt oluşturulmaz, mevcut şikayet döner.
+func (s *ReportService) CreateReport(reporterID uint64, targetType models.ReportTargetType, targetID uint64, reason string) (*models.Report, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("şikayet gerekçesi boş olamaz")
+	}
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return nil, errors.New("şikayet gerekçesi en fazla 500 karakter olabilir")
+	}
+	if targetType == models.ReportTargetUser && targetID == reporterID {
+		return nil, errors.New("kendinizi şikayet edemezsiniz")
+	}
+	exists, err := s.reports.TargetExists(targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrReportTargetNotFound
+	}
+
+	existing, err := s.reports.FindOpen(reporterID, targetType, targetID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	report := models.Report{
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		Status:     models.ReportStatusOpen,
+	}
+	if err := s.reports.Create(&report); err != nil {
+		return nil, errors.New("şikayet kaydedilemedi")
+	}
+
+	log.Printf("[ReportService.CreateReport] Şikayet oluşturuldu (ReportID: %d, Hedef: %s/%d, Şikayet eden: %d)", report.ID, targetType, targetID, reporterID)
+	return &report, nil
+}
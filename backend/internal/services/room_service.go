@@ -1,34 +1,35 @@
 package services
 
 import (
-	"database/sql"
 	"errors"
 	"event/backend/internal/dtos"
 	"event/backend/internal/models"
+	"event/backend/internal/repository"
 	"fmt"
 	"log"
 	"strings"
 	"time"
-
-	"gorm.io/gorm"
 )
 
 // RoomServiceInput, RoomService oluşturmak için gereken bağımlılıkları tanımlar.
 type RoomServiceInput struct {
-	DB          *gorm.DB
-	UserService *UserService
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
+	UserService  UserFinder
 }
 
 // RoomService oda ile ilgili işlemleri yönetir
 type RoomService struct {
-	db          *gorm.DB
-	userService *UserService
+	repos       repository.Repositories
+	uow         repository.UnitOfWork
+	userService UserFinder
 }
 
 // NewRoomService yeni bir RoomService örneği oluşturur
 func NewRoomService(input RoomServiceInput) *RoomService {
 	return &RoomService{
-		db:          input.DB,
+		repos:       input.Repositories,
+		uow:         input.UnitOfWork,
 		userService: input.UserService,
 	}
 }
@@ -71,16 +72,6 @@ type RoomListItemDTO struct {
 
 // CreateRoom yeni bir oda oluşturur
 func (s *RoomService) CreateRoom(creatorID uint64, dto CreateRoomDTO) (*models.Room, error) {
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	room := models.Room{
 		Name:          dto.Name,
 		Description:   dto.Description,
@@ -88,25 +79,25 @@ func (s *RoomService) CreateRoom(creatorID uint64, dto CreateRoomDTO) (*models.R
 		CreatorUserID: creatorID,
 	}
 
-	if err := tx.Create(&room).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
-
-	member := models.RoomMember{
-		RoomID:   room.ID,
-		UserID:   creatorID,
-		Role:     "admin",
-		JoinedAt: time.Now(),
-		IsActive: true,
-	}
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Rooms.Create(&room); err != nil {
+			return err
+		}
 
-	if err := tx.Create(&member).Error; err != nil {
-		tx.Rollback()
+		member := models.RoomMember{
+			RoomID:   room.ID,
+			UserID:   creatorID,
+			Role:     "admin",
+			JoinedAt: time.Now(),
+			IsActive: true,
+		}
+		return repos.Rooms.AddMember(&member)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &room, tx.Commit().Error
+	return &room, nil
 }
 
 // CreateGroupChatRoom yeni bir grup sohbet odası oluşturur
@@ -150,17 +141,6 @@ func (s *RoomService) CreateGroupChatRoom(creatorID uint64, dto CreateGroupChatD
 	}
 	log.Printf("[CreateGroupChatRoom] Grup adı belirlendi: %s", roomName)
 
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		log.Printf("[CreateGroupChatRoom] Hata: Veritabanı transaction başlatılamadı: %v", tx.Error)
-		return nil, tx.Error
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	room := models.Room{
 		Name:          roomName,
 		CreatorUserID: creatorID,
@@ -169,55 +149,53 @@ func (s *RoomService) CreateGroupChatRoom(creatorID uint64, dto CreateGroupChatD
 		UpdatedAt:     time.Now(),
 	}
 
-	if err := tx.Create(&room).Error; err != nil {
-		tx.Rollback()
-		log.Printf("[CreateGroupChatRoom] Hata: Oda oluşturulamadı: %v", err)
-		return nil, err
-	}
-	log.Printf("[CreateGroupChatRoom] Oda başarıyla oluşturuldu: Ad: %s", room.Name)
-
-	// Üyeleri ekle
-	for memberID := range uniqueMemberIDsMap {
-		role := "member"
-		if memberID == creatorID {
-			role = "admin" // Oluşturan admin olsun
+	err := s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Rooms.Create(&room); err != nil {
+			log.Printf("[CreateGroupChatRoom] Hata: Oda oluşturulamadı: %v", err)
+			return err
 		}
-		roomMember := models.RoomMember{
-			RoomID:   room.ID,
-			UserID:   memberID,
-			Role:     role,
-			JoinedAt: time.Now(),
-			IsActive: true,
-		}
-		if err := tx.Create(&roomMember).Error; err != nil {
-			tx.Rollback()
-			log.Printf("[CreateGroupChatRoom] Hata: Oda üyesi eklenemedi (KullanıcıID: %d, OdaID: %d): %v", memberID, room.ID, err)
-			return nil, err
-		}
-		log.Printf("[CreateGroupChatRoom] Oda üyesi eklendi: KullanıcıID: %d, Rol: %s, OdaID: %d", memberID, role, room.ID)
-	}
+		log.Printf("[CreateGroupChatRoom] Oda başarıyla oluşturuldu: Ad: %s", room.Name)
 
-	if err := tx.Commit().Error; err != nil {
-		log.Printf("[CreateGroupChatRoom] Hata: Veritabanı transaction commit edilemedi: %v", err)
+		// Üyeleri ekle
+		for memberID := range uniqueMemberIDsMap {
+			role := "member"
+			if memberID == creatorID {
+				role = "admin" // Oluşturan admin olsun
+			}
+			roomMember := models.RoomMember{
+				RoomID:   room.ID,
+				UserID:   memberID,
+				Role:     role,
+				JoinedAt: time.Now(),
+				IsActive: true,
+			}
+			if err := repos.Rooms.AddMember(&roomMember); err != nil {
+				log.Printf("[CreateGroupChatRoom] Hata: Oda üyesi eklenemedi (KullanıcıID: %d, OdaID: %d): %v", memberID, room.ID, err)
+				return err
+			}
+			log.Printf("[CreateGroupChatRoom] Oda üyesi eklendi: KullanıcıID: %d, Rol: %s, OdaID: %d", memberID, role, room.ID)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	log.Printf("[CreateGroupChatRoom] Tamamlandı - OdaID: %d başarıyla oluşturuldu ve üyeler eklendi.", room.ID)
 
-	var createdRoomWithDetails models.Room
-	if err := s.db.Preload("Members.User").Preload("Creator").First(&createdRoomWithDetails, "id = ?", room.ID).Error; err != nil {
+	createdRoomWithDetails, err := s.repos.Rooms.FindByIDWithMemberUsers(room.ID)
+	if err != nil {
 		log.Printf("[CreateGroupChatRoom] Oda (ID: %d) oluşturulduktan sonra detayları (üyelerle) preload edilemedi: %v", room.ID, err)
 		return &room, nil
 	}
 	log.Printf("[CreateGroupChatRoom] Oda (ID: %d) detayları yüklendi, %d üye bulundu.", createdRoomWithDetails.ID, len(createdRoomWithDetails.Members))
-	return &createdRoomWithDetails, nil
+	return createdRoomWithDetails, nil
 }
 
 // GetPublicRooms herkese açık odaları listeler
 func (s *RoomService) GetPublicRooms() ([]RoomListItemDTO, error) {
-	var rooms []models.Room
-
-	if err := s.db.Preload("Creator").Where("is_public = ?", true).Order("created_at DESC").Find(&rooms).Error; err != nil {
+	rooms, err := s.repos.Rooms.FindPublic()
+	if err != nil {
 		return nil, err
 	}
 
@@ -225,16 +203,17 @@ func (s *RoomService) GetPublicRooms() ([]RoomListItemDTO, error) {
 
 	for _, room := range rooms {
 		log.Printf("[GetPublicRooms] Processing room ID: %d, Name: %s", room.ID, room.Name)
-		var membersCount int64
-		if err := s.db.Model(&models.RoomMember{}).Where("room_id = ? AND is_active = ?", room.ID, true).Count(&membersCount).Error; err != nil {
+		membersCount, err := s.repos.Rooms.CountActiveMembers(room.ID)
+		if err != nil {
 			log.Printf("[GetPublicRooms] Error counting members for room %d: %v", room.ID, err)
 			membersCount = 0
 		} else {
 			log.Printf("[GetPublicRooms] Fetched membersCount for room %d: %d", room.ID, membersCount)
 		}
 
-		var eventsCount int64
-		if err := s.db.Model(&models.Event{}).Where("room_id = ?", room.ID).Count(&eventsCount).Error; err != nil {
+		eventCounts, err := s.repos.Events.CountByRooms([]uint64{room.ID})
+		eventsCount := eventCounts[room.ID]
+		if err != nil {
 			log.Printf("[GetPublicRooms] Error counting events for room %d: %v", room.ID, err)
 			eventsCount = 0
 		} else {
@@ -269,11 +248,10 @@ func (s *RoomService) GetPublicRooms() ([]RoomListItemDTO, error) {
 // userID, odaya erişmeye çalışan kullanıcının ID'sidir.
 func (s *RoomService) GetRoomByID(roomID uint64, userID uint64) (*models.Room, error) {
 	log.Printf("[GetRoomByID] Başladı - OdaID: %d, KullanıcıID: %d", roomID, userID)
-	var room models.Room
-
 	log.Printf("[GetRoomByID] Oda bilgileri ve üyeler çekiliyor (Preload Members) - OdaID: %d", roomID)
-	if err := s.db.Preload("Members").Preload("Creator").First(&room, "id = ?", roomID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	room, err := s.repos.Rooms.FindByIDWithMembers(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("[GetRoomByID] Oda bulunamadı - OdaID: %d", roomID)
 			return nil, errors.New("oda bulunamadı")
 		}
@@ -294,8 +272,7 @@ func (s *RoomService) GetRoomByID(roomID uint64, userID uint64) (*models.Room, e
 		}
 		if !isMember {
 			log.Printf("[GetRoomByID] Kullanıcı (ID: %d) preload edilen üyeler arasında bulunamadı veya aktif değil. Veritabanından ek kontrol yapılıyor.", userID)
-			var memberCheck models.RoomMember
-			if err := s.db.Where("room_id = ? AND user_id = ? AND is_active = ?", roomID, userID, true).First(&memberCheck).Error; err != nil {
+			if _, err := s.repos.Rooms.FindActiveMember(roomID, userID); err != nil {
 				log.Printf("[GetRoomByID] Özel oda erişim kontrolü hatası (kullanıcı üye değil, aktif değil veya DB hatası): %v", err)
 				return nil, errors.New("bu odaya erişim yetkiniz yok veya oda bulunamadı")
 			}
@@ -304,20 +281,19 @@ func (s *RoomService) GetRoomByID(roomID uint64, userID uint64) (*models.Room, e
 	}
 
 	log.Printf("[GetRoomByID] Tamamlandı - OdaID: %d döndürülüyor.", roomID)
-	return &room, nil
+	return room, nil
 }
 
 // UpdateRoom oda bilgilerini günceller
 func (s *RoomService) UpdateRoom(roomID uint64, userID uint64, dto UpdateRoomDTO) (*models.Room, error) {
-	var member models.RoomMember
-	if err := s.db.Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, userID, "admin", true).First(&member).Error; err != nil {
+	if isAdmin, err := s.repos.Rooms.IsActiveAdmin(roomID, userID); err != nil || !isAdmin {
 		log.Printf("[UpdateRoom] Yetki hatası: Kullanıcı %d, Oda %d için admin değil veya üye değil. Hata: %v", userID, roomID, err)
 		return nil, errors.New("bu işlem için yetkiniz yok veya oda üyesi değilsiniz")
 	}
 
-	var room models.Room
-	if err := s.db.First(&room, "id = ?", roomID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	room, err := s.repos.Rooms.FindByID(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("[UpdateRoom] Oda bulunamadı: ID %d", roomID)
 			return nil, errors.New("oda bulunamadı")
 		}
@@ -342,23 +318,23 @@ func (s *RoomService) UpdateRoom(roomID uint64, userID uint64, dto UpdateRoomDTO
 
 	if !needsUpdate {
 		log.Printf("[UpdateRoom] Güncellenecek bir değişiklik yok. Oda: %d", roomID)
-		return &room, nil
+		return room, nil
 	}
 	updates["updated_at"] = time.Now()
 
-	if err := s.db.Model(&room).Updates(updates).Error; err != nil {
+	if err := s.repos.Rooms.UpdateFields(roomID, updates); err != nil {
 		log.Printf("[UpdateRoom] Oda güncelleme hatası (OdaID: %d): %v", roomID, err)
 		return nil, err
 	}
 	log.Printf("[UpdateRoom] Oda başarıyla güncellendi: ID %d", roomID)
-	return &room, nil
+	return s.repos.Rooms.FindByID(roomID)
 }
 
 // DeleteRoom odayı siler
 func (s *RoomService) DeleteRoom(roomID uint64, userID uint64) error {
-	var room models.Room
-	if err := s.db.Select("creator_user_id").First(&room, roomID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	room, err := s.repos.Rooms.FindByID(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("[DeleteRoom] Oda bulunamadı: ID %d", roomID)
 			return errors.New("oda bulunamadı")
 		}
@@ -368,21 +344,19 @@ func (s *RoomService) DeleteRoom(roomID uint64, userID uint64) error {
 
 	isCreator := room.CreatorUserID == userID
 
-	var member models.RoomMember
 	if !isCreator {
-		if err := s.db.Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, userID, "admin", true).First(&member).Error; err == nil {
-			isAdmin := true
-			if !isCreator && !isAdmin {
-				log.Printf("[DeleteRoom] Yetkisiz silme denemesi: Kullanıcı %d, Oda %d. Kurucu değil ve admin değil.", userID, roomID)
-				return errors.New("bu odayı silme yetkiniz yok")
-			}
-		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		isAdmin, err := s.repos.Rooms.IsActiveAdmin(roomID, userID)
+		if err != nil {
 			log.Printf("[DeleteRoom] Admin kontrolü sırasında DB hatası (OdaID: %d, KullanıcıID: %d): %v", roomID, userID, err)
 			return errors.New("oda silinirken bir hata oluştu")
 		}
+		if !isAdmin {
+			log.Printf("[DeleteRoom] Yetkisiz silme denemesi: Kullanıcı %d, Oda %d. Kurucu değil ve admin değil.", userID, roomID)
+			return errors.New("bu odayı silme yetkiniz yok")
+		}
 	}
 
-	if err := s.db.Delete(&models.Room{}, roomID).Error; err != nil {
+	if err := s.repos.Rooms.Delete(roomID); err != nil {
 		log.Printf("[DeleteRoom] Oda silinirken hata (OdaID: %d): %v", roomID, err)
 		return errors.New("oda silinirken bir hata oluştu")
 	}
@@ -394,9 +368,9 @@ func (s *RoomService) DeleteRoom(roomID uint64, userID uint64) error {
 // AddRoomMember odaya yeni üye ekler
 func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requesterID uint64) error {
 	// Oda bilgilerini al
-	var room models.Room
-	if err := s.db.First(&room, roomID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	room, err := s.repos.Rooms.FindByID(roomID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("[AddRoomMember] Oda bulunamadı: ID %d", roomID)
 			return errors.New("oda bulunamadı")
 		}
@@ -409,8 +383,7 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 
 	if !room.IsPublic {
 		// Oda herkese açık değilse, sadece adminler üye ekleyebilir.
-		var adminMember models.RoomMember
-		if err := s.db.Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, requesterID, "admin", true).First(&adminMember).Error; err != nil {
+		if isAdmin, err := s.repos.Rooms.IsActiveAdmin(roomID, requesterID); err != nil || !isAdmin {
 			log.Printf("[AddRoomMember] Yetki hatası (Özel Oda): Kullanıcı %d, Oda %d için admin değil. Hata: %v", requesterID, roomID, err)
 			return errors.New("özel odalara sadece yöneticiler üye ekleyebilir")
 		}
@@ -419,8 +392,7 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 		// Bu, bir adminin herkese açık bir odaya başkasını eklemesi senaryosunu kapsar.
 		// Herkesin başkasını eklemesini engeller.
 		if !isJoiningSelf {
-			var adminMember models.RoomMember
-			if err := s.db.Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, requesterID, "admin", true).First(&adminMember).Error; err != nil {
+			if isAdmin, err := s.repos.Rooms.IsActiveAdmin(roomID, requesterID); err != nil || !isAdmin {
 				log.Printf("[AddRoomMember] Yetki hatası (Herkese Açık Oda - Başkasını Ekleme): Kullanıcı %d, Oda %d için admin değil. Hata: %v", requesterID, roomID, err)
 				return errors.New("bir odaya başka bir üyeyi sadece yöneticiler ekleyebilir")
 			}
@@ -428,8 +400,7 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 		// Eğer kullanıcı kendini ekliyorsa (isJoiningSelf == true), herkese açık odada yetki kontrolüne gerek yok.
 	}
 
-	var existingMember models.RoomMember
-	err := s.db.Where("room_id = ? AND user_id = ?", roomID, userIDToAdd).First(&existingMember).Error
+	existingMember, err := s.repos.Rooms.FindMember(roomID, userIDToAdd)
 	if err == nil {
 		if existingMember.IsActive {
 			log.Printf("[AddRoomMember] Kullanıcı %d zaten Oda %d üyesi ve aktif.", userIDToAdd, roomID)
@@ -438,13 +409,13 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 		existingMember.IsActive = true
 		existingMember.Role = "member"
 		existingMember.JoinedAt = time.Now()
-		if err := s.db.Save(&existingMember).Error; err != nil {
+		if err := s.repos.Rooms.SaveMember(existingMember); err != nil {
 			log.Printf("[AddRoomMember] Mevcut pasif üye aktif edilirken hata (OdaID: %d, KullanıcıID: %d): %v", roomID, userIDToAdd, err)
 			return errors.New("üyeyi odaya eklerken bir hata oluştu")
 		}
 		log.Printf("[AddRoomMember] Mevcut pasif üye %d, Oda %d için yeniden aktif edildi.", userIDToAdd, roomID)
 		return nil
-	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+	} else if !errors.Is(err, repository.ErrNotFound) {
 		log.Printf("[AddRoomMember] Mevcut üye kontrolü sırasında DB hatası (OdaID: %d, KullanıcıID: %d): %v", roomID, userIDToAdd, err)
 		return errors.New("üyeyi odaya eklerken bir hata oluştu")
 	}
@@ -457,7 +428,7 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 		IsActive: true,
 	}
 
-	if err := s.db.Create(&newMember).Error; err != nil {
+	if err := s.repos.Rooms.AddMember(&newMember); err != nil {
 		log.Printf("[AddRoomMember] Yeni üye oluşturulurken hata (OdaID: %d, KullanıcıID: %d): %v", roomID, userIDToAdd, err)
 		return errors.New("kullanıcıyı odaya eklerken bir hata oluştu")
 	}
@@ -469,16 +440,15 @@ func (s *RoomService) AddRoomMember(roomID uint64, userIDToAdd uint64, requester
 // RemoveRoomMember odadan üye çıkarır (soft delete yapar)
 func (s *RoomService) RemoveRoomMember(roomID uint64, memberID uint64, removerID uint64) error {
 	if removerID != memberID {
-		var adminMember models.RoomMember
-		if err := s.db.Where("room_id = ? AND user_id = ? AND role = ? AND is_active = ?", roomID, removerID, "admin", true).First(&adminMember).Error; err != nil {
+		if isAdmin, err := s.repos.Rooms.IsActiveAdmin(roomID, removerID); err != nil || !isAdmin {
 			log.Printf("[RemoveRoomMember] Yetki hatası: Kullanıcı %d, Oda %d için admin değil veya üye değil. Hata: %v", removerID, roomID, err)
 			return errors.New("bu işlem için yetkiniz yok")
 		}
 	}
 
-	var memberToRemove models.RoomMember
-	if err := s.db.Where("room_id = ? AND user_id = ? AND is_active = ?", roomID, memberID, true).First(&memberToRemove).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	memberToRemove, err := s.repos.Rooms.FindActiveMember(roomID, memberID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("[RemoveRoomMember] Çıkarılacak aktif üye bulunamadı: Kullanıcı %d, Oda %d", memberID, roomID)
 			return errors.New("çıkarılacak aktif üye bulunamadı")
 		}
@@ -487,8 +457,11 @@ func (s *RoomService) RemoveRoomMember(roomID uint64, memberID uint64, removerID
 	}
 
 	if memberToRemove.Role == "admin" && removerID == memberID {
-		var adminCount int64
-		s.db.Model(&models.RoomMember{}).Where("room_id = ? AND role = ? AND is_active = ?", roomID, "admin", true).Count(&adminCount)
+		adminCount, err := s.repos.Rooms.CountActiveAdmins(roomID)
+		if err != nil {
+			log.Printf("[RemoveRoomMember] Admin sayısı alınırken DB hatası (OdaID: %d): %v", roomID, err)
+			return errors.New("kullanıcıyı odadan çıkarırken bir hata oluştu")
+		}
 		if adminCount <= 1 {
 			log.Printf("[RemoveRoomMember] Engellendi: Son admin kendini odadan (OdaID: %d) çıkaramaz.", roomID)
 			return errors.New("son admin kendini odadan çıkaramaz")
@@ -496,7 +469,7 @@ func (s *RoomService) RemoveRoomMember(roomID uint64, memberID uint64, removerID
 	}
 
 	memberToRemove.IsActive = false
-	if err := s.db.Save(&memberToRemove).Error; err != nil {
+	if err := s.repos.Rooms.SaveMember(memberToRemove); err != nil {
 		log.Printf("[RemoveRoomMember] Üyelik pasif yapılırken hata (OdaID: %d, KullanıcıID: %d): %v", roomID, memberID, err)
 		return errors.New("kullanıcıyı odadan çıkarırken bir hata oluştu")
 	}
@@ -509,24 +482,18 @@ func (s *RoomService) RemoveRoomMember(roomID uint64, memberID uint64, removerID
 // TODO: Bu fonksiyonun DTO döndürmesi daha iyi olabilir (RoomListItemDTO gibi)
 // Şu anda direkt model döndürüyor.
 func (s *RoomService) GetUserRooms(userID uint64) ([]models.Room, error) {
-	var rooms []models.Room
-
-	var activeMemberships []models.RoomMember
-	if err := s.db.Where("user_id = ? AND is_active = ?", userID, true).Find(&activeMemberships).Error; err != nil {
+	roomIDs, err := s.repos.Rooms.ActiveRoomIDs(userID)
+	if err != nil {
 		log.Printf("[GetUserRooms] Kullanıcı %d için aktif üyelikler alınırken hata: %v", userID, err)
 		return nil, err
 	}
 
-	if len(activeMemberships) == 0 {
+	if len(roomIDs) == 0 {
 		return []models.Room{}, nil
 	}
 
-	var roomIDs []uint64
-	for _, membership := range activeMemberships {
-		roomIDs = append(roomIDs, membership.RoomID)
-	}
-
-	if err := s.db.Preload("Creator").Preload("Members.User", "is_active = ?", true).Where("id IN ?", roomIDs).Order("updated_at DESC").Find(&rooms).Error; err != nil {
+	rooms, err := s.repos.Rooms.FindWithActiveMembers(roomIDs)
+	if err != nil {
 		log.Printf("[GetUserRooms] Kullanıcı %d için odalar (ID'ler: %v) çekilirken hata: %v", userID, roomIDs, err)
 		return nil, err
 	}
@@ -537,8 +504,8 @@ func (s *RoomService) GetUserRooms(userID uint64) ([]models.Room, error) {
 
 // GetRoomMembers bir odanın aktif üyelerinin kullanıcı bilgilerini döndürür.
 func (s *RoomService) GetRoomMembers(roomID uint64) ([]models.User, error) {
-	var members []models.RoomMember
-	if err := s.db.Preload("User").Where("room_id = ? AND is_active = ?", roomID, true).Find(&members).Error; err != nil {
+	members, err := s.repos.Rooms.FindActiveMembersWithUsers(roomID)
+	if err != nil {
 		log.Printf("[GetRoomMembers] Oda %d için üyeler çekilirken hata: %v", roomID, err)
 		return nil, err
 	}
@@ -572,32 +539,21 @@ func (s *RoomService) GetOrCreateDMRoom(userID1, userID2 uint64) (*models.Room,
 
 	roomName := fmt.Sprintf("DM_%d_%d", minUserID, maxUserID)
 
-	var room models.Room
-	err := s.db.Where("name = ? AND is_public = ?", roomName, false).First(&room).Error
+	room, err := s.repos.Rooms.FindPrivateByName(roomName)
 	if err == nil {
 		log.Printf("[GetOrCreateDMRoom] Mevcut DM odası bulundu: ID %d, Ad: %s", room.ID, room.Name)
-		if errLoad := s.db.Preload("Members.User").Preload("Creator").First(&room, room.ID).Error; errLoad != nil {
+		roomWithDetails, errLoad := s.repos.Rooms.FindByIDWithMemberUsers(room.ID)
+		if errLoad != nil {
 			log.Printf("[GetOrCreateDMRoom] Mevcut DM odası (ID: %d) detayları yüklenemedi: %v", room.ID, errLoad)
 			return nil, fmt.Errorf("mevcut DM odası detayları yüklenemedi: %w", errLoad)
 		}
-		return &room, nil
-	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return roomWithDetails, nil
+	} else if !errors.Is(err, repository.ErrNotFound) {
 		log.Printf("[GetOrCreateDMRoom] Mevcut DM odası aranırken DB hatası: %v", err)
 		return nil, fmt.Errorf("mevcut DM odası aranırken DB hatası: %w", err)
 	}
 
 	log.Printf("[GetOrCreateDMRoom] Mevcut DM odası bulunamadı, yeni bir tane oluşturuluyor: %s", roomName)
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		log.Printf("[GetOrCreateDMRoom] Hata: Veritabanı transaction başlatılamadı: %v", tx.Error)
-		return nil, tx.Error
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	newRoom := models.Room{
 		Name:          roomName,
 		Description:   fmt.Sprintf("Direkt Mesaj: Kullanıcı %d ve Kullanıcı %d", minUserID, maxUserID),
@@ -607,98 +563,47 @@ func (s *RoomService) GetOrCreateDMRoom(userID1, userID2 uint64) (*models.Room,
 		UpdatedAt:     time.Now(),
 	}
 
-	if err := tx.Create(&newRoom).Error; err != nil {
-		tx.Rollback()
-		log.Printf("[GetOrCreateDMRoom] Hata: Yeni DM odası oluşturulamadı: %v", err)
-		return nil, fmt.Errorf("yeni DM odası oluşturulamadı: %w", err)
-	}
-	log.Printf("[GetOrCreateDMRoom] Yeni DM odası başarıyla oluşturuldu: ID %d, Ad: %s", newRoom.ID, newRoom.Name)
-
-	// İki kullanıcıyı da üye olarak ekle
-	membersToCreate := []models.RoomMember{
-		{RoomID: newRoom.ID, UserID: minUserID, Role: "member", JoinedAt: time.Now(), IsActive: true},
-		{RoomID: newRoom.ID, UserID: maxUserID, Role: "member", JoinedAt: time.Now(), IsActive: true},
-	}
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Rooms.Create(&newRoom); err != nil {
+			log.Printf("[GetOrCreateDMRoom] Hata: Yeni DM odası oluşturulamadı: %v", err)
+			return fmt.Errorf("yeni DM odası oluşturulamadı: %w", err)
+		}
+		log.Printf("[GetOrCreateDMRoom] Yeni DM odası başarıyla oluşturuldu: ID %d, Ad: %s", newRoom.ID, newRoom.Name)
 
-	for _, member := range membersToCreate {
-		if err := tx.Create(&member).Error; err != nil {
-			tx.Rollback()
-			log.Printf("[GetOrCreateDMRoom] Hata: DM odasına üye eklenemedi (KullanıcıID: %d, OdaID: %d): %v", member.UserID, newRoom.ID, err)
-			return nil, fmt.Errorf("DM odasına üye eklenemedi: %w", err)
+		// İki kullanıcıyı da üye olarak ekle
+		membersToCreate := []models.RoomMember{
+			{RoomID: newRoom.ID, UserID: minUserID, Role: "member", JoinedAt: time.Now(), IsActive: true},
+			{RoomID: newRoom.ID, UserID: maxUserID, Role: "member", JoinedAt: time.Now(), IsActive: true},
 		}
-		log.Printf("[GetOrCreateDMRoom] DM odasına üye eklendi: KullanıcıID: %d, OdaID: %d", member.UserID, newRoom.ID)
-	}
 
-	if err := tx.Commit().Error; err != nil {
-		log.Printf("[GetOrCreateDMRoom] Hata: Veritabanı transaction commit edilemedi: %v", err)
-		return nil, fmt.Errorf("veritabanı transaction commit edilemedi: %w", err)
+		for _, member := range membersToCreate {
+			if err := repos.Rooms.AddMember(&member); err != nil {
+				log.Printf("[GetOrCreateDMRoom] Hata: DM odasına üye eklenemedi (KullanıcıID: %d, OdaID: %d): %v", member.UserID, newRoom.ID, err)
+				return fmt.Errorf("DM odasına üye eklenemedi: %w", err)
+			}
+			log.Printf("[GetOrCreateDMRoom] DM odasına üye eklendi: KullanıcıID: %d, OdaID: %d", member.UserID, newRoom.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	log.Printf("[GetOrCreateDMRoom] Tamamlandı - Yeni DM OdaID: %d başarıyla oluşturuldu ve üyeler eklendi.", newRoom.ID)
 
-	var createdRoomWithDetails models.Room
-	if err := s.db.Preload("Members.User").Preload("Creator").First(&createdRoomWithDetails, newRoom.ID).Error; err != nil {
+	createdRoomWithDetails, err := s.repos.Rooms.FindByIDWithMemberUsers(newRoom.ID)
+	if err != nil {
 		log.Printf("[GetOrCreateDMRoom] Yeni DM odası (ID: %d) oluşturulduktan sonra detayları yüklenemedi: %v", newRoom.ID, err)
 		return &newRoom, nil
 	}
-	return &createdRoomWithDetails, nil
+	return createdRoomWithDetails, nil
 }
 
 // GetUserConversations, belirli bir kullanıcının tüm sohbetlerini,
 // en son mesaj ve diğer kullanıcı bilgileriyle birlikte getirir.
 func (s *RoomService) GetUserConversations(currentUserID uint64) ([]dtos.ConversationRoomDTO, error) {
-	const query = `
-		SELECT
-			r.id,
-			r.name,
-			CASE WHEN r.name LIKE 'DM_%' AND mc.member_count = 2 THEN 1 ELSE 0 END AS is_dm,
-			other_user.user_id AS other_user_id,
-			ou.first_name AS other_user_first_name,
-			ou.last_name AS other_user_last_name,
-			ou.profile_picture_url AS other_user_avatar_url,
-			lm.content AS last_message_content,
-			lm.created_at AS last_message_timestamp,
-			CONCAT(sender.first_name, ' ', sender.last_name) AS last_message_sender_name
-		FROM rooms r
-		JOIN room_members current_rm ON r.id = current_rm.room_id AND current_rm.user_id = ? AND current_rm.is_active = 1
-		LEFT JOIN (
-			SELECT room_id, COUNT(*) AS member_count
-			FROM room_members
-			WHERE is_active = 1
-			GROUP BY room_id
-		) mc ON r.id = mc.room_id
-		LEFT JOIN room_members other_user ON r.name LIKE 'DM_%' AND r.id = other_user.room_id AND other_user.user_id != ? AND other_user.is_active = 1
-		LEFT JOIN (
-			SELECT 
-				m.room_id,
-				m.content,
-				m.created_at,
-				m.sender_id,
-				ROW_NUMBER() OVER(PARTITION BY m.room_id ORDER BY m.created_at DESC) as rn
-			FROM messages m
-			WHERE m.deleted_at IS NULL
-		) lm ON r.id = lm.room_id AND lm.rn = 1
-		LEFT JOIN users sender ON lm.sender_id = sender.id
-		LEFT JOIN users ou ON other_user.user_id = ou.id
-		WHERE r.deleted_at IS NULL
-		ORDER BY lm.created_at IS NULL, lm.created_at DESC;
-    `
-
-	type QueryResult struct {
-		ID                    uint64
-		Name                  string
-		IsDM                  bool
-		OtherUserID           sql.NullInt64
-		OtherUserFirstName    sql.NullString
-		OtherUserLastName     sql.NullString
-		OtherUserAvatarURL    sql.NullString
-		LastMessageContent    sql.NullString
-		LastMessageTimestamp  sql.NullTime
-		LastMessageSenderName sql.NullString
-	}
-
-	var results []QueryResult
-	if err := s.db.Raw(query, currentUserID, currentUserID).Scan(&results).Error; err != nil {
+	results, err := s.repos.Rooms.FindConversations(currentUserID)
+	if err != nil {
 		log.Printf("Error getting user conversations for user %d: %v", currentUserID, err)
 		return nil, err
 	}
@@ -725,7 +630,7 @@ func (s *RoomService) GetUserConversations(currentUserID uint64) ([]dtos.Convers
 			convo.LastMessage = &dtos.LastMessageDTO{
 				Content:    res.LastMessageContent.String,
 				Timestamp:  res.LastMessageTimestamp.Time,
-				SenderName: res.LastMessageSenderName.String,
+				SenderName: strings.TrimSpace(res.LastMessageSenderFirstName.String + " " + res.LastMessageSenderLastName.String),
 			}
 		}
 		conversations = append(conversations, convo)
@@ -736,38 +641,13 @@ func (s *RoomService) GetUserConversations(currentUserID uint64) ([]dtos.Convers
 
 // IsUserMemberOfRoom, bir kullanıcının belirtilen odanın aktif bir üyesi olup olmadığını kontrol eder.
 func (s *RoomService) IsUserMemberOfRoom(userID, roomID uint64) (bool, error) {
-	var count int64
-	err := s.db.Model(&models.RoomMember{}).Where("user_id = ? AND room_id = ? AND is_active = ?", userID, roomID, true).Count(&count).Error
-	if err != nil {
-		return false, err
-	}
-	return count > 0, nil
+	return s.repos.Rooms.IsActiveMember(roomID, userID)
 }
 
 // GetRoomsByFilter odaları sağlanan filtreye göre listeler
 func (s *RoomService) GetRoomsByFilter(filter string, userID uint64) ([]RoomListItemDTO, error) {
-	var rooms []models.Room
-	query := s.db.Preload("Creator")
-
-	switch filter {
-	case "public":
-		query = query.Where("is_public = ?", true)
-	case "private":
-		query = query.Joins("JOIN room_members ON room_members.room_id = rooms.id").
-			Where("rooms.is_public = ? AND room_members.user_id = ? AND room_members.is_active = ?", false, userID, true)
-	case "mine":
-		query = query.Where("creator_user_id = ?", userID)
-	case "member":
-		query = query.Joins("JOIN room_members ON room_members.room_id = rooms.id").
-			Where("room_members.user_id = ? AND rooms.creator_user_id != ? AND room_members.is_active = ?", userID, userID, true)
-	case "all":
-		fallthrough
-	default:
-		query = query.Joins("LEFT JOIN room_members ON room_members.room_id = rooms.id AND room_members.user_id = ? AND room_members.is_active = ?", userID, true).
-			Where("rooms.is_public = ? OR room_members.user_id = ?", true, userID)
-	}
-
-	if err := query.Distinct().Order("created_at DESC").Find(&rooms).Error; err != nil {
+	rooms, err := s.repos.Rooms.FindByFilter(filter, userID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -776,44 +656,23 @@ func (s *RoomService) GetRoomsByFilter(filter string, userID uint64) ([]RoomList
 		roomIDs[i] = room.ID
 	}
 
-	memberCountMap := make(map[uint64]int64)
-	if len(roomIDs) > 0 {
-		var memberCounts []struct {
-			RoomID uint64
-			Count  int64
-		}
-		s.db.Model(&models.RoomMember{}).
-			Select("room_id, count(*) as count").
-			Where("room_id IN ? AND is_active = ?", roomIDs, true).
-			Group("room_id").
-			Find(&memberCounts)
-		for _, mc := range memberCounts {
-			memberCountMap[mc.RoomID] = mc.Count
-		}
+	// Sayımlar liste için yardımcı bilgidir; alınamazsa sıfır gösterilir
+	memberCountMap, err := s.repos.Rooms.ActiveMemberCounts(roomIDs)
+	if err != nil {
+		log.Printf("[GetRoomsByFilter] Üye sayıları alınamadı: %v", err)
 	}
 
-	eventCountMap := make(map[uint64]int64)
-	if len(roomIDs) > 0 {
-		var eventCounts []struct {
-			RoomID uint64
-			Count  int64
-		}
-		s.db.Model(&models.Event{}).
-			Select("room_id, count(*) as count").
-			Where("room_id IN ?", roomIDs).
-			Group("room_id").
-			Find(&eventCounts)
-		for _, ec := range eventCounts {
-			eventCountMap[ec.RoomID] = ec.Count
-		}
+	eventCountMap, err := s.repos.Events.CountByRooms(roomIDs)
+	if err != nil {
+		log.Printf("[GetRoomsByFilter] Etkinlik sayıları alınamadı: %v", err)
 	}
 
 	userMemberMap := make(map[uint64]bool)
 	if userID > 0 && len(roomIDs) > 0 {
-		var userMemberOfRoomIDs []uint64
-		s.db.Model(&models.RoomMember{}).
-			Where("room_id IN ? AND user_id = ? AND is_active = ?", roomIDs, userID, true).
-			Pluck("room_id", &userMemberOfRoomIDs)
+		userMemberOfRoomIDs, err := s.repos.Rooms.FilterActiveMemberRooms(userID, roomIDs)
+		if err != nil {
+			log.Printf("[GetRoomsByFilter] Kullanıcı üyelikleri alınamadı: %v", err)
+		}
 		for _, id := range userMemberOfRoomIDs {
 			userMemberMap[id] = true
 		}
@@ -870,14 +729,13 @@ func (s *RoomService) InviteUserToRoom(roomID, inviterID, inviteeID uint64, mess
 	}
 
 	// Mevcut bekleyen davet var mı kontrol et
-	var existingInvitation models.RoomInvitation
-	if err := s.db.Where("room_id = ? AND invitee_id = ? AND status = ?", roomID, inviteeID, models.RoomInvitationPending).First(&existingInvitation).Error; err == nil {
-		return &existingInvitation, errors.New("bu kullanıcı için zaten bekleyen bir davet var")
+	if existingInvitation, err := s.repos.Rooms.FindPendingInvitation(roomID, inviteeID); err == nil {
+		return existingInvitation, errors.New("bu kullanıcı için zaten bekleyen bir davet var")
 	}
 
 	// Odayı getir
-	var room models.Room
-	if err := s.db.First(&room, roomID).Error; err != nil {
+	room, err := s.repos.Rooms.FindByID(roomID)
+	if err != nil {
 		return nil, errors.New("oda bulunamadı")
 	}
 
@@ -890,20 +748,21 @@ func (s *RoomService) InviteUserToRoom(roomID, inviterID, inviteeID uint64, mess
 		Status:    models.RoomInvitationPending,
 	}
 
-	if err := s.db.Create(&invitation).Error; err != nil {
+	// Davet ve davet edilene giden bildirim tek işlemde kaydedilir
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Rooms.CreateInvitation(&invitation); err != nil {
+			return err
+		}
+		_, err := notify(repos.Notifications, inviteeID, "room_invitation", fmt.Sprintf("'%s' odasına davet edildiniz", room.Name), &invitation.ID)
+		return err
+	})
+	if err != nil {
+		log.Printf("Room invitation oluşturulamadı: %v", err)
 		return nil, err
 	}
 
 	log.Printf("🎯 ROOM INVITATION OLUŞTURULDU: ID=%d, RoomID=%d, InviterID=%d, InviteeID=%d", invitation.ID, roomID, inviterID, inviteeID)
-
-	// Bildirim oluştur
-	notificationService := NewNotificationService()
-	_, err = notificationService.CreateNotification(inviteeID, "room_invitation", fmt.Sprintf("'%s' odasına davet edildiniz", room.Name), &invitation.ID)
-	if err != nil {
-		log.Printf("Room invitation notification oluşturulamadı: %v", err)
-	} else {
-		log.Printf("📢 NOTIFICATION OLUŞTURULDU: UserID=%d, Type=room_invitation, RelatedID=%d", inviteeID, invitation.ID)
-	}
+	log.Printf("📢 NOTIFICATION OLUŞTURULDU: UserID=%d, Type=room_invitation, RelatedID=%d", inviteeID, invitation.ID)
 
 	return &invitation, nil
 }
@@ -913,8 +772,8 @@ func (s *RoomService) AcceptRoomInvitation(invitationID, userID uint64) error {
 	log.Printf("🔍 INVITATION KABUL EDİLİYOR: InvitationID=%d, UserID=%d", invitationID, userID)
 
 	// Daveti getir
-	var invitation models.RoomInvitation
-	if err := s.db.First(&invitation, invitationID).Error; err != nil {
+	invitation, err := s.repos.Rooms.FindInvitation(invitationID)
+	if err != nil {
 		log.Printf("❌ DAVET BULUNAMADI: InvitationID=%d, Error=%v", invitationID, err)
 		return errors.New("davet bulunamadı")
 	}
@@ -938,45 +797,29 @@ func (s *RoomService) AcceptRoomInvitation(invitationID, userID uint64) error {
 		return errors.New("zaten bu odanın üyesisiniz")
 	}
 
-	// Transaction başlat
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	return s.uow.WithTx(func(repos repository.Repositories) error {
+		// Daveti kabul et
+		if err := repos.Rooms.SetInvitationStatus(invitation.ID, models.RoomInvitationAccepted); err != nil {
+			return err
 		}
-	}()
-
-	// Daveti kabul et
-	invitation.Status = models.RoomInvitationAccepted
-	if err := tx.Save(&invitation).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// Kullanıcıyı odaya üye olarak ekle
-	member := models.RoomMember{
-		RoomID:   invitation.RoomID,
-		UserID:   userID,
-		Role:     "member",
-		JoinedAt: time.Now(),
-		IsActive: true,
-	}
-	if err := tx.Create(&member).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
 
-	return tx.Commit().Error
+		// Kullanıcıyı odaya üye olarak ekle
+		member := models.RoomMember{
+			RoomID:   invitation.RoomID,
+			UserID:   userID,
+			Role:     "member",
+			JoinedAt: time.Now(),
+			IsActive: true,
+		}
+		return repos.Rooms.AddMember(&member)
+	})
 }
 
 // DeclineRoomInvitation oda davetini reddeder
 func (s *RoomService) DeclineRoomInvitation(invitationID, userID uint64) error {
 	// Daveti getir
-	var invitation models.RoomInvitation
-	if err := s.db.First(&invitation, invitationID).Error; err != nil {
+	invitation, err := s.repos.Rooms.FindInvitation(invitationID)
+	if err != nil {
 		return errors.New("davet bulunamadı")
 	}
 
@@ -991,36 +834,19 @@ func (s *RoomService) DeclineRoomInvitation(invitationID, userID uint64) error {
 	}
 
 	// Daveti reddet
-	invitation.Status = models.RoomInvitationDeclined
-	return s.db.Save(&invitation).Error
+	return s.repos.Rooms.SetInvitationStatus(invitation.ID, models.RoomInvitationDeclined)
 }
 
 // GetRoomInvitations kullanıcıya gelen oda davetlerini getirir
 func (s *RoomService) GetRoomInvitations(userID uint64) ([]models.RoomInvitation, error) {
-	var invitations []models.RoomInvitation
-	if err := s.db.Where("invitee_id = ? AND status = ?", userID, models.RoomInvitationPending).
-		Preload("Room").
-		Preload("Inviter").
-		Order("created_at DESC").
-		Find(&invitations).Error; err != nil {
-		return nil, err
-	}
-	return invitations, nil
+	return s.repos.Rooms.FindPendingInvitationsFor(userID)
 }
 
 // GetUnreadMessagesCount kullanıcının tüm odalarındaki okunmamış mesaj sayısını döndürür
 func (s *RoomService) GetUnreadMessagesCount(userID uint64) (int, error) {
 	// Kullanıcının üye olduğu odaları ve son okuma zamanlarını al
-	type RoomReadStatus struct {
-		RoomID     uint64
-		LastReadAt *time.Time
-	}
-
-	var roomReadStatuses []RoomReadStatus
-	if err := s.db.Model(&models.RoomMember{}).
-		Select("room_id, last_read_at").
-		Where("user_id = ? AND is_active = true", userID).
-		Scan(&roomReadStatuses).Error; err != nil {
+	roomReadStatuses, err := s.repos.Rooms.FindReadStatuses(userID)
+	if err != nil {
 		log.Printf("[GetUnreadMessagesCount] Kullanıcı odaları alınamadı: %v", err)
 		return 0, err
 	}
@@ -1034,24 +860,11 @@ func (s *RoomService) GetUnreadMessagesCount(userID uint64) (int, error) {
 
 	// Her oda için okunmamış mesaj sayısını hesapla
 	for _, roomStatus := range roomReadStatuses {
-		var unreadCount int64
-
-		if roomStatus.LastReadAt == nil {
-			// Hiç mesaj okunmamış, odadaki tüm mesajları say
-			if err := s.db.Model(&models.Message{}).
-				Where("room_id = ?", roomStatus.RoomID).
-				Count(&unreadCount).Error; err != nil {
-				log.Printf("[GetUnreadMessagesCount] Oda %d için mesaj sayısı alınamadı: %v", roomStatus.RoomID, err)
-				continue
-			}
-		} else {
-			// Son okuma zamanından sonraki mesajları say
-			if err := s.db.Model(&models.Message{}).
-				Where("room_id = ? AND timestamp > ?", roomStatus.RoomID, *roomStatus.LastReadAt).
-				Count(&unreadCount).Error; err != nil {
-				log.Printf("[GetUnreadMessagesCount] Oda %d için okunmamış mesaj sayısı alınamadı: %v", roomStatus.RoomID, err)
-				continue
-			}
+		// Son okuma zamanından sonra (hiç okunmamışsa tümü) diğer üyelerin gönderdiği mesajları say
+		unreadCount, err := s.repos.Messages.CountUnread(roomStatus.RoomID, userID, roomStatus.LastReadAt)
+		if err != nil {
+			log.Printf("[GetUnreadMessagesCount] Oda %d için okunmamış mesaj sayısı alınamadı: %v", roomStatus.RoomID, err)
+			continue
 		}
 
 		totalUnreadCount += int(unreadCount)
@@ -1073,10 +886,7 @@ func (s *RoomService) MarkRoomAsRead(userID, roomID uint64) error {
 	}
 
 	// RoomMember'da LastReadAt'ı güncelle
-	now := time.Now()
-	if err := s.db.Model(&models.RoomMember{}).
-		Where("user_id = ? AND room_id = ?", userID, roomID).
-		Update("last_read_at", now).Error; err != nil {
+	if err := s.repos.Rooms.UpdateLastRead(roomID, userID, time.Now()); err != nil {
 		log.Printf("[MarkRoomAsRead] Hata: %v", err)
 		return err
 	}
@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"event/backend/internal/models"
+	"event/backend/internal/testutil"
+)
+
+func TestGetOrCreateDMRoomIsIdempotent(t *testing.T) {
+	env := testutil.New(t)
+	rooms := env.Services().Rooms
+
+	alice := env.User()
+	bob := env.User()
+
+	first, err := rooms.GetOrCreateDMRoom(alice.ID, bob.ID)
+	if err != nil {
+		t.Fatalf("DM odası oluşturulamadı: %v", err)
+	}
+	again, err := rooms.GetOrCreateDMRoom(alice.ID, bob.ID)
+	if err != nil {
+		t.Fatalf("DM odası tekrar alınamadı: %v", err)
+	}
+	reversed, err := rooms.GetOrCreateDMRoom(bob.ID, alice.ID)
+	if err != nil {
+		t.Fatalf("DM odası ters sırayla alınamadı: %v", err)
+	}
+	if again.ID != first.ID || reversed.ID != first.ID {
+		t.Fatalf("aynı DM odası dönmeli: %d, %d, %d", first.ID, again.ID, reversed.ID)
+	}
+	if first.IsPublic {
+		t.Fatal("DM odası herkese açık olmamalı")
+	}
+
+	var roomCount, memberCount int64
+	env.DB.Model(&models.Room{}).Where("name = ?", first.Name).Count(&roomCount)
+	env.DB.Model(&models.RoomMember{}).Where("room_id = ? AND is_active = ?", first.ID, true).Count(&memberCount)
+	if roomCount != 1 {
+		t.Fatalf("tek DM odası olmalı, %d", roomCount)
+	}
+	if memberCount != 2 {
+		t.Fatalf("DM odasının iki üyesi olmalı, %d", memberCount)
+	}
+
+	if _, err := rooms.GetOrCreateDMRoom(alice.ID, alice.ID); err == nil {
+		t.Fatal("kullanıcı kendisiyle DM odası açamamalı")
+	}
+}
+
+func TestUnreadMessagesCount(t *testing.T) {
+	env := testutil.New(t)
+	rooms := env.Services().Rooms
+
+	reader := env.User()
+	sender := env.User()
+	room := env.Room(sender)
+	env.Join(room, reader)
+	otherRoom := env.Room(sender)
+	env.Join(otherRoom, reader)
+	unrelated := env.Room(sender)
+
+	past := time.Now().Add(-time.Hour)
+	env.Message(room, sender, "merhaba", past)
+	env.Message(room, sender, "nasılsın", past.Add(time.Minute))
+	env.Message(room, reader, "iyiyim", past.Add(2*time.Minute))
+	env.Message(otherRoom, sender, "duyuru", past)
+	env.Message(unrelated, sender, "üye olunmayan oda", past)
+
+	assertUnread := func(t *testing.T, want int) {
+		t.Helper()
+		got, err := rooms.GetUnreadMessagesCount(reader.ID)
+		if err != nil {
+			t.Fatalf("okunmamış mesaj sayısı alınamadı: %v", err)
+		}
+		if got != want {
+			t.Fatalf("okunmamış mesaj sayısı %d olmalı, %d", want, got)
+		}
+	}
+
+	// Kullanıcının kendi mesajları ve üyesi olmadığı odalar sayılmaz
+	assertUnread(t, 3)
+
+	if err := rooms.MarkRoomAsRead(reader.ID, room.ID); err != nil {
+		t.Fatalf("oda okundu işaretlenemedi: %v", err)
+	}
+	assertUnread(t, 1)
+
+	env.Message(room, sender, "yeni mesaj", time.Now().Add(time.Minute))
+	assertUnread(t, 2)
+
+	if err := rooms.MarkRoomAsRead(reader.ID, unrelated.ID); err == nil {
+		t.Fatal("üye olunmayan oda okundu işaretlenememeli")
+	}
+}
+
+func TestIsUserMemberOfRoom(t *testing.T) {
+	env := testutil.New(t)
+	rooms := env.Services().Rooms
+
+	owner := env.User()
+	member := env.User()
+	outsider := env.User()
+	room := env.Room(owner)
+	env.Join(room, member)
+
+	// WebSocket bağlantısı bu kontrole dayanır; herkese açık odada da yalnızca aktif üyeler dinleyebilir
+	assertMember := func(t *testing.T, user *models.User, want bool) {
+		t.Helper()
+		got, err := rooms.IsUserMemberOfRoom(user.ID, room.ID)
+		if err != nil {
+			t.Fatalf("oda üyeliği kontrol edilemedi: %v", err)
+		}
+		if got != want {
+			t.Fatalf("kullanıcı %d için üyelik %v olmalı, %v", user.ID, want, got)
+		}
+	}
+	assertMember(t, owner, true)
+	assertMember(t, member, true)
+	assertMember(t, outsider, false)
+
+	if err := rooms.RemoveRoomMember(room.ID, member.ID, owner.ID); err != nil {
+		t.Fatalf("üye çıkarılamadı: %v", err)
+	}
+	assertMember(t, member, false)
+}
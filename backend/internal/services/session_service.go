@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+)
+
+// SessionDisconnector iptal edilen oturumların canlı bağlantılarını (WebSocket) keser.
+// websocket.Hub bu arayüzü uygular.
+type SessionDisconnector interface {
+	DisconnectSessions(sessionIDs ...string)
+}
+
+// SessionRevoker bir kullanıcının tüm oturumlarını sonlandırabilen servisler (örn. SessionService)
+type SessionRevoker interface {
+	RevokeAll(userID uint64) (int, error)
+}
+
+// SessionService kullanıcının aktif oturumlarını (cihazlarını) kaydeder, listeler ve sonlandırır
+type SessionService struct {
+	repos        repository.Repositories
+	uow          repository.UnitOfWork
+	config       *config.Config
+	disconnector SessionDisconnector
+	revocations  auth.RevocationStore
+}
+
+// SessionServiceInput, SessionService için bağımlılıkları içerir.
+type SessionServiceInput struct {
+	Repositories repository.Repositories
+	UnitOfWork   repository.UnitOfWork
+	Config       *config.Config
+	Disconnector SessionDisconnector
+	Revocations  auth.RevocationStore
+}
+
+// NewSessionService yeni bir SessionService oluşturur
+func NewSessionService(input SessionServiceInput) *SessionService {
+	return &SessionService{
+		repos:        input.Repositories,
+		uow:          input.UnitOfWork,
+		config:       input.Config,
+		disconnector: input.Disconnector,
+		revocations:  input.Revocations,
+	}
+}
+
+// SessionResponse oturum listesinde dönen bilgiler. Current isteği yapan oturumu işaretler.
+type SessionResponse struct {
+	models.Session
+	Current bool `json:"current"`
+}
+
+// ErrSessionNotFound kullanıcıya ait olmayan, bulunmayan veya zaten sonlanmış oturum için döner
+var ErrSessionNotFound = errors.New("oturum bulunamadı")
+
+// List kullanıcının aktif oturumlarını son görülme zamanına göre listeler.
+// currentSessionID isteği yapan erişim tokeninin oturumudur.
+func (s *SessionService) List(userID uint64, currentSessionID string) ([]SessionResponse, error) {
+	sessions, err := s.activeSessions(s.repos, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = SessionResponse{Session: session, Current: session.FamilyID == currentSessionID}
+	}
+	return responses, nil
+}
+
+// Revoke kullanıcının bir oturumunu sonlandırır ve o oturumun WebSocket bağlantılarını keser
+func (s *SessionService) Revoke(userID, sessionID uint64) error {
+	sessions, err := s.activeSessions(s.repos, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return s.revoke([]models.Session{session})
+		}
+	}
+	return ErrSessionNotFound
+}
+
+// RevokeOthers isteği yapan oturum dışındaki tüm oturumları sonlandırır ve sonlanan oturum sayısını döndürür
+func (s *SessionService) RevokeOthers(userID uint64, currentSessionID string) (int, error) {
+	sessions, err := s.activeSessions(s.repos, userID)
+	if err != nil {
+		return 0, err
+	}
+	sessions = filterSessions(sessions, func(session models.Session) bool { return session.FamilyID != currentSessionID })
+
+	if err := s.revoke(sessions); err != nil {
+		return 0, err
+	}
+	return len(sessions), nil
+}
+
+// RevokeByFamily çıkış yapılan oturumu sonlandırır. Oturum bulunamazsa hata dönmez.
+func (s *SessionService) RevokeByFamily(userID uint64, familyID string) error {
+	sessions, err := s.activeSessions(s.repos, userID)
+	if err != nil {
+		return err
+	}
+	return s.revoke(filterSessions(sessions, func(session models.Session) bool { return session.FamilyID == familyID }))
+}
+
+// RevokeAll kullanıcının tüm oturumlarını sonlandırır ve bağlantılarını keser (örn. hesap askıya alındığında)
+func (s *SessionService) RevokeAll(userID uint64) (int, error) {
+	sessions, err := s.activeSessions(s.repos, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.revoke(sessions); err != nil {
+		return 0, err
+	}
+	return len(sessions), nil
+}
+
+// revokeAllTx kullanıcının keepFamilyID dışındaki tüm oturumlarını verilen işlem içinde sonlanmış olarak işaretler.
+// İşlem commit edildikten sonra dönen oturumlar için disconnect çağrılmalıdır.
+func (s *SessionService) revokeAllTx(repos repository.Repositories, userID uint64, keepFamilyID string) ([]models.Session, error) {
+	sessions, err := s.activeSessions(repos, userID)
+	if err != nil {
+		return nil, err
+	}
+	if keepFamilyID != "" {
+		sessions = filterSessions(sessions, func(session models.Session) bool { return session.FamilyID != keepFamilyID })
+	}
+	if err := s.markRevoked(repos, sessions, time.Now()); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// isActive oturumun kullanıcıya ait ve sonlanmamış olup olmadığını döndürür
+func (s *SessionService) isActive(repos repository.Repositories, userID uint64, familyID string) bool {
+	if familyID == "" {
+		return false
+	}
+	sessions, err := s.activeSessions(repos, userID)
+	if err != nil {
+		return false
+	}
+	for _, session := range sessions {
+		if session.FamilyID == familyID {
+			return true
+		}
+	}
+	return false
+}
+
+// start yeni bir token ailesi için oturum kaydı oluşturur
+func (s *SessionService) start(repos repository.Repositories, userID uint64, familyID string, client ClientInfo) error {
+	now := time.Now()
+	session := models.Session{
+		UserID:      userID,
+		FamilyID:    familyID,
+		DeviceLabel: client.DeviceLabel,
+		UserAgent:   client.UserAgent,
+		IPAddress:   client.IPAddress,
+		CreatedAt:   now,
+		LastSeenAt:  now,
+	}
+	if err := repos.Sessions.Create(&session); err != nil {
+		return errors.New("oturum kaydedilemedi")
+	}
+	return nil
+}
+
+// touch token yenilendiğinde oturumun son görülme zamanını ve istemci bilgilerini günceller
+func (s *SessionService) touch(repos repository.Repositories, familyID string, client ClientInfo) error {
+	return repos.Sessions.Touch(familyID, time.Now(), client.UserAgent, client.IPAddress)
+}
+
+// revoke oturumları tek işlemde sonlandırır, ardından erişim token'larını iptal edip bağlantıları keser
+func (s *SessionService) revoke(sessions []models.Session) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	if err := s.uow.WithTx(func(repos repository.Repositories) error {
+		return s.markRevoked(repos, sessions, time.Now())
+	}); err != nil {
+		return errors.New("oturum sonlandırılamadı")
+	}
+
+	s.disconnect(sessions)
+	return nil
+}
+
+// markRevoked oturumları ve yenileme token ailelerini iptal edilmiş olarak işaretler
+func (s *SessionService) markRevoked(repos repository.Repositories, sessions []models.Session, now time.Time) error {
+	for _, session := range sessions {
+		if err := repos.Sessions.MarkRevoked(session.ID, now); err != nil {
+			return err
+		}
+		if err := repos.RefreshTokens.RevokeFamily(session.FamilyID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// disconnect sonlanan oturumların hâlâ geçerli erişim token'larını iptal eder ve WebSocket bağlantılarını keser.
+// Oturumda üretilmiş son erişim tokeni en geç JWTExpiration sonra geçersiz olacağından iptal kaydı o kadar tutulur.
+func (s *SessionService) disconnect(sessions []models.Session) {
+	if len(sessions) == 0 {
+		return
+	}
+
+	until := time.Now().Add(s.config.JWTExpiration)
+	familyIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if err := auth.RevokeSession(s.revocations, session.FamilyID, until); err != nil {
+			log.Printf("[SessionService.disconnect] Oturum erişim token'ları iptal edilemedi (SessionID: %d): %v", session.ID, err)
+		}
+		familyIDs = append(familyIDs, session.FamilyID)
+	}
+
+	if s.disconnector != nil {
+		s.disconnector.DisconnectSessions(familyIDs...)
+	}
+	log.Printf("[SessionService.disconnect] %d oturum sonlandırıldı (UserID: %d)", len(sessions), sessions[0].UserID)
+}
+
+// activeSessions kullanıcının sonlanmamış ve yenileme süresi dolmamış oturumlarını son görülme zamanına göre döndürür
+func (s *SessionService) activeSessions(repos repository.Repositories, userID uint64) ([]models.Session, error) {
+	return repos.Sessions.FindActive(userID, time.Now().Add(-s.config.RefreshExpiration))
+}
+
+// filterSessions keep koşulunu sağlayan oturumları döndürür
+func filterSessions(sessions []models.Session, keep func(models.Session) bool) []models.Session {
+	filtered := sessions[:0]
+	for _, session := range sessions {
+		if keep(session) {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
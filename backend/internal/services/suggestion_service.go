@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// suggestionHorizon önerilecek etkinliklerin aranacağı süre
+const suggestionHorizon = 366 * 24 * time.Hour
+
 // SuggestionService önerileri yöneten servisi temsil eder
 type SuggestionService struct {
 	UserRepo     repository.UserRepository
@@ -74,15 +77,22 @@ func (s *SuggestionService) GetSuggestedEvents(userID uint64) ([]models.Event, e
 
 	// Şu andan itibaren gelecekteki etkinlikleri al
 	now := time.Now()
-	events, err := s.EventRepo.GetUpcomingEvents(now)
+	events, err := s.EventRepo.GetUpcomingEvents(now, now.Add(suggestionHorizon))
 	if err != nil {
 		return nil, err
 	}
 
 	var suggestions []EventSuggestion
 	var suggestedEvents []models.Event
+	// Tekrarlanan bir seri yalnızca en yakın tekrarıyla önerilir; liste başlangıca göre sıralıdır
+	seen := make(map[uint64]bool)
 
 	for _, event := range events {
+		if seen[event.ID] {
+			continue
+		}
+		seen[event.ID] = true
+
 		// Özel etkinlikleri kontrol et - sadece kullanıcı davetliyse veya odanın üyesiyse öner
 		if event.IsPrivate {
 			// Etkinlik bir odaya aitse ve kullanıcı o odanın üyesi değilse atla
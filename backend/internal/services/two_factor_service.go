@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"event/backend/internal/auth"
+	"event/backend/internal/config"
+	"event/backend/internal/models"
+	"event/backend/internal/repository"
+	"event/backend/internal/utils"
+)
+
+// recoveryCodeCount kurulumda üretilen kurtarma kodu sayısı
+const recoveryCodeCount = 10
+
+// totpSkew saat kaymalarına karşı kabul edilen önceki/sonraki zaman adımı sayısı
+const totpSkew = 1
+
+// TwoFactorService TOTP tabanlı iki adımlı doğrulamanın kurulumunu, doğrulamasını ve kapatılmasını yönetir.
+// Kurulum onayı ve kapatmadaki hatalı kodlar, girişteki kod adımıyla aynı LoginGuard sayaçlarına işlenir.
+type TwoFactorService struct {
+	repos      repository.Repositories
+	uow        repository.UnitOfWork
+	config     *config.Config
+	passwords  auth.PasswordHasher
+	loginGuard *LoginGuard
+}
+
+// TwoFactorServiceInput, TwoFactorService için bağımlılıkları içerir.
+// LoginGuard, AuthService'e verilenle aynı olmalıdır.
+type TwoFactorServiceInput struct {
+	Repositories   repository.Repositories
+	UnitOfWork     repository.UnitOfWork
+	Config         *config.Config
+	PasswordHasher auth.PasswordHasher
+	LoginGuard     *LoginGuard
+}
+
+// NewTwoFactorService yeni bir TwoFactorService oluşturur
+func NewTwoFactorService(input TwoFactorServiceInput) *TwoFactorService {
+	return &TwoFactorService{
+		repos:      input.Repositories,
+		uow:        input.UnitOfWork,
+		config:     input.Config,
+		passwords:  input.PasswordHasher,
+		loginGuard: input.LoginGuard,
+	}
+}
+
+// TwoFactorSetupResponse kurulum başlatıldığında istemciye dönen bilgiler.
+// ProvisioningURI istemci tarafında QR kod olarak gösterilir.
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+var (
+	// ErrTwoFactorAlreadyEnabled iki adımlı doğrulama zaten açıksa döner
+	ErrTwoFactorAlreadyEnabled = errors.New("iki adımlı doğrulama zaten etkin")
+	// ErrTwoFactorNotEnabled iki adımlı doğrulama kapalıyken döner
+	ErrTwoFactorNotEnabled = errors.New("iki adımlı doğrulama etkin değil")
+	// ErrInvalidTwoFactorCode geçersiz veya daha önce kullanılmış kod için döner
+	ErrInvalidTwoFactorCode = errors.New("doğrulama kodu geçersiz")
+)
+
+// BeginSetup yeni bir TOTP anahtarı üretir ve kullanıcıya kaydeder. Kurulum ConfirmSetup ile
+// geçerli bir kod girilene kadar tamamlanmış sayılmaz; tekrar çağrılırsa anahtar yenilenir.
+func (s *TwoFactorService) BeginSetup(userID uint64) (*TwoFactorSetupResponse, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.New("doğrulama anahtarı oluşturulamadı")
+	}
+	if err := s.repos.Users.UpdateFields(user.ID, map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_last_step": 0,
+	}); err != nil {
+		return nil, errors.New("doğrulama anahtarı kaydedilemedi")
+	}
+
+	return &TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.config.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmSetup authenticator uygulamasından gelen kodu doğrular ve iki adımlı doğrulamayı etkinleştirir.
+// Kurtarma kodları yalnızca bu yanıtta düz metin olarak döner. Hatalı kodlar hatalı giriş gibi sayılır.
+func (s *TwoFactorService) ConfirmSetup(userID uint64, code, ip string) ([]string, error) {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("önce iki adımlı doğrulama kurulumunu başlatmalısınız")
+	}
+	if err := s.loginGuard.CheckAllowed(user, user.Email, ip); err != nil {
+		return nil, err
+	}
+
+	step, ok := utils.ValidateTOTPCode(user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		s.loginGuard.RecordFailure(user, user.Email, ip)
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.New("kurtarma kodları oluşturulamadı")
+	}
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		now := time.Now()
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{
+			"totp_enabled_at": now,
+			"totp_last_step":  step,
+		}); err != nil {
+			return err
+		}
+		return repos.RecoveryCodes.Replace(user.ID, hashes, now)
+	})
+	if err != nil {
+		return nil, errors.New("iki adımlı doğrulama etkinleştirilemedi")
+	}
+
+	s.loginGuard.RecordSuccess(user)
+	log.Printf("[TwoFactorService.ConfirmSetup] İki adımlı doğrulama etkinleştirildi (UserID: %d)", user.ID)
+	return codes, nil
+}
+
+// Disable şifre ve geçerli bir kod (TOTP veya kurtarma kodu) ile iki adımlı doğrulamayı kapatır.
+// Hatalı şifre ve kodlar hatalı giriş gibi sayılır; çalınmış bir oturumla kodlar denenerek koruma kapatılamaz.
+func (s *TwoFactorService) Disable(userID uint64, password, code, ip string) error {
+	user, err := s.repos.Users.FindByID(userID)
+	if err != nil {
+		return errors.New("kullanıcı bulunamadı")
+	}
+	if !user.IsTwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := s.loginGuard.CheckAllowed(user, user.Email, ip); err != nil {
+		return err
+	}
+	if ok, _ := s.passwords.Verify(password, user.PasswordHash); !ok {
+		s.loginGuard.RecordFailure(user, user.Email, ip)
+		return errors.New("şifre hatalı")
+	}
+	if err := s.VerifyCode(user, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.loginGuard.RecordFailure(user, user.Email, ip)
+		}
+		return err
+	}
+	s.loginGuard.RecordSuccess(user)
+
+	err = s.uow.WithTx(func(repos repository.Repositories) error {
+		if err := repos.Users.UpdateFields(user.ID, map[string]interface{}{
+			"totp_secret":     "",
+			"totp_enabled_at": nil,
+			"totp_last_step":  0,
+		}); err != nil {
+			return err
+		}
+		return repos.RecoveryCodes.DeleteAll(user.ID)
+	})
+	if err != nil {
+		return errors.New("iki adımlı doğrulama kapatılamadı")
+	}
+
+	log.Printf("[TwoFactorService.Disable] İki adımlı doğrulama kapatıldı (UserID: %d)", user.ID)
+	return nil
+}
+
+// VerifyCode kullanıcının TOTP kodunu veya kullanılmamış bir kurtarma kodunu doğrular.
+// Kabul edilen TOTP zaman adımı ve kurtarma kodları tek kullanımlıktır.
+func (s *TwoFactorService) VerifyCode(user *models.User, code string) error {
+	if !user.IsTwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+
+	if step, ok := utils.ValidateTOTPCode(user.TOTPSecret, code, time.Now(), totpSkew); ok {
+		// Koşullu güncelleme: aynı kod (veya daha eski bir adım) ikinci kez kabul edilmez
+		advanced, err := s.repos.Users.AdvanceTOTPStep(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return ErrInvalidTwoFactorCode
+		}
+		user.TOTPLastStep = step
+		return nil
+	}
+
+	// Kurtarma kodu olarak dene
+	used, err := s.repos.RecoveryCodes.Use(user.ID, utils.HashToken(normalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	log.Printf("[TwoFactorService.VerifyCode] Kurtarma kodu kullanıldı (UserID: %d)", user.ID)
+	return nil
+}
+
+// generateRecoveryCodes "xxxx-xxxx-xxxx-xxxx" biçiminde kurtarma kodları ve özetlerini üretir
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateRandomToken(8)
+		if err != nil {
+			return nil, nil, err
+		}
+		code := fmt.Sprintf("%s-%s-%s-%s", raw[0:4], raw[4:8], raw[8:12], raw[12:16])
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode kullanıcının kodu tire, boşluk veya büyük harfle girmesine izin verir
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
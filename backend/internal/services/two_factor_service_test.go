@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"event/backend/internal/services"
+	"event/backend/internal/testutil"
+)
+
+func TestTwoFactorConfirmThrottlesInvalidCodes(t *testing.T) {
+	env := testutil.New(t)
+	twoFactor := env.Services().TwoFactor
+	user := env.User()
+
+	if _, err := twoFactor.BeginSetup(user.ID); err != nil {
+		t.Fatalf("kurulum başlatılamadı: %v", err)
+	}
+
+	threshold := env.Config.AccountBackoffThreshold
+	for i := 0; i < threshold; i++ {
+		if _, err := twoFactor.ConfirmSetup(user.ID, "kod-yok", "203.0.113.7"); !errors.Is(err, services.ErrInvalidTwoFactorCode) {
+			t.Fatalf("%d. denemede ErrInvalidTwoFactorCode beklenirdi: %v", i+1, err)
+		}
+	}
+
+	// Eşikten sonra kod denenmeden girişteki gibi bekleme hatası döner
+	_, err := twoFactor.ConfirmSetup(user.ID, "kod-yok", "203.0.113.7")
+	var blocked *services.LoginBlockedError
+	if !errors.As(err, &blocked) || blocked.RetryAfter <= 0 {
+		t.Fatalf("LoginBlockedError beklenirdi: %v", err)
+	}
+
+	// Aynı sayaç girişi de yavaşlatır
+	if _, err := env.Services().Auth.Login(user.Email, "yanlis-sifre", services.ClientInfo{}); !errors.As(err, &blocked) {
+		t.Fatalf("giriş de engellenmeli: %v", err)
+	}
+}
+
+func TestTwoFactorStatusIsNotPublic(t *testing.T) {
+	env := testutil.New(t)
+	user := env.User()
+	now := user.CreatedAt
+	user.TOTPEnabledAt = &now
+
+	raw, err := json.Marshal(user)
+	if err != nil {
+		t.Fatalf("kullanıcı serileştirilemedi: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("JSON çözümlenemedi: %v", err)
+	}
+	if _, ok := fields["totp_enabled_at"]; ok {
+		t.Fatalf("iki adımlı doğrulama durumu profilde yayınlanmamalı: %s", raw)
+	}
+}
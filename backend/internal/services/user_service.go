@@ -5,16 +5,20 @@ import (
 	"event/backend/internal/repository"
 )
 
+// UserFinder diğer servislerin kullanıcıyı ID ile bulmak için kullandığı arayüz (örn. UserService)
+type UserFinder interface {
+	FindUserByID(id uint64) (*models.User, error)
+}
+
 // UserService kullanıcı işlemlerini yöneten servis
 type UserService struct {
 	userRepo repository.UserRepository
 }
 
 // NewUserService yeni bir UserService örneği oluşturur.
-// Bu fonksiyon artık repository'yi doğrudan kendisi oluşturuyor.
-func NewUserService() *UserService {
+func NewUserService(userRepo repository.UserRepository) *UserService {
 	return &UserService{
-		userRepo: repository.NewUserRepository(),
+		userRepo: userRepo,
 	}
 }
 
@@ -0,0 +1,161 @@
+package testutil
+
+import (
+	"fmt"
+	"time"
+
+	"event/backend/internal/models"
+)
+
+// User bir kullanıcı ekler. Kullanıcı adı ve e-posta benzersiz üretilir; opts kaydetmeden önce alanları değiştirir.
+func (e *Env) User(opts ...func(*models.User)) *models.User {
+	e.T.Helper()
+	e.seq++
+	now := time.Now()
+	user := &models.User{
+		Username:        fmt.Sprintf("user%d", e.seq),
+		Email:           fmt.Sprintf("user%d@example.com", e.seq),
+		PasswordHash:    "-",
+		FirstName:       "Test",
+		LastName:        fmt.Sprintf("Kullanıcı %d", e.seq),
+		EmailVerifiedAt: &now,
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+	e.must(e.DB.Create(user).Error, "kullanıcı eklenemedi")
+	return user
+}
+
+// Friends iki kullanıcı arasında kabul edilmiş bir arkadaşlık ekler
+func (e *Env) Friends(requester, addressee *models.User) *models.Friendship {
+	e.T.Helper()
+	friendship := &models.Friendship{
+		RequesterID: requester.ID,
+		AddresseeID: addressee.ID,
+		Status:      string(models.FriendshipAccepted),
+	}
+	e.must(e.DB.Create(friendship).Error, "arkadaşlık eklenemedi")
+	return friendship
+}
+
+// Room creator'ın yöneticisi olduğu bir oda ekler
+func (e *Env) Room(creator *models.User, opts ...func(*models.Room)) *models.Room {
+	e.T.Helper()
+	e.seq++
+	room := &models.Room{
+		Name:          fmt.Sprintf("Oda %d", e.seq),
+		Description:   "Test odası",
+		CreatorUserID: creator.ID,
+		IsPublic:      true,
+	}
+	for _, opt := range opts {
+		opt(room)
+	}
+	e.must(e.DB.Create(room).Error, "oda eklenemedi")
+	e.addMember(room, creator, "admin")
+	return room
+}
+
+// Join kullanıcıları odaya aktif üye olarak ekler
+func (e *Env) Join(room *models.Room, users ...*models.User) {
+	e.T.Helper()
+	for _, user := range users {
+		e.addMember(room, user, "member")
+	}
+}
+
+func (e *Env) addMember(room *models.Room, user *models.User, role string) {
+	e.T.Helper()
+	member := &models.RoomMember{
+		RoomID:   room.ID,
+		UserID:   user.ID,
+		Role:     role,
+		JoinedAt: time.Now(),
+		IsActive: true,
+	}
+	e.must(e.DB.Create(member).Error, "oda üyesi eklenemedi")
+}
+
+// Event creator'ın oluşturduğu, bir hafta sonra başlayan herkese açık bir etkinlik ekler
+func (e *Env) Event(creator *models.User, opts ...func(*models.Event)) *models.Event {
+	e.T.Helper()
+	e.seq++
+	start := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Minute)
+	end := start.Add(2 * time.Hour)
+	event := &models.Event{
+		Title:          fmt.Sprintf("Etkinlik %d", e.seq),
+		Description:    "Test etkinliği",
+		Location:       "İstanbul",
+		CreatorUserID:  creator.ID,
+		FinalStartTime: &start,
+		FinalEndTime:   &end,
+	}
+	for _, opt := range opts {
+		opt(event)
+	}
+	e.must(e.DB.Create(event).Error, "etkinlik eklenemedi")
+	return event
+}
+
+// Private etkinliği özel yapar
+func Private(event *models.Event) {
+	event.IsPrivate = true
+}
+
+// InRoom etkinliği bir odaya bağlar
+func InRoom(room *models.Room) func(*models.Event) {
+	return func(event *models.Event) {
+		event.RoomID = &room.ID
+	}
+}
+
+// StartsAt etkinliğin nihai zamanını start'tan başlayan iki saatlik aralık yapar
+func StartsAt(start time.Time) func(*models.Event) {
+	return func(event *models.Event) {
+		end := start.Add(2 * time.Hour)
+		event.FinalStartTime = &start
+		event.FinalEndTime = &end
+	}
+}
+
+// Recurring etkinliği verilen RRULE ile tekrarlanan bir seri yapar; seri nihai başlangıç zamanından başlar
+func Recurring(rule string) func(*models.Event) {
+	return func(event *models.Event) {
+		event.RecurrenceRule = rule
+	}
+}
+
+// Capacity etkinliğin katılımcı sınırını n yapar
+func Capacity(n int) func(*models.Event) {
+	return func(event *models.Event) {
+		event.Capacity = &n
+	}
+}
+
+// Message odaya sender adına at zamanında gönderilmiş bir mesaj ekler
+func (e *Env) Message(room *models.Room, sender *models.User, content string, at time.Time) *models.Message {
+	e.T.Helper()
+	message := &models.Message{
+		RoomID:    room.ID,
+		UserID:    sender.ID,
+		Content:   content,
+		Timestamp: at,
+	}
+	e.must(e.DB.Create(message).Error, "mesaj eklenemedi")
+	return message
+}
+
+// Proposal suggester'dan recipient'a bekleyen bir etkinlik önerisi ekler
+func (e *Env) Proposal(suggester, recipient *models.User, details models.JSONB) *models.EventProposal {
+	e.T.Helper()
+	proposal := &models.EventProposal{
+		SuggesterUserID:          suggester.ID,
+		RecipientUserID:          recipient.ID,
+		ProposedEventDetailsJSON: details,
+		Status:                   models.ProposalPending,
+		ProposedAt:               time.Now(),
+	}
+	e.must(e.DB.Create(proposal).Error, "öneri eklenemedi")
+	return proposal
+}
@@ -0,0 +1,103 @@
+// Package testutil servis katmanını gerçek bir veritabanına karşı sınamak için test yardımcıları sağlar.
+// Her test kendi bellek içi SQLite veritabanını alır; şema migration'larla kurulur ve servisler
+// uygulamadaki gibi app.New ile bağlanır. Veriler fixture yardımcılarıyla (User, Friends, Room, Event, ...) eklenir.
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"event/backend/internal/app"
+	"event/backend/internal/config"
+	"event/backend/internal/migrations"
+	"event/backend/pkg/database"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// dbCounter aynı isimli alt testlerin bile ayrı veritabanı almasını sağlar
+var dbCounter atomic.Uint64
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// Env bir teste ait yalıtılmış veritabanını ve bu veritabanıyla kurulmuş uygulamayı tutar
+type Env struct {
+	T      testing.TB
+	Config *config.Config
+	DB     *gorm.DB
+	App    *app.App
+
+	// seq fixture'larda benzersiz kullanıcı adı ve e-posta üretmek için kullanılır
+	seq int
+}
+
+// Config testler için yapılandırma döndürür: bellek içi SQLite, sessiz sorgu günlüğü
+func Config(t testing.TB) *config.Config {
+	cfg := config.Default()
+	cfg.Env = "test"
+	cfg.DBDriver = "sqlite"
+	// Bağlantılar aynı bellek içi veritabanını paylaşır; isim teste özgü olduğu için testler birbirini görmez
+	cfg.DBName = fmt.Sprintf("file:%s_%d?mode=memory&cache=shared",
+		unsafeNameChars.ReplaceAllString(t.Name(), "_"), dbCounter.Add(1))
+	cfg.DBConnMaxLifetime = 0
+	cfg.DBLogLevel = "silent"
+	cfg.RevocationStore = "memory"
+	return cfg
+}
+
+// NewDB teste özel boş bir veritabanı açar ve tüm migration'ları uygular. Veritabanı test bitince kapatılır.
+func NewDB(t testing.TB, cfg *config.Config) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("test veritabanı açılamadı: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := database.Close(db); err != nil {
+			t.Errorf("test veritabanı kapatılamadı: %v", err)
+		}
+	})
+
+	migrator, err := migrations.NewMigrator(db, cfg)
+	if err != nil {
+		t.Fatalf("migrator oluşturulamadı: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migration'lar uygulanamadı: %v", err)
+	}
+	return db
+}
+
+// New yalıtılmış bir veritabanı ve bu veritabanıyla kurulmuş uygulama döndürür.
+// WebSocket hub'ı çalıştırılmaz; oturum sonlandırma gibi hub'a mesaj gönderen akışlar için test içinde
+// go env.App.Hub.Run() çağrılmalıdır.
+func New(t testing.TB) *Env {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := Config(t)
+	db := NewDB(t, cfg)
+	application, err := app.New(cfg, db)
+	if err != nil {
+		t.Fatalf("uygulama kurulamadı: %v", err)
+	}
+	return &Env{T: t, Config: cfg, DB: db, App: application}
+}
+
+// Services uygulamanın servislerine kısa erişim sağlar
+func (e *Env) Services() *app.Services {
+	return &e.App.Services
+}
+
+// must fixture yardımcılarında beklenmeyen veritabanı hatalarında testi durdurur
+func (e *Env) must(err error, what string) {
+	e.T.Helper()
+	if err != nil {
+		e.T.Fatalf("%s: %v", what, err)
+	}
+}
@@ -10,19 +10,41 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// accessTokenType erişim token'larının typ claim'i. Diğer token türleri (e-posta doğrulama, mfa_pending)
+// bu claim'i taşımadığı için imza anahtarları aynı olsa bile erişim tokeni olarak kabul edilmez.
+const accessTokenType = "access"
+
 // JWTClaims JWT token içeriğini temsil eder
 type JWTClaims struct {
 	UserID uint64 `json:"user_id"`
 	Email  string `json:"email"`
+	// SessionID tokenin ait olduğu oturum (yenileme tokeni ailesi); oturum iptal edilince token da geçersiz olur
+	SessionID string `json:"sid,omitempty"`
+	// TokenType her zaman accessTokenType'tır
+	TokenType string `json:"typ"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken yeni bir JWT token oluşturur
+// GenerateToken herhangi bir oturuma bağlı olmayan yeni bir JWT token oluşturur
 func GenerateToken(userID uint64, email string, cfg *config.Config) (string, error) {
+	return GenerateSessionToken(userID, email, "", cfg)
+}
+
+// GenerateSessionToken verilen oturuma bağlı yeni bir JWT token oluşturur. Her token benzersiz bir jti taşır.
+func GenerateSessionToken(userID uint64, email, sessionID string, cfg *config.Config) (string, error) {
+	// jti, token'ın süresi dolmadan iptal edilebilmesi (çıkış) için kullanılır
+	jti, err := GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		SessionID: sessionID,
+		TokenType: accessTokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.JWTExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -32,9 +54,10 @@ func GenerateToken(userID uint64, email string, cfg *config.Config) (string, err
 	return token.SignedString([]byte(cfg.JWTSecret))
 }
 
-// GenerateRefreshToken yenileme tokeni oluşturur
-func GenerateRefreshToken(userID uint64, cfg *config.Config) (string, error) {
+// GenerateRefreshToken yenileme tokeni oluşturur. jti, tokenin veritabanındaki kaydını tanımlar.
+func GenerateRefreshToken(userID uint64, jti string, cfg *config.Config) (string, error) {
 	claims := jwt.RegisteredClaims{
+		ID:        jti,
 		ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshExpiration)),
 		IssuedAt:  jwt.NewNumericDate(time.Now()),
 		NotBefore: jwt.NewNumericDate(time.Now()),
@@ -46,7 +69,8 @@ func GenerateRefreshToken(userID uint64, cfg *config.Config) (string, error) {
 	return token.SignedString([]byte(cfg.RefreshSecret))
 }
 
-// ValidateToken JWT token'ı doğrular ve içeriğini döndürür
+// ValidateToken erişim tokenini doğrular ve içeriğini döndürür.
+// typ claim'i access olmayan ya da aud taşıyan (başka amaçla üretilmiş) token'lar reddedilir.
 func ValidateToken(tokenString string, cfg *config.Config) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -59,15 +83,34 @@ func ValidateToken(tokenString string, cfg *config.Config) (*JWTClaims, error) {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("geçersiz token")
 	}
+	if claims.TokenType != accessTokenType || len(claims.Audience) > 0 {
+		return nil, errors.New("token bir erişim tokeni değil")
+	}
+	return claims, nil
+}
 
-	return nil, errors.New("geçersiz token")
+// RefreshTokenInfo doğrulanmış bir yenileme tokeninin içeriğini taşır
+type RefreshTokenInfo struct {
+	UserID   uint64
+	JTI      string
+	IssuedAt time.Time
 }
 
 // ValidateRefreshToken yenileme tokenini doğrular ve kullanıcı ID'sini döndürür
 func ValidateRefreshToken(tokenString string, cfg *config.Config) (uint64, error) {
+	info, err := ParseRefreshToken(tokenString, cfg)
+	if err != nil {
+		return 0, err
+	}
+	return info.UserID, nil
+}
+
+// ParseRefreshToken yenileme tokenini doğrular ve içeriğini döndürür
+func ParseRefreshToken(tokenString string, cfg *config.Config) (*RefreshTokenInfo, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("beklenmeyen imza yöntemi: %v", token.Header["alg"])
@@ -77,26 +120,31 @@ func ValidateRefreshToken(tokenString string, cfg *config.Config) (uint64, error
 	})
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return 0, errors.New("geçersiz yenileme tokeni")
+		return nil, errors.New("geçersiz yenileme tokeni")
 	}
 
 	claims, ok := token.Claims.(*jwt.RegisteredClaims)
 	if !ok {
-		return 0, errors.New("token içeriği okunamadı")
+		return nil, errors.New("token içeriği okunamadı")
 	}
 
 	// Subject'ten userID'yi çıkar
 	var userID uint64
 	_, err = fmt.Sscanf(claims.Subject, "%d", &userID)
 	if err != nil {
-		return 0, errors.New("token içindeki kullanıcı ID'si geçersiz")
+		return nil, errors.New("token içindeki kullanıcı ID'si geçersiz")
 	}
 
-	return userID, nil
+	info := &RefreshTokenInfo{UserID: userID, JTI: claims.ID}
+	if claims.IssuedAt != nil {
+		info.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return info, nil
 }
 
 // ExtractTokenFromHeader "Bearer" Authorization header'ından token çıkarır
@@ -113,3 +161,99 @@ func ExtractTokenFromHeader(authHeader string) (string, error) {
 
 	return tokenString, nil
 }
+
+// emailVerificationAudience doğrulama token'larının başka amaçla kullanılmasını engeller
+const emailVerificationAudience = "email-verification"
+
+// EmailVerificationClaims e-posta doğrulama bağlantısındaki imzalı içerik
+type EmailVerificationClaims struct {
+	UserID uint64 `json:"user_id"`
+	Email  string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// GenerateEmailVerificationToken e-posta doğrulama bağlantısı için imzalı token üretir.
+// Token e-posta adresini de içerir; adres değişirse eski bağlantılar geçersiz olur.
+func GenerateEmailVerificationToken(userID uint64, email string, cfg *config.Config) (string, error) {
+	claims := EmailVerificationClaims{
+		UserID: userID,
+		Email:  email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.EmailVerificationTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "event-app",
+			Audience:  jwt.ClaimStrings{emailVerificationAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.EmailVerificationSecret))
+}
+
+// ValidateEmailVerificationToken e-posta doğrulama tokenini doğrular ve içeriğini döndürür
+func ValidateEmailVerificationToken(tokenString string, cfg *config.Config) (*EmailVerificationClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &EmailVerificationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("beklenmeyen imzalama metodu")
+		}
+		return []byte(cfg.EmailVerificationSecret), nil
+	}, jwt.WithAudience(emailVerificationAudience))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*EmailVerificationClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("geçersiz doğrulama tokeni")
+}
+
+// mfaPendingAudience şifresi doğrulanmış ancak iki adımlı doğrulamayı tamamlamamış girişleri işaretler
+const mfaPendingAudience = "mfa_pending"
+
+// mfaSigningKey mfa_pending token'larını erişim token'larından ayrı bir anahtarla imzalar;
+// böylece bu token'lar hiçbir koşulda erişim tokeni olarak kabul edilmez
+func mfaSigningKey(cfg *config.Config) []byte {
+	return []byte(cfg.JWTSecret + ":" + mfaPendingAudience)
+}
+
+// GenerateMFAPendingToken iki adımlı doğrulama kodunu bekleyen kısa ömürlü bir token üretir
+func GenerateMFAPendingToken(userID uint64, cfg *config.Config) (string, error) {
+	claims := jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.MFAPendingExpiration)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		Issuer:    "event-app",
+		Subject:   fmt.Sprintf("%d", userID),
+		Audience:  jwt.ClaimStrings{mfaPendingAudience},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(mfaSigningKey(cfg))
+}
+
+// ValidateMFAPendingToken mfa_pending tokenini doğrular ve kullanıcı ID'sini döndürür
+func ValidateMFAPendingToken(tokenString string, cfg *config.Config) (uint64, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("beklenmeyen imzalama metodu")
+		}
+		return mfaSigningKey(cfg), nil
+	}, jwt.WithAudience(mfaPendingAudience))
+
+	if err != nil {
+		return 0, err
+	}
+
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid {
+		return 0, errors.New("geçersiz doğrulama tokeni")
+	}
+
+	var userID uint64
+	if _, err := fmt.Sscanf(claims.Subject, "%d", &userID); err != nil {
+		return 0, errors.New("token içindeki kullanıcı ID'si geçersiz")
+	}
+	return userID, nil
+}
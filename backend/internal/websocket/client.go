@@ -3,7 +3,6 @@ package websocket
 import (
 	"bytes"
 	"log"
-	"net/http"
 	"time"
 
 	"event/backend/internal/models"
@@ -11,33 +10,11 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-const (
-	// Time allowed to write a message to the peer.
-	writeWait = 10 * time.Second
-
-	// Time allowed to read the next pong message from the peer.
-	pongWait = 60 * time.Second
-
-	// Send pings to peer with this period. Must be less than pongWait.
-	pingPeriod = (pongWait * 9) / 10
-
-	// Maximum message size allowed from peer.
-	maxMessageSize = 512
-)
-
 var (
 	newline = []byte{'\n'}
 	space   = []byte{' '}
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Geliştirme için tüm origin'lere izin ver
-	},
-}
-
 // Client is a middleman between the websocket connection and the hub.
 type Client struct {
 	Hub *Hub
@@ -51,6 +28,9 @@ type Client struct {
 	// Oda ID'si ve Kullanıcı ID'si
 	RoomID uint64
 	UserID uint64
+
+	// Bağlantının açıldığı token'ın oturumu; oturum iptal edilince bağlantı kesilir
+	SessionID string
 }
 
 // readPump pumps messages from the websocket connection to the hub.
@@ -59,7 +39,9 @@ func (c *Client) ReadPump() {
 		c.Hub.Unregister <- c
 		c.Conn.Close()
 	}()
-	c.Conn.SetReadLimit(maxMessageSize)
+	// Bağlantı limitleri yapılandırmadan gelir (WS_MAX_MESSAGE_SIZE, WS_PONG_WAIT)
+	pongWait := c.Hub.config.WSPongWait
+	c.Conn.SetReadLimit(int64(c.Hub.config.WSMaxMessageSize))
 	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.Conn.SetPongHandler(func(string) error { c.Conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
@@ -84,7 +66,9 @@ func (c *Client) ReadPump() {
 
 // writePump pumps messages from the hub to the websocket connection.
 func (c *Client) WritePump() {
-	ticker := time.NewTicker(pingPeriod)
+	// Ping'ler istemcinin pong bekleme süresi dolmadan gönderilir
+	writeWait := c.Hub.config.WSWriteWait
+	ticker := time.NewTicker(c.Hub.config.WSPongWait * 9 / 10)
 	defer func() {
 		ticker.Stop()
 		c.Conn.Close()
@@ -123,19 +107,3 @@ func (c *Client) WritePump() {
 		}
 	}
 }
-
-// ServeWs handles websocket requests from the peer.
-func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	// Client oluştururken alan adlarının doğru (büyük harf) olduğundan emin ol
-	client := &Client{Hub: hub, Conn: conn, Send: make(chan []byte, 256)}
-	client.Hub.Register <- client
-
-	// Metodların doğru (büyük harf) adlarıyla çağrıldığından emin ol
-	go client.WritePump()
-	go client.ReadPump()
-}
@@ -4,12 +4,24 @@ import (
 	"encoding/json"
 	"log"
 	"strconv"
+	"sync"
+	"time"
 
+	"event/backend/internal/config"
 	"event/backend/internal/dtos"     // MessageDTO için
 	"event/backend/internal/models"   // Veritabanı modelleri için
 	"event/backend/internal/services" // ChatService ve UserService için eklenecek
 	// Veritabanı erişimi için (ChatService kullanınca direkt gerekmeyebilir)
 	// UserService için gerekebilir
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// disconnectQueueSize Run döngüsü meşgulken bekletilebilecek bağlantı kesme isteği sayısı
+	disconnectQueueSize = 64
+	// disconnectTimeout kuyruk doluyken DisconnectSessions'ın en fazla bekleyeceği süre
+	disconnectTimeout = 2 * time.Second
 )
 
 // Hub aktif client'ları ve odalara mesaj yayınını yönetir.
@@ -26,12 +38,21 @@ type Hub struct {
 	// Client'lardan kayıt silme istekleri.
 	Unregister chan *Client
 
+	// Oturumu sonlandırılan client'ların bağlantısını kesme istekleri (oturum kimlikleri).
+	disconnect chan []string
+
+	// Stop çağrıldığında kapatılır; Run döngüsünü sonlandırır ve bekleyen göndericileri serbest bırakır.
+	done     chan struct{}
+	stopOnce sync.Once
+
+	config      *config.Config
 	chatService *services.ChatService // ChatService eklendi
 	userService *services.UserService // UserService eklendi
 }
 
 // HubInput, Hub oluşturmak için gereken bağımlılıkları tanımlar.
 type HubInput struct {
+	Config      *config.Config
 	ChatService *services.ChatService
 	UserService *services.UserService
 }
@@ -42,16 +63,34 @@ func NewHub(input HubInput) *Hub {
 		Broadcast:   make(chan *models.Message),
 		Register:    make(chan *Client),
 		Unregister:  make(chan *Client),
+		disconnect:  make(chan []string, disconnectQueueSize),
+		done:        make(chan struct{}),
 		rooms:       make(map[uint64]map[*Client]bool),
+		config:      input.Config,
 		chatService: input.ChatService,
 		userService: input.UserService,
 	}
 }
 
+// NewClient doğrulanmış bir bağlantı için gönderim kuyruğu yapılandırmadaki boyutta olan bir Client oluşturur
+func (h *Hub) NewClient(conn *websocket.Conn, roomID, userID uint64, sessionID string) *Client {
+	return &Client{
+		Hub:       h,
+		Conn:      conn,
+		Send:      make(chan []byte, h.config.WSSendBufferSize),
+		RoomID:    roomID,
+		UserID:    userID,
+		SessionID: sessionID,
+	}
+}
+
 // Run Hub'ı çalıştırır.
 func (h *Hub) Run() {
 	for {
 		select {
+		case <-h.done:
+			return
+
 		case client := <-h.Register:
 			if _, ok := h.rooms[client.RoomID]; !ok {
 				h.rooms[client.RoomID] = make(map[*Client]bool)
@@ -72,6 +111,9 @@ func (h *Hub) Run() {
 				}
 			}
 
+		case sessionIDs := <-h.disconnect:
+			h.disconnectSessions(sessionIDs)
+
 		case messageData := <-h.Broadcast: // Gelen veri artık ham mesaj bilgisini içeriyor.
 			// Gelen *models.Message (içinde sadece RoomID, UserID ve Content var) bilgisinden
 			// tam bir veritabanı kaydı oluştur.
@@ -119,3 +161,53 @@ func (h *Hub) Run() {
 		}
 	}
 }
+
+// Stop Run döngüsünü sonlandırır. Birden fazla kez çağrılabilir.
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() { close(h.done) })
+}
+
+// DisconnectSessions verilen oturumlara ait tüm client'ların bağlantısını hemen keser.
+// Oturum iptal edildiğinde servisler tarafından istek işlenirken çağrılır; bu yüzden hub durmuşsa
+// veya kuyruk disconnectTimeout boyunca doluysa beklemeden döner. Oturumun token'ları zaten iptal
+// edildiğinden yeni bağlantı açılamaz; yalnızca açık bağlantı kendiliğinden kapanana kadar kalır.
+func (h *Hub) DisconnectSessions(sessionIDs ...string) {
+	if len(sessionIDs) == 0 {
+		return
+	}
+
+	timer := time.NewTimer(disconnectTimeout)
+	defer timer.Stop()
+	select {
+	case h.disconnect <- sessionIDs:
+	case <-h.done:
+		log.Printf("Hub stopped; %d revoked session(s) were not disconnected", len(sessionIDs))
+	case <-timer.C:
+		log.Printf("Hub disconnect queue is full; %d revoked session(s) were not disconnected", len(sessionIDs))
+	}
+}
+
+// disconnectSessions Run döngüsü içinde çalışır; Send kanalının kapatılması WritePump'ın
+// kapatma mesajı gönderip bağlantıyı kapatmasını sağlar.
+func (h *Hub) disconnectSessions(sessionIDs []string) {
+	revoked := make(map[string]bool, len(sessionIDs))
+	for _, id := range sessionIDs {
+		if id != "" {
+			revoked[id] = true
+		}
+	}
+
+	for roomID, roomClients := range h.rooms {
+		for client := range roomClients {
+			if !revoked[client.SessionID] {
+				continue
+			}
+			delete(roomClients, client)
+			close(client.Send)
+			log.Printf("Client disconnected from room '%d' because session was revoked, user %d", roomID, client.UserID)
+		}
+		if len(roomClients) == 0 {
+			delete(h.rooms, roomID)
+		}
+	}
+}
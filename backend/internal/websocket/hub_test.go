@@ -0,0 +1,32 @@
+package websocket_test
+
+import (
+	"testing"
+	"time"
+
+	"event/backend/internal/config"
+	"event/backend/internal/websocket"
+)
+
+// Oturum sonlandırma istek içinde çağrıldığından hub meşgul ya da durmuş olsa bile beklememelidir
+func TestDisconnectSessionsDoesNotBlock(t *testing.T) {
+	hub := websocket.NewHub(websocket.HubInput{Config: config.Default()})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Run çalışmıyorken istekler kuyrukta bekler
+		hub.DisconnectSessions("aile-1")
+		hub.Stop()
+		// Kuyruk dolu olsa bile durmuş hub'a gönderim hemen döner
+		for i := 0; i < 100; i++ {
+			hub.DisconnectSessions("aile-2")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("DisconnectSessions hub çalışmıyorken bloklandı")
+	}
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"fmt"
+
+	"event/backend/internal/config"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Desteklenen veritabanı sürücüleri; gorm.Dialector.Name() ile aynı değerlerdir
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// dialector yapılandırmadaki sürücü için GORM dialector'ünü oluşturur.
+// SQLite sürücüsü saf Go'dur (cgo gerektirmez); yerel geliştirme ve CI'da MySQL sunucusu olmadan çalışmayı sağlar.
+func dialector(cfg *config.Config) (gorm.Dialector, error) {
+	switch cfg.DBDriver {
+	case DriverMySQL:
+		return mysql.Open(cfg.GetDSN()), nil
+	case DriverPostgres:
+		return postgres.Open(cfg.GetDSN()), nil
+	case DriverSQLite:
+		return sqlite.Open(cfg.GetDSN()), nil
+	default:
+		return nil, fmt.Errorf("desteklenmeyen veritabanı sürücüsü: %q", cfg.DBDriver)
+	}
+}
+
+// RandomOrder veritabanının rastgele sıralama ifadesini döndürür (Order ile kullanılır)
+func RandomOrder(db *gorm.DB) string {
+	if db.Dialector.Name() == DriverMySQL {
+		return "RAND()"
+	}
+	return "RANDOM()"
+}
@@ -0,0 +1,189 @@
+// Package ical RFC 5545 iCalendar verisini bileşen ağacı olarak tutar ve metne dönüştürür.
+// Satırlar CRLF ile biter ve 75 baytı geçenler UTF-8 karakterleri bölünmeden katlanır.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// dateTimeLayout UTC zamanlar için biçim (sonunda Z bulunur)
+	dateTimeLayout = "20060102T150405Z"
+	// localDateTimeLayout TZID parametresiyle yazılan yerel zamanlar için biçim
+	localDateTimeLayout = "20060102T150405"
+	// maxLineOctets katlanmadan önce bir satırın alabileceği en fazla bayt
+	maxLineOctets = 75
+)
+
+// Param bir özelliğin parametresidir (ör. TZID=Europe/Istanbul)
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property bileşenin tek bir içerik satırıdır. Value kaçışları uygulanmış ham değerdir.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param verilen adlı parametrenin değerini döndürür
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// Component VCALENDAR, VEVENT, VTIMEZONE gibi bir iCalendar bileşenidir
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// NewCalendar prodID ile tanımlanan, Gregoryen takvimli boş bir VCALENDAR oluşturur
+func NewCalendar(prodID string) *Component {
+	cal := &Component{Name: "VCALENDAR"}
+	cal.Add("VERSION", "2.0")
+	cal.Add("PRODID", prodID)
+	cal.Add("CALSCALE", "GREGORIAN")
+	return cal
+}
+
+// Add ham değerli bir özellik ekler
+func (c *Component) Add(name, value string, params ...Param) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// AddText metin değerli bir özelliği kaçış karakterleriyle ekler
+func (c *Component) AddText(name, text string, params ...Param) {
+	c.Add(name, EscapeText(text), params...)
+}
+
+// AddTime zaman değerli bir özellik ekler. loc nil veya UTC ise zaman UTC olarak,
+// değilse o saat dilimindeki yerel zaman TZID parametresiyle yazılır.
+func (c *Component) AddTime(name string, t time.Time, loc *time.Location, params ...Param) {
+	if loc == nil || loc == time.UTC {
+		c.Add(name, t.UTC().Format(dateTimeLayout), params...)
+		return
+	}
+	params = append([]Param{{Name: "TZID", Value: loc.String()}}, params...)
+	c.Add(name, t.In(loc).Format(localDateTimeLayout), params...)
+}
+
+// AddTimes AddTime gibi çalışır; birden fazla zamanı virgülle ayırarak tek satırda yazar (ör. EXDATE)
+func (c *Component) AddTimes(name string, times []time.Time, loc *time.Location) {
+	if len(times) == 0 {
+		return
+	}
+	values := make([]string, len(times))
+	var params []Param
+	for i, t := range times {
+		if loc == nil || loc == time.UTC {
+			values[i] = t.UTC().Format(dateTimeLayout)
+		} else {
+			values[i] = t.In(loc).Format(localDateTimeLayout)
+		}
+	}
+	if loc != nil && loc != time.UTC {
+		params = []Param{{Name: "TZID", Value: loc.String()}}
+	}
+	c.Add(name, strings.Join(values, ","), params...)
+}
+
+// AddComponent alt bileşen ekler
+func (c *Component) AddComponent(child *Component) {
+	c.Components = append(c.Components, child)
+}
+
+// Encode bileşeni ve alt bileşenlerini iCalendar metni olarak w'ye yazar
+func (c *Component) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	c.encode(bw)
+	return bw.Flush()
+}
+
+// String bileşenin iCalendar metnini döndürür
+func (c *Component) String() string {
+	var sb strings.Builder
+	_ = c.Encode(&sb)
+	return sb.String()
+}
+
+// encode bileşeni satır satır yazar
+func (c *Component) encode(w *bufio.Writer) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		writeLine(w, p.line())
+	}
+	for _, child := range c.Components {
+		child.encode(w)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+// line özelliği katlanmamış içerik satırı olarak döndürür
+func (p *Property) line() string {
+	var sb strings.Builder
+	sb.WriteString(p.Name)
+	for _, param := range p.Params {
+		sb.WriteByte(';')
+		sb.WriteString(param.Name)
+		sb.WriteByte('=')
+		sb.WriteString(quoteParam(param.Value))
+	}
+	sb.WriteByte(':')
+	sb.WriteString(p.Value)
+	return sb.String()
+}
+
+// writeLine satırı 75 baytlık parçalara katlayarak yazar; devam satırları bir boşlukla başlar
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		// UTF-8 karakterin ortasından bölmemek için devam baytlarını geri al
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+// quoteParam ':', ';' veya ',' içeren parametre değerlerini tırnak içine alır.
+// Parametre değerlerinde çift tırnak bulunamayacağından çıkarılır.
+func quoteParam(value string) string {
+	value = strings.ReplaceAll(value, `"`, "")
+	value = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// textEscaper TEXT değerlerindeki özel karakterleri kaçışlar
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// EscapeText metni TEXT değeri olarak yazılabilecek biçime getirir
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -0,0 +1,204 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	got := EscapeText("a,b;c\\d\nsatır")
+	want := `a\,b\;c\\d\nsatır`
+	if got != want {
+		t.Fatalf("EscapeText = %q, %q olmalı", got, want)
+	}
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	event := &Component{Name: "VEVENT"}
+	event.AddText("SUMMARY", strings.Repeat("ğ", 60))
+	event.Add("X-TEST", "a", Param{Name: "CN", Value: `Ad "Soyad": x`})
+
+	out := event.String()
+	if !strings.HasSuffix(out, "END:VEVENT\r\n") {
+		t.Fatalf("satırlar CRLF ile bitmeli: %q", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	for _, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Fatalf("satır %d bayt, en fazla %d olmalı: %q", len(line), maxLineOctets, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Fatalf("satır UTF-8 karakterin ortasından bölünmüş: %q", line)
+		}
+	}
+
+	// Devam satırları birleştirildiğinde özgün değer elde edilmeli
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("ğ", 60)+"\r\n") {
+		t.Fatalf("katlanmış satır geri açılamadı: %q", unfolded)
+	}
+	if !strings.Contains(unfolded, `X-TEST;CN="Ad Soyad: x":a`) {
+		t.Fatalf("parametre değeri tırnaklanmalı: %q", unfolded)
+	}
+}
+
+func TestAddTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("saat dilimi veritabanı yok")
+	}
+	at := time.Date(2026, 7, 1, 16, 0, 0, 0, time.UTC)
+
+	event := &Component{Name: "VEVENT"}
+	event.AddTime("DTSTART", at, berlin)
+	event.AddTime("DTSTAMP", at, nil)
+	event.AddTimes("EXDATE", []time.Time{at, at.AddDate(0, 0, 7)}, berlin)
+
+	out := event.String()
+	for _, want := range []string{
+		"DTSTART;TZID=Europe/Berlin:20260701T180000\r\n",
+		"DTSTAMP:20260701T160000Z\r\n",
+		"EXDATE;TZID=Europe/Berlin:20260701T180000,20260708T180000\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("%q bekleniyordu:\n%s", want, out)
+		}
+	}
+}
+
+func TestTimezoneTransitions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("saat dilimi veritabanı yok")
+	}
+	tz := Timezone(berlin, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(tz.Components) != 3 {
+		t.Fatalf("başlangıç ve iki geçiş bekleniyordu, %d bileşen var:\n%s", len(tz.Components), tz)
+	}
+	want := []struct {
+		name, start, from, to string
+	}{
+		{"STANDARD", "20260101T010000", "+0100", "+0100"},
+		{"DAYLIGHT", "20260329T020000", "+0100", "+0200"},
+		{"STANDARD", "20261025T030000", "+0200", "+0100"},
+	}
+	for i, w := range want {
+		c := tz.Components[i]
+		props := map[string]string{}
+		for _, p := range c.Properties {
+			props[p.Name] = p.Value
+		}
+		if c.Name != w.name || props["DTSTART"] != w.start || props["TZOFFSETFROM"] != w.from || props["TZOFFSETTO"] != w.to {
+			t.Fatalf("%d. bileşen %s %v, beklenen %+v", i, c.Name, props, w)
+		}
+	}
+
+	fixed := Timezone(time.FixedZone("Sabit", 3*3600), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(fixed.Components) != 1 {
+		t.Fatalf("geçişi olmayan dilimde tek bileşen olmalı:\n%s", fixed)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	cal := NewCalendar("-//Test//TR")
+	event := &Component{Name: "VEVENT"}
+	event.AddText("SUMMARY", strings.Repeat("ğ", 50)+", bölüm; 1\nikinci satır")
+	event.Add("ATTENDEE", "mailto:a@example.com", Param{Name: "CN", Value: "Soyad: Ad"})
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	event.AddTime("DTSTART", time.Date(2026, 3, 29, 10, 0, 0, 0, berlin), berlin)
+	cal.AddComponent(event)
+
+	parsed, err := Parse(strings.NewReader(cal.String()))
+	if err != nil {
+		t.Fatalf("Parse hatası: %v", err)
+	}
+	events := parsed.Children("VEVENT")
+	if parsed.Name != "VCALENDAR" || len(events) != 1 {
+		t.Fatalf("VCALENDAR içinde tek VEVENT bekleniyordu: %+v", parsed)
+	}
+	if got, want := events[0].Text("SUMMARY"), strings.Repeat("ğ", 50)+", bölüm; 1\nikinci satır"; got != want {
+		t.Fatalf("SUMMARY = %q, %q olmalı", got, want)
+	}
+	if got := events[0].Property("ATTENDEE").Param("CN"); got != "Soyad: Ad" {
+		t.Fatalf("tırnaklı parametre = %q", got)
+	}
+	start, allDay, err := events[0].Property("DTSTART").Time(time.UTC)
+	if err != nil || allDay {
+		t.Fatalf("DTSTART ayrıştırılamadı: %v", err)
+	}
+	if want := time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("DTSTART = %v, %v olmalı", start, want)
+	}
+}
+
+func TestParseTimes(t *testing.T) {
+	istanbul, _ := time.LoadLocation("Europe/Istanbul")
+	tests := []struct {
+		line   string
+		want   time.Time
+		allDay bool
+	}{
+		{"DTSTART:20261102T180000Z", time.Date(2026, 11, 2, 18, 0, 0, 0, time.UTC), false},
+		{"DTSTART:20261102T180000", time.Date(2026, 11, 2, 15, 0, 0, 0, time.UTC), false},
+		{"DTSTART;VALUE=DATE:20261102", time.Date(2026, 11, 1, 21, 0, 0, 0, time.UTC), true},
+		{"DTSTART;TZID=America/New_York:20261102T180000", time.Date(2026, 11, 2, 23, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		prop, err := parseLine(tt.line)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.line, err)
+		}
+		got, allDay, err := prop.Time(istanbul)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.line, err)
+		}
+		if !got.Equal(tt.want) || allDay != tt.allDay {
+			t.Errorf("%s = %v (tam gün %v), %v (tam gün %v) olmalı", tt.line, got.UTC(), allDay, tt.want, tt.allDay)
+		}
+	}
+
+	prop, _ := parseLine("DTSTART;TZID=Bilinmeyen/Dilim:20261102T180000")
+	if _, _, err := prop.Time(time.UTC); err == nil {
+		t.Fatal("bilinmeyen saat dilimi reddedilmeli")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nSUMMARY;CN=\"kapanmamış:x\r\nEND:VCALENDAR\r\n",
+		"SUMMARY:bileşen dışında\r\n",
+		"BEGIN:VCALENDAR\r\n",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("%q için hata bekleniyordu", input)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M":   90 * time.Minute,
+		"P1D":       24 * time.Hour,
+		"P2W":       14 * 24 * time.Hour,
+		"P1DT2H":    26 * time.Hour,
+		"-PT15M":    -15 * time.Minute,
+		"PT45S":     45 * time.Second,
+		"P1DT0H30M": 24*time.Hour + 30*time.Minute,
+	}
+	for input, want := range tests {
+		got, err := ParseDuration(input)
+		if err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; %v olmalı", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "P", "PT", "1H", "P1H", "PT1D", "P1DT"} {
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) hata vermeli", input)
+		}
+	}
+}
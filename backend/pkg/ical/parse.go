@@ -0,0 +1,313 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout VALUE=DATE biçimindeki tam gün değerleri için biçim
+const dateLayout = "20060102"
+
+// Parse iCalendar metnini bileşen ağacına ayrıştırır ve en dıştaki bileşeni (genellikle VCALENDAR) döndürür.
+// Katlanmış satırlar birleştirilir; hem CRLF hem LF satır sonları kabul edilir. Değerlerdeki kaçışlar
+// çözülmez; TEXT değerleri için UnescapeText kullanılır.
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+	for _, l := range lines {
+		prop, err := parseLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("satır %d: %w", l.number, err)
+		}
+		switch {
+		case strings.EqualFold(prop.Name, "BEGIN"):
+			c := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("satır %d: birden fazla kök bileşen", l.number)
+				}
+				root = c
+			} else {
+				stack[len(stack)-1].AddComponent(c)
+			}
+			stack = append(stack, c)
+		case strings.EqualFold(prop.Name, "END"):
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1].Name, prop.Value) {
+				return nil, fmt.Errorf("satır %d: beklenmeyen END:%s", l.number, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("satır %d: bileşen dışında özellik", l.number)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+	}
+	if root == nil {
+		return nil, errors.New("iCalendar verisi boş")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%s bileşeni kapatılmamış", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// contentLine katlaması açılmış bir satır ve dosyadaki ilk satır numarası
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfoldLines satırları okur ve boşluk veya sekmeyle başlayan devam satırlarını öncekiyle birleştirir
+func unfoldLines(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var lines []contentLine
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, contentLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine "AD;PARAM=değer:değer" biçimindeki içerik satırını ayrıştırır.
+// Tırnak içindeki parametre değerleri ':', ';' ve ',' içerebilir.
+func parseLine(line string) (Property, error) {
+	var prop Property
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, errors.New("geçersiz içerik satırı")
+	}
+	prop.Name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, errors.New("geçersiz parametre")
+		}
+		param := Param{Name: strings.ToUpper(rest[:eq])}
+		j := i + 1 + eq + 1
+		var value strings.Builder
+		for j < len(line) && line[j] != ';' && line[j] != ':' {
+			if line[j] == '"' {
+				end := strings.IndexByte(line[j+1:], '"')
+				if end < 0 {
+					return prop, errors.New("kapatılmamış tırnak")
+				}
+				value.WriteString(line[j+1 : j+1+end])
+				j += end + 2
+				continue
+			}
+			value.WriteByte(line[j])
+			j++
+		}
+		if j >= len(line) {
+			return prop, errors.New("özellik değeri eksik")
+		}
+		param.Value = value.String()
+		prop.Params = append(prop.Params, param)
+		i = j
+	}
+
+	prop.Value = line[i+1:]
+	return prop, nil
+}
+
+// textUnescaper EscapeText'in tersini yapar
+var textUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+// UnescapeText TEXT değerindeki kaçışları çözer
+func UnescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+// Property verilen adlı ilk özelliği döndürür; yoksa nil
+func (c *Component) Property(name string) *Property {
+	for i := range c.Properties {
+		if strings.EqualFold(c.Properties[i].Name, name) {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// PropertiesNamed verilen adlı tüm özellikleri döndürür
+func (c *Component) PropertiesNamed(name string) []Property {
+	var result []Property
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Children verilen adlı alt bileşenleri döndürür
+func (c *Component) Children(name string) []*Component {
+	var result []*Component
+	for _, child := range c.Components {
+		if strings.EqualFold(child.Name, name) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// Text özelliğin kaçışları çözülmüş TEXT değerini döndürür; özellik yoksa boş metin
+func (c *Component) Text(name string) string {
+	if p := c.Property(name); p != nil {
+		return UnescapeText(p.Value)
+	}
+	return ""
+}
+
+// Location özelliğin TZID parametresindeki saat dilimini döndürür. TZID yoksa zaman UTC ("Z") veya
+// yüzen (floating) zamandır ve nil döner.
+func (p *Property) Location() (*time.Location, error) {
+	tzid := p.Param("TZID")
+	if tzid == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return nil, fmt.Errorf("bilinmeyen saat dilimi: %s", tzid)
+	}
+	return loc, nil
+}
+
+// Times özelliğin virgülle ayrılmış zaman değerlerini ayrıştırır. TZID'li zamanlar o dilimde, "Z" ile bitenler
+// UTC'de, diğerleri (yüzen zamanlar ve tam gün değerleri) floating diliminde yorumlanır.
+// allDay değerlerin VALUE=DATE biçiminde olup olmadığını bildirir.
+func (p *Property) Times(floating *time.Location) (times []time.Time, allDay bool, err error) {
+	loc, err := p.Location()
+	if err != nil {
+		return nil, false, err
+	}
+	if loc == nil {
+		loc = floating
+	}
+	for _, value := range strings.Split(p.Value, ",") {
+		value = strings.TrimSpace(value)
+		var t time.Time
+		switch {
+		case len(value) == len(dateLayout) || strings.EqualFold(p.Param("VALUE"), "DATE"):
+			t, err = time.ParseInLocation(dateLayout, value, loc)
+			allDay = true
+		case strings.HasSuffix(value, "Z"):
+			t, err = time.Parse(dateTimeLayout, value)
+		default:
+			t, err = time.ParseInLocation(localDateTimeLayout, value, loc)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("geçersiz %s değeri: %s", p.Name, value)
+		}
+		times = append(times, t)
+	}
+	return times, allDay, nil
+}
+
+// Time özelliğin tek zaman değerini ayrıştırır; ayrıntılar için Times
+func (p *Property) Time(floating *time.Location) (time.Time, bool, error) {
+	times, allDay, err := p.Times(floating)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(times) != 1 {
+		return time.Time{}, false, fmt.Errorf("%s tek bir zaman içermeli", p.Name)
+	}
+	return times[0], allDay, nil
+}
+
+// ParseDuration RFC 5545 süre değerini (ör. PT1H30M, P1D, P2W) ayrıştırır
+func ParseDuration(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("geçersiz süre: %s", s)
+	value := strings.ToUpper(strings.TrimSpace(s))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, invalid
+	}
+	value = value[1:]
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+	}
+	var total time.Duration
+	inTime, timeParts := false, 0
+	number := ""
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == 'T':
+			if inTime || number != "" {
+				return 0, invalid
+			}
+			inTime = true
+		case ch >= '0' && ch <= '9':
+			number += string(ch)
+		default:
+			unit, ok := units[ch]
+			// Tarih kısmında yalnızca W ve D, saat kısmında yalnızca H, M ve S geçerlidir
+			if !ok || number == "" || inTime != (ch == 'H' || ch == 'M' || ch == 'S') {
+				return 0, invalid
+			}
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, invalid
+			}
+			total += time.Duration(n) * unit
+			number = ""
+			if inTime {
+				timeParts++
+			}
+		}
+	}
+	if number != "" || (inTime && timeParts == 0) {
+		return 0, invalid
+	}
+	return sign * total, nil
+}
@@ -0,0 +1,79 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timezone loc için [from, to) aralığındaki UTC farkı geçişlerini içeren bir VTIMEZONE bileşeni üretir.
+// Go saat dilimi veritabanı geçiş kurallarını dışa açmadığından her geçiş ayrı bir STANDARD/DAYLIGHT
+// alt bileşeni olarak yazılır; aralıkta geçiş yoksa tek bir sabit fark yazılır.
+func Timezone(loc *time.Location, from, to time.Time) *Component {
+	tz := &Component{Name: "VTIMEZONE"}
+	tz.Add("TZID", loc.String())
+
+	current := from.In(loc)
+	name, offset := current.Zone()
+	tz.AddComponent(observance(current.IsDST(), current, name, offset, offset))
+
+	// Geçişler en az birkaç hafta arayla olduğundan günlük adımlarla aranır, bulunan gün içinde saniyesine kadar daraltılır
+	for t := from; t.Before(to); {
+		next := t.Add(24 * time.Hour)
+		if _, nextOffset := next.In(loc).Zone(); nextOffset != offset {
+			at := findTransition(loc, t, next, offset)
+			after := at.In(loc)
+			newName, newOffset := after.Zone()
+			// DTSTART geçişten önceki farkla ifade edilen yerel zamandır
+			before := at.In(time.FixedZone("", offset))
+			tz.AddComponent(observance(after.IsDST(), before, newName, offset, newOffset))
+			offset = newOffset
+		}
+		t = next
+	}
+	return tz
+}
+
+// findTransition (lo, hi] aralığında UTC farkının değiştiği ilk saniyeyi ikili aramayla bulur
+func findTransition(loc *time.Location, lo, hi time.Time, offset int) time.Time {
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if mid.Equal(lo) {
+			break
+		}
+		if _, o := mid.In(loc).Zone(); o == offset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// observance tek bir STANDARD veya DAYLIGHT alt bileşeni oluşturur
+func observance(dst bool, start time.Time, name string, offsetFrom, offsetTo int) *Component {
+	c := &Component{Name: "STANDARD"}
+	if dst {
+		c.Name = "DAYLIGHT"
+	}
+	c.Add("DTSTART", start.Format(localDateTimeLayout))
+	c.Add("TZOFFSETFROM", formatOffset(offsetFrom))
+	c.Add("TZOFFSETTO", formatOffset(offsetTo))
+	if name != "" {
+		c.AddText("TZNAME", name)
+	}
+	return c
+}
+
+// formatOffset saniye cinsinden UTC farkını ±hhmm (gerekirse ±hhmmss) biçiminde döndürür
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	h, m, s := seconds/3600, seconds%3600/60, seconds%60
+	if s != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, h, m)
+}
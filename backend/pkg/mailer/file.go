@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileMailer e-postaları göndermek yerine outbox dizinine .eml dosyası olarak yazar.
+// Yerel geliştirme ve testlerde gerçek bir SMTP sunucusuna ihtiyaç duymadan kullanılır.
+type FileMailer struct {
+	dir  string
+	from string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewFileMailer yeni bir FileMailer oluşturur
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+// Send e-postayı outbox dizinine yazar
+func (m *FileMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("outbox dizini oluşturulamadı: %v", err)
+	}
+
+	m.seq++
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%03d-%s.eml", time.Now().Format("20060102T150405"), m.seq, recipient)
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg), 0o644); err != nil {
+		return fmt.Errorf("e-posta outbox'a yazılamadı: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"event/backend/internal/config"
+)
+
+// Message gönderilecek bir e-postayı temsil eder
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer e-posta gönderimi için ortak arayüz
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// NewFromConfig yapılandırmaya göre uygun Mailer'ı döndürür.
+// SMTP_HOST tanımlıysa SMTP, değilse yerel geliştirme için dosya tabanlı outbox kullanılır.
+func NewFromConfig(cfg *config.Config) Mailer {
+	if cfg.SMTPHost != "" {
+		return NewSMTPMailer(SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUser,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	}
+	return NewFileMailer(cfg.MailOutboxDir, cfg.MailFrom)
+}
+
+// buildMessage RFC 5322 uyumlu düz metin bir e-posta oluşturur
+func buildMessage(from string, msg Message) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&sb, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&sb, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(sb.String())
+}
@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+)
+
+// SMTPConfig SMTP sunucu bağlantı bilgilerini içerir
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer e-postaları bir SMTP sunucusu üzerinden gönderir
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer yeni bir SMTPMailer oluşturur
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: cfg}
+}
+
+// Send e-postayı SMTP ile gönderir. Kullanıcı adı boşsa kimlik doğrulama yapılmaz.
+func (m *SMTPMailer) Send(msg Message) error {
+	addr := fmt.Sprintf("%s:%s", m.config.Host, m.config.Port)
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{msg.To}, buildMessage(m.config.From, msg)); err != nil {
+		return fmt.Errorf("e-posta gönderilemedi: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// lockName MySQL GET_LOCK için kilit adı
+	lockName = "event_schema_migrations"
+	// advisoryLockKey PostgreSQL pg_advisory_lock için sabit anahtar
+	advisoryLockKey int64 = 7_301_452_118
+	// staleLockAfter SQLite kilit satırının, onu alan süreç çöktüyse bayat sayılacağı süre
+	staleLockAfter = 15 * time.Minute
+	// lockPollInterval SQLite kilidinin yeniden deneme aralığı
+	lockPollInterval = 250 * time.Millisecond
+)
+
+// migrationLock SQLite'ta kilit olarak kullanılan tek satırlık tablo
+type migrationLock struct {
+	ID       uint `gorm:"primaryKey"`
+	LockedAt time.Time
+}
+
+func (migrationLock) TableName() string {
+	return "schema_migrations_lock"
+}
+
+// withLock fn'i veritabanı düzeyinde bir kilit altında çalıştırır; böylece aynı anda açılan birden fazla örnek
+// migration'ları yarıştırmaz. Kilidi bekleyen örnek, diğeri bitirdiğinde yalnızca kalan migration'ları görür.
+// MySQL ve PostgreSQL'de bağlantıya bağlı danışma kilitleri kullanılır; süreç çökerse kilit bağlantıyla birlikte bırakılır.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if m.lockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.lockTimeout)
+		defer cancel()
+	}
+
+	var (
+		release func() error
+		err     error
+	)
+	switch m.db.Dialector.Name() {
+	case "mysql", "postgres":
+		release, err = m.advisoryLock(ctx)
+	default:
+		release, err = m.tableLock(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := release(); err != nil {
+			log.Printf("[Migrate.withLock] Migration kilidi bırakılamadı: %v", err)
+		}
+	}()
+
+	return fn()
+}
+
+// advisoryLock kilidi ayrılmış bir bağlantı üzerinde alır; migration'lar havuzdaki diğer bağlantılarla çalışır
+func (m *Migrator) advisoryLock(ctx context.Context) (func() error, error) {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migration kilidi için bağlantı alınamadı: %w", err)
+	}
+
+	var unlock string
+	var key interface{}
+	if m.db.Dialector.Name() == "mysql" {
+		// GET_LOCK -1 ile süresiz bekler; bekleme ctx ile sınırlanır. Başarılıysa 1 döner.
+		var ok sql.NullInt64
+		unlock, key = "SELECT RELEASE_LOCK(?)", lockName
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", key).Scan(&ok)
+		if err == nil && ok.Int64 != 1 {
+			err = errors.New("GET_LOCK başarısız")
+		}
+	} else {
+		unlock, key = "SELECT pg_advisory_unlock($1)", advisoryLockKey
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("migration kilidi alınamadı: %w", err)
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), unlock, key)
+		return err
+	}, nil
+}
+
+// tableLock kilidi schema_migrations_lock tablosuna tek satır ekleyerek alır. Satırı ekleyemeyen örnek
+// kilit bırakılana kadar bekler; staleLockAfter'dan eski satırlar çökmüş bir süreçten kaldığı için silinir.
+func (m *Migrator) tableLock(ctx context.Context) (func() error, error) {
+	// AutoMigrate yerine IF NOT EXISTS: aynı anda açılan örnekler tabloyu birlikte oluşturmaya çalışabilir
+	if err := m.db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations_lock (id INTEGER PRIMARY KEY, locked_at TIMESTAMP NOT NULL)").Error; err != nil {
+		return nil, fmt.Errorf("migration kilit tablosu oluşturulamadı: %w", err)
+	}
+
+	for {
+		if err := m.db.Where("locked_at < ?", time.Now().Add(-staleLockAfter)).Delete(&migrationLock{}).Error; err != nil {
+			return nil, fmt.Errorf("bayat migration kilidi silinemedi: %w", err)
+		}
+		result := m.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&migrationLock{ID: 1, LockedAt: time.Now()})
+		if result.Error != nil {
+			return nil, fmt.Errorf("migration kilidi alınamadı: %w", result.Error)
+		}
+		if result.RowsAffected == 1 {
+			return func() error {
+				return m.db.Delete(&migrationLock{}, 1).Error
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("migration kilidi beklenirken zaman aşımı: %w", ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
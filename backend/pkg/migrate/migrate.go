@@ -0,0 +1,232 @@
+// Package migrate sürümlü veritabanı migration'larını uygular ve geri alır.
+// Uygulanan sürümler schema_migrations tablosunda tutulur; her migration kendi transaction'ında çalışır
+// ve sürüm kaydı aynı transaction'da yazılır. MySQL'de DDL ifadeleri örtük commit yaptığından
+// yarıda kalan bir migration'ın şema değişiklikleri geri alınamaz; bu yüzden migration'lar küçük tutulmalıdır.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration tek bir şema veya veri değişikliğini tanımlar. Version benzersiz ve artan olmalıdır;
+// uygulanmış bir migration sonradan değiştirilmemelidir, yeni bir sürüm eklenmelidir.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	// Down nil ise migration geri alınamaz
+	Down func(tx *gorm.DB) error
+}
+
+// SQL verilen ifadeleri sırayla çalıştıran bir Up/Down fonksiyonu döndürür.
+// İfadeler tüm desteklenen veritabanlarında geçerli olmalıdır.
+func SQL(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// schemaMigration schema_migrations tablosundaki bir satır
+type schemaMigration struct {
+	Version   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:255;not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status bir migration'ın veritabanındaki durumunu gösterir
+type Status struct {
+	Version uint64
+	Name    string
+	// AppliedAt nil ise migration bekliyor
+	AppliedAt *time.Time
+	// Unknown veritabanında uygulanmış ama bu sürümde tanımlı olmayan migration'lar için true'dur
+	Unknown bool
+}
+
+// Migrator migration'ları bir veritabanına uygular
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+// New sürümlere göre sıralanmış bir Migrator oluşturur. Tekrarlanan sürümler ve Up'ı olmayan
+// migration'lar programlama hatası sayılır.
+func New(db *gorm.DB, migrations []Migration, lockTimeout time.Duration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version == 0 || m.Up == nil {
+			return nil, fmt.Errorf("migration %d (%s): sürüm ve Up zorunludur", m.Version, m.Name)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration sürümü tekrarlanıyor: %d", m.Version)
+		}
+	}
+
+	return &Migrator{db: db, migrations: sorted, lockTimeout: lockTimeout}, nil
+}
+
+// Up bekleyen tüm migration'ları sırayla uygular ve uygulananları döndürür
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func() error {
+		done, err := m.appliedVersions()
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			if err := m.apply(mig); err != nil {
+				return err
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down son uygulanan steps adet migration'ı tersten geri alır ve geri alınanları döndürür
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func() error {
+		done, err := m.appliedVersions()
+		if err != nil {
+			return err
+		}
+		known := make(map[uint64]Migration, len(m.migrations))
+		for _, mig := range m.migrations {
+			known[mig.Version] = mig
+		}
+
+		versions := make([]uint64, 0, len(done))
+		for v := range done {
+			versions = append(versions, v)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for _, v := range versions {
+			if len(reverted) == steps {
+				break
+			}
+			mig, ok := known[v]
+			if !ok {
+				return fmt.Errorf("migration %d bu sürümde tanımlı değil; geri alınamaz", v)
+			}
+			if mig.Down == nil {
+				return fmt.Errorf("migration %d (%s) geri alınamaz", mig.Version, mig.Name)
+			}
+			if err := m.revert(mig); err != nil {
+				return err
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status tanımlı ve uygulanmış tüm migration'ları sürüm sırasıyla döndürür
+func (m *Migrator) Status() ([]Status, error) {
+	done, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if row, ok := done[mig.Version]; ok {
+			appliedAt := row.AppliedAt
+			s.AppliedAt = &appliedAt
+			delete(done, mig.Version)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, row := range done {
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, Status{Version: row.Version, Name: row.Name, AppliedAt: &appliedAt, Unknown: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Pending henüz uygulanmamış migration'ları döndürür
+func (m *Migrator) Pending() ([]Migration, error) {
+	done, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := done[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) apply(mig Migration) error {
+	start := time.Now()
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d (%s) uygulanamadı: %w", mig.Version, mig.Name, err)
+	}
+	log.Printf("[Migrate.Up] %d_%s uygulandı (%s)", mig.Version, mig.Name, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func (m *Migrator) revert(mig Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, mig.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d (%s) geri alınamadı: %w", mig.Version, mig.Name, err)
+	}
+	log.Printf("[Migrate.Down] %d_%s geri alındı", mig.Version, mig.Name)
+	return nil
+}
+
+// appliedVersions schema_migrations tablosunu gerekirse oluşturur ve uygulanmış sürümleri döndürür
+func (m *Migrator) appliedVersions() (map[uint64]schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("schema_migrations tablosu oluşturulamadı: %w", err)
+	}
+	var rows []schemaMigration
+	if err := m.db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("uygulanmış migration'lar okunamadı: %w", err)
+	}
+	done := make(map[uint64]schemaMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+	return done, nil
+}
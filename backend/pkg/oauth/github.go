@@ -0,0 +1,120 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GitHub OAuth uç noktaları. GitHub OIDC desteklemediği için kullanıcı bilgileri REST API'den alınır.
+const (
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubAPIURL   = "https://api.github.com"
+)
+
+// GitHubProvider GitHub hesabı ile giriş sağlayıcısı
+type GitHubProvider struct {
+	config   Config
+	client   *http.Client
+	authURL  string
+	tokenURL string
+	apiURL   string
+}
+
+// NewGitHubProvider yeni bir GitHub sağlayıcısı oluşturur
+func NewGitHubProvider(cfg Config) *GitHubProvider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"read:user", "user:email"}
+	}
+	return &GitHubProvider{
+		config:   cfg,
+		client:   defaultHTTPClient(),
+		authURL:  githubAuthURL,
+		tokenURL: githubTokenURL,
+		apiURL:   githubAPIURL,
+	}
+}
+
+// WithEndpoints uç noktaları değiştirir (GitHub Enterprise veya testler için)
+func (p *GitHubProvider) WithEndpoints(authURL, tokenURL, apiURL string) *GitHubProvider {
+	p.authURL = authURL
+	p.tokenURL = tokenURL
+	p.apiURL = strings.TrimRight(apiURL, "/")
+	return p
+}
+
+// WithHTTPClient sağlayıcı isteklerinde kullanılacak HTTP istemcisini değiştirir
+func (p *GitHubProvider) WithHTTPClient(client *http.Client) *GitHubProvider {
+	p.client = client
+	return p
+}
+
+// Name sağlayıcının adını döndürür
+func (p *GitHubProvider) Name() string {
+	return "github"
+}
+
+// AuthCodeURL yetkilendirme adresini üretir. GitHub nonce kullanmaz.
+func (p *GitHubProvider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	return buildAuthURL(p.authURL, p.config, state, codeChallenge, nil)
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// Exchange kodu token ile değiştirir ve kullanıcının profilini ve doğrulanmış birincil e-postasını alır
+func (p *GitHubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*UserInfo, error) {
+	tok, err := exchangeCode(ctx, p.client, p.tokenURL, p.config, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var user githubUser
+	if err := getJSON(ctx, p.client, p.apiURL+"/user", tok.AccessToken, &user); err != nil {
+		return nil, fmt.Errorf("GitHub kullanıcı bilgisi alınamadı: %v", err)
+	}
+	if user.ID == 0 {
+		return nil, errors.New("GitHub kullanıcı kimliği alınamadı")
+	}
+
+	info := &UserInfo{
+		Subject:           fmt.Sprintf("%d", user.ID),
+		Name:              user.Name,
+		PreferredUsername: user.Login,
+		Picture:           user.AvatarURL,
+	}
+	if parts := strings.SplitN(strings.TrimSpace(user.Name), " ", 2); len(parts) == 2 {
+		info.GivenName, info.FamilyName = parts[0], parts[1]
+	}
+
+	// Profildeki e-posta doğrulanmış olmayabilir; doğrulanmış birincil adres ayrıca sorgulanır
+	var emails []githubEmail
+	if err := getJSON(ctx, p.client, p.apiURL+"/user/emails", tok.AccessToken, &emails); err == nil {
+		for _, e := range emails {
+			if e.Primary && e.Verified {
+				info.Email = e.Email
+				info.EmailVerified = true
+				break
+			}
+		}
+	}
+	if info.Email == "" {
+		info.Email = user.Email
+	}
+
+	return info, nil
+}
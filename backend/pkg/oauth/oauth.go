@@ -0,0 +1,185 @@
+// Package oauth, OAuth2 yetkilendirme kodu akışını (PKCE ile) ve OpenID Connect
+// kimlik doğrulamasını harici kütüphaneye ihtiyaç duymadan uygular.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// UserInfo sağlayıcıdan alınan ve hesap eşleştirmede kullanılan kullanıcı bilgileri
+type UserInfo struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	GivenName         string
+	FamilyName        string
+	PreferredUsername string
+	Picture           string
+}
+
+// Provider bir OAuth2/OIDC kimlik sağlayıcısını temsil eder
+type Provider interface {
+	// Name sağlayıcının URL'lerde ve veritabanında kullanılan adı (örn. "google")
+	Name() string
+	// AuthCodeURL kullanıcının yönlendirileceği yetkilendirme adresini üretir
+	AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error)
+	// Exchange yetkilendirme kodunu token ile değiştirir ve kullanıcı bilgilerini döndürür
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*UserInfo, error)
+}
+
+// Config tüm sağlayıcılar için ortak istemci ayarları
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// ErrProviderNotFound yapılandırılmamış bir sağlayıcı istendiğinde döner
+var ErrProviderNotFound = errors.New("kimlik sağlayıcı bulunamadı")
+
+// GenerateCodeVerifier RFC 7636'ya uygun rastgele bir PKCE code_verifier üretir
+func GenerateCodeVerifier() (string, error) {
+	return randomURLSafe(32)
+}
+
+// CodeChallengeS256 code_verifier için S256 code_challenge değerini hesaplar
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// GenerateState CSRF koruması için rastgele bir state değeri üretir
+func GenerateState() (string, error) {
+	return randomURLSafe(32)
+}
+
+// GenerateNonce OIDC id_token tekrar saldırılarına karşı rastgele bir nonce üretir
+func GenerateNonce() (string, error) {
+	return randomURLSafe(16)
+}
+
+func randomURLSafe(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// tokenResponse token endpoint'inin yanıtı
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// buildAuthURL yetkilendirme adresine standart ve PKCE parametrelerini ekler
+func buildAuthURL(endpoint string, cfg Config, state, codeChallenge string, extra url.Values) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("geçersiz yetkilendirme adresi: %v", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", cfg.RedirectURL)
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	if len(cfg.Scopes) > 0 {
+		q.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	for k, v := range extra {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// exchangeCode yetkilendirme kodunu code_verifier ile token endpoint'inde değiştirir
+func exchangeCode(ctx context.Context, client *http.Client, tokenURL string, cfg Config, code, codeVerifier string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", cfg.RedirectURL)
+	form.Set("client_id", cfg.ClientID)
+	form.Set("client_secret", cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tok tokenResponse
+	if err := doJSON(client, req, &tok); err != nil {
+		return nil, fmt.Errorf("token alınamadı: %v", err)
+	}
+	if tok.Error != "" {
+		return nil, fmt.Errorf("token alınamadı: %s %s", tok.Error, tok.ErrorDescription)
+	}
+	if tok.AccessToken == "" {
+		return nil, errors.New("token alınamadı: yanıtta access_token yok")
+	}
+	return &tok, nil
+}
+
+// getJSON Bearer token ile bir JSON kaynağını okur
+func getJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return doJSON(client, req, out)
+}
+
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	// Token endpoint'i hata durumunda da JSON gövde döndürür; önce gövdeyi çözmeyi dene
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("beklenmeyen yanıt (HTTP %d)", resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		if tok, ok := out.(*tokenResponse); ok && tok.Error != "" {
+			return nil
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// defaultHTTPClient sağlayıcı istekleri için zaman aşımı olan istemci
+func defaultHTTPClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second}
+}
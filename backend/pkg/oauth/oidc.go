@@ -0,0 +1,302 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// discoveryDocument OIDC sağlayıcısının /.well-known/openid-configuration yanıtı
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// jsonWebKey JWKS içindeki tek bir anahtar
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwksDocument struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// idTokenClaims id_token içinden okunan alanlar
+type idTokenClaims struct {
+	Nonce             string      `json:"nonce"`
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"`
+	Name              string      `json:"name"`
+	GivenName         string      `json:"given_name"`
+	FamilyName        string      `json:"family_name"`
+	PreferredUsername string      `json:"preferred_username"`
+	Picture           string      `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// jwksRefreshInterval bilinmeyen bir kid ile karşılaşıldığında anahtarların en sık yenilenme aralığı
+const jwksRefreshInterval = time.Minute
+
+// OIDCProvider discovery belgesi üzerinden yapılandırılan genel bir OpenID Connect sağlayıcısı.
+// Google ve diğer standart OIDC sağlayıcıları bu tip ile kullanılır.
+type OIDCProvider struct {
+	name   string
+	issuer string
+	config Config
+	client *http.Client
+
+	mu          sync.Mutex
+	discovery   *discoveryDocument
+	keys        map[string]interface{}
+	keysFetched time.Time
+}
+
+// NewOIDCProvider yeni bir OIDC sağlayıcısı oluşturur. Discovery belgesi ilk kullanımda alınır.
+func NewOIDCProvider(name, issuer string, cfg Config) *OIDCProvider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &OIDCProvider{
+		name:   name,
+		issuer: strings.TrimRight(issuer, "/"),
+		config: cfg,
+		client: defaultHTTPClient(),
+	}
+}
+
+// NewGoogleProvider Google hesabı ile giriş için OIDC sağlayıcısı oluşturur
+func NewGoogleProvider(cfg Config) *OIDCProvider {
+	return NewOIDCProvider("google", "https://accounts.google.com", cfg)
+}
+
+// WithHTTPClient sağlayıcı isteklerinde kullanılacak HTTP istemcisini değiştirir
+func (p *OIDCProvider) WithHTTPClient(client *http.Client) *OIDCProvider {
+	p.client = client
+	return p
+}
+
+// Name sağlayıcının adını döndürür
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL yetkilendirme adresini nonce ile birlikte üretir
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+	return buildAuthURL(doc.AuthorizationEndpoint, p.config, state, codeChallenge, map[string][]string{
+		"nonce": {nonce},
+	})
+}
+
+// Exchange kodu token ile değiştirir, id_token imzasını ve içeriğini doğrular
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*UserInfo, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := exchangeCode(ctx, p.client, doc.TokenEndpoint, p.config, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("sağlayıcı id_token döndürmedi")
+	}
+
+	claims, err := p.verifyIDToken(ctx, doc, tok.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("id_token nonce değeri eşleşmiyor")
+	}
+
+	info := &UserInfo{
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     parseBoolClaim(claims.EmailVerified),
+		Name:              claims.Name,
+		GivenName:         claims.GivenName,
+		FamilyName:        claims.FamilyName,
+		PreferredUsername: claims.PreferredUsername,
+		Picture:           claims.Picture,
+	}
+	if info.Subject == "" {
+		return nil, errors.New("id_token sub değeri içermiyor")
+	}
+	return info, nil
+}
+
+// verifyIDToken id_token imzasını JWKS ile, iss/aud/exp alanlarını yapılandırmayla doğrular
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, doc *discoveryDocument, raw string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, doc, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id_token doğrulanamadı: %v", err)
+	}
+	return claims, nil
+}
+
+// getDiscovery discovery belgesini bir kez alır ve önbellekte tutar
+func (p *OIDCProvider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var doc discoveryDocument
+	if err := getJSON(ctx, p.client, p.issuer+"/.well-known/openid-configuration", "", &doc); err != nil {
+		return nil, fmt.Errorf("OIDC discovery belgesi alınamadı: %v", err)
+	}
+	if strings.TrimRight(doc.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("OIDC issuer eşleşmiyor: beklenen %s, gelen %s", p.issuer, doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery belgesi eksik")
+	}
+
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+// getKey kid'e ait imza anahtarını döndürür. Bilinmeyen kid için (anahtar rotasyonu) JWKS yeniden alınır.
+func (p *OIDCProvider) getKey(ctx context.Context, doc *discoveryDocument, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("imza anahtarı bulunamadı (kid: %s)", kid)
+	}
+
+	var set jwksDocument
+	if err := getJSON(ctx, p.client, doc.JWKSURI, "", &set); err != nil {
+		return nil, fmt.Errorf("JWKS alınamadı: %v", err)
+	}
+
+	keys := make(map[string]interface{})
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	p.keys = keys
+	p.keysFetched = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("imza anahtarı bulunamadı (kid: %s)", kid)
+}
+
+// lookupKey kid boşsa ve tek anahtar varsa onu kullanır
+func (p *OIDCProvider) lookupKey(kid string) (interface{}, bool) {
+	if p.keys == nil {
+		return nil, false
+	}
+	if key, ok := p.keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// publicKey JWK'yı RSA veya ECDSA açık anahtarına çevirir
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("desteklenmeyen eğri: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("desteklenmeyen anahtar tipi: %s", k.Kty)
+	}
+}
+
+// parseBoolClaim bazı sağlayıcıların email_verified değerini metin olarak göndermesini tolere eder
+func parseBoolClaim(v interface{}) bool {
+	switch val := v.(type) {
+	case bool:
+		return val
+	case string:
+		return val == "true"
+	default:
+		return false
+	}
+}
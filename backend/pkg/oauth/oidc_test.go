@@ -0,0 +1,326 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIssuer discovery, JWKS ve PKCE doğrulayan token endpoint'i sunan sahte bir OIDC sağlayıcısı
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	mu sync.Mutex
+	// codes verilen yetkilendirme kodlarını code_challenge ve nonce ile eşler
+	codes map[string]issuedCode
+	// claims bir sonraki id_token'a eklenecek/değiştirilecek alanlar
+	claims jwt.MapClaims
+}
+
+type issuedCode struct {
+	challenge string
+	nonce     string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("RSA anahtarı üretilemedi: %v", err)
+	}
+
+	m := &mockIssuer{t: t, key: key, kid: "test-key", codes: make(map[string]issuedCode)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.handleDiscovery)
+	mux.HandleFunc("/jwks", m.handleJWKS)
+	mux.HandleFunc("/token", m.handleToken)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockIssuer) issuer() string {
+	return m.server.URL
+}
+
+// authorize, kullanıcının sağlayıcıda giriş yaptığını varsayarak yetkilendirme adresine karşılık bir kod üretir
+func (m *mockIssuer) authorize(authURL string) string {
+	m.t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("yetkilendirme adresi çözümlenemedi: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		m.t.Fatalf("code_challenge_method S256 olmalı, gelen: %q", q.Get("code_challenge_method"))
+	}
+
+	code := "code-" + q.Get("state")
+	m.mu.Lock()
+	m.codes[code] = issuedCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	m.mu.Unlock()
+	return code
+}
+
+func (m *mockIssuer) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 m.issuer(),
+		"authorization_endpoint": m.issuer() + "/authorize",
+		"token_endpoint":         m.issuer() + "/token",
+		"jwks_uri":               m.issuer() + "/jwks",
+	})
+}
+
+func (m *mockIssuer) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := m.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": m.kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (m *mockIssuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	issued, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	extra := m.claims
+	m.mu.Unlock()
+
+	if !ok || CodeChallengeS256(r.PostForm.Get("code_verifier")) != issued.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":            m.issuer(),
+		"sub":            "user-123",
+		"aud":            r.PostForm.Get("client_id"),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          issued.nonce,
+		"email":          "ayse@example.com",
+		"email_verified": true,
+		"given_name":     "Ayşe",
+		"family_name":    "Yılmaz",
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = m.kid
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		m.t.Fatalf("id_token imzalanamadı: %v", err)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestProvider(m *mockIssuer) *OIDCProvider {
+	return NewOIDCProvider("test", m.issuer(), Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:5173/oauth/callback/test",
+	}).WithHTTPClient(m.server.Client())
+}
+
+// startFlow akışı başlatır ve sağlayıcıdan dönen kodu, verifier'ı ve nonce'u döndürür
+func startFlow(t *testing.T, p *OIDCProvider, m *mockIssuer) (code, verifier, nonce string) {
+	t.Helper()
+
+	state, _ := GenerateState()
+	verifier, _ = GenerateCodeVerifier()
+	nonce, _ = GenerateNonce()
+
+	authURL, err := p.AuthCodeURL(context.Background(), state, CodeChallengeS256(verifier), nonce)
+	if err != nil {
+		t.Fatalf("AuthCodeURL hata döndürdü: %v", err)
+	}
+	if !strings.HasPrefix(authURL, m.issuer()+"/authorize?") {
+		t.Fatalf("beklenmeyen yetkilendirme adresi: %s", authURL)
+	}
+	return m.authorize(authURL), verifier, nonce
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+
+	code, verifier, nonce := startFlow(t, p, m)
+	info, err := p.Exchange(context.Background(), code, verifier, nonce)
+	if err != nil {
+		t.Fatalf("Exchange hata döndürdü: %v", err)
+	}
+
+	if info.Subject != "user-123" {
+		t.Errorf("Subject = %q, beklenen %q", info.Subject, "user-123")
+	}
+	if info.Email != "ayse@example.com" || !info.EmailVerified {
+		t.Errorf("e-posta bilgisi hatalı: %q doğrulanmış=%v", info.Email, info.EmailVerified)
+	}
+	if info.GivenName != "Ayşe" || info.FamilyName != "Yılmaz" {
+		t.Errorf("ad bilgisi hatalı: %q %q", info.GivenName, info.FamilyName)
+	}
+}
+
+func TestOIDCProviderRejectsWrongCodeVerifier(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+
+	code, _, nonce := startFlow(t, p, m)
+	otherVerifier, _ := GenerateCodeVerifier()
+	if _, err := p.Exchange(context.Background(), code, otherVerifier, nonce); err == nil {
+		t.Fatal("yanlış code_verifier ile Exchange başarılı olmamalı")
+	}
+}
+
+func TestOIDCProviderRejectsNonceMismatch(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+
+	code, verifier, _ := startFlow(t, p, m)
+	if _, err := p.Exchange(context.Background(), code, verifier, "baska-nonce"); err == nil {
+		t.Fatal("nonce eşleşmezken Exchange başarılı olmamalı")
+	}
+}
+
+func TestOIDCProviderRejectsInvalidIDTokenClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"farklı audience", jwt.MapClaims{"aud": "baska-istemci"}},
+		{"farklı issuer", jwt.MapClaims{"iss": "https://evil.example.com"}},
+		{"süresi dolmuş", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockIssuer(t)
+			m.claims = tt.claims
+			p := newTestProvider(m)
+
+			code, verifier, nonce := startFlow(t, p, m)
+			if _, err := p.Exchange(context.Background(), code, verifier, nonce); err == nil {
+				t.Fatal("geçersiz id_token kabul edilmemeli")
+			}
+		})
+	}
+}
+
+func TestOIDCProviderRejectsForeignSignature(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m)
+
+	// Sağlayıcı JWKS'i aldıktan sonra token'lar başka bir anahtarla imzalanır
+	code, verifier, nonce := startFlow(t, p, m)
+	if _, err := p.Exchange(context.Background(), code, verifier, nonce); err != nil {
+		t.Fatalf("ilk Exchange hata döndürdü: %v", err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("RSA anahtarı üretilemedi: %v", err)
+	}
+	m.mu.Lock()
+	m.key = otherKey
+	m.mu.Unlock()
+
+	// JWKS de yeni anahtarı yayınladığı için kid aynı kaldığında eski önbellekteki anahtarla doğrulama başarısız olur
+	code, verifier, nonce = startFlow(t, p, m)
+	if _, err := p.Exchange(context.Background(), code, verifier, nonce); err == nil {
+		t.Fatal("önbellekteki anahtarla eşleşmeyen imza kabul edilmemeli")
+	}
+}
+
+func TestGitHubProviderExchange(t *testing.T) {
+	var challenge string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if CodeChallengeS256(r.PostForm.Get("code_verifier")) != challenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/api/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 42, "login": "octocat", "name": "Mona Lisa"})
+	})
+	mux.HandleFunc("/api/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []map[string]interface{}{
+			{"email": "other@example.com", "primary": false, "verified": true},
+			{"email": "octocat@example.com", "primary": true, "verified": true},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewGitHubProvider(Config{ClientID: "id", ClientSecret: "secret", RedirectURL: "http://localhost/cb"}).
+		WithEndpoints(server.URL+"/authorize", server.URL+"/token", server.URL+"/api").
+		WithHTTPClient(server.Client())
+
+	verifier, _ := GenerateCodeVerifier()
+	challenge = CodeChallengeS256(verifier)
+	authURL, err := p.AuthCodeURL(context.Background(), "state", challenge, "")
+	if err != nil {
+		t.Fatalf("AuthCodeURL hata döndürdü: %v", err)
+	}
+	if !strings.Contains(authURL, "code_challenge="+challenge) {
+		t.Fatalf("yetkilendirme adresinde code_challenge yok: %s", authURL)
+	}
+
+	info, err := p.Exchange(context.Background(), "code", verifier, "")
+	if err != nil {
+		t.Fatalf("Exchange hata döndürdü: %v", err)
+	}
+	if info.Subject != "42" || info.PreferredUsername != "octocat" {
+		t.Errorf("kullanıcı bilgisi hatalı: %+v", info)
+	}
+	if info.Email != "octocat@example.com" || !info.EmailVerified {
+		t.Errorf("doğrulanmış birincil e-posta seçilmeli, gelen: %q (%v)", info.Email, info.EmailVerified)
+	}
+}
@@ -0,0 +1,46 @@
+package oauth
+
+import (
+	"strings"
+
+	"event/backend/internal/config"
+)
+
+// ProvidersFromConfig istemci kimliği tanımlanmış sağlayıcıları ada göre döndürür.
+// Her sağlayıcının dönüş adresi OAuthRedirectBaseURL + "/" + ad şeklindedir.
+func ProvidersFromConfig(cfg *config.Config) map[string]Provider {
+	providers := make(map[string]Provider)
+	redirect := func(name string) string {
+		return strings.TrimRight(cfg.OAuthRedirectBaseURL, "/") + "/" + name
+	}
+
+	if cfg.GoogleClientID != "" {
+		p := NewGoogleProvider(Config{
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURL:  redirect("google"),
+		})
+		providers[p.Name()] = p
+	}
+
+	if cfg.GitHubClientID != "" {
+		p := NewGitHubProvider(Config{
+			ClientID:     cfg.GitHubClientID,
+			ClientSecret: cfg.GitHubClientSecret,
+			RedirectURL:  redirect("github"),
+		})
+		providers[p.Name()] = p
+	}
+
+	if cfg.OIDCIssuer != "" && cfg.OIDCClientID != "" {
+		name := cfg.OIDCProviderName
+		p := NewOIDCProvider(name, cfg.OIDCIssuer, Config{
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  redirect(name),
+		})
+		providers[p.Name()] = p
+	}
+
+	return providers
+}
@@ -0,0 +1,500 @@
+// Package rrule RFC 5545 tekrar kurallarının (RRULE) etkinlikler için gereken alt kümesini ayrıştırır ve açar.
+// Desteklenen parçalar: FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY ve WKST.
+// Tekrarlar DTSTART'ın saat diliminde duvar saatine göre üretilir; böylece yaz saati geçişlerinde
+// etkinlik yerel saatinde kalır.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency kuralın tekrar sıklığı
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxIterations sonsuz veya çok seyrek eşleşen kurallarda açma döngüsünün üst sınırı
+const maxIterations = 100000
+
+// dateTimeLayout ve dateLayout RFC 5545 temel tarih biçimleri
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum BYDAY listesindeki bir gün. N sıfırdan farklıysa aylık kurallarda ayın N. (negatifse sondan N.) günüdür.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+// Rule ayrıştırılmış bir tekrar kuralı
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// Count sıfırsa sınırsızdır; Count ve Until birlikte kullanılamaz
+	Count int
+	// Until sıfır değilse son tekrarın başlangıcı için üst sınırdır (dahil)
+	Until time.Time
+	// untilDate UNTIL yalnızca tarih olarak verildiyse true; bu durumda o günün sonu DTSTART'ın saat diliminde hesaplanır
+	untilDate  bool
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	WeekStart  time.Weekday
+}
+
+// Parse "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10" biçimindeki kuralı ayrıştırır. Başta "RRULE:" öneki olabilir.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+	if s == "" {
+		return nil, errors.New("tekrar kuralı boş")
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("geçersiz tekrar kuralı parçası: %q", part)
+		}
+		key = strings.ToUpper(key)
+		value = strings.ToUpper(value)
+		if seen[key] {
+			return nil, fmt.Errorf("tekrar kuralında %s birden fazla kez verilmiş", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case Daily, Weekly, Monthly:
+				rule.Freq = Frequency(value)
+			default:
+				return nil, fmt.Errorf("desteklenmeyen tekrar sıklığı: %s (DAILY, WEEKLY veya MONTHLY olmalı)", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("geçersiz INTERVAL: %s", value)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("geçersiz COUNT: %s", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, dateOnly, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until, rule.untilDate = until, dateOnly
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				day, err := parseWeekdayNum(item)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(item)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("geçersiz BYMONTHDAY: %s", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "WKST":
+			day, ok := weekdayCodes[value]
+			if !ok {
+				return nil, fmt.Errorf("geçersiz WKST: %s", value)
+			}
+			rule.WeekStart = day
+		default:
+			return nil, fmt.Errorf("desteklenmeyen tekrar kuralı parçası: %s", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("tekrar kuralında FREQ zorunludur")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, errors.New("tekrar kuralında COUNT ve UNTIL birlikte kullanılamaz")
+	}
+	if rule.Freq != Monthly {
+		for _, day := range rule.ByDay {
+			if day.N != 0 {
+				return nil, fmt.Errorf("sıralı BYDAY (%s) yalnızca MONTHLY kurallarda kullanılabilir", day)
+			}
+		}
+	}
+	if rule.Freq == Weekly && len(rule.ByMonthDay) > 0 {
+		return nil, errors.New("BYMONTHDAY WEEKLY kurallarda kullanılamaz")
+	}
+	return rule, nil
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("geçersiz BYDAY: %s", s)
+	}
+	day, ok := weekdayCodes[s[len(s)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("geçersiz BYDAY: %s", s)
+	}
+	result := WeekdayNum{Day: day}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("geçersiz BYDAY: %s", s)
+		}
+		result.N = n
+	}
+	return result, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateTimeLayout, value); err == nil {
+		return t, false, nil
+	}
+	// Saat dilimi belirtilmemiş tarih-saat UTC kabul edilir
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("geçersiz UNTIL: %s", value)
+}
+
+// String kuralı "RRULE:" öneki olmadan RFC 5545 biçiminde döndürür
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(dateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(dateTimeLayout))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// SetUntil kuralı verilen ana kadar (dahil) sınırlar ve COUNT'u kaldırır
+func (r *Rule) SetUntil(t time.Time) {
+	r.Count = 0
+	r.Until = t.UTC().Truncate(time.Second)
+	r.untilDate = false
+}
+
+// until DTSTART'ın saat dilimine göre etkin üst sınırı döndürür
+func (r *Rule) until(loc *time.Location) time.Time {
+	if r.Until.IsZero() || !r.untilDate {
+		return r.Until
+	}
+	y, m, d := r.Until.Date()
+	return time.Date(y, m, d, 23, 59, 59, 0, loc)
+}
+
+// Iterate kuralın dtstart'tan itibaren ürettiği tekrarları sırayla fn'e verir; fn false dönerse durur.
+// dtstart her zaman ilk tekrar sayılmaz: yalnızca kurala uyuyorsa üretilir (RFC 5545'te DTSTART'ın
+// kurala uyması beklenir).
+func (r *Rule) Iterate(dtstart time.Time, fn func(time.Time) bool) {
+	loc := dtstart.Location()
+	until := r.until(loc)
+	emitted := 0
+	for period := 0; period < maxIterations; period++ {
+		for _, t := range r.candidates(dtstart, period) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !until.IsZero() && t.After(until) {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// Between [from, to) aralığında başlayan tekrarları döndürür
+func (r *Rule) Between(dtstart, from, to time.Time) []time.Time {
+	var result []time.Time
+	r.Iterate(dtstart, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return true
+	})
+	return result
+}
+
+// Includes t anının kuralın ürettiği tekrarlardan biri olup olmadığını döndürür
+func (r *Rule) Includes(dtstart, t time.Time) bool {
+	found := false
+	r.Iterate(dtstart, func(occurrence time.Time) bool {
+		if occurrence.Equal(t) {
+			found = true
+		}
+		return occurrence.Before(t)
+	})
+	return found
+}
+
+// CountBefore t anından önce başlayan tekrar sayısını döndürür
+func (r *Rule) CountBefore(dtstart, t time.Time) int {
+	n := 0
+	r.Iterate(dtstart, func(occurrence time.Time) bool {
+		if !occurrence.Before(t) {
+			return false
+		}
+		n++
+		return true
+	})
+	return n
+}
+
+// candidates verilen periyottaki (gün, hafta veya ay) aday tekrarları sıralı olarak döndürür
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	hour, min, sec := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, min, sec, dtstart.Nanosecond(), loc)
+	}
+	y, m, d := dtstart.Date()
+
+	switch r.Freq {
+	case Daily:
+		t := at(y, m, d+period*r.Interval)
+		if r.matchesDay(t) {
+			return []time.Time{t}
+		}
+		return nil
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []WeekdayNum{{Day: dtstart.Weekday()}}
+		}
+		// Haftanın başı WKST'ye göre hesaplanır; INTERVAL hafta atlamaları bu başlangıca göre yapılır
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := d - offset + period*7*r.Interval
+		var result []time.Time
+		for i := 0; i < 7; i++ {
+			t := at(y, m, weekStart+i)
+			for _, day := range days {
+				if t.Weekday() == day.Day {
+					result = append(result, t)
+					break
+				}
+			}
+		}
+		return result
+
+	case Monthly:
+		first := time.Date(y, m+time.Month(period*r.Interval), 1, 0, 0, 0, 0, loc)
+		year, month := first.Year(), first.Month()
+		monthDays := r.monthDays(year, month, d)
+		result := make([]time.Time, 0, len(monthDays))
+		for _, day := range monthDays {
+			result = append(result, at(year, month, day))
+		}
+		return result
+	}
+	return nil
+}
+
+// matchesDay günlük kurallarda BYDAY ve BYMONTHDAY süzgeçlerini uygular
+func (r *Rule) matchesDay(t time.Time) bool {
+	if len(r.ByDay) > 0 {
+		ok := false
+		for _, day := range r.ByDay {
+			if t.Weekday() == day.Day {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.ByMonthDay) > 0 {
+		last := daysIn(t.Year(), t.Month())
+		ok := false
+		for _, md := range r.ByMonthDay {
+			if resolveMonthDay(md, last) == t.Day() {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// monthDays aylık kuralda verilen aydaki geçerli günleri artan sırada döndürür.
+// Ayda bulunmayan günler (ör. 31 Şubat) atlanır.
+func (r *Rule) monthDays(year int, month time.Month, startDay int) []int {
+	last := daysIn(year, month)
+
+	var byMonthDay map[int]bool
+	if len(r.ByMonthDay) > 0 || len(r.ByDay) == 0 {
+		byMonthDay = make(map[int]bool)
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{startDay}
+		}
+		for _, md := range days {
+			if day := resolveMonthDay(md, last); day > 0 {
+				byMonthDay[day] = true
+			}
+		}
+	}
+
+	var byDay map[int]bool
+	if len(r.ByDay) > 0 {
+		byDay = make(map[int]bool)
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		for _, wd := range r.ByDay {
+			// Ayın ilk wd.Day günü
+			first := 1 + (int(wd.Day)-int(firstWeekday)+7)%7
+			var matches []int
+			for day := first; day <= last; day += 7 {
+				matches = append(matches, day)
+			}
+			switch {
+			case wd.N == 0:
+				for _, day := range matches {
+					byDay[day] = true
+				}
+			case wd.N > 0 && wd.N <= len(matches):
+				byDay[matches[wd.N-1]] = true
+			case wd.N < 0 && -wd.N <= len(matches):
+				byDay[matches[len(matches)+wd.N]] = true
+			}
+		}
+	}
+
+	var result []int
+	for day := 1; day <= last; day++ {
+		if byMonthDay != nil && !byMonthDay[day] {
+			continue
+		}
+		if byDay != nil && !byDay[day] {
+			continue
+		}
+		result = append(result, day)
+	}
+	return result
+}
+
+// resolveMonthDay negatif ay günlerini (-1 = son gün) çözer; ayda olmayan günler için 0 döner
+func resolveMonthDay(md, last int) int {
+	if md < 0 {
+		md = last + md + 1
+	}
+	if md < 1 || md > last {
+		return 0
+	}
+	return md
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// ShiftDays kuralın gün seçicilerini days gün kaydırır. "Bu ve sonrakiler" düzenlemesinde başlangıç
+// başka bir güne taşındığında yeni serinin aynı düzeni koruması için kullanılır.
+func (r *Rule) ShiftDays(days int) error {
+	if days == 0 {
+		return nil
+	}
+	for i, wd := range r.ByDay {
+		if wd.N != 0 {
+			return errors.New("sıralı BYDAY kullanan kurallarda tekrarlar başka bir güne taşınamaz")
+		}
+		r.ByDay[i].Day = time.Weekday(((int(wd.Day)+days)%7 + 7) % 7)
+	}
+	for i, md := range r.ByMonthDay {
+		shifted := md + days
+		if md < 0 || shifted < 1 || shifted > 28 {
+			return errors.New("bu değişiklik tekrar kuralının ay günleriyle uyumlu değil")
+		}
+		r.ByMonthDay[i] = shifted
+	}
+	return nil
+}
+
+// Clone kuralın bağımsız bir kopyasını döndürür
+func (r *Rule) Clone() *Rule {
+	clone := *r
+	clone.ByDay = append([]WeekdayNum(nil), r.ByDay...)
+	clone.ByMonthDay = append([]int(nil), r.ByMonthDay...)
+	return &clone
+}
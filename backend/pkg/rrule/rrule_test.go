@@ -0,0 +1,191 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, s string) *Rule {
+	t.Helper()
+	rule, err := Parse(s)
+	if err != nil {
+		t.Fatalf("%q ayrıştırılamadı: %v", s, err)
+	}
+	return rule
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+	return loc
+}
+
+func formatAll(times []time.Time) []string {
+	result := make([]string, len(times))
+	for i, t := range times {
+		result[i] = t.Format("2006-01-02 15:04 MST")
+	}
+	return result
+}
+
+func assertTimes(t *testing.T, got []time.Time, want ...string) {
+	t.Helper()
+	formatted := formatAll(got)
+	if len(formatted) != len(want) {
+		t.Fatalf("%d tekrar bekleniyordu, %d geldi: %v", len(want), len(formatted), formatted)
+	}
+	for i := range want {
+		if formatted[i] != want[i] {
+			t.Fatalf("%d. tekrar %q olmalı, %q geldi (tümü: %v)", i, want[i], formatted[i], formatted)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	utc := time.UTC
+	far := time.Date(2100, 1, 1, 0, 0, 0, 0, utc)
+
+	cases := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []string
+	}{
+		{
+			name:    "günlük COUNT",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2026, 1, 30, 9, 0, 0, 0, utc),
+			want:    []string{"2026-01-30 09:00 UTC", "2026-01-31 09:00 UTC", "2026-02-01 09:00 UTC"},
+		},
+		{
+			name:    "iki haftada bir pazartesi ve çarşamba",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=4",
+			dtstart: time.Date(2026, 10, 19, 18, 0, 0, 0, utc), // Pazartesi
+			want: []string{
+				"2026-10-19 18:00 UTC", "2026-10-21 18:00 UTC",
+				"2026-11-02 18:00 UTC", "2026-11-04 18:00 UTC",
+			},
+		},
+		{
+			name:    "haftalık kural DTSTART'tan önceki günleri atlar",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+			dtstart: time.Date(2026, 10, 21, 18, 0, 0, 0, utc), // Çarşamba
+			want:    []string{"2026-10-23 18:00 UTC", "2026-10-26 18:00 UTC", "2026-10-30 18:00 UTC"},
+		},
+		{
+			name:    "aylık 31'i olmayan ayları atlar",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: time.Date(2026, 1, 31, 12, 0, 0, 0, utc),
+			want:    []string{"2026-01-31 12:00 UTC", "2026-03-31 12:00 UTC", "2026-05-31 12:00 UTC"},
+		},
+		{
+			name:    "ayın son cuması",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			dtstart: time.Date(2026, 10, 30, 20, 0, 0, 0, utc),
+			want:    []string{"2026-10-30 20:00 UTC", "2026-11-27 20:00 UTC", "2026-12-25 20:00 UTC"},
+		},
+		{
+			name:    "ayın son günü",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			dtstart: time.Date(2027, 1, 31, 10, 0, 0, 0, utc),
+			want:    []string{"2027-01-31 10:00 UTC", "2027-02-28 10:00 UTC", "2027-03-31 10:00 UTC"},
+		},
+		{
+			name:    "UNTIL dahil",
+			rule:    "FREQ=DAILY;UNTIL=20261021T180000Z",
+			dtstart: time.Date(2026, 10, 19, 18, 0, 0, 0, utc),
+			want:    []string{"2026-10-19 18:00 UTC", "2026-10-20 18:00 UTC", "2026-10-21 18:00 UTC"},
+		},
+		{
+			name:    "yalnızca tarih olan UNTIL günün sonuna kadar geçerli",
+			rule:    "FREQ=DAILY;UNTIL=20261020",
+			dtstart: time.Date(2026, 10, 19, 18, 0, 0, 0, utc),
+			want:    []string{"2026-10-19 18:00 UTC", "2026-10-20 18:00 UTC"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := mustParse(t, tc.rule)
+			assertTimes(t, rule.Between(tc.dtstart, tc.dtstart, far), tc.want...)
+		})
+	}
+}
+
+func TestExpandKeepsWallClockAcrossDST(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	rule := mustParse(t, "FREQ=WEEKLY;COUNT=3")
+	// 29 Mart 2026'da Avrupa yaz saatine geçer
+	dtstart := time.Date(2026, 3, 22, 19, 0, 0, 0, berlin)
+
+	got := rule.Between(dtstart, dtstart, dtstart.AddDate(1, 0, 0))
+	assertTimes(t, got, "2026-03-22 19:00 CET", "2026-03-29 19:00 CEST", "2026-04-05 19:00 CEST")
+	if got[1].Sub(got[0]) != 7*24*time.Hour-time.Hour {
+		t.Fatalf("yaz saati geçişinde aradaki süre bir saat kısalmalı, %v", got[1].Sub(got[0]))
+	}
+}
+
+func TestBetweenWindow(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY")
+	dtstart := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+
+	assertTimes(t, rule.Between(dtstart, from, to), "2026-10-10 08:00 UTC", "2026-10-11 08:00 UTC")
+	if !rule.Includes(dtstart, from) {
+		t.Fatal("kurala uyan an tekrar sayılmalı")
+	}
+	if rule.Includes(dtstart, from.Add(time.Hour)) {
+		t.Fatal("kurala uymayan an tekrar sayılmamalı")
+	}
+	if n := rule.CountBefore(dtstart, from); n != 9 {
+		t.Fatalf("10 Ekim'den önce 9 tekrar olmalı, %d", n)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"COUNT=3",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261020T000000Z",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;FREQ=WEEKLY",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%q reddedilmeliydi", s)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2;COUNT=10;BYDAY=MO,WE",
+		"FREQ=MONTHLY;UNTIL=20271231T235959Z;BYDAY=-1FR",
+		"FREQ=MONTHLY;UNTIL=20271231;BYMONTHDAY=1,15;WKST=SU",
+	} {
+		if got := mustParse(t, "RRULE:"+s).String(); got != s {
+			t.Errorf("%q yeniden yazılınca %q oldu", s, got)
+		}
+	}
+}
+
+func TestShiftDays(t *testing.T) {
+	rule := mustParse(t, "FREQ=WEEKLY;BYDAY=SA,MO")
+	if err := rule.ShiftDays(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := rule.String(); got != "FREQ=WEEKLY;BYDAY=SU,TU" {
+		t.Fatalf("günler bir gün kaymalı, %q", got)
+	}
+	if err := mustParse(t, "FREQ=MONTHLY;BYDAY=1MO").ShiftDays(1); err == nil {
+		t.Fatal("sıralı BYDAY kaydırılamamalı")
+	}
+}
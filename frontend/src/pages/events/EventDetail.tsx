@@ -19,6 +19,7 @@ import Avatar from '../../components/common/Avatar';
 import apiService from '../../services/apiService';
 import { formatDate, formatTime } from '../../utils/dateUtils';
 import InviteToEventModal from '../../components/events/InviteToEventModal';
+import type { AttendanceCounts, AttendeeStatus, EventAttendee, RSVPStatus } from '../../types/event';
 
 interface EventDetail {
     id: number;
@@ -29,26 +30,31 @@ interface EventDetail {
     endDate?: string;
     creatorId?: number;
     creatorName: string;
-    attendeesCount: number;
+    attendeesCount: number; // Katılacaklar ve getirdikleri misafirler
     isPrivate: boolean;
     roomId?: number;
     roomName?: string;
-    attendees: {
-        id: number;
-        name: string;
-        avatarUrl?: string;
-    }[];
     imageUrl?: string;
-    isUserAttending?: boolean;
 }
 
-// Katılımcı tipi
-interface Attendee {
-    id: number;
-    name: string;
-    avatarUrl?: string;
-    status: 'attending' | 'invited' | 'declined';
-}
+// Backend'in kabul ettiği en fazla ek misafir sayısı
+const MAX_GUESTS = 10;
+
+// Yanıt seçenekleri ve seçiliyken kullanılan renkler
+const rsvpOptions: { status: RSVPStatus; label: string; activeClassName: string }[] = [
+    { status: 'going', label: 'Katılıyorum', activeClassName: 'bg-green-600 border-green-600 text-white' },
+    { status: 'maybe', label: 'Belki', activeClassName: 'bg-yellow-500 border-yellow-500 text-white' },
+    { status: 'not_going', label: 'Katılmıyorum', activeClassName: 'bg-red-600 border-red-600 text-white' },
+];
+
+// Katılımcı listesindeki durum etiketleri
+const attendeeStatusLabels: Record<AttendeeStatus, { label: string; className: string }> = {
+    going: { label: 'Katılıyor', className: 'bg-green-100 text-green-700' },
+    maybe: { label: 'Belki', className: 'bg-yellow-100 text-yellow-700' },
+    not_going: { label: 'Katılmıyor', className: 'bg-red-100 text-red-700' },
+    waitlisted: { label: 'Bekleme Listesinde', className: 'bg-gray-100 text-gray-700' },
+    invited: { label: 'Davet Edildi', className: 'bg-blue-100 text-blue-700' },
+};
 
 // Zaman seçeneği tipi
 interface TimeOption {
@@ -74,10 +80,14 @@ const EventDetail: React.FC = () => {
     const { user, isAuthenticated } = useAuth();
     const { addNotification } = useApp();
     const [event, setEvent] = useState<EventDetail | null>(null);
-    const [attendees, setAttendees] = useState<Attendee[]>([]);
+    const [attendees, setAttendees] = useState<EventAttendee[]>([]);
+    const [counts, setCounts] = useState<AttendanceCounts | null>(null);
     const [timeOptions, setTimeOptions] = useState<TimeOption[]>([]);
     const [isLoading, setIsLoading] = useState(true);
-    const [isAttending, setIsAttending] = useState(false);
+    // Kullanıcının etkinlikteki durumu; yanıt vermediyse null
+    const [myStatus, setMyStatus] = useState<AttendeeStatus | null>(null);
+    const [guests, setGuests] = useState(0);
+    const [note, setNote] = useState('');
     const [hasSentRequest, setHasSentRequest] = useState(false);
     const [showAllAttendees, setShowAllAttendees] = useState(false);
     const [isSubmitting, setIsSubmitting] = useState(false);
@@ -88,40 +98,28 @@ const EventDetail: React.FC = () => {
     const fetchAttendees = async () => {
         if (!id) return;
         try {
-            const attendeesData = await apiService.get<Attendee[]>(`/api/events/${id}/attendees`);
+            const { attendees: attendeesData, counts: countsData } = await eventService.getAttendees(Number(id));
             setAttendees(attendeesData);
-
-            // Event state'ini güncelle: katılımcı sayısı ve kullanıcının katılım durumu
-            if (user && event) {
-                const userIsAttending = attendeesData.some(attendee =>
-                    attendee.id === user.id && attendee.status === 'attending'
-                );
-                setIsAttending(userIsAttending);
-
-                // Katılımcı sayısını güncelle (sadece katılanları say)
-                const attendingCount = attendeesData.filter(a => a.status === 'attending').length;
-                setEvent(prevEvent => {
-                    if (!prevEvent) return null;
-                    return {
-                        ...prevEvent,
-                        attendeesCount: attendingCount,
-                        isUserAttending: userIsAttending
-                    };
-                });
-            } else if (event) {
-                // Giriş yapmamış kullanıcı için de katılımcı sayısını güncelle
-                const attendingCount = attendeesData.filter(a => a.status === 'attending').length;
-                setEvent(prevEvent => {
-                    if (!prevEvent) return null;
-                    return {
-                        ...prevEvent,
-                        attendeesCount: attendingCount
-                    };
-                });
-            }
+            setCounts(countsData);
+
+            // Kullanıcının kendi yanıtını forma yansıt
+            const mine = user ? attendeesData.find(attendee => attendee.id === user.id) : undefined;
+            setMyStatus(mine?.status ?? null);
+            setGuests(mine?.guests ?? 0);
+            setNote(mine?.note ?? '');
+
+            // Katılımcı sayısı kapasiteden düşülen yerlerdir: katılacaklar ve getirdikleri misafirler
+            setEvent(prevEvent => {
+                if (!prevEvent) return null;
+                return {
+                    ...prevEvent,
+                    attendeesCount: countsData.going + countsData.guests
+                };
+            });
         } catch (error) {
             console.error('Katılımcılar alınırken hata:', error);
             setAttendees([]); // Hata durumunda listeyi temizle
+            setCounts(null);
         }
     };
 
@@ -143,7 +141,10 @@ const EventDetail: React.FC = () => {
                     return;
                 }
 
-                // EventData (Event) EventDetail tipine dönüştürülüyor
+                // EventData (EventDetailResponse) EventDetail tipine dönüştürülüyor
+                const creatorName = eventData.creator
+                    ? `${eventData.creator.first_name} ${eventData.creator.last_name}`.trim() || eventData.creator.username
+                    : undefined;
                 const detailedEventData: EventDetail = {
                     id: eventData.id,
                     title: eventData.title,
@@ -152,17 +153,15 @@ const EventDetail: React.FC = () => {
                     startDate: eventData.final_start_time || eventData.created_at,
                     endDate: eventData.final_end_time,
                     creatorId: eventData.creator_user_id,
-                    creatorName: eventData.creatorName || 'Bilinmiyor',
-                    attendeesCount: eventData.attendeesCount || 0,
+                    creatorName: creatorName || 'Bilinmiyor',
+                    attendeesCount: eventData.attendees_count || 0,
                     isPrivate: eventData.is_private,
                     roomId: eventData.room_id,
                     roomName: undefined, // Room detayı ayrı API çağrısı ile gelecek
                     imageUrl: eventData.image_url,
-                    attendees: [], // Ayrı API çağrısı ile dolacak
-                    isUserAttending: false, // Varsayılan değer, backend'den bu bilgi gelmiyor
                 };
                 setEvent(detailedEventData);
-                setIsAttending(false); // Varsayılan değer
+                setCounts(eventData.attendance_counts);
 
                 // Katılımcıları API'den çek
                 await fetchAttendees();
@@ -232,7 +231,7 @@ const EventDetail: React.FC = () => {
         return <span>{formatTime(event.startDate)}</span>;
     };
 
-    const handleAttendanceToggle = async () => {
+    const handleRSVP = async (status: RSVPStatus) => {
         if (!isAuthenticated) {
             navigate('/giris', { state: { from: `/etkinlikler/${id}` } });
             return;
@@ -240,31 +239,20 @@ const EventDetail: React.FC = () => {
 
         setIsSubmitting(true);
         try {
-            if (isAttending) {
-                await eventService.cancelAttendance(Number(id));
-                addNotification({
-                    type: 'success',
-                    message: 'Etkinlik katılımınız iptal edildi',
-                    duration: 3000
-                });
-
-            } else if (event?.isPrivate) {
-                await eventService.attendEvent(Number(id));
-                addNotification({
-                    type: 'success',
-                    message: 'Özel etkinliğe katılım isteğiniz gönderildi.',
-                    duration: 3000
-                });
+            const message = await eventService.rsvp(Number(id), {
+                status,
+                guests: status === 'not_going' ? 0 : guests,
+                note: note.trim(),
+            });
+            // Özel etkinlikte kaydı olmayan kullanıcının yanıtı katılım isteği olarak iletilir
+            if (event?.isPrivate && !myStatus) {
                 setHasSentRequest(true);
-
-            } else {
-                await eventService.attendEvent(Number(id));
-                addNotification({
-                    type: 'success',
-                    message: 'Etkinliğe katılımınız onaylandı',
-                    duration: 3000
-                });
             }
+            addNotification({
+                type: 'success',
+                message: message || 'Yanıtınız kaydedildi',
+                duration: 3000
+            });
 
             // Katılımcı listesini yenile (bu fonksiyon aynı zamanda state'i de güncelleyecek)
             await fetchAttendees();
@@ -390,18 +378,6 @@ const EventDetail: React.FC = () => {
             )
         }
 
-        if (isAttending) {
-            return (
-                <button
-                    onClick={handleAttendanceToggle}
-                    className="w-full bg-red-600 text-white font-semibold py-3 px-4 rounded-lg hover:bg-red-700 transition-colors duration-300 ease-in-out shadow-md focus:outline-none focus:ring-2 focus:ring-red-500 focus:ring-offset-2"
-                    disabled={isSubmitting}
-                >
-                    {isSubmitting ? 'İptal Ediliyor...' : 'Katılımdan Ayrıl'}
-                </button>
-            );
-        }
-
         if (hasSentRequest) {
             return (
                 <div className="text-center font-semibold text-gray-600 bg-yellow-200 py-3 px-4 rounded-lg">
@@ -410,14 +386,66 @@ const EventDetail: React.FC = () => {
             );
         }
 
+        // Özel etkinlikte henüz kaydı olmayan kullanıcı önce katılım isteği gönderir
+        if (event?.isPrivate && (!myStatus || myStatus === 'invited')) {
+            return (
+                <button
+                    onClick={() => handleRSVP('going')}
+                    className="w-full bg-green-600 text-white font-semibold py-3 px-4 rounded-lg hover:bg-green-700 transition-colors duration-300 ease-in-out shadow-md focus:outline-none focus:ring-2 focus:ring-green-500 focus:ring-offset-2"
+                    disabled={isSubmitting}
+                >
+                    {isSubmitting ? 'İşleniyor...' : 'Katılma İsteği Gönder'}
+                </button>
+            );
+        }
+
         return (
-            <button
-                onClick={handleAttendanceToggle}
-                className="w-full bg-green-600 text-white font-semibold py-3 px-4 rounded-lg hover:bg-green-700 transition-colors duration-300 ease-in-out shadow-md focus:outline-none focus:ring-2 focus:ring-green-500 focus:ring-offset-2"
-                disabled={isSubmitting}
-            >
-                {isSubmitting ? 'İşleniyor...' : (event?.isPrivate ? 'Katılma İsteği Gönder' : 'Katıl')}
-            </button>
+            <div className="flex-1 space-y-3">
+                {myStatus === 'waitlisted' && (
+                    <div className="text-sm font-medium text-gray-700 bg-gray-100 py-2 px-3 rounded-lg">
+                        Etkinlik dolu, bekleme listesindesiniz. Yer açıldığında katılımcılar arasına alınacaksınız.
+                    </div>
+                )}
+                <div className="grid grid-cols-3 gap-2">
+                    {rsvpOptions.map((option) => {
+                        const isSelected = myStatus === option.status || (option.status === 'going' && myStatus === 'waitlisted');
+                        return (
+                            <button
+                                key={option.status}
+                                onClick={() => handleRSVP(option.status)}
+                                disabled={isSubmitting}
+                                className={`py-3 px-2 rounded-lg border text-sm font-semibold transition-colors shadow-sm focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500 ${isSelected
+                                    ? option.activeClassName
+                                    : 'bg-white border-gray-300 text-gray-700 hover:bg-gray-100'
+                                    }`}
+                            >
+                                {option.label}
+                            </button>
+                        );
+                    })}
+                </div>
+                <div className="flex items-center space-x-3">
+                    <label htmlFor="rsvp-guests" className="text-sm text-gray-600">Ek misafir</label>
+                    <input
+                        id="rsvp-guests"
+                        type="number"
+                        min={0}
+                        max={MAX_GUESTS}
+                        value={guests}
+                        onChange={(e) => setGuests(Math.min(MAX_GUESTS, Math.max(0, Number(e.target.value) || 0)))}
+                        className="w-20 border border-gray-300 rounded-lg px-2 py-1 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"
+                    />
+                </div>
+                <textarea
+                    value={note}
+                    onChange={(e) => setNote(e.target.value)}
+                    maxLength={500}
+                    rows={2}
+                    placeholder="Etkinlik sahibine not (isteğe bağlı)"
+                    className="w-full border border-gray-300 rounded-lg px-3 py-2 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"
+                />
+                <p className="text-xs text-gray-500">Misafir sayısını veya notu değiştirdikten sonra yanıtınızı yeniden seçin.</p>
+            </div>
         );
     };
 
@@ -584,14 +612,24 @@ const EventDetail: React.FC = () => {
                                 <div className="bg-white rounded-xl shadow-lg p-6 border border-gray-200">
                                     <div className="flex justify-between items-center mb-5">
                                         <h2 className="text-xl font-semibold text-gray-800">Katılımcılar ve Davetliler</h2>
-                                        {attendees && attendees.length > 0 && (
-                                            <div className="flex space-x-2">
+                                        {counts && (
+                                            <div className="flex flex-wrap gap-2 justify-end">
                                                 <span className="bg-green-100 text-green-800 text-sm font-semibold px-3 py-1 rounded-full">
-                                                    {attendees.filter(a => a.status === 'attending').length} Katılıyor
+                                                    {counts.going} Katılıyor{counts.guests > 0 && ` (+${counts.guests})`}
                                                 </span>
-                                                {attendees.filter(a => a.status === 'invited').length > 0 && (
+                                                {counts.maybe > 0 && (
                                                     <span className="bg-yellow-100 text-yellow-800 text-sm font-semibold px-3 py-1 rounded-full">
-                                                        {attendees.filter(a => a.status === 'invited').length} Davet Edildi
+                                                        {counts.maybe} Belki
+                                                    </span>
+                                                )}
+                                                {counts.waitlisted > 0 && (
+                                                    <span className="bg-gray-100 text-gray-800 text-sm font-semibold px-3 py-1 rounded-full">
+                                                        {counts.waitlisted} Bekliyor
+                                                    </span>
+                                                )}
+                                                {!!counts.invited && (
+                                                    <span className="bg-blue-100 text-blue-800 text-sm font-semibold px-3 py-1 rounded-full">
+                                                        {counts.invited} Davet Edildi
                                                     </span>
                                                 )}
                                             </div>
@@ -610,18 +648,15 @@ const EventDetail: React.FC = () => {
                                                         />
                                                         <div className="flex-1 min-w-0">
                                                             <p className="font-medium text-gray-800 truncate" title={attendee.name}>{attendee.name}</p>
-                                                            <span className={`text-xs font-medium px-1.5 py-0.5 rounded-full inline-block ${attendee.status === 'attending'
-                                                                ? 'bg-green-100 text-green-700'
-                                                                : attendee.status === 'invited'
-                                                                    ? 'bg-yellow-100 text-yellow-700'
-                                                                    : 'bg-red-100 text-red-700' // declined veya diğer durumlar
-                                                                }`}>
-                                                                {attendee.status === 'attending'
-                                                                    ? 'Katılıyor'
-                                                                    : attendee.status === 'invited'
-                                                                        ? 'Davet Edildi'
-                                                                        : 'Katılmıyor'}
+                                                            <span className={`text-xs font-medium px-1.5 py-0.5 rounded-full inline-block ${attendeeStatusLabels[attendee.status]?.className ?? 'bg-gray-100 text-gray-700'}`}>
+                                                                {attendeeStatusLabels[attendee.status]?.label ?? attendee.status}
                                                             </span>
+                                                            {!!attendee.guests && (
+                                                                <span className="ml-2 text-xs text-gray-500">+{attendee.guests} misafir</span>
+                                                            )}
+                                                            {attendee.note && (
+                                                                <p className="text-xs text-gray-500 mt-1 break-words">{attendee.note}</p>
+                                                            )}
                                                         </div>
                                                     </div>
                                                 ))}
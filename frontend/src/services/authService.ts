@@ -106,7 +106,7 @@ const authService = {
      */
     forgotPassword: async (email: string): Promise<void> => {
         try {
-            await apiService.post('/auth/forgot-password', { email });
+            await apiService.post('/api/auth/forgot-password', { email });
         } catch (error) {
             console.error('Forgot password error:', error);
             throw error;
@@ -120,7 +120,7 @@ const authService = {
      */
     resetPassword: async (token: string, newPassword: string): Promise<void> => {
         try {
-            await apiService.post('/auth/reset-password', {
+            await apiService.post('/api/auth/reset-password', {
                 token,
                 password: newPassword,
             });
@@ -167,7 +167,7 @@ const authService = {
      */
     changePassword: async (currentPassword: string, newPassword: string): Promise<void> => {
         try {
-            await apiService.post('/auth/change-password', {
+            await apiService.post('/api/auth/change-password', {
                 currentPassword,
                 newPassword,
             });
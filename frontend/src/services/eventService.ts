@@ -1,7 +1,14 @@
 import apiService from './apiService';
 import type { CreateEventFormData } from '../components/events/CreateEventModal';
 import type { EventItem } from './dashboardService';
-import type { Event, CreateEventDTO } from '../types/event';
+import type { Event, CreateEventDTO, EventAttendees, EventDetailResponse, RSVPDTO } from '../types/event';
+
+// Backend yanıtları { success, message, data } zarfıyla döner
+interface ApiEnvelope<T> {
+    success: boolean;
+    message?: string;
+    data: T;
+}
 
 /**
  * Etkinlik hizmetleri için API servisi
@@ -73,10 +80,10 @@ const eventService = {
      * @param id Etkinlik ID
      * @returns Etkinlik detayı
      */
-    getEventById: async (id: number): Promise<Event | null> => {
+    getEventById: async (id: number): Promise<EventDetailResponse | null> => {
         try {
-            const response = await apiService.get<Event>(`/api/events/${id}`);
-            return response;
+            const response = await apiService.get<ApiEnvelope<EventDetailResponse>>(`/api/events/${id}`);
+            return response.data;
         } catch (error) {
             console.error('Etkinlik detayı alınırken hata oluştu:', error);
             return null;
@@ -222,6 +229,31 @@ const eventService = {
         }
     },
 
+    /**
+     * Etkinliğe yanıt verme (katılıyorum, belki, katılmıyorum)
+     * @param eventId Etkinlik ID
+     * @param rsvp Yanıt, ek misafir sayısı ve not
+     * @returns Sunucunun mesajı (ör. bekleme listesine alındınız)
+     */
+    rsvp: async (eventId: number, rsvp: RSVPDTO): Promise<string | undefined> => {
+        try {
+            const response = await apiService.post<ApiEnvelope<unknown>>(`/api/events/${eventId}/attend`, rsvp);
+            return response.message;
+        } catch (error) {
+            console.error('Etkinlik yanıtı kaydedilirken hata:', error);
+            throw error;
+        }
+    },
+
+    /**
+     * Etkinliğin katılımcılarını ve durumlara göre sayılarını getirir
+     * @param eventId Etkinlik ID
+     */
+    getAttendees: async (eventId: number): Promise<EventAttendees> => {
+        const response = await apiService.get<ApiEnvelope<EventAttendees>>(`/api/events/${eventId}/attendees`);
+        return response.data;
+    },
+
     /**
      * Etkinlik katılımını iptal etme
      * @param eventId Etkinlik ID
@@ -44,4 +44,44 @@ export interface CreateEventDTO {
 
 export interface UpdateEventDTO extends Partial<CreateEventDTO> {
     id: number;
-} 
\ No newline at end of file
+}
+
+// Kullanıcının etkinliğe verebileceği yanıtlar
+export type RSVPStatus = 'going' | 'maybe' | 'not_going';
+
+// Katılımcı listesindeki durumlar; invited yanıt vermemiş davetlileri gösterir
+export type AttendeeStatus = RSVPStatus | 'waitlisted' | 'invited';
+
+export interface RSVPDTO {
+    status: RSVPStatus;
+    guests?: number; // Kullanıcıyla gelecek ek kişi sayısı (0-10)
+    note?: string;
+}
+
+export interface AttendanceCounts {
+    going: number;
+    maybe: number;
+    not_going: number;
+    waitlisted: number;
+    invited?: number;
+    guests: number; // Katılacakların getirdiği ek misafirler
+}
+
+export interface EventAttendee {
+    id: number;
+    name: string;
+    avatarUrl?: string;
+    status: AttendeeStatus;
+    guests?: number;
+    note?: string; // Yalnızca etkinlik sahibine ve notu yazan kullanıcıya gelir
+}
+
+export interface EventAttendees {
+    attendees: EventAttendee[];
+    counts: AttendanceCounts;
+}
+
+export interface EventDetailResponse extends Event {
+    attendees_count: number; // Katılacaklar ve getirdikleri misafirler
+    attendance_counts: AttendanceCounts;
+}